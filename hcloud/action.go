@@ -43,23 +43,62 @@ type ActionResourceType string
 
 // List of action resource reference types.
 const (
-	ActionResourceTypeServer     ActionResourceType = "server"
-	ActionResourceTypeImage      ActionResourceType = "image"
-	ActionResourceTypeISO        ActionResourceType = "iso"
-	ActionResourceTypeFloatingIP ActionResourceType = "floating_ip"
-	ActionResourceTypeVolume     ActionResourceType = "volume"
+	ActionResourceTypeServer         ActionResourceType = "server"
+	ActionResourceTypeImage          ActionResourceType = "image"
+	ActionResourceTypeISO            ActionResourceType = "iso"
+	ActionResourceTypeFloatingIP     ActionResourceType = "floating_ip"
+	ActionResourceTypeVolume         ActionResourceType = "volume"
+	ActionResourceTypeFirewall       ActionResourceType = "firewall"
+	ActionResourceTypeLoadBalancer   ActionResourceType = "load_balancer"
+	ActionResourceTypeCertificate    ActionResourceType = "certificate"
+	ActionResourceTypePlacementGroup ActionResourceType = "placement_group"
+	ActionResourceTypePrimaryIP      ActionResourceType = "primary_ip"
 )
 
 // ActionError is the error of an action.
 type ActionError struct {
 	Code    string
 	Message string
+	// PollRequest is the request polling the action which reported the error,
+	// if known. It is not the request which started the action.
+	PollRequest *RequestInfo
 }
 
 func (e ActionError) Error() string {
 	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
 }
 
+// Describe returns the error message followed by the request polling the
+// action which reported the error, if known, in the format of Error.Describe.
+func (e ActionError) Describe() string {
+	return describeError(e.Error(), e.PollRequest)
+}
+
+// Is reports whether the error has the same error code as target, which is
+// an ActionError or one of the sentinel errors like ErrLocked.
+func (e ActionError) Is(target error) bool {
+	switch t := target.(type) {
+	case sentinelError:
+		return e.Code == string(t)
+	case ActionError:
+		return e.Code == t.Code
+	case *ActionError:
+		return t != nil && e.Code == t.Code
+	}
+	return false
+}
+
+// As sets target to a copy of the error if target is a **ActionError, so
+// errors can be extracted as pointers as well as values.
+func (e ActionError) As(target interface{}) bool {
+	if t, ok := target.(**ActionError); ok {
+		err := e
+		*t = &err
+		return true
+	}
+	return false
+}
+
 func (a *Action) Error() error {
 	if a.ErrorCode != "" && a.ErrorMessage != "" {
 		return ActionError{
@@ -182,7 +221,7 @@ func (c *ActionClient) WatchProgress(ctx context.Context, action *Action) (<-cha
 				break
 			}
 
-			a, _, err := c.GetByID(ctx, action.ID)
+			a, resp, err := c.GetByID(ctx, action.ID)
 			if err != nil {
 				errCh <- err
 				return
@@ -197,7 +236,12 @@ func (c *ActionClient) WatchProgress(ctx context.Context, action *Action) (<-cha
 				errCh <- nil
 				return
 			case ActionStatusError:
-				errCh <- a.Error()
+				err := a.Error()
+				if actionErr, ok := err.(ActionError); ok {
+					actionErr.PollRequest = resp.requestInfo()
+					err = actionErr
+				}
+				errCh <- err
 				return
 			}
 		}
@@ -205,3 +249,32 @@ func (c *ActionClient) WatchProgress(ctx context.Context, action *Action) (<-cha
 
 	return progressCh, errCh
 }
+
+// waitFor blocks until the action completes and returns its error, if any.
+// Progress updates are discarded.
+func (c *ActionClient) waitFor(ctx context.Context, action *Action) error {
+	progressCh, errCh := c.WatchProgress(ctx, action)
+	for {
+		select {
+		case _, ok := <-progressCh:
+			if !ok {
+				progressCh = nil
+			}
+		case err := <-errCh:
+			return err
+		}
+	}
+}
+
+// waitForAll waits for each of the non-nil actions and returns the first error.
+func (c *ActionClient) waitForAll(ctx context.Context, actions ...*Action) error {
+	for _, action := range actions {
+		if action == nil {
+			continue
+		}
+		if err := c.waitFor(ctx, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
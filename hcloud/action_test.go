@@ -235,6 +235,9 @@ loop:
 	if e, ok := err.(ActionError); !ok || e.Code != "action_failed" {
 		t.Fatalf("expected hcloud.Error, but got: %#v", err)
 	}
+	if e := err.(ActionError); e.PollRequest == nil || e.PollRequest.Method != "GET" || e.PollRequest.Path != "/actions/1" || e.PollRequest.StatusCode != 200 {
+		t.Errorf("unexpected request info: %+v", e.PollRequest)
+	}
 	if len(progressUpdates) != 1 || progressUpdates[0] != 50 {
 		t.Fatalf("unexpected progress updates: %v", progressUpdates)
 	}
@@ -0,0 +1,408 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// CertificateType is the type of a certificate.
+type CertificateType string
+
+const (
+	// CertificateTypeUploaded is the type of a certificate which has been
+	// uploaded by the user.
+	CertificateTypeUploaded CertificateType = "uploaded"
+
+	// CertificateTypeManaged is the type of a certificate which is issued and
+	// renewed by the Hetzner Cloud.
+	CertificateTypeManaged CertificateType = "managed"
+)
+
+// CertificateStatusType is the status of the issuance or renewal of a
+// managed certificate.
+type CertificateStatusType string
+
+// List of certificate issuance and renewal statuses.
+const (
+	CertificateStatusTypePending     CertificateStatusType = "pending"
+	CertificateStatusTypeCompleted   CertificateStatusType = "completed"
+	CertificateStatusTypeFailed      CertificateStatusType = "failed"
+	CertificateStatusTypeScheduled   CertificateStatusType = "scheduled"
+	CertificateStatusTypeUnavailable CertificateStatusType = "unavailable"
+)
+
+// CertificateStatus tracks the issuance and renewal of a managed certificate.
+type CertificateStatus struct {
+	Issuance CertificateStatusType
+	Renewal  CertificateStatusType
+	Error    *Error
+}
+
+// IsFailed returns true if either the issuance or the renewal of the
+// certificate failed.
+func (st *CertificateStatus) IsFailed() bool {
+	return st.Issuance == CertificateStatusTypeFailed || st.Renewal == CertificateStatusTypeFailed
+}
+
+// CertificateUsedByRef points to a resource using a certificate.
+type CertificateUsedByRef struct {
+	ID   int
+	Type CertificateUsedByRefType
+}
+
+// CertificateUsedByRefType is the type of a resource using a certificate.
+type CertificateUsedByRefType string
+
+// CertificateUsedByRefTypeLoadBalancer is the type of a Load Balancer using
+// a certificate.
+const CertificateUsedByRefTypeLoadBalancer CertificateUsedByRefType = "load_balancer"
+
+// Certificate represents a certificate in the Hetzner Cloud.
+type Certificate struct {
+	ID             int
+	Name           string
+	Labels         map[string]string
+	Type           CertificateType
+	Certificate    string
+	Created        time.Time
+	NotValidBefore time.Time
+	NotValidAfter  time.Time
+	DomainNames    []string
+	Fingerprint    string
+	Status         *CertificateStatus // only set for managed certificates
+	UsedBy         []CertificateUsedByRef
+}
+
+// ExpiresWithin returns true if the certificate is no longer valid at t+d.
+// Certificates without a known expiry, like managed certificates which have
+// not been issued yet, never expire.
+func (c *Certificate) ExpiresWithin(t time.Time, d time.Duration) bool {
+	if c.NotValidAfter.IsZero() {
+		return false
+	}
+	return c.NotValidAfter.Before(t.Add(d))
+}
+
+// ParseCertificatePEM parses a PEM encoded certificate chain and returns a
+// Certificate with the validity period, domain names and fingerprint of the
+// first certificate in the chain. The fingerprint is the colon separated
+// SHA-256 hash of the DER encoded certificate, as reported by the API.
+func ParseCertificatePEM(data string) (*Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("hcloud: no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("hcloud: invalid certificate: %s", err)
+	}
+
+	var domainNames []string
+	seen := map[string]bool{}
+	for _, name := range append([]string{cert.Subject.CommonName}, cert.DNSNames...) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			domainNames = append(domainNames, name)
+		}
+	}
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := make([]string, len(sum))
+	for i, b := range sum {
+		fingerprint[i] = fmt.Sprintf("%02x", b)
+	}
+	return &Certificate{
+		Type:           CertificateTypeUploaded,
+		Certificate:    data,
+		NotValidBefore: cert.NotBefore,
+		NotValidAfter:  cert.NotAfter,
+		DomainNames:    domainNames,
+		Fingerprint:    strings.Join(fingerprint, ":"),
+	}, nil
+}
+
+// CertificateClient is a client for the certificates API.
+type CertificateClient struct {
+	client *Client
+}
+
+// GetByID retrieves a certificate by its ID. If the certificate does not exist, nil is returned.
+func (c *CertificateClient) GetByID(ctx context.Context, id int) (*Certificate, *Response, error) {
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("/certificates/%d", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.CertificateGetResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, nil, err
+	}
+	return CertificateFromSchema(body.Certificate), resp, nil
+}
+
+// GetByName retrieves a certificate by its name. If the certificate does not exist, nil is returned.
+func (c *CertificateClient) GetByName(ctx context.Context, name string) (*Certificate, *Response, error) {
+	certificates, response, err := c.List(ctx, CertificateListOpts{Name: name})
+	if len(certificates) == 0 {
+		return nil, response, err
+	}
+	return certificates[0], response, err
+}
+
+// Get retrieves a certificate by its ID if the input can be parsed as an integer, otherwise it
+// retrieves a certificate by its name. If the certificate does not exist, nil is returned.
+func (c *CertificateClient) Get(ctx context.Context, idOrName string) (*Certificate, *Response, error) {
+	if id, err := strconv.Atoi(idOrName); err == nil {
+		return c.GetByID(ctx, int(id))
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// CertificateListOpts specifies options for listing certificates.
+type CertificateListOpts struct {
+	ListOpts
+	Name string
+}
+
+func (l CertificateListOpts) values() url.Values {
+	vals := l.ListOpts.values()
+	if l.Name != "" {
+		vals.Add("name", l.Name)
+	}
+	return vals
+}
+
+// List returns a list of certificates for a specific page.
+func (c *CertificateClient) List(ctx context.Context, opts CertificateListOpts) ([]*Certificate, *Response, error) {
+	path := "/certificates?" + opts.values().Encode()
+	req, err := c.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.CertificateListResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+	certificates := make([]*Certificate, 0, len(body.Certificates))
+	for _, s := range body.Certificates {
+		certificates = append(certificates, CertificateFromSchema(s))
+	}
+	return certificates, resp, nil
+}
+
+// All returns all certificates.
+func (c *CertificateClient) All(ctx context.Context) ([]*Certificate, error) {
+	return c.AllWithOpts(ctx, CertificateListOpts{ListOpts: ListOpts{PerPage: 50}})
+}
+
+// AllWithOpts returns all certificates for the given options.
+func (c *CertificateClient) AllWithOpts(ctx context.Context, opts CertificateListOpts) ([]*Certificate, error) {
+	allCertificates := []*Certificate{}
+
+	_, err := c.client.all(func(page int) (*Response, error) {
+		opts.Page = page
+		certificates, resp, err := c.List(ctx, opts)
+		if err != nil {
+			return resp, err
+		}
+		allCertificates = append(allCertificates, certificates...)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allCertificates, nil
+}
+
+// AllExpiringWithin returns all certificates matching the given options which
+// are no longer valid after the window has passed, sorted by expiry. Expired
+// certificates are included.
+func (c *CertificateClient) AllExpiringWithin(ctx context.Context, opts CertificateListOpts, window time.Duration) ([]*Certificate, error) {
+	certificates, err := c.AllWithOpts(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiring := []*Certificate{}
+	for _, certificate := range certificates {
+		if certificate.ExpiresWithin(now, window) {
+			expiring = append(expiring, certificate)
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].NotValidAfter.Before(expiring[j].NotValidAfter)
+	})
+	return expiring, nil
+}
+
+// CertificateCreateOpts specifies options for creating a certificate.
+type CertificateCreateOpts struct {
+	Name   string
+	Type   CertificateType // defaults to CertificateTypeUploaded
+	Labels map[string]string
+
+	// Certificate and PrivateKey are the PEM encoded certificate chain and
+	// private key of an uploaded certificate.
+	Certificate string
+	PrivateKey  string
+
+	// DomainNames are the domain names a managed certificate is requested for.
+	DomainNames []string
+}
+
+// Validate checks if options are valid.
+func (o CertificateCreateOpts) Validate() error {
+	if o.Name == "" {
+		return errors.New("missing name")
+	}
+	switch o.Type {
+	case "", CertificateTypeUploaded:
+		if o.Certificate == "" {
+			return errors.New("missing certificate")
+		}
+		if o.PrivateKey == "" {
+			return errors.New("missing private key")
+		}
+		if len(o.DomainNames) > 0 {
+			return errors.New("domain names are only supported for managed certificates")
+		}
+		if _, err := ParseCertificatePEM(o.Certificate); err != nil {
+			return err
+		}
+	case CertificateTypeManaged:
+		if len(o.DomainNames) == 0 {
+			return errors.New("missing domain names")
+		}
+		if o.Certificate != "" || o.PrivateKey != "" {
+			return errors.New("certificate and private key are only supported for uploaded certificates")
+		}
+	default:
+		return errors.New("invalid type")
+	}
+	return nil
+}
+
+// CertificateCreateResult is the result of creating a certificate.
+type CertificateCreateResult struct {
+	Certificate *Certificate
+	Action      *Action // only set for managed certificates
+}
+
+// Create creates a new certificate. Uploaded certificates are usable right
+// away, while managed certificates are issued asynchronously and the returned
+// action completes once the issuance finished.
+func (c *CertificateClient) Create(ctx context.Context, opts CertificateCreateOpts) (CertificateCreateResult, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return CertificateCreateResult{}, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.CertificateCreateRequest{
+		Name:        opts.Name,
+		Type:        string(opts.Type),
+		DomainNames: opts.DomainNames,
+		Certificate: opts.Certificate,
+		PrivateKey:  opts.PrivateKey,
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return CertificateCreateResult{}, nil, err
+	}
+	req, err := c.client.NewRequest(ctx, "POST", "/certificates", bytes.NewReader(reqBodyData))
+	if err != nil {
+		return CertificateCreateResult{}, nil, err
+	}
+
+	respBody := schema.CertificateCreateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return CertificateCreateResult{}, resp, err
+	}
+	result := CertificateCreateResult{
+		Certificate: CertificateFromSchema(respBody.Certificate),
+	}
+	if respBody.Action != nil {
+		result.Action = ActionFromSchema(*respBody.Action)
+	}
+	return result, resp, nil
+}
+
+// CertificateUpdateOpts specifies options for updating a certificate.
+type CertificateUpdateOpts struct {
+	Name   string
+	Labels map[string]string
+}
+
+// Update updates a certificate.
+func (c *CertificateClient) Update(ctx context.Context, certificate *Certificate, opts CertificateUpdateOpts) (*Certificate, *Response, error) {
+	reqBody := schema.CertificateUpdateRequest{}
+	if opts.Name != "" {
+		reqBody.Name = &opts.Name
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/certificates/%d", certificate.ID)
+	req, err := c.client.NewRequest(ctx, "PUT", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.CertificateUpdateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return CertificateFromSchema(respBody.Certificate), resp, nil
+}
+
+// Delete deletes a certificate.
+func (c *CertificateClient) Delete(ctx context.Context, certificate *Certificate) (*Response, error) {
+	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("/certificates/%d", certificate.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req, nil)
+}
+
+// RetryIssuance retries the issuance of a managed certificate whose issuance
+// or renewal failed.
+func (c *CertificateClient) RetryIssuance(ctx context.Context, certificate *Certificate) (*Action, *Response, error) {
+	path := fmt.Sprintf("/certificates/%d/actions/retry", certificate.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.CertificateIssuanceRetryResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
@@ -0,0 +1,363 @@
+package hcloud
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func generateTestCertificate(t *testing.T, notBefore, notAfter time.Time) (certPEM, keyPEM string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com", "www.example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestParseCertificatePEM(t *testing.T) {
+	notBefore := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	certPEM, _ := generateTestCertificate(t, notBefore, notAfter)
+
+	certificate, err := ParseCertificatePEM(certPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !certificate.NotValidBefore.Equal(notBefore) || !certificate.NotValidAfter.Equal(notAfter) {
+		t.Errorf("unexpected validity: %v - %v", certificate.NotValidBefore, certificate.NotValidAfter)
+	}
+	if !equalStrings(certificate.DomainNames, []string{"example.com", "www.example.com"}) {
+		t.Errorf("unexpected domain names: %v", certificate.DomainNames)
+	}
+	if parts := strings.Split(certificate.Fingerprint, ":"); len(parts) != 32 || len(parts[0]) != 2 {
+		t.Errorf("unexpected fingerprint: %s", certificate.Fingerprint)
+	}
+
+	if _, err := ParseCertificatePEM("not a certificate"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestCertificateExpiresWithin(t *testing.T) {
+	now := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	certificate := &Certificate{NotValidAfter: now.Add(48 * time.Hour)}
+	if certificate.ExpiresWithin(now, 24*time.Hour) {
+		t.Error("expected certificate not to expire within 24h")
+	}
+	if !certificate.ExpiresWithin(now, 72*time.Hour) {
+		t.Error("expected certificate to expire within 72h")
+	}
+	if (&Certificate{}).ExpiresWithin(now, 72*time.Hour) {
+		t.Error("expected certificate without expiry not to expire")
+	}
+}
+
+func TestCertificateClientGetByID(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.CertificateGetResponse{
+			Certificate: schema.Certificate{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	certificate, _, err := env.Client.Certificate.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certificate == nil {
+		t.Fatal("no certificate")
+	}
+	if certificate.ID != 1 {
+		t.Errorf("unexpected certificate ID: %v", certificate.ID)
+	}
+
+	t.Run("via Get", func(t *testing.T) {
+		certificate, _, err := env.Client.Certificate.Get(ctx, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if certificate == nil {
+			t.Fatal("no certificate")
+		}
+		if certificate.ID != 1 {
+			t.Errorf("unexpected certificate ID: %v", certificate.ID)
+		}
+	})
+}
+
+func TestCertificateClientGetByIDNotFound(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(schema.ErrorResponse{
+			Error: schema.Error{
+				Code: string(ErrorCodeNotFound),
+			},
+		})
+	})
+
+	ctx := context.Background()
+	certificate, _, err := env.Client.Certificate.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certificate != nil {
+		t.Fatal("expected no certificate")
+	}
+}
+
+func TestCertificateClientGetByName(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "name=mycert" {
+			t.Fatal("missing name query")
+		}
+		json.NewEncoder(w).Encode(schema.CertificateListResponse{
+			Certificates: []schema.Certificate{{ID: 1, Name: "mycert"}},
+		})
+	})
+
+	ctx := context.Background()
+	certificate, _, err := env.Client.Certificate.Get(ctx, "mycert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certificate == nil {
+		t.Fatal("no certificate")
+	}
+	if certificate.ID != 1 {
+		t.Errorf("unexpected certificate ID: %v", certificate.ID)
+	}
+}
+
+func TestCertificateClientAllExpiringWithin(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	now := time.Now()
+	env.Mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.CertificateListResponse{
+			Certificates: []schema.Certificate{
+				{ID: 1, NotValidAfter: now.Add(20 * 24 * time.Hour)},
+				{ID: 2, NotValidAfter: now.Add(90 * 24 * time.Hour)},
+				{ID: 3, NotValidAfter: now.Add(-24 * time.Hour)},
+				{ID: 4, Type: "managed", Status: &schema.CertificateStatus{Issuance: "pending"}},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	certificates, err := env.Client.Certificate.AllExpiringWithin(ctx, CertificateListOpts{}, 30*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certificates) != 2 || certificates[0].ID != 3 || certificates[1].ID != 1 {
+		t.Errorf("unexpected certificates: %v", certificates)
+	}
+}
+
+func TestCertificateClientCreateUploaded(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	certPEM, keyPEM := generateTestCertificate(t, time.Now(), time.Now().Add(time.Hour))
+	env.Mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.CertificateCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name != "mycert" || reqBody.Type != "" || reqBody.Certificate != certPEM || reqBody.PrivateKey != keyPEM {
+			t.Errorf("unexpected request: %+v", reqBody)
+		}
+		json.NewEncoder(w).Encode(schema.CertificateCreateResponse{
+			Certificate: schema.Certificate{ID: 1, Name: "mycert", Type: "uploaded", Certificate: certPEM},
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.Certificate.Create(ctx, CertificateCreateOpts{
+		Name:        "mycert",
+		Certificate: certPEM,
+		PrivateKey:  keyPEM,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Certificate.ID != 1 {
+		t.Errorf("unexpected certificate ID: %v", result.Certificate.ID)
+	}
+	if result.Action != nil {
+		t.Errorf("unexpected action: %v", result.Action)
+	}
+	if result.Certificate.Fingerprint == "" || len(result.Certificate.DomainNames) != 2 {
+		t.Errorf("expected fields to be parsed from the certificate: %+v", result.Certificate)
+	}
+}
+
+func TestCertificateClientCreateManaged(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.CertificateCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Type != "managed" || !equalStrings(reqBody.DomainNames, []string{"example.com"}) {
+			t.Errorf("unexpected request: %+v", reqBody)
+		}
+		json.NewEncoder(w).Encode(schema.CertificateCreateResponse{
+			Certificate: schema.Certificate{
+				ID:     1,
+				Type:   "managed",
+				Status: &schema.CertificateStatus{Issuance: "pending", Renewal: "unavailable"},
+			},
+			Action: &schema.Action{ID: 2},
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.Certificate.Create(ctx, CertificateCreateOpts{
+		Name:        "mycert",
+		Type:        CertificateTypeManaged,
+		DomainNames: []string{"example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Action == nil || result.Action.ID != 2 {
+		t.Errorf("unexpected action: %v", result.Action)
+	}
+	if result.Certificate.Status == nil || result.Certificate.Status.Issuance != CertificateStatusTypePending {
+		t.Errorf("unexpected status: %v", result.Certificate.Status)
+	}
+}
+
+func TestCertificateCreateOptsValidate(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t, time.Now(), time.Now().Add(time.Hour))
+	testCases := map[string]CertificateCreateOpts{
+		"missing name":               {Certificate: certPEM, PrivateKey: keyPEM},
+		"missing certificate":        {Name: "cert", PrivateKey: keyPEM},
+		"missing private key":        {Name: "cert", Certificate: certPEM},
+		"invalid certificate":        {Name: "cert", Certificate: "foo", PrivateKey: keyPEM},
+		"managed without domains":    {Name: "cert", Type: CertificateTypeManaged},
+		"managed with certificate":   {Name: "cert", Type: CertificateTypeManaged, DomainNames: []string{"example.com"}, Certificate: certPEM},
+		"uploaded with domain names": {Name: "cert", Certificate: certPEM, PrivateKey: keyPEM, DomainNames: []string{"example.com"}},
+		"invalid type":               {Name: "cert", Type: "foo"},
+	}
+	for name, opts := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := opts.Validate(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestCertificateClientUpdate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Error("expected PUT")
+		}
+		var reqBody schema.CertificateUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Labels == nil || (*reqBody.Labels)["key"] != "value" {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		json.NewEncoder(w).Encode(schema.CertificateUpdateResponse{
+			Certificate: schema.Certificate{ID: 1, Labels: map[string]string{"key": "value"}},
+		})
+	})
+
+	ctx := context.Background()
+	certificate, _, err := env.Client.Certificate.Update(ctx, &Certificate{ID: 1}, CertificateUpdateOpts{
+		Labels: map[string]string{"key": "value"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if certificate.Labels["key"] != "value" {
+		t.Errorf("unexpected labels: %v", certificate.Labels)
+	}
+}
+
+func TestCertificateClientDelete(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Error("expected DELETE")
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := env.Client.Certificate.Delete(ctx, &Certificate{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertificateClientRetryIssuance(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/certificates/1/actions/retry", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("expected POST")
+		}
+		json.NewEncoder(w).Encode(schema.CertificateIssuanceRetryResponse{
+			Action: schema.Action{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	action, _, err := env.Client.Certificate.RetryIssuance(ctx, &Certificate{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ID != 1 {
+		t.Errorf("unexpected action ID: %v", action.ID)
+	}
+}
@@ -49,7 +49,8 @@ func ExponentialBackoff(b float64, d time.Duration) BackoffFunc {
 // Client is a client for the Hetzner Cloud API.
 type Client struct {
 	endpoint           string
-	token              string
+	tokenProvider      TokenProvider
+	dnsTokenProvider   TokenProvider
 	pollInterval       time.Duration
 	backoffFunc        BackoffFunc
 	httpClient         *http.Client
@@ -57,20 +58,29 @@ type Client struct {
 	applicationVersion string
 	userAgent          string
 	debugWriter        io.Writer
-
-	Action     ActionClient
-	Datacenter DatacenterClient
-	FloatingIP FloatingIPClient
-	Image      ImageClient
-	ISO        ISOClient
-	Location   LocationClient
-	Network    NetworkClient
-	Pricing    PricingClient
-	Server     ServerClient
-	ServerType ServerTypeClient
-	SSHKey     SSHKeyClient
-	Volume     VolumeClient
-	DNSServer  DNSServerClient
+	dryRun             *DryRun
+	strictDecoding     bool
+	unknownFieldsFunc  UnknownFieldsFunc
+
+	Action           ActionClient
+	Certificate      CertificateClient
+	Datacenter       DatacenterClient
+	Firewall         FirewallClient
+	FloatingIP       FloatingIPClient
+	Image            ImageClient
+	ISO              ISOClient
+	LoadBalancer     LoadBalancerClient
+	LoadBalancerType LoadBalancerTypeClient
+	Location         LocationClient
+	Network          NetworkClient
+	PlacementGroup   PlacementGroupClient
+	Pricing          PricingClient
+	PrimaryIP        PrimaryIPClient
+	Server           ServerClient
+	ServerType       ServerTypeClient
+	SSHKey           SSHKeyClient
+	Volume           VolumeClient
+	DNSServer        DNSServerClient
 }
 
 // A ClientOption is used to configure a Client.
@@ -85,8 +95,30 @@ func WithEndpoint(endpoint string) ClientOption {
 
 // WithToken configures a Client to use the specified token for authentication.
 func WithToken(token string) ClientOption {
+	return WithTokenProvider(StaticToken(token))
+}
+
+// WithTokenProvider configures a Client to authenticate each request with the
+// token provided by p at the time the request is created. This allows
+// rotating the token without creating a new Client.
+func WithTokenProvider(p TokenProvider) ClientOption {
+	return func(client *Client) {
+		client.tokenProvider = p
+	}
+}
+
+// WithDNSToken configures a Client to use the specified token for requests
+// against the DNS API. By default, the token of the Cloud API is used.
+func WithDNSToken(token string) ClientOption {
+	return WithDNSTokenProvider(StaticToken(token))
+}
+
+// WithDNSTokenProvider configures a Client to authenticate each request
+// against the DNS API with the token provided by p. By default, the token
+// provider of the Cloud API is used.
+func WithDNSTokenProvider(p TokenProvider) ClientOption {
 	return func(client *Client) {
-		client.token = token
+		client.dnsTokenProvider = p
 	}
 }
 
@@ -123,6 +155,29 @@ func WithDebugWriter(debugWriter io.Writer) ClientOption {
 	}
 }
 
+// UnknownFieldsFunc is called by a Client in strict decoding mode with the
+// paths of response fields the schema types do not model, like
+// "server.public_net.ipv4.foo". See schema.UnknownFields for the path format.
+type UnknownFieldsFunc func(r *http.Request, fields []string)
+
+// WithStrictDecoding configures a Client to check response bodies for fields
+// the schema types do not model, to detect changes of the API. Unknown fields
+// do not fail the request. They are reported to f or, if f is nil, written to
+// the debug writer configured with WithDebugWriter.
+func WithStrictDecoding(f UnknownFieldsFunc) ClientOption {
+	return func(client *Client) {
+		client.strictDecoding = true
+		client.unknownFieldsFunc = f
+	}
+}
+
+// WithHTTPClient configures a Client to perform HTTP requests with httpClient.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
 // NewClient creates a new client.
 func NewClient(options ...ClientOption) *Client {
 	client := &Client{
@@ -139,13 +194,19 @@ func NewClient(options ...ClientOption) *Client {
 	client.buildUserAgent()
 
 	client.Action = ActionClient{client: client}
+	client.Certificate = CertificateClient{client: client}
 	client.Datacenter = DatacenterClient{client: client}
+	client.Firewall = FirewallClient{client: client}
 	client.FloatingIP = FloatingIPClient{client: client}
 	client.Image = ImageClient{client: client}
 	client.ISO = ISOClient{client: client}
+	client.LoadBalancer = LoadBalancerClient{client: client}
+	client.LoadBalancerType = LoadBalancerTypeClient{client: client}
 	client.Location = LocationClient{client: client}
 	client.Network = NetworkClient{client: client}
+	client.PlacementGroup = PlacementGroupClient{client: client}
 	client.Pricing = PricingClient{client: client}
+	client.PrimaryIP = PrimaryIPClient{client: client}
 	client.Server = ServerClient{client: client}
 	client.ServerType = ServerTypeClient{client: client}
 	client.SSHKey = SSHKeyClient{client: client}
@@ -164,7 +225,9 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Re
 		return nil, err
 	}
 	req.Header.Set("User-Agent", c.userAgent)
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	if err := c.authorize(ctx, req); err != nil {
+		return nil, err
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -174,13 +237,18 @@ func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Re
 
 // Do performs an HTTP request against the API.
 func (c *Client) Do(r *http.Request, v interface{}) (*Response, error) {
+	if c.dryRun != nil {
+		if resp, ok, err := c.dryRun.do(c.endpoint, r, v); ok {
+			return resp, err
+		}
+	}
 	var retries int
 	for {
 		resp, err := c.httpClient.Do(r)
 		if err != nil {
 			return nil, err
 		}
-		response := &Response{Response: resp}
+		response := &Response{Response: resp, attempts: retries + 1}
 		body, err := ioutil.ReadAll(resp.Body)
 		if err != nil {
 			resp.Body.Close()
@@ -210,13 +278,21 @@ func (c *Client) Do(r *http.Request, v interface{}) (*Response, error) {
 		if resp.StatusCode >= 400 && resp.StatusCode <= 599 {
 			err = errorFromResponse(resp, body)
 			if err == nil {
-				err = fmt.Errorf("hcloud: server responded with status code %d", resp.StatusCode)
+				err = StatusError{StatusCode: resp.StatusCode, Request: response.requestInfo()}
 			} else {
 				if err, ok := err.(Error); ok && err.Code == ErrorCodeRateLimitExceeded {
 					c.backoff(retries)
 					retries++
+					// The token may have been rotated during the backoff.
+					if err := c.reauthorize(r); err != nil {
+						return response, err
+					}
 					continue
 				}
+				if apiErr, ok := err.(Error); ok {
+					apiErr.Request = response.requestInfo()
+					err = apiErr
+				}
 			}
 			return response, err
 		}
@@ -225,6 +301,9 @@ func (c *Client) Do(r *http.Request, v interface{}) (*Response, error) {
 				_, err = io.Copy(w, bytes.NewReader(body))
 			} else {
 				err = json.Unmarshal(body, v)
+				if err == nil && c.strictDecoding {
+					c.reportUnknownFields(r, body, v)
+				}
 			}
 		}
 
@@ -232,10 +311,36 @@ func (c *Client) Do(r *http.Request, v interface{}) (*Response, error) {
 	}
 }
 
+// reportUnknownFields reports the fields of body which neither v nor the
+// response meta data model.
+func (c *Client) reportUnknownFields(r *http.Request, body []byte, v interface{}) {
+	fields, err := schema.UnknownFields(body, v, &schema.MetaResponse{})
+	if err != nil || len(fields) == 0 {
+		return
+	}
+	switch {
+	case c.unknownFieldsFunc != nil:
+		c.unknownFieldsFunc(r, fields)
+	case c.debugWriter != nil:
+		fmt.Fprintf(c.debugWriter, "--- Unknown fields in response to %s %s:\n%s\n\n",
+			r.Method, r.URL.Path, strings.Join(fields, "\n"))
+	}
+}
+
 func (c *Client) backoff(retries int) {
 	time.Sleep(c.backoffFunc(retries))
 }
 
+// sleep pauses for the duration d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
 func (c *Client) all(f func(int) (*Response, error)) (*Response, error) {
 	var (
 		page = 1
@@ -282,6 +387,24 @@ func errorFromResponse(resp *http.Response, body []byte) error {
 type Response struct {
 	*http.Response
 	Meta Meta
+
+	attempts int
+}
+
+// requestInfo returns the RequestInfo of the response's request.
+func (r *Response) requestInfo() *RequestInfo {
+	info := &RequestInfo{
+		Method:     r.Request.Method,
+		Path:       r.Request.URL.Path,
+		StatusCode: r.StatusCode,
+		RequestID:  r.Header.Get("X-Correlation-Id"),
+		Attempts:   r.attempts,
+		Time:       time.Now(),
+	}
+	if info.RequestID == "" {
+		info.RequestID = r.Header.Get("X-Request-Id")
+	}
+	return info
 }
 
 func (r *Response) readMeta(body []byte) error {
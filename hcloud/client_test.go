@@ -3,6 +3,7 @@ package hcloud
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -82,6 +83,97 @@ func TestClientError(t *testing.T) {
 	if apiError.Message != "An error occured" {
 		t.Errorf("unexpected error message: %q", apiError.Message)
 	}
+	if r := apiError.Request; r == nil || r.Method != "GET" || r.Path != "/error" || r.StatusCode != http.StatusUnprocessableEntity || r.Attempts != 1 || r.Time.IsZero() {
+		t.Errorf("unexpected request info: %+v", apiError.Request)
+	}
+}
+
+func TestClientStatusError(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "4ee4e8e1")
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>Bad Gateway</html>"))
+	})
+
+	_, _, err := env.Client.Server.List(context.Background(), ServerListOpts{})
+	var statusErr StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("unexpected error of type %T: %v", err, err)
+	}
+	if err.Error() != "hcloud: server responded with status code 502" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+	if r := statusErr.Request; r == nil || r.Method != "GET" || r.Path != "/servers" || r.StatusCode != http.StatusBadGateway || r.RequestID != "4ee4e8e1" || r.Attempts != 1 {
+		t.Errorf("unexpected request info: %+v", statusErr.Request)
+	}
+}
+
+func TestClientErrorMalformedDetails(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/volumes/1/actions/attach", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error": {"code": "server_already_attached", "message": "already attached", "details": {"server": "42"}}}`))
+	})
+
+	_, _, err := env.Client.Volume.Attach(context.Background(), &Volume{ID: 1}, &Server{ID: 42})
+	if !errors.Is(err, ErrServerAlreadyAttached) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var apiErr Error
+	if !errors.As(err, &apiErr) || apiErr.Message != "already attached" || apiErr.Details != nil {
+		t.Errorf("unexpected error: %+v", apiErr)
+	}
+}
+
+func TestClientErrorRequestInfo(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	callCount := 0
+	env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Correlation-Id", fmt.Sprintf("req-%d", callCount))
+		code, status := ErrorCodeRateLimitExceeded, http.StatusTooManyRequests
+		if callCount == 3 {
+			code, status = ErrorCodeLocked, http.StatusLocked
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(schema.ErrorResponse{
+			Error: schema.Error{Code: string(code), Message: "server is locked"},
+		})
+	})
+
+	before := time.Now()
+	_, err := env.Client.Server.Delete(context.Background(), &Server{ID: 1})
+	var apiErr Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := apiErr.Request
+	if r == nil {
+		t.Fatal("expected request info")
+	}
+	if r.Method != "DELETE" || r.Path != "/servers/1" || r.StatusCode != http.StatusLocked || r.RequestID != "req-3" || r.Attempts != 3 {
+		t.Errorf("unexpected request info: %+v", r)
+	}
+	if r.Time.Before(before) {
+		t.Errorf("unexpected time: %v", r.Time)
+	}
+
+	description := apiErr.Describe()
+	if !strings.HasPrefix(description, "server is locked (locked) method=DELETE path=/servers/1 status=423 request_id=req-3 attempts=3 time=") {
+		t.Errorf("unexpected description: %s", description)
+	}
+	if strings.Contains(description, "token") {
+		t.Errorf("description contains the token: %s", description)
+	}
 }
 
 func TestClientMeta(t *testing.T) {
@@ -263,6 +355,63 @@ func TestClientDo(t *testing.T) {
 	}
 }
 
+func TestClientStrictDecoding(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"servers": [{"id": 1, "name": "web", "rescue": true}, {"id": 2, "name": "db", "rescue": false}],
+			"meta": {"pagination": {"page": 1, "per_page": 25, "last_page": 1, "total_entries": 2, "pages": 1}}
+		}`)
+	})
+
+	t.Run("callback", func(t *testing.T) {
+		var (
+			reported []string
+			path     string
+		)
+		client := NewClient(
+			WithEndpoint(env.Server.URL),
+			WithStrictDecoding(func(r *http.Request, fields []string) {
+				path = r.URL.Path
+				reported = fields
+			}),
+		)
+		servers, _, err := client.Server.List(context.Background(), ServerListOpts{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(servers) != 2 {
+			t.Errorf("unexpected servers: %v", servers)
+		}
+		if path != "/servers" {
+			t.Errorf("unexpected path: %s", path)
+		}
+		expected := []string{"meta.pagination.pages", "servers[].rescue"}
+		if strings.Join(reported, ",") != strings.Join(expected, ",") {
+			t.Errorf("expected unknown fields %v, got %v", expected, reported)
+		}
+	})
+
+	t.Run("debug writer", func(t *testing.T) {
+		var buf strings.Builder
+		client := NewClient(
+			WithEndpoint(env.Server.URL),
+			WithStrictDecoding(nil),
+			WithDebugWriter(&buf),
+		)
+		if _, _, err := client.Server.List(context.Background(), ServerListOpts{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "--- Unknown fields in response to GET /servers:\nmeta.pagination.pages\nservers[].rescue\n\n"
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected debug output to contain %q, got:\n%s", expected, buf.String())
+		}
+	})
+}
+
 func TestBuildUserAgent(t *testing.T) {
 	testCases := []struct {
 		name               string
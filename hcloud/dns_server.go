@@ -126,7 +126,9 @@ func (c *DNSServerClient) NewRequest(ctx context.Context, method, path string, b
 		return nil, err
 	}
 	req.Header.Set("User-Agent", c.client.userAgent)
-	req.Header.Set("Auth-API-Token", c.client.token)
+	if err := c.client.authorizeDNS(ctx, req); err != nil {
+		return nil, err
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -0,0 +1,263 @@
+package hcloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DryRunMutation is a mutating request a Client in dry-run mode did not send.
+type DryRunMutation struct {
+	Method string
+	Path   string // path relative to the API endpoint, like /servers/1/actions/poweron
+	Body   []byte // JSON request body, nil if the request had no body
+}
+
+// String returns the method, path and body of the mutation.
+func (m DryRunMutation) String() string {
+	if len(m.Body) == 0 {
+		return m.Method + " " + m.Path
+	}
+	return m.Method + " " + m.Path + " " + string(m.Body)
+}
+
+// DryRun records the mutations of a Client configured with WithDryRun.
+//
+// Instead of sending POST, PUT and DELETE requests, the client records them
+// and answers them with synthetic responses: actions succeed right away and
+// created or updated resources are echoed back from the request. Synthetic
+// actions and created resources get negative IDs and can be retrieved with
+// GET requests, so waiting for actions works as usual. All other GET
+// requests are sent to the API.
+type DryRun struct {
+	mu        sync.Mutex
+	mutations []DryRunMutation
+	lastID    int
+	objects   map[string]map[string]interface{}
+}
+
+// NewDryRun creates a new DryRun.
+func NewDryRun() *DryRun {
+	return &DryRun{objects: map[string]map[string]interface{}{}}
+}
+
+// WithDryRun configures a Client to record mutating requests in d instead
+// of sending them.
+func WithDryRun(d *DryRun) ClientOption {
+	return func(client *Client) {
+		client.dryRun = d
+	}
+}
+
+// Mutations returns the recorded mutations in the order they were made.
+func (d *DryRun) Mutations() []DryRunMutation {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	mutations := make([]DryRunMutation, len(d.mutations))
+	copy(mutations, d.mutations)
+	return mutations
+}
+
+// Report returns a numbered list of the recorded mutations, one per line.
+func (d *DryRun) Report() string {
+	var buf strings.Builder
+	for i, m := range d.Mutations() {
+		fmt.Fprintf(&buf, "%d. %s\n", i+1, m)
+	}
+	return buf.String()
+}
+
+// do answers r with a synthetic response if it is a mutation or retrieves a
+// synthetic object. It reports whether it handled the request.
+func (d *DryRun) do(endpoint string, r *http.Request, v interface{}) (*Response, bool, error) {
+	path := r.URL.Path
+	for _, prefix := range []string{endpoint, DNSEndpoint} {
+		if u := r.URL.Scheme + "://" + r.URL.Host + r.URL.Path; strings.HasPrefix(u, prefix) {
+			path = strings.TrimPrefix(u, prefix)
+			break
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var respBody map[string]interface{}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		obj, ok := d.objects[path]
+		if !ok {
+			return nil, false, nil
+		}
+		respBody = obj
+	default:
+		var reqBody []byte
+		if r.Body != nil {
+			var err error
+			reqBody, err = ioutil.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return nil, true, err
+			}
+		}
+		d.mutations = append(d.mutations, DryRunMutation{Method: r.Method, Path: path, Body: reqBody})
+		respBody = d.respond(r.Method, path, reqBody)
+	}
+
+	status := http.StatusOK
+	if r.Method == http.MethodPost {
+		status = http.StatusCreated
+	}
+	resp := &Response{Response: &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    r,
+	}}
+	if v == nil {
+		return resp, true, nil
+	}
+	if w, ok := v.(io.Writer); ok {
+		return resp, true, json.NewEncoder(w).Encode(respBody)
+	}
+	fillLenient(reflect.ValueOf(v).Elem(), respBody)
+	return resp, true, nil
+}
+
+// respond returns the synthetic response body to a mutation.
+func (d *DryRun) respond(method, path string, reqBody []byte) map[string]interface{} {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	resource := segments[0]
+	singular := strings.TrimSuffix(resource, "s")
+
+	var obj map[string]interface{}
+	json.Unmarshal(reqBody, &obj)
+	if obj == nil {
+		obj = map[string]interface{}{}
+	}
+
+	switch {
+	case len(segments) >= 4 && segments[2] == "actions":
+		action := d.newAction(segments[3], singular, segments[1])
+		return map[string]interface{}{
+			"action":  action,
+			"actions": []interface{}{action},
+		}
+	case method == http.MethodPost && len(segments) == 1:
+		d.lastID--
+		obj["id"] = d.lastID
+		obj["created"] = time.Now()
+		d.objects["/"+resource+"/"+strconv.Itoa(d.lastID)] = map[string]interface{}{singular: obj}
+		action := d.newAction("create_"+singular, singular, strconv.Itoa(d.lastID))
+		return map[string]interface{}{
+			singular:       obj,
+			"action":       action,
+			"next_actions": []interface{}{},
+		}
+	case method == http.MethodPut && len(segments) == 2:
+		if id, err := strconv.Atoi(segments[1]); err == nil {
+			obj["id"] = id
+		} else {
+			obj["id"] = segments[1]
+		}
+		return map[string]interface{}{singular: obj}
+	case method == http.MethodDelete && len(segments) == 2:
+		delete(d.objects, path)
+		return map[string]interface{}{"action": d.newAction("delete_"+singular, singular, segments[1])}
+	}
+	command := segments[len(segments)-1]
+	return map[string]interface{}{"action": d.newAction(command, singular, "")}
+}
+
+// newAction returns a synthetic succeeded action and makes it retrievable.
+func (d *DryRun) newAction(command, resourceType, resourceID string) map[string]interface{} {
+	d.lastID--
+	now := time.Now()
+	resources := []interface{}{}
+	if id, err := strconv.Atoi(resourceID); err == nil {
+		resources = append(resources, map[string]interface{}{"id": id, "type": resourceType})
+	}
+	action := map[string]interface{}{
+		"id":        d.lastID,
+		"command":   command,
+		"status":    "success",
+		"progress":  100,
+		"started":   now,
+		"finished":  now,
+		"error":     nil,
+		"resources": resources,
+	}
+	d.objects["/actions/"+strconv.Itoa(d.lastID)] = map[string]interface{}{"action": action}
+	return action
+}
+
+// fillLenient sets v to data the way encoding/json would, except that values
+// not fitting into their destination are skipped instead of failing.
+// Synthetic responses echo request bodies, which refer to resources by name
+// where responses embed whole objects.
+func fillLenient(v reflect.Value, data interface{}) {
+	if data == nil {
+		return
+	}
+	if b, err := json.Marshal(data); err == nil {
+		nv := reflect.New(v.Type())
+		if err := json.Unmarshal(b, nv.Interface()); err == nil {
+			v.Set(nv.Elem())
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		nv := reflect.New(v.Type().Elem())
+		fillLenient(nv.Elem(), data)
+		v.Set(nv)
+	case reflect.Struct:
+		obj, ok := data.(map[string]interface{})
+		if !ok {
+			return
+		}
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name := field.Name
+			if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+				continue
+			} else if tag != "" {
+				name = tag
+			}
+			if value, ok := obj[name]; ok {
+				fillLenient(v.Field(i), value)
+			}
+		}
+	case reflect.Slice:
+		items, ok := data.([]interface{})
+		if !ok {
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			fillLenient(s.Index(i), item)
+		}
+		v.Set(s)
+	case reflect.String:
+		switch data.(type) {
+		case int, float64:
+			v.SetString(fmt.Sprint(data))
+		}
+	}
+}
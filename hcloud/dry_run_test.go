@@ -0,0 +1,136 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestDryRun(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{ID: 1, Name: "db"},
+		})
+	})
+
+	dryRun := NewDryRun()
+	client := NewClient(
+		WithEndpoint(env.Server.URL),
+		WithPollInterval(time.Millisecond),
+		WithDryRun(dryRun),
+	)
+	ctx := context.Background()
+
+	server, _, err := client.Server.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.Name != "db" {
+		t.Errorf("unexpected server: %+v", server)
+	}
+
+	result, _, err := client.Server.Create(ctx, ServerCreateOpts{
+		Name:       "web",
+		ServerType: &ServerType{Name: "cx11"},
+		Image:      &Image{Name: "ubuntu-20.04"},
+		Labels:     map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Server.ID >= 0 || result.Server.Name != "web" || result.Server.Labels["env"] != "prod" {
+		t.Errorf("unexpected server: %+v", result.Server)
+	}
+	if result.Action.Status != ActionStatusSuccess || result.Action.Command != "create_server" {
+		t.Errorf("unexpected action: %+v", result.Action)
+	}
+
+	created, _, err := client.Server.GetByID(ctx, result.Server.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created == nil || created.Name != "web" {
+		t.Errorf("expected created server to be retrievable, got %+v", created)
+	}
+
+	action, _, err := client.Server.Poweroff(ctx, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Command != "poweroff" || len(action.Resources) != 1 || action.Resources[0].ID != 1 {
+		t.Errorf("unexpected action: %+v", action)
+	}
+	_, errCh := client.Action.WatchProgress(ctx, action)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+
+	updated, _, err := client.Server.Update(ctx, server, ServerUpdateOpts{Name: "db-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != 1 || updated.Name != "db-1" {
+		t.Errorf("unexpected server: %+v", updated)
+	}
+
+	if _, err := client.Server.Delete(ctx, server); err != nil {
+		t.Fatal(err)
+	}
+
+	mutations := dryRun.Mutations()
+	if len(mutations) != 4 {
+		t.Fatalf("expected 4 mutations, got %v", mutations)
+	}
+	expected := []struct{ Method, Path string }{
+		{"POST", "/servers"},
+		{"POST", "/servers/1/actions/poweroff"},
+		{"PUT", "/servers/1"},
+		{"DELETE", "/servers/1"},
+	}
+	for i, m := range mutations {
+		if m.Method != expected[i].Method || m.Path != expected[i].Path {
+			t.Errorf("unexpected mutation %d: %s", i, m)
+		}
+	}
+	if string(mutations[2].Body) != `{"name":"db-1"}` {
+		t.Errorf("unexpected body: %s", mutations[2].Body)
+	}
+
+	report := dryRun.Report()
+	expectedReport := "1. " + mutations[0].String() + "\n" +
+		"2. POST /servers/1/actions/poweroff\n" +
+		"3. PUT /servers/1 {\"name\":\"db-1\"}\n" +
+		"4. DELETE /servers/1\n"
+	if report != expectedReport {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestDryRunDNS(t *testing.T) {
+	dryRun := NewDryRun()
+	client := NewClient(WithDryRun(dryRun))
+
+	zone, _, err := client.DNSServer.CreateZone(context.Background(), CreateOrUpdateZone{Name: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if zone.Name != "example.com" || zone.ID == "" {
+		t.Errorf("unexpected zone: %+v", zone)
+	}
+	if mutations := dryRun.Mutations(); len(mutations) != 1 || mutations[0].Path != "/zones" {
+		t.Errorf("unexpected mutations: %v", mutations)
+	}
+}
@@ -1,6 +1,10 @@
 package hcloud
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // ErrorCode represents an error code returned from the API.
 type ErrorCode string
@@ -33,17 +37,144 @@ const (
 	ErrorCodeLimitReached = ErrorCodeRateLimitExceeded
 )
 
+// Sentinel errors for the error codes, to check errors returned from the
+// API with errors.Is, also when they are wrapped:
+//
+//	if errors.Is(err, hcloud.ErrNotFound) {
+//		// ...
+//	}
+var (
+	ErrServiceError          error = sentinelError(ErrorCodeServiceError)
+	ErrRateLimitExceeded     error = sentinelError(ErrorCodeRateLimitExceeded)
+	ErrUnknownError          error = sentinelError(ErrorCodeUnknownError)
+	ErrNotFound              error = sentinelError(ErrorCodeNotFound)
+	ErrInvalidInput          error = sentinelError(ErrorCodeInvalidInput)
+	ErrForbidden             error = sentinelError(ErrorCodeForbidden)
+	ErrJSONError             error = sentinelError(ErrorCodeJSONError)
+	ErrLocked                error = sentinelError(ErrorCodeLocked)
+	ErrResourceLimitExceeded error = sentinelError(ErrorCodeResourceLimitExceeded)
+	ErrResourceUnavailable   error = sentinelError(ErrorCodeResourceUnavailable)
+	ErrUniquenessError       error = sentinelError(ErrorCodeUniquenessError)
+	ErrProtected             error = sentinelError(ErrorCodeProtected)
+	ErrMaintenance           error = sentinelError(ErrorCodeMaintenance)
+	ErrConflict              error = sentinelError(ErrorCodeConflict)
+	ErrServerAlreadyAttached error = sentinelError(ErrorCodeServerAlreadyAttached)
+)
+
+// sentinelError is the type of the sentinel errors. It matches errors with
+// its error code.
+type sentinelError ErrorCode
+
+func (e sentinelError) Error() string {
+	return "hcloud: " + string(e)
+}
+
 // Error is an error returned from the API.
+//
+// Details holds the details of the error, if the API reported any, as
+// ErrorDetailsInvalidInput, ErrorDetailsUniquenessError,
+// ErrorDetailsResourceLimitExceeded, ErrorDetailsServerAlreadyAttached or
+// ErrorDetailsLocked, depending on the error code.
 type Error struct {
 	Code    ErrorCode
 	Message string
 	Details interface{}
+	Request *RequestInfo // the request the error originated from, if known
 }
 
 func (e Error) Error() string {
 	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
 }
 
+// Describe returns the error message followed by the request the error
+// originated from, if known, like
+//
+//	server not found (not_found) method=GET path=/v1/servers/1 status=404 request_id=4ee4e8e1 attempts=1 time=2021-01-05T10:00:00Z
+//
+// The format is stable, to be used in logs and bug reports.
+func (e Error) Describe() string {
+	return describeError(e.Error(), e.Request)
+}
+
+// Is reports whether the error has the same error code as target, which is
+// one of the sentinel errors like ErrNotFound or an Error.
+func (e Error) Is(target error) bool {
+	switch t := target.(type) {
+	case sentinelError:
+		return e.Code == ErrorCode(t)
+	case Error:
+		return e.Code == t.Code
+	case *Error:
+		return t != nil && e.Code == t.Code
+	}
+	return false
+}
+
+// As sets target to a copy of the error if target is a **Error, so errors
+// can be extracted as pointers as well as values.
+func (e Error) As(target interface{}) bool {
+	if t, ok := target.(**Error); ok {
+		err := e
+		*t = &err
+		return true
+	}
+	return false
+}
+
+// StatusError is returned when the API responds with an error status code but
+// the response does not contain an error, for example when a proxy in front of
+// the API fails.
+type StatusError struct {
+	StatusCode int
+	Request    *RequestInfo // the request the error originated from, if known
+}
+
+func (e StatusError) Error() string {
+	return fmt.Sprintf("hcloud: server responded with status code %d", e.StatusCode)
+}
+
+// Describe returns the error message followed by the request the error
+// originated from, if known, in the format of Error.Describe.
+func (e StatusError) Describe() string {
+	return describeError(e.Error(), e.Request)
+}
+
+// RequestInfo describes the request an error originated from. It never
+// contains the token or other headers of the request.
+type RequestInfo struct {
+	Method     string
+	Path       string // path of the request URL, without the query
+	StatusCode int
+	RequestID  string // ID the API assigned to the request, for support requests
+	Attempts   int    // number of times the request was sent, including retries
+	Time       time.Time
+}
+
+// String returns the request information as space separated key=value pairs,
+// like "method=GET path=/v1/servers/1 status=404 request_id=4ee4e8e1
+// attempts=1 time=2021-01-05T10:00:00Z". Empty values are printed as "-".
+func (r RequestInfo) String() string {
+	value := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	timestamp := "-"
+	if !r.Time.IsZero() {
+		timestamp = r.Time.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("method=%s path=%s status=%d request_id=%s attempts=%d time=%s",
+		value(r.Method), value(r.Path), r.StatusCode, value(r.RequestID), r.Attempts, timestamp)
+}
+
+func describeError(message string, request *RequestInfo) string {
+	if request == nil {
+		return message
+	}
+	return message + " " + request.String()
+}
+
 // ErrorDetailsInvalidInput contains the details of an 'invalid_input' error.
 type ErrorDetailsInvalidInput struct {
 	Fields []ErrorDetailsInvalidInputField
@@ -55,8 +186,42 @@ type ErrorDetailsInvalidInputField struct {
 	Messages []string
 }
 
-// IsError returns whether err is an API error with the given error code.
+// ErrorDetailsUniquenessError contains the details of a 'uniqueness_error' error.
+type ErrorDetailsUniquenessError struct {
+	Fields []ErrorDetailsUniquenessErrorField
+}
+
+// ErrorDetailsUniquenessErrorField names a field whose value is already used.
+type ErrorDetailsUniquenessErrorField struct {
+	Name string
+}
+
+// ErrorDetailsResourceLimitExceeded contains the details of a
+// 'resource_limit_exceeded' error.
+type ErrorDetailsResourceLimitExceeded struct {
+	Limits []ErrorDetailsResourceLimitExceededLimit
+}
+
+// ErrorDetailsResourceLimitExceededLimit names a limit which was exceeded,
+// like "project_limit".
+type ErrorDetailsResourceLimitExceededLimit struct {
+	Name string
+}
+
+// ErrorDetailsServerAlreadyAttached contains the details of a
+// 'server_already_attached' error.
+type ErrorDetailsServerAlreadyAttached struct {
+	Server *Server // the server the resource is attached to, only the ID is set
+}
+
+// ErrorDetailsLocked contains the details of a 'locked' error.
+type ErrorDetailsLocked struct {
+	Action *Action // the action locking the resource, only the ID is set
+}
+
+// IsError returns whether err or an error it wraps is an API error with the
+// given error code.
 func IsError(err error, code ErrorCode) bool {
-	apiErr, ok := err.(Error)
-	return ok && apiErr.Code == code
+	var apiErr Error
+	return errors.As(err, &apiErr) && apiErr.Code == code
 }
@@ -0,0 +1,125 @@
+package hcloud
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("deleting server: %w", Error{Code: ErrorCodeNotFound, Message: "server not found"})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected wrapped error to match ErrNotFound")
+	}
+	if errors.Is(err, ErrLocked) {
+		t.Error("expected wrapped error not to match ErrLocked")
+	}
+	if !errors.Is(err, Error{Code: ErrorCodeNotFound}) {
+		t.Error("expected wrapped error to match Error with the same code")
+	}
+	if !errors.Is(err, &Error{Code: ErrorCodeNotFound}) {
+		t.Error("expected wrapped error to match *Error with the same code")
+	}
+	if !IsError(err, ErrorCodeNotFound) {
+		t.Error("expected IsError to recognize wrapped error")
+	}
+
+	invalidInput := fmt.Errorf("creating server: %w", Error{
+		Code:    ErrorCodeInvalidInput,
+		Details: ErrorDetailsInvalidInput{Fields: []ErrorDetailsInvalidInputField{{Name: "name"}}},
+	})
+	if !errors.Is(invalidInput, ErrInvalidInput) {
+		t.Error("expected error with details to match ErrInvalidInput")
+	}
+
+	actionErr := fmt.Errorf("waiting for action: %w", ActionError{Code: "locked", Message: "locked"})
+	if !errors.Is(actionErr, ErrLocked) {
+		t.Error("expected wrapped action error to match ErrLocked")
+	}
+	if !errors.Is(actionErr, ActionError{Code: "locked"}) {
+		t.Error("expected wrapped action error to match ActionError with the same code")
+	}
+	if errors.Is(actionErr, ActionError{Code: "action_failed"}) {
+		t.Error("expected wrapped action error not to match ActionError with another code")
+	}
+
+	if ErrNotFound.Error() != "hcloud: not_found" {
+		t.Errorf("unexpected sentinel message: %s", ErrNotFound)
+	}
+}
+
+func TestErrorAs(t *testing.T) {
+	err := fmt.Errorf("attaching volume: %w", Error{
+		Code:    ErrorCodeServerAlreadyAttached,
+		Details: ErrorDetailsServerAlreadyAttached{Server: &Server{ID: 42}},
+	})
+
+	var apiErr Error
+	if !errors.As(err, &apiErr) {
+		t.Fatal("expected errors.As to extract Error")
+	}
+	if details, ok := apiErr.Details.(ErrorDetailsServerAlreadyAttached); !ok || details.Server.ID != 42 {
+		t.Errorf("unexpected details: %+v", apiErr.Details)
+	}
+
+	var apiErrPtr *Error
+	if !errors.As(err, &apiErrPtr) || apiErrPtr.Code != ErrorCodeServerAlreadyAttached {
+		t.Errorf("expected errors.As to extract *Error, got %v", apiErrPtr)
+	}
+
+	var actionErr *ActionError
+	if !errors.As(fmt.Errorf("wrapped: %w", ActionError{Code: "action_failed"}), &actionErr) || actionErr.Code != "action_failed" {
+		t.Errorf("expected errors.As to extract *ActionError, got %v", actionErr)
+	}
+	if errors.As(err, &actionErr) {
+		t.Error("expected errors.As not to extract *ActionError from an API error")
+	}
+}
+
+func TestRequestInfoString(t *testing.T) {
+	testCases := map[string]struct {
+		info     RequestInfo
+		expected string
+	}{
+		"complete": {
+			info: RequestInfo{
+				Method:     "GET",
+				Path:       "/v1/servers/1",
+				StatusCode: 404,
+				RequestID:  "4ee4e8e1",
+				Attempts:   2,
+				Time:       time.Date(2021, 1, 5, 11, 0, 0, 0, time.FixedZone("CET", 3600)),
+			},
+			expected: "method=GET path=/v1/servers/1 status=404 request_id=4ee4e8e1 attempts=2 time=2021-01-05T10:00:00Z",
+		},
+		"empty": {
+			expected: "method=- path=- status=0 request_id=- attempts=0 time=-",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if s := tc.info.String(); s != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, s)
+			}
+		})
+	}
+}
+
+func TestErrorDescribe(t *testing.T) {
+	err := Error{Code: ErrorCodeNotFound, Message: "server not found"}
+	if s := err.Describe(); s != "server not found (not_found)" {
+		t.Errorf("unexpected description without request: %q", s)
+	}
+
+	err.Request = &RequestInfo{Method: "GET", Path: "/v1/servers/1", StatusCode: 404, Attempts: 1}
+	if s := err.Describe(); s != "server not found (not_found) method=GET path=/v1/servers/1 status=404 request_id=- attempts=1 time=-" {
+		t.Errorf("unexpected description: %q", s)
+	}
+
+	actionErr := ActionError{Code: "action_failed", Message: "action failed", PollRequest: err.Request}
+	if s := actionErr.Describe(); s != "action failed (action_failed) method=GET path=/v1/servers/1 status=404 request_id=- attempts=1 time=-" {
+		t.Errorf("unexpected description: %q", s)
+	}
+}
@@ -0,0 +1,484 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// Firewall represents a Firewall in the Hetzner Cloud.
+type Firewall struct {
+	ID        int
+	Name      string
+	Labels    map[string]string
+	Created   time.Time
+	Rules     []FirewallRule
+	AppliedTo []FirewallResource
+}
+
+// FirewallRule represents a Firewall's rules.
+type FirewallRule struct {
+	Direction      FirewallRuleDirection
+	SourceIPs      []net.IPNet
+	DestinationIPs []net.IPNet
+	Protocol       FirewallRuleProtocol
+	Port           *string // a single port like "80" or a range like "80-85"
+	Description    *string
+}
+
+// Validate checks if the rule is valid.
+func (r FirewallRule) Validate() error {
+	switch r.Direction {
+	case FirewallRuleDirectionIn:
+		if len(r.SourceIPs) == 0 {
+			return errors.New("missing source IPs")
+		}
+	case FirewallRuleDirectionOut:
+		if len(r.DestinationIPs) == 0 {
+			return errors.New("missing destination IPs")
+		}
+	default:
+		return errors.New("missing or invalid direction")
+	}
+	switch r.Protocol {
+	case FirewallRuleProtocolTCP, FirewallRuleProtocolUDP:
+		if r.Port == nil {
+			return errors.New("missing port")
+		}
+		if err := validateFirewallRulePort(*r.Port); err != nil {
+			return err
+		}
+	case FirewallRuleProtocolICMP, FirewallRuleProtocolESP, FirewallRuleProtocolGRE:
+		if r.Port != nil {
+			return fmt.Errorf("port is not supported for protocol %s", r.Protocol)
+		}
+	default:
+		return errors.New("missing or invalid protocol")
+	}
+	return nil
+}
+
+func validateFirewallRulePort(port string) error {
+	parts := strings.SplitN(port, "-", 2)
+	var bounds []int
+	for _, part := range parts {
+		p, err := strconv.Atoi(part)
+		if err != nil || p < 1 || p > 65535 {
+			return fmt.Errorf("invalid port: %s", port)
+		}
+		bounds = append(bounds, p)
+	}
+	if len(bounds) == 2 && bounds[0] > bounds[1] {
+		return fmt.Errorf("invalid port range: %s", port)
+	}
+	return nil
+}
+
+// FirewallRuleDirection specifies the direction of a Firewall rule.
+type FirewallRuleDirection string
+
+const (
+	// FirewallRuleDirectionIn specifies a rule for inbound traffic.
+	FirewallRuleDirectionIn FirewallRuleDirection = "in"
+
+	// FirewallRuleDirectionOut specifies a rule for outbound traffic.
+	FirewallRuleDirectionOut FirewallRuleDirection = "out"
+)
+
+// FirewallRuleProtocol specifies the protocol of a Firewall rule.
+type FirewallRuleProtocol string
+
+// List of Firewall rule protocols.
+const (
+	FirewallRuleProtocolTCP  FirewallRuleProtocol = "tcp"
+	FirewallRuleProtocolUDP  FirewallRuleProtocol = "udp"
+	FirewallRuleProtocolICMP FirewallRuleProtocol = "icmp"
+	FirewallRuleProtocolESP  FirewallRuleProtocol = "esp"
+	FirewallRuleProtocolGRE  FirewallRuleProtocol = "gre"
+)
+
+// FirewallResourceType specifies the resource to apply a Firewall on.
+type FirewallResourceType string
+
+const (
+	// FirewallResourceTypeServer specifies a Server.
+	FirewallResourceTypeServer FirewallResourceType = "server"
+
+	// FirewallResourceTypeLabelSelector specifies a label selector.
+	FirewallResourceTypeLabelSelector FirewallResourceType = "label_selector"
+)
+
+// FirewallResource represents a resource to apply the new Firewall on.
+type FirewallResource struct {
+	Type          FirewallResourceType
+	Server        *FirewallResourceServer
+	LabelSelector *FirewallResourceLabelSelector
+}
+
+// Validate checks if the resource is valid.
+func (r FirewallResource) Validate() error {
+	switch r.Type {
+	case FirewallResourceTypeServer:
+		if r.Server == nil {
+			return errors.New("missing server")
+		}
+	case FirewallResourceTypeLabelSelector:
+		if r.LabelSelector == nil || r.LabelSelector.Selector == "" {
+			return errors.New("missing label selector")
+		}
+	default:
+		return errors.New("missing or invalid resource type")
+	}
+	return nil
+}
+
+// FirewallResourceServer represents a Server to apply a Firewall on.
+type FirewallResourceServer struct {
+	ID int
+}
+
+// FirewallResourceLabelSelector represents a LabelSelector to apply a Firewall on.
+type FirewallResourceLabelSelector struct {
+	Selector string
+}
+
+// FirewallClient is a client for the Firewalls API.
+type FirewallClient struct {
+	client *Client
+}
+
+// GetByID retrieves a Firewall by its ID. If the Firewall does not exist, nil is returned.
+func (c *FirewallClient) GetByID(ctx context.Context, id int) (*Firewall, *Response, error) {
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("/firewalls/%d", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.FirewallGetResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, nil, err
+	}
+	return FirewallFromSchema(body.Firewall), resp, nil
+}
+
+// GetByName retrieves a Firewall by its name. If the Firewall does not exist, nil is returned.
+func (c *FirewallClient) GetByName(ctx context.Context, name string) (*Firewall, *Response, error) {
+	firewalls, response, err := c.List(ctx, FirewallListOpts{Name: name})
+	if len(firewalls) == 0 {
+		return nil, response, err
+	}
+	return firewalls[0], response, err
+}
+
+// Get retrieves a Firewall by its ID if the input can be parsed as an integer, otherwise it
+// retrieves a Firewall by its name. If the Firewall does not exist, nil is returned.
+func (c *FirewallClient) Get(ctx context.Context, idOrName string) (*Firewall, *Response, error) {
+	if id, err := strconv.Atoi(idOrName); err == nil {
+		return c.GetByID(ctx, int(id))
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// FirewallListOpts specifies options for listing Firewalls.
+type FirewallListOpts struct {
+	ListOpts
+	Name string
+}
+
+func (l FirewallListOpts) values() url.Values {
+	vals := l.ListOpts.values()
+	if l.Name != "" {
+		vals.Add("name", l.Name)
+	}
+	return vals
+}
+
+// List returns a list of Firewalls for a specific page.
+func (c *FirewallClient) List(ctx context.Context, opts FirewallListOpts) ([]*Firewall, *Response, error) {
+	path := "/firewalls?" + opts.values().Encode()
+	req, err := c.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.FirewallListResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+	firewalls := make([]*Firewall, 0, len(body.Firewalls))
+	for _, s := range body.Firewalls {
+		firewalls = append(firewalls, FirewallFromSchema(s))
+	}
+	return firewalls, resp, nil
+}
+
+// All returns all Firewalls.
+func (c *FirewallClient) All(ctx context.Context) ([]*Firewall, error) {
+	return c.AllWithOpts(ctx, FirewallListOpts{ListOpts: ListOpts{PerPage: 50}})
+}
+
+// AllWithOpts returns all Firewalls with the given options.
+func (c *FirewallClient) AllWithOpts(ctx context.Context, opts FirewallListOpts) ([]*Firewall, error) {
+	allFirewalls := []*Firewall{}
+
+	_, err := c.client.all(func(page int) (*Response, error) {
+		opts.Page = page
+		firewalls, resp, err := c.List(ctx, opts)
+		if err != nil {
+			return resp, err
+		}
+		allFirewalls = append(allFirewalls, firewalls...)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allFirewalls, nil
+}
+
+// FirewallCreateOpts specifies options for creating a new Firewall.
+type FirewallCreateOpts struct {
+	Name    string
+	Labels  map[string]string
+	Rules   []FirewallRule
+	ApplyTo []FirewallResource
+}
+
+// Validate checks if options are valid.
+func (o FirewallCreateOpts) Validate() error {
+	if o.Name == "" {
+		return errors.New("missing name")
+	}
+	if err := validateFirewallRules(o.Rules); err != nil {
+		return err
+	}
+	return validateFirewallResources(o.ApplyTo)
+}
+
+// FirewallCreateResult is the result of a create Firewall operation.
+type FirewallCreateResult struct {
+	Firewall *Firewall
+	Actions  []*Action
+}
+
+// Create creates a new Firewall.
+func (c *FirewallClient) Create(ctx context.Context, opts FirewallCreateOpts) (FirewallCreateResult, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return FirewallCreateResult{}, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.FirewallCreateRequest{
+		Name:    opts.Name,
+		Rules:   firewallRulesToSchema(opts.Rules),
+		ApplyTo: firewallResourcesToSchema(opts.ApplyTo),
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return FirewallCreateResult{}, nil, err
+	}
+	req, err := c.client.NewRequest(ctx, "POST", "/firewalls", bytes.NewReader(reqBodyData))
+	if err != nil {
+		return FirewallCreateResult{}, nil, err
+	}
+
+	respBody := schema.FirewallCreateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return FirewallCreateResult{}, resp, err
+	}
+	return FirewallCreateResult{
+		Firewall: FirewallFromSchema(respBody.Firewall),
+		Actions:  ActionsFromSchema(respBody.Actions),
+	}, resp, nil
+}
+
+// FirewallUpdateOpts specifies options for updating a Firewall.
+type FirewallUpdateOpts struct {
+	Name   string
+	Labels map[string]string
+}
+
+// Update updates a Firewall.
+func (c *FirewallClient) Update(ctx context.Context, firewall *Firewall, opts FirewallUpdateOpts) (*Firewall, *Response, error) {
+	reqBody := schema.FirewallUpdateRequest{}
+	if opts.Name != "" {
+		reqBody.Name = &opts.Name
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/firewalls/%d", firewall.ID)
+	req, err := c.client.NewRequest(ctx, "PUT", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.FirewallUpdateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return FirewallFromSchema(respBody.Firewall), resp, nil
+}
+
+// Delete deletes a Firewall.
+func (c *FirewallClient) Delete(ctx context.Context, firewall *Firewall) (*Response, error) {
+	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("/firewalls/%d", firewall.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req, nil)
+}
+
+// FirewallSetRulesOpts specifies options for setting rules of a Firewall.
+type FirewallSetRulesOpts struct {
+	Rules []FirewallRule
+}
+
+// SetRules sets the rules of a Firewall. All existing rules are overwritten,
+// passing no rules removes all of them.
+func (c *FirewallClient) SetRules(ctx context.Context, firewall *Firewall, opts FirewallSetRulesOpts) ([]*Action, *Response, error) {
+	if err := validateFirewallRules(opts.Rules); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.FirewallActionSetRulesRequest{
+		Rules: firewallRulesToSchema(opts.Rules),
+	}
+	if reqBody.Rules == nil {
+		reqBody.Rules = []schema.FirewallRule{}
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/firewalls/%d/actions/set_rules", firewall.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.FirewallActionSetRulesResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionsFromSchema(respBody.Actions), resp, nil
+}
+
+// ApplyResources applies a Firewall to servers or label selectors.
+func (c *FirewallClient) ApplyResources(ctx context.Context, firewall *Firewall, resources []FirewallResource) ([]*Action, *Response, error) {
+	if err := validateFirewallResources(resources); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.FirewallActionApplyToResourcesRequest{
+		ApplyTo: firewallResourcesToSchema(resources),
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/firewalls/%d/actions/apply_to_resources", firewall.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.FirewallActionApplyToResourcesResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionsFromSchema(respBody.Actions), resp, nil
+}
+
+// RemoveResources removes a Firewall from servers or label selectors.
+func (c *FirewallClient) RemoveResources(ctx context.Context, firewall *Firewall, resources []FirewallResource) ([]*Action, *Response, error) {
+	if err := validateFirewallResources(resources); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.FirewallActionRemoveFromResourcesRequest{
+		RemoveFrom: firewallResourcesToSchema(resources),
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/firewalls/%d/actions/remove_from_resources", firewall.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.FirewallActionRemoveFromResourcesResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionsFromSchema(respBody.Actions), resp, nil
+}
+
+func validateFirewallRules(rules []FirewallRule) error {
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rule %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func validateFirewallResources(resources []FirewallResource) error {
+	for i, resource := range resources {
+		if err := resource.Validate(); err != nil {
+			return fmt.Errorf("resource %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+func firewallRulesToSchema(rules []FirewallRule) []schema.FirewallRule {
+	var s []schema.FirewallRule
+	for _, rule := range rules {
+		s = append(s, SchemaFromFirewallRule(rule))
+	}
+	return s
+}
+
+func firewallResourcesToSchema(resources []FirewallResource) []schema.FirewallResource {
+	var s []schema.FirewallResource
+	for _, resource := range resources {
+		r := schema.FirewallResource{Type: string(resource.Type)}
+		switch resource.Type {
+		case FirewallResourceTypeServer:
+			r.Server = &schema.FirewallResourceServer{ID: resource.Server.ID}
+		case FirewallResourceTypeLabelSelector:
+			r.LabelSelector = &schema.FirewallResourceLabelSelector{Selector: resource.LabelSelector.Selector}
+		}
+		s = append(s, r)
+	}
+	return s
+}
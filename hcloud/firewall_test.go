@@ -0,0 +1,404 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestFirewallClientGetByID(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.FirewallGetResponse{
+			Firewall: schema.Firewall{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	firewall, _, err := env.Client.Firewall.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firewall == nil {
+		t.Fatal("no firewall")
+	}
+	if firewall.ID != 1 {
+		t.Errorf("unexpected firewall ID: %v", firewall.ID)
+	}
+
+	t.Run("via Get", func(t *testing.T) {
+		firewall, _, err := env.Client.Firewall.Get(ctx, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if firewall == nil {
+			t.Fatal("no firewall")
+		}
+		if firewall.ID != 1 {
+			t.Errorf("unexpected firewall ID: %v", firewall.ID)
+		}
+	})
+}
+
+func TestFirewallClientGetByIDNotFound(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(schema.ErrorResponse{
+			Error: schema.Error{
+				Code: string(ErrorCodeNotFound),
+			},
+		})
+	})
+
+	ctx := context.Background()
+	firewall, _, err := env.Client.Firewall.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firewall != nil {
+		t.Fatal("expected no firewall")
+	}
+}
+
+func TestFirewallClientGetByName(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "name=myfirewall" {
+			t.Fatal("missing name query")
+		}
+		json.NewEncoder(w).Encode(schema.FirewallListResponse{
+			Firewalls: []schema.Firewall{{ID: 1, Name: "myfirewall"}},
+		})
+	})
+
+	ctx := context.Background()
+	firewall, _, err := env.Client.Firewall.Get(ctx, "myfirewall")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firewall == nil {
+		t.Fatal("no firewall")
+	}
+	if firewall.ID != 1 {
+		t.Errorf("unexpected firewall ID: %v", firewall.ID)
+	}
+}
+
+func TestFirewallClientAllWithOpts(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls", func(w http.ResponseWriter, r *http.Request) {
+		if s := r.URL.Query().Get("label_selector"); s != "env=prod" {
+			t.Errorf("unexpected label selector: %s", s)
+		}
+		json.NewEncoder(w).Encode(struct {
+			Firewalls []schema.Firewall `json:"firewalls"`
+			Meta      schema.Meta       `json:"meta"`
+		}{
+			Firewalls: []schema.Firewall{{ID: 1}, {ID: 2}},
+			Meta: schema.Meta{
+				Pagination: &schema.MetaPagination{Page: 1, LastPage: 1, PerPage: 50, TotalEntries: 2},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	firewalls, err := env.Client.Firewall.AllWithOpts(ctx, FirewallListOpts{ListOpts: ListOpts{LabelSelector: "env=prod"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firewalls) != 2 {
+		t.Fatalf("unexpected number of firewalls: %d", len(firewalls))
+	}
+}
+
+func TestFirewallClientCreate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.FirewallCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name != "myfirewall" {
+			t.Errorf("unexpected name: %v", reqBody.Name)
+		}
+		if reqBody.Labels == nil || (*reqBody.Labels)["key"] != "value" {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		if len(reqBody.Rules) != 1 {
+			t.Fatalf("unexpected rules: %v", reqBody.Rules)
+		}
+		rule := reqBody.Rules[0]
+		if rule.Direction != "in" || rule.Protocol != "tcp" || *rule.Port != "80-85" {
+			t.Errorf("unexpected rule: %+v", rule)
+		}
+		if len(rule.SourceIPs) != 2 || rule.SourceIPs[0] != "10.0.0.0/8" || rule.SourceIPs[1] != "2001:db8::/32" {
+			t.Errorf("unexpected source IPs: %v", rule.SourceIPs)
+		}
+		if len(reqBody.ApplyTo) != 2 ||
+			reqBody.ApplyTo[0].Server == nil || reqBody.ApplyTo[0].Server.ID != 5 ||
+			reqBody.ApplyTo[1].LabelSelector == nil || reqBody.ApplyTo[1].LabelSelector.Selector != "env=prod" {
+			t.Errorf("unexpected apply to: %v", reqBody.ApplyTo)
+		}
+		json.NewEncoder(w).Encode(schema.FirewallCreateResponse{
+			Firewall: schema.Firewall{ID: 1},
+			Actions:  []schema.Action{{ID: 2}, {ID: 3}},
+		})
+	})
+
+	_, v4, _ := net.ParseCIDR("10.0.0.0/8")
+	_, v6, _ := net.ParseCIDR("2001:db8::/32")
+	ctx := context.Background()
+	result, _, err := env.Client.Firewall.Create(ctx, FirewallCreateOpts{
+		Name:   "myfirewall",
+		Labels: map[string]string{"key": "value"},
+		Rules: []FirewallRule{{
+			Direction: FirewallRuleDirectionIn,
+			SourceIPs: []net.IPNet{*v4, *v6},
+			Protocol:  FirewallRuleProtocolTCP,
+			Port:      String("80-85"),
+		}},
+		ApplyTo: []FirewallResource{
+			{Type: FirewallResourceTypeServer, Server: &FirewallResourceServer{ID: 5}},
+			{Type: FirewallResourceTypeLabelSelector, LabelSelector: &FirewallResourceLabelSelector{Selector: "env=prod"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Firewall.ID != 1 {
+		t.Errorf("unexpected firewall ID: %v", result.Firewall.ID)
+	}
+	if len(result.Actions) != 2 {
+		t.Errorf("unexpected number of actions: %d", len(result.Actions))
+	}
+}
+
+func TestFirewallCreateOptsValidate(t *testing.T) {
+	_, all, _ := net.ParseCIDR("0.0.0.0/0")
+	testCases := map[string]FirewallCreateOpts{
+		"missing name": {},
+		"missing direction": {Name: "fw", Rules: []FirewallRule{
+			{SourceIPs: []net.IPNet{*all}, Protocol: FirewallRuleProtocolICMP},
+		}},
+		"missing source IPs": {Name: "fw", Rules: []FirewallRule{
+			{Direction: FirewallRuleDirectionIn, Protocol: FirewallRuleProtocolICMP},
+		}},
+		"missing destination IPs": {Name: "fw", Rules: []FirewallRule{
+			{Direction: FirewallRuleDirectionOut, SourceIPs: []net.IPNet{*all}, Protocol: FirewallRuleProtocolICMP},
+		}},
+		"missing port": {Name: "fw", Rules: []FirewallRule{
+			{Direction: FirewallRuleDirectionIn, SourceIPs: []net.IPNet{*all}, Protocol: FirewallRuleProtocolTCP},
+		}},
+		"invalid port range": {Name: "fw", Rules: []FirewallRule{
+			{Direction: FirewallRuleDirectionIn, SourceIPs: []net.IPNet{*all}, Protocol: FirewallRuleProtocolUDP, Port: String("90-80")},
+		}},
+		"port with icmp": {Name: "fw", Rules: []FirewallRule{
+			{Direction: FirewallRuleDirectionIn, SourceIPs: []net.IPNet{*all}, Protocol: FirewallRuleProtocolICMP, Port: String("80")},
+		}},
+		"missing server": {Name: "fw", ApplyTo: []FirewallResource{
+			{Type: FirewallResourceTypeServer},
+		}},
+	}
+	for name, opts := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := opts.Validate(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestFirewallClientCreateInvalidOpts(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	_, _, err := env.Client.Firewall.Create(context.Background(), FirewallCreateOpts{})
+	if err == nil || err.Error() != "invalid options: missing name" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFirewallClientUpdate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Error("expected PUT")
+		}
+		var reqBody schema.FirewallUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name == nil || *reqBody.Name != "test" {
+			t.Errorf("unexpected name: %v", reqBody.Name)
+		}
+		if reqBody.Labels != nil {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		json.NewEncoder(w).Encode(schema.FirewallUpdateResponse{
+			Firewall: schema.Firewall{ID: 1, Name: "test"},
+		})
+	})
+
+	ctx := context.Background()
+	firewall, _, err := env.Client.Firewall.Update(ctx, &Firewall{ID: 1}, FirewallUpdateOpts{Name: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firewall.Name != "test" {
+		t.Errorf("unexpected name: %v", firewall.Name)
+	}
+}
+
+func TestFirewallClientDelete(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Error("expected DELETE")
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := env.Client.Firewall.Delete(ctx, &Firewall{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFirewallClientSetRules(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1/actions/set_rules", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.FirewallActionSetRulesRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if len(reqBody.Rules) != 1 {
+			t.Fatalf("unexpected rules: %v", reqBody.Rules)
+		}
+		rule := reqBody.Rules[0]
+		if rule.Direction != "out" || rule.Protocol != "icmp" || rule.Port != nil {
+			t.Errorf("unexpected rule: %+v", rule)
+		}
+		if len(rule.DestinationIPs) != 1 || rule.DestinationIPs[0] != "0.0.0.0/0" {
+			t.Errorf("unexpected destination IPs: %v", rule.DestinationIPs)
+		}
+		json.NewEncoder(w).Encode(schema.FirewallActionSetRulesResponse{
+			Actions: []schema.Action{{ID: 1}},
+		})
+	})
+
+	_, all, _ := net.ParseCIDR("0.0.0.0/0")
+	ctx := context.Background()
+	actions, _, err := env.Client.Firewall.SetRules(ctx, &Firewall{ID: 1}, FirewallSetRulesOpts{
+		Rules: []FirewallRule{{
+			Direction:      FirewallRuleDirectionOut,
+			DestinationIPs: []net.IPNet{*all},
+			Protocol:       FirewallRuleProtocolICMP,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 1 || actions[0].ID != 1 {
+		t.Errorf("unexpected actions: %v", actions)
+	}
+}
+
+func TestFirewallClientSetRulesEmpty(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1/actions/set_rules", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if rules, ok := reqBody["rules"].([]interface{}); !ok || len(rules) != 0 {
+			t.Errorf("unexpected rules: %v", reqBody["rules"])
+		}
+		json.NewEncoder(w).Encode(schema.FirewallActionSetRulesResponse{})
+	})
+
+	ctx := context.Background()
+	if _, _, err := env.Client.Firewall.SetRules(ctx, &Firewall{ID: 1}, FirewallSetRulesOpts{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFirewallClientApplyResources(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1/actions/apply_to_resources", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.FirewallActionApplyToResourcesRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if len(reqBody.ApplyTo) != 1 || reqBody.ApplyTo[0].Type != "server" || reqBody.ApplyTo[0].Server.ID != 5 {
+			t.Errorf("unexpected resources: %v", reqBody.ApplyTo)
+		}
+		json.NewEncoder(w).Encode(schema.FirewallActionApplyToResourcesResponse{
+			Actions: []schema.Action{{ID: 1}},
+		})
+	})
+
+	ctx := context.Background()
+	actions, _, err := env.Client.Firewall.ApplyResources(ctx, &Firewall{ID: 1}, []FirewallResource{
+		{Type: FirewallResourceTypeServer, Server: &FirewallResourceServer{ID: 5}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 1 {
+		t.Errorf("unexpected actions: %v", actions)
+	}
+}
+
+func TestFirewallClientRemoveResources(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/firewalls/1/actions/remove_from_resources", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.FirewallActionRemoveFromResourcesRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if len(reqBody.RemoveFrom) != 1 || reqBody.RemoveFrom[0].LabelSelector.Selector != "env=prod" {
+			t.Errorf("unexpected resources: %v", reqBody.RemoveFrom)
+		}
+		json.NewEncoder(w).Encode(schema.FirewallActionRemoveFromResourcesResponse{
+			Actions: []schema.Action{{ID: 1}},
+		})
+	})
+
+	ctx := context.Background()
+	actions, _, err := env.Client.Firewall.RemoveResources(ctx, &Firewall{ID: 1}, []FirewallResource{
+		{Type: FirewallResourceTypeLabelSelector, LabelSelector: &FirewallResourceLabelSelector{Selector: "env=prod"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 1 {
+		t.Errorf("unexpected actions: %v", actions)
+	}
+}
@@ -1,5 +1,7 @@
 // Package hcloud is a library for the Hetzner Cloud API.
 package hcloud
 
+//go:generate go run ./internal/mockgen
+
 // Version is the library's version following Semantic Versioning.
 const Version = "1.18.0"
@@ -0,0 +1,155 @@
+package hcloudtest
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// action is an action which runs for duration after it was started and then
+// succeeds.
+type action struct {
+	schema.Action
+	duration  time.Duration
+	onSuccess func()
+	done      bool
+}
+
+// startAction starts an action on the given resources. onSuccess, if not
+// nil, is called when the action succeeds and applies the changes which only
+// become visible once the action has finished.
+func (s *Server) startAction(command string, onSuccess func(), resources ...schema.ActionResourceReference) *action {
+	a := &action{
+		Action: schema.Action{
+			ID:        s.newID("action"),
+			Command:   command,
+			Started:   s.Clock.Now(),
+			Resources: resources,
+		},
+		duration:  s.actionDuration,
+		onSuccess: onSuccess,
+	}
+	s.actions[a.ID] = a
+	if a.duration <= 0 {
+		s.finishAction(a)
+	}
+	return a
+}
+
+func (s *Server) finishAction(a *action) {
+	a.done = true
+	if a.onSuccess != nil {
+		a.onSuccess()
+	}
+}
+
+// settleActions finishes all actions whose duration has passed on the clock.
+func (s *Server) settleActions() {
+	now := s.Clock.Now()
+	ids := make([]int, 0, len(s.actions))
+	for id, a := range s.actions {
+		if !a.done && !now.Before(a.Started.Add(a.duration)) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		s.finishAction(s.actions[id])
+	}
+}
+
+// locked reports whether the resource has an action which is still running
+// and returns the ID of that action.
+func (s *Server) locked(typ string, id int) (int, bool) {
+	for _, a := range s.actions {
+		if a.done {
+			continue
+		}
+		for _, r := range a.Resources {
+			if r.Type == typ && r.ID == id {
+				return a.ID, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) renderAction(a *action) schema.Action {
+	out := a.Action
+	out.Resources = append([]schema.ActionResourceReference{}, a.Resources...)
+	if a.done {
+		finished := a.Started.Add(a.duration)
+		out.Status = string(hcloud.ActionStatusSuccess)
+		out.Progress = 100
+		out.Finished = &finished
+		return out
+	}
+	out.Status = string(hcloud.ActionStatusRunning)
+	out.Progress = int(s.Clock.Now().Sub(a.Started) * 100 / a.duration)
+	return out
+}
+
+func resourceRef(typ string, id int) schema.ActionResourceReference {
+	return schema.ActionResourceReference{Type: typ, ID: id}
+}
+
+func (s *Server) handleActions(r *request) (int, interface{}, error) {
+	if len(r.segments) == 1 && r.Method == "GET" {
+		return s.listActions(r, func(a *action) bool { return true })
+	}
+	id, ok := r.id(1)
+	if len(r.segments) != 2 || r.Method != "GET" || !ok {
+		return 0, nil, newError(hcloud.ErrorCodeNotFound, "not found")
+	}
+	a, ok := s.actions[id]
+	if !ok {
+		return 0, nil, notFound("action", id)
+	}
+	return http.StatusOK, schema.ActionGetResponse{Action: s.renderAction(a)}, nil
+}
+
+// listActions lists the actions matching filter and the id and status
+// query parameters.
+func (s *Server) listActions(r *request, filter func(a *action) bool) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, a := range s.actions {
+		if !filter(a) {
+			continue
+		}
+		if wanted := query["id"]; len(wanted) > 0 && !containsString(wanted, strconv.Itoa(id)) {
+			continue
+		}
+		if statuses := query["status"]; len(statuses) > 0 && !containsString(statuses, s.renderAction(a).Status) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "actions", ids,
+		func(id int) map[string]string { return nil },
+		func(id int) interface{} { return s.renderAction(s.actions[id]) },
+	)
+}
+
+// resourceActions returns a handler listing the actions of a resource.
+func (s *Server) resourceActions(typ string) func(r *request, id int) (int, interface{}, error) {
+	return func(r *request, id int) (int, interface{}, error) {
+		return s.listActions(r, func(a *action) bool {
+			for _, ref := range a.Resources {
+				if ref.Type == typ && ref.ID == id {
+					return true
+				}
+			}
+			return false
+		})
+	}
+}
+
+// actionResponse returns the response of an action endpoint.
+func (s *Server) actionResponse(a *action) (int, interface{}, error) {
+	return http.StatusCreated, map[string]interface{}{"action": s.renderAction(a)}, nil
+}
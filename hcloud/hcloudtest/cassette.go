@@ -0,0 +1,336 @@
+package hcloudtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// RecorderMode is the mode of a Recorder.
+type RecorderMode int
+
+// Recorder modes.
+const (
+	// ModeReplay serves responses from the cassette and never sends requests.
+	ModeReplay RecorderMode = iota
+
+	// ModeRecord sends requests to the API and records the interactions.
+	ModeRecord
+)
+
+// Cassette is a sequence of recorded interactions with an API.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request recorded in a Cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response recorded in a Cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// A RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithUnorderedMatching configures a Recorder to replay interactions in any
+// order. Each request is answered by the first unused interaction matching
+// it, so repeated requests like polling an action still receive their
+// responses in recorded order. By default, requests must be made in the
+// order they were recorded.
+func WithUnorderedMatching() RecorderOption {
+	return func(r *Recorder) {
+		r.unordered = true
+	}
+}
+
+// WithTransport configures the transport a Recorder sends requests with in
+// record mode. By default, http.DefaultTransport is used.
+func WithTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubber adds a function modifying interactions before they are saved,
+// to remove secrets in addition to the ones scrubbed by default.
+func WithScrubber(scrub func(*Interaction)) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrub)
+	}
+}
+
+// Recorder is an http.RoundTripper which records interactions with the Cloud
+// and DNS APIs to a cassette file and replays them in tests:
+//
+//	rec, err := hcloudtest.NewRecorder("testdata/servers.json", hcloudtest.ModeReplay)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer func() {
+//		if err := rec.Stop(); err != nil {
+//			t.Error(err)
+//		}
+//	}()
+//	client := hcloud.NewClient(hcloud.WithToken(token), hcloud.WithHTTPClient(rec.HTTPClient()))
+//
+// Recorded interactions are scrubbed of API tokens and root passwords.
+// In replay mode, requests are matched by method, path, query and body.
+// Requests matching no interaction fail with an error, which Stop reports
+// again in case the error got lost on the way.
+type Recorder struct {
+	path      string
+	mode      RecorderMode
+	unordered bool
+	transport http.RoundTripper
+	scrubbers []func(*Interaction)
+
+	mu        sync.Mutex
+	cassette  *Cassette
+	used      []bool
+	next      int
+	unmatched []string
+}
+
+// NewRecorder creates a Recorder for the cassette file at path. In replay
+// mode, the cassette is loaded from the file.
+func NewRecorder(path string, mode RecorderMode, options ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		cassette:  &Cassette{},
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("hcloudtest: loading cassette: %s", err)
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("hcloudtest: loading cassette %s: %s", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// HTTPClient returns an HTTP client using the recorder as transport, to be
+// used with hcloud.WithHTTPClient.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop saves the cassette in record mode. In replay mode, it returns an error
+// if any request did not match a recorded interaction.
+func (r *Recorder) Stop() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mode == ModeReplay {
+		if len(r.unmatched) > 0 {
+			return fmt.Errorf("hcloudtest: %d unmatched requests: %s", len(r.unmatched), strings.Join(r.unmatched, ", "))
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	outReq := new(http.Request)
+	*outReq = *req
+	if body != nil {
+		outReq.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	resp, err := r.transport.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: cloneHeader(req.Header),
+			Body:   string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     cloneHeader(resp.Header),
+			Body:       string(respBody),
+		},
+	}
+	scrub(interaction)
+	for _, scrubber := range r.scrubbers {
+		scrubber(interaction)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := newMatchKey(req.Method, req.URL, string(body))
+	for i := r.next; i < len(r.cassette.Interactions); i++ {
+		if r.used[i] {
+			continue
+		}
+		interaction := r.cassette.Interactions[i]
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			return nil, fmt.Errorf("hcloudtest: invalid URL in cassette: %s", err)
+		}
+		if newMatchKey(interaction.Request.Method, recordedURL, interaction.Request.Body) == key {
+			r.used[i] = true
+			if !r.unordered {
+				r.next = i + 1
+			}
+			return interaction.Response.httpResponse(req), nil
+		}
+		if !r.unordered {
+			break
+		}
+	}
+
+	desc := req.Method + " " + req.URL.RequestURI()
+	r.unmatched = append(r.unmatched, desc)
+	if !r.unordered && r.next < len(r.cassette.Interactions) {
+		expected := r.cassette.Interactions[r.next].Request
+		return nil, fmt.Errorf("hcloudtest: request %s does not match the next recorded interaction %s %s", desc, expected.Method, expected.URL)
+	}
+	return nil, fmt.Errorf("hcloudtest: no recorded interaction matches request %s", desc)
+}
+
+func (resp RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cloneHeader(resp.Header),
+		Body:          ioutil.NopCloser(strings.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+func cloneHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	clone := make(http.Header, len(header))
+	for key, values := range header {
+		clone[key] = append([]string(nil), values...)
+	}
+	return clone
+}
+
+// matchKey is what requests are matched by when replaying.
+type matchKey struct {
+	method string
+	path   string
+	query  string
+	body   string
+}
+
+func newMatchKey(method string, u *url.URL, body string) matchKey {
+	return matchKey{
+		method: method,
+		path:   u.Path,
+		query:  u.Query().Encode(),
+		body:   normalizeBody(body),
+	}
+}
+
+// normalizeBody returns JSON bodies with sorted object keys and without
+// insignificant whitespace. Other bodies are returned as they are.
+func normalizeBody(body string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return body
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+const scrubbed = "REDACTED"
+
+// secretHeaders are the request headers carrying API tokens.
+var secretHeaders = []string{"Authorization", "Auth-API-Token"}
+
+var rootPasswordRegexp = regexp.MustCompile(`("root_password"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// scrub removes API tokens and root passwords from an interaction.
+func scrub(interaction *Interaction) {
+	for _, name := range secretHeaders {
+		if interaction.Request.Header.Get(name) != "" {
+			interaction.Request.Header.Set(name, scrubbed)
+		}
+	}
+	interaction.Request.Body = rootPasswordRegexp.ReplaceAllString(interaction.Request.Body, `$1"`+scrubbed+`"`)
+	interaction.Response.Body = rootPasswordRegexp.ReplaceAllString(interaction.Response.Body, `$1"`+scrubbed+`"`)
+}
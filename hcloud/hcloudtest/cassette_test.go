@@ -0,0 +1,204 @@
+package hcloudtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hcloudtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "testdata", "servers.json")
+	ctx := context.Background()
+	opts := hcloud.ServerCreateOpts{
+		Name:       "web",
+		ServerType: &hcloud.ServerType{Name: "cx11"},
+		Image:      &hcloud.Image{Name: "ubuntu-20.04"},
+		Labels:     map[string]string{"env": "prod", "tier": "web"},
+	}
+
+	srv := NewServer(WithToken("secret"))
+	rec, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := srv.Client(hcloud.WithToken("secret"), hcloud.WithHTTPClient(rec.HTTPClient()))
+	recorded, _, err := client.Server.Create(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded.RootPassword == "" || recorded.RootPassword == scrubbed {
+		t.Fatalf("expected the real root password while recording, got %q", recorded.RootPassword)
+	}
+	if _, err := client.Server.AllWithOpts(ctx, hcloud.ServerListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "env=prod", PerPage: 10}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") || strings.Contains(string(data), recorded.RootPassword) {
+		t.Errorf("cassette contains secrets:\n%s", data)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 2 {
+		t.Fatalf("expected 2 interactions, got %d", len(cassette.Interactions))
+	}
+
+	rec, err = NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = hcloud.NewClient(hcloud.WithEndpoint(srv.URL), hcloud.WithToken("other"), hcloud.WithHTTPClient(rec.HTTPClient()))
+	replayed, _, err := client.Server.Create(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.Server.ID != recorded.Server.ID || replayed.RootPassword != scrubbed {
+		t.Errorf("unexpected replayed result: %+v", replayed)
+	}
+	servers, err := client.Server.AllWithOpts(ctx, hcloud.ServerListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "env=prod", PerPage: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 1 || servers[0].Name != "web" {
+		t.Errorf("unexpected servers: %v", servers)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Server.GetByID(ctx, recorded.Server.ID)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction matches request GET /servers/1") {
+		t.Errorf("expected unmatched request error, got %v", err)
+	}
+	if err := rec.Stop(); err == nil {
+		t.Error("expected Stop to report the unmatched request")
+	}
+}
+
+// writeTestCassette writes a cassette polling action 1 until it succeeds.
+func writeTestCassette(t *testing.T, dir string) string {
+	action := func(status string, progress int) string {
+		return fmt.Sprintf(`{"action": {"id": 1, "command": "create_server", "status": %q, "progress": %d, "started": "2021-01-01T00:00:00Z", "resources": []}}`, status, progress)
+	}
+	interaction := func(method, url, body string, status int, respBody string) *Interaction {
+		return &Interaction{
+			Request: RecordedRequest{Method: method, URL: url, Body: body},
+			Response: RecordedResponse{
+				StatusCode: status,
+				Header:     map[string][]string{"Content-Type": {"application/json"}},
+				Body:       respBody,
+			},
+		}
+	}
+	cassette := Cassette{Interactions: []*Interaction{
+		interaction("PUT", "https://api.hetzner.cloud/v1/ssh_keys/1", `{"name":"key","labels":{"a":"1","b":"2"}}`, 200,
+			`{"ssh_key": {"id": 1, "name": "key", "fingerprint": "", "public_key": "", "labels": {"a": "1", "b": "2"}}}`),
+		interaction("GET", "https://api.hetzner.cloud/v1/actions/1", "", 200, action("running", 0)),
+		interaction("GET", "https://api.hetzner.cloud/v1/actions/1", "", 200, action("running", 50)),
+		interaction("GET", "https://api.hetzner.cloud/v1/actions/1", "", 200, action("success", 100)),
+	}}
+	data, err := json.Marshal(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "cassette.json")
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRecorderReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hcloudtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	ctx := context.Background()
+
+	t.Run("ordered", func(t *testing.T) {
+		rec, err := NewRecorder(writeTestCassette(t, dir), ModeReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := hcloud.NewClient(hcloud.WithHTTPClient(rec.HTTPClient()), hcloud.WithPollInterval(time.Millisecond))
+
+		// The JSON body is matched regardless of its key order.
+		_, _, err = client.SSHKey.Update(ctx, &hcloud.SSHKey{ID: 1}, hcloud.SSHKeyUpdateOpts{
+			Name:   "key",
+			Labels: map[string]string{"b": "2", "a": "1"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Polling consumes the recorded action responses in order until the
+		// action succeeds.
+		_, errCh := client.Action.WatchProgress(ctx, &hcloud.Action{ID: 1})
+		if err := <-errCh; err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := client.Action.GetByID(ctx, 1); err == nil {
+			t.Error("expected error after all interactions were replayed")
+		}
+		if err := rec.Stop(); err == nil {
+			t.Error("expected Stop to report the unmatched request")
+		}
+	})
+
+	t.Run("ordered mismatch", func(t *testing.T) {
+		rec, err := NewRecorder(writeTestCassette(t, dir), ModeReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := hcloud.NewClient(hcloud.WithHTTPClient(rec.HTTPClient()))
+		_, _, err = client.Action.GetByID(ctx, 1)
+		if err == nil || !strings.Contains(err.Error(), "does not match the next recorded interaction PUT") {
+			t.Errorf("expected ordering error, got %v", err)
+		}
+	})
+
+	t.Run("unordered", func(t *testing.T) {
+		rec, err := NewRecorder(writeTestCassette(t, dir), ModeReplay, WithUnorderedMatching())
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := hcloud.NewClient(hcloud.WithHTTPClient(rec.HTTPClient()))
+		action, _, err := client.Action.GetByID(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if action.Progress != 0 {
+			t.Errorf("expected first recorded response, got progress %d", action.Progress)
+		}
+		_, _, err = client.SSHKey.Update(ctx, &hcloud.SSHKey{ID: 1}, hcloud.SSHKeyUpdateOpts{
+			Name:   "key",
+			Labels: map[string]string{"a": "1", "b": "2"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package hcloudtest
+
+import (
+	"strconv"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// The fake API knows a fixed set of locations, datacenters and server types.
+var (
+	locations = []schema.Location{
+		{ID: 1, Name: "fsn1", Description: "Falkenstein DC Park 1", Country: "DE", City: "Falkenstein", NetworkZone: "eu-central"},
+		{ID: 2, Name: "nbg1", Description: "Nuremberg DC Park 1", Country: "DE", City: "Nuremberg", NetworkZone: "eu-central"},
+		{ID: 3, Name: "hel1", Description: "Helsinki DC Park 1", Country: "FI", City: "Helsinki", NetworkZone: "eu-central"},
+		{ID: 4, Name: "ash", Description: "Ashburn, VA", Country: "US", City: "Ashburn, VA", NetworkZone: "us-east"},
+	}
+
+	datacenters = []schema.Datacenter{
+		{ID: 4, Name: "fsn1-dc14", Description: "Falkenstein 1 DC14", Location: locations[0]},
+		{ID: 2, Name: "nbg1-dc3", Description: "Nuremberg 1 DC 3", Location: locations[1]},
+		{ID: 3, Name: "hel1-dc2", Description: "Helsinki 1 DC 2", Location: locations[2]},
+		{ID: 5, Name: "ash-dc1", Description: "Ashburn DC1", Location: locations[3]},
+	}
+
+	serverTypes = []schema.ServerType{
+		{ID: 1, Name: "cx11", Description: "CX11", Cores: 1, Memory: 2, Disk: 20, StorageType: "local", CPUType: "shared"},
+		{ID: 3, Name: "cx21", Description: "CX21", Cores: 2, Memory: 4, Disk: 40, StorageType: "local", CPUType: "shared"},
+		{ID: 22, Name: "cpx11", Description: "CPX 11", Cores: 2, Memory: 2, Disk: 40, StorageType: "local", CPUType: "shared"},
+	}
+)
+
+// idOrNameMatches reports whether v, an ID or name as decoded from JSON,
+// refers to a resource with the given ID and name.
+func idOrNameMatches(v interface{}, id int, name string) bool {
+	switch v := v.(type) {
+	case float64:
+		return int(v) == id
+	case string:
+		return v != "" && (v == name || v == strconv.Itoa(id))
+	}
+	return false
+}
+
+func findLocation(v interface{}) (schema.Location, bool) {
+	for _, location := range locations {
+		if idOrNameMatches(v, location.ID, location.Name) {
+			return location, true
+		}
+	}
+	return schema.Location{}, false
+}
+
+func findDatacenter(v interface{}) (schema.Datacenter, bool) {
+	for _, datacenter := range datacenters {
+		if idOrNameMatches(v, datacenter.ID, datacenter.Name) {
+			return datacenter, true
+		}
+	}
+	return schema.Datacenter{}, false
+}
+
+// datacenterInLocation returns the datacenter of a location.
+func datacenterInLocation(location schema.Location) schema.Datacenter {
+	for _, datacenter := range datacenters {
+		if datacenter.Location.ID == location.ID {
+			return datacenter
+		}
+	}
+	return datacenters[0]
+}
+
+func findServerType(v interface{}) (schema.ServerType, bool) {
+	for _, serverType := range serverTypes {
+		if idOrNameMatches(v, serverType.ID, serverType.Name) {
+			return serverType, true
+		}
+	}
+	return schema.ServerType{}, false
+}
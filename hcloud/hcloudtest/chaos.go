@@ -0,0 +1,298 @@
+package hcloudtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+// ChaosKind is a kind of trouble a ChaosTransport injects.
+type ChaosKind string
+
+// Kinds of trouble a ChaosTransport injects.
+const (
+	// ChaosLatency delays the request by the rule's Latency.
+	ChaosLatency ChaosKind = "latency"
+
+	// ChaosConnectionError fails the request with a connection reset error
+	// without sending it.
+	ChaosConnectionError ChaosKind = "connection_error"
+
+	// ChaosServerError responds with the rule's StatusCode. Status 500 comes
+	// with a service_error response, other codes with a plain text body like
+	// a proxy in front of the API would send.
+	ChaosServerError ChaosKind = "server_error"
+
+	// ChaosRateLimit responds with a rate_limit_exceeded error and RateLimit
+	// headers announcing no remaining requests.
+	ChaosRateLimit ChaosKind = "rate_limit"
+
+	// ChaosLocked responds with a locked error.
+	ChaosLocked ChaosKind = "locked"
+
+	// ChaosConflict responds with a conflict error.
+	ChaosConflict ChaosKind = "conflict"
+
+	// ChaosTruncatedBody sends the request and cuts the response body in half.
+	ChaosTruncatedBody ChaosKind = "truncated_body"
+)
+
+// ChaosRule describes which requests a ChaosTransport injects trouble into.
+type ChaosRule struct {
+	// Method is the HTTP method of matching requests. If empty, requests of
+	// all methods match.
+	Method string
+
+	// Path is a pattern as understood by path.Match which the request path
+	// must match, for example "/v1/servers/*". If empty, all paths match.
+	Path string
+
+	// Kind is the kind of trouble to inject.
+	Kind ChaosKind
+
+	// Probability is the chance between 0 and 1 that a matching request is
+	// affected. If zero, all matching requests are affected.
+	Probability float64
+
+	// Count is the number of requests to affect. If zero, there is no limit.
+	Count int
+
+	// Latency is the delay injected by ChaosLatency rules.
+	Latency time.Duration
+
+	// StatusCode is the status of ChaosServerError responses. If zero, 503
+	// is used.
+	StatusCode int
+
+	// RateLimitReset is the time after which a ChaosRateLimit response
+	// announces the rate limit to reset. If zero, one second is used.
+	RateLimitReset time.Duration
+}
+
+// ChaosInjection is a record of trouble injected by a ChaosTransport.
+type ChaosInjection struct {
+	Time   time.Time
+	Rule   int // index of the rule that matched
+	Kind   ChaosKind
+	Method string
+	Path   string
+}
+
+// String returns a description of the injection like "GET /v1/servers: locked".
+func (i ChaosInjection) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Method, i.Path, i.Kind)
+}
+
+// ErrChaosConnectionReset is the cause of connection errors injected by a
+// ChaosTransport.
+var ErrChaosConnectionReset = errors.New("connection reset by peer (injected)")
+
+// ChaosTransport is an http.RoundTripper which injects latency, errors and
+// broken responses into requests to the API, to test how programs cope with
+// trouble:
+//
+//	chaos := hcloudtest.NewChaosTransport(nil, 1,
+//		hcloudtest.ChaosRule{Path: "/v1/servers/*", Kind: hcloudtest.ChaosLocked, Probability: 0.2},
+//		hcloudtest.ChaosRule{Kind: hcloudtest.ChaosLatency, Latency: time.Second, Probability: 0.1},
+//	)
+//	client := hcloud.NewClient(hcloud.WithToken(token), hcloud.WithHTTPClient(chaos.HTTPClient()))
+//
+// For every request, the rules are evaluated in order. Latency is added up
+// over all matching latency rules, while the first other matching rule
+// decides the request's fate. Random decisions are taken from a source
+// seeded with the given seed, so a sequence of requests is affected the same
+// way on every run.
+type ChaosTransport struct {
+	base http.RoundTripper
+
+	mu         sync.Mutex
+	rand       *rand.Rand
+	rules      []ChaosRule
+	counts     []int
+	injections []ChaosInjection
+}
+
+// NewChaosTransport creates a ChaosTransport which sends requests with base,
+// or http.DefaultTransport if base is nil.
+func NewChaosTransport(base http.RoundTripper, seed int64, rules ...ChaosRule) *ChaosTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &ChaosTransport{
+		base:   base,
+		rand:   rand.New(rand.NewSource(seed)),
+		rules:  rules,
+		counts: make([]int, len(rules)),
+	}
+}
+
+// HTTPClient returns an HTTP client using the transport, to be used with
+// hcloud.WithHTTPClient.
+func (t *ChaosTransport) HTTPClient() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// Injections returns the trouble injected so far in the order it was
+// injected.
+func (t *ChaosTransport) Injections() []ChaosInjection {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	injections := make([]ChaosInjection, len(t.injections))
+	copy(injections, t.injections)
+	return injections
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ChaosTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	latency, rule := t.match(req)
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-req.Context().Done():
+			closeBody(req)
+			return nil, req.Context().Err()
+		}
+	}
+	if rule == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	switch rule.Kind {
+	case ChaosConnectionError:
+		closeBody(req)
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: ErrChaosConnectionReset}
+	case ChaosServerError:
+		closeBody(req)
+		status := rule.StatusCode
+		if status == 0 {
+			status = http.StatusServiceUnavailable
+		}
+		if status == http.StatusInternalServerError {
+			return chaosErrorResponse(req, newError(hcloud.ErrorCodeServiceError, "injected service error"), nil), nil
+		}
+		header := http.Header{"Content-Type": {"text/plain; charset=utf-8"}}
+		return chaosResponse(req, status, header, []byte(http.StatusText(status)+"\n")), nil
+	case ChaosRateLimit:
+		closeBody(req)
+		reset := rule.RateLimitReset
+		if reset == 0 {
+			reset = time.Second
+		}
+		header := http.Header{}
+		header.Set("RateLimit-Limit", "3600")
+		header.Set("RateLimit-Remaining", "0")
+		header.Set("RateLimit-Reset", strconv.FormatInt(time.Now().Add(reset).Unix(), 10))
+		return chaosErrorResponse(req, newError(hcloud.ErrorCodeRateLimitExceeded, "injected rate limit"), header), nil
+	case ChaosLocked:
+		closeBody(req)
+		return chaosErrorResponse(req, newError(hcloud.ErrorCodeLocked, "injected locked error"), nil), nil
+	case ChaosConflict:
+		closeBody(req)
+		return chaosErrorResponse(req, newError(hcloud.ErrorCodeConflict, "injected conflict"), nil), nil
+	case ChaosTruncatedBody:
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = body[:len(body)/2]
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Del("Content-Length")
+		return resp, nil
+	}
+	closeBody(req)
+	return nil, fmt.Errorf("hcloudtest: unknown chaos kind %q", rule.Kind)
+}
+
+// match returns the latency to inject into req and the rule deciding its
+// fate, if any, and records the injections.
+func (t *ChaosTransport) match(req *http.Request) (time.Duration, *ChaosRule) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var latency time.Duration
+	for i := range t.rules {
+		rule := &t.rules[i]
+		if rule.Method != "" && rule.Method != req.Method {
+			continue
+		}
+		if rule.Path != "" {
+			if ok, _ := path.Match(rule.Path, req.URL.Path); !ok {
+				continue
+			}
+		}
+		if rule.Count > 0 && t.counts[i] >= rule.Count {
+			continue
+		}
+		if rule.Probability > 0 && t.rand.Float64() >= rule.Probability {
+			continue
+		}
+
+		t.counts[i]++
+		t.injections = append(t.injections, ChaosInjection{
+			Time:   time.Now(),
+			Rule:   i,
+			Kind:   rule.Kind,
+			Method: req.Method,
+			Path:   req.URL.Path,
+		})
+		if rule.Kind == ChaosLatency {
+			latency += rule.Latency
+			continue
+		}
+		return latency, rule
+	}
+	return latency, nil
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
+
+func chaosResponse(req *http.Request, status int, header http.Header, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func chaosErrorResponse(req *http.Request, apiErr *apiError, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	body, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    apiErr.Code,
+			"message": apiErr.Message,
+			"details": apiErr.Details,
+		},
+	})
+	return chaosResponse(req, apiErr.Status, header, body)
+}
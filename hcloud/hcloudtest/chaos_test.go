@@ -0,0 +1,178 @@
+package hcloudtest
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestChaosTransport(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		Rule  ChaosRule
+		Check func(t *testing.T, err error)
+	}{
+		"connection error": {
+			Rule: ChaosRule{Kind: ChaosConnectionError},
+			Check: func(t *testing.T, err error) {
+				var cause error
+				if urlErr, ok := err.(*url.Error); ok {
+					if opErr, ok := urlErr.Err.(*net.OpError); ok {
+						cause = opErr.Err
+					}
+				}
+				if cause != ErrChaosConnectionReset {
+					t.Errorf("expected connection reset, got %v", err)
+				}
+			},
+		},
+		"internal server error": {
+			Rule: ChaosRule{Kind: ChaosServerError, StatusCode: 500},
+			Check: func(t *testing.T, err error) {
+				if !hcloud.IsError(err, hcloud.ErrorCodeServiceError) {
+					t.Errorf("expected service error, got %v", err)
+				}
+			},
+		},
+		"bad gateway": {
+			Rule: ChaosRule{Kind: ChaosServerError, StatusCode: 502},
+			Check: func(t *testing.T, err error) {
+				if err == nil || err.Error() != "hcloud: server responded with status code 502" {
+					t.Errorf("unexpected error: %v", err)
+				}
+			},
+		},
+		"locked": {
+			Rule: ChaosRule{Method: "GET", Path: "/images/*", Kind: ChaosLocked},
+			Check: func(t *testing.T, err error) {
+				if !hcloud.IsError(err, hcloud.ErrorCodeLocked) {
+					t.Errorf("expected locked error, got %v", err)
+				}
+			},
+		},
+		"conflict": {
+			Rule: ChaosRule{Kind: ChaosConflict},
+			Check: func(t *testing.T, err error) {
+				if !hcloud.IsError(err, hcloud.ErrorCodeConflict) {
+					t.Errorf("expected conflict error, got %v", err)
+				}
+			},
+		},
+		"truncated body": {
+			Rule: ChaosRule{Kind: ChaosTruncatedBody},
+			Check: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "unexpected end of JSON input") {
+					t.Errorf("expected JSON error, got %v", err)
+				}
+			},
+		},
+		"not matching": {
+			Rule: ChaosRule{Method: "POST", Kind: ChaosLocked},
+			Check: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			chaos := NewChaosTransport(nil, 1, testCase.Rule)
+			client := srv.Client(hcloud.WithHTTPClient(chaos.HTTPClient()))
+			_, _, err := client.Image.GetByID(ctx, 1)
+			testCase.Check(t, err)
+		})
+	}
+}
+
+func TestChaosTransportRateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	chaos := NewChaosTransport(nil, 1, ChaosRule{Kind: ChaosRateLimit, Count: 2, RateLimitReset: time.Minute})
+	client := srv.Client(hcloud.WithHTTPClient(chaos.HTTPClient()))
+
+	// The client retries rate limited requests until they succeed.
+	if _, _, err := client.Image.GetByID(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	injections := chaos.Injections()
+	if len(injections) != 2 {
+		t.Fatalf("expected 2 injections, got %v", injections)
+	}
+	if s := injections[0].String(); s != "GET /images/1: rate_limit" {
+		t.Errorf("unexpected injection: %s", s)
+	}
+
+	req, err := client.NewRequest(ctx, "GET", "/images/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chaos = NewChaosTransport(nil, 1, ChaosRule{Kind: ChaosRateLimit, RateLimitReset: time.Minute})
+	httpResp, err := chaos.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if httpResp.StatusCode != 429 || httpResp.Header.Get("RateLimit-Remaining") != "0" || httpResp.Header.Get("RateLimit-Reset") == "" {
+		t.Errorf("unexpected rate limit response: %d %v", httpResp.StatusCode, httpResp.Header)
+	}
+}
+
+func TestChaosTransportLatency(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	chaos := NewChaosTransport(nil, 1,
+		ChaosRule{Kind: ChaosLatency, Latency: time.Hour},
+		ChaosRule{Kind: ChaosLocked},
+	)
+	client := srv.Client(hcloud.WithHTTPClient(chaos.HTTPClient()))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Image.GetByID(ctx, 1)
+	if err == nil || ctx.Err() != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	injections := chaos.Injections()
+	if len(injections) != 2 || injections[0].Kind != ChaosLatency || injections[1].Kind != ChaosLocked {
+		t.Errorf("unexpected injections: %v", injections)
+	}
+}
+
+func TestChaosTransportSeed(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+
+	run := func(seed int64) []bool {
+		chaos := NewChaosTransport(nil, seed, ChaosRule{Kind: ChaosLocked, Probability: 0.5})
+		client := srv.Client(hcloud.WithHTTPClient(chaos.HTTPClient()))
+		var failed []bool
+		for i := 0; i < 20; i++ {
+			_, _, err := client.Image.GetByID(ctx, 1)
+			failed = append(failed, err != nil)
+		}
+		if len(chaos.Injections()) == 0 || len(chaos.Injections()) == 20 {
+			t.Errorf("expected some requests to fail, got %d failures", len(chaos.Injections()))
+		}
+		return failed
+	}
+
+	first, second := run(42), run(42)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("runs with the same seed differ: %v, %v", first, second)
+		}
+	}
+}
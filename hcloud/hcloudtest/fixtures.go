@@ -0,0 +1,28 @@
+package hcloudtest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// UnmarshalStrict decodes the JSON fixture data into v like json.Unmarshal
+// and fails the test for every field of data v does not model, to keep
+// fixtures and schema types from drifting apart:
+//
+//	var resp schema.ServerGetResponse
+//	hcloudtest.UnmarshalStrict(t, fixture, &resp)
+func UnmarshalStrict(t testing.TB, data []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("hcloudtest: cannot decode fixture: %s", err)
+	}
+	fields, err := schema.UnknownFields(data, v)
+	if err != nil {
+		t.Fatalf("hcloudtest: cannot decode fixture: %s", err)
+	}
+	for _, field := range fields {
+		t.Errorf("hcloudtest: fixture contains unknown field %s", field)
+	}
+}
@@ -0,0 +1,41 @@
+package hcloudtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// recordingTB records the errors reported by UnmarshalStrict.
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (tb *recordingTB) Helper() {}
+
+func (tb *recordingTB) Errorf(format string, args ...interface{}) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	tb := &recordingTB{TB: t}
+	var resp schema.VolumeGetResponse
+	UnmarshalStrict(tb, []byte(`{"volume": {"id": 1, "name": "db", "format": "ext4", "server": null}}`), &resp)
+
+	if resp.Volume.ID != 1 || resp.Volume.Name != "db" {
+		t.Errorf("unexpected volume: %+v", resp.Volume)
+	}
+	expected := []string{"hcloudtest: fixture contains unknown field volume.format"}
+	if !reflect.DeepEqual(tb.errors, expected) {
+		t.Errorf("expected errors %v, got %v", expected, tb.errors)
+	}
+
+	tb = &recordingTB{TB: t}
+	UnmarshalStrict(tb, []byte(`{"volume": {"id": 1}}`), &resp)
+	if len(tb.errors) != 0 {
+		t.Errorf("unexpected errors: %v", tb.errors)
+	}
+}
@@ -0,0 +1,274 @@
+package hcloudtest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func (s *Server) handleFloatingIPs(r *request) (int, interface{}, error) {
+	return route(r, "floating_ip", func(id int) bool { return s.floatingIPs[id] != nil }, resourceHandlers{
+		list:        s.listFloatingIPs,
+		create:      s.createFloatingIP,
+		get:         s.getFloatingIP,
+		update:      s.updateFloatingIP,
+		delete:      s.deleteFloatingIP,
+		listActions: s.resourceActions("floating_ip"),
+		actions: map[string]func(r *request, id int) (int, interface{}, error){
+			"assign":            s.assignFloatingIP,
+			"unassign":          s.unassignFloatingIP,
+			"change_dns_ptr":    s.changeFloatingIPDNSPtr,
+			"change_protection": s.changeFloatingIPProtection,
+		},
+	})
+}
+
+func (s *Server) listFloatingIPs(r *request) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, floatingIP := range s.floatingIPs {
+		if name := query.Get("name"); name != "" && floatingIP.Name != name {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "floating_ips", ids,
+		func(id int) map[string]string { return s.floatingIPs[id].Labels },
+		func(id int) interface{} { return *s.floatingIPs[id] },
+	)
+}
+
+// floatingIPAddress returns the address of the Floating IP with the given ID.
+// IPv4 addresses are taken from 203.0.113.0/24 and IPv6 networks from
+// 2001:db8::/32, which are reserved for documentation.
+func floatingIPAddress(typ string, id int) string {
+	if typ == string(hcloud.FloatingIPTypeIPv6) {
+		return fmt.Sprintf("2001:db8:%x::/64", id)
+	}
+	return fmt.Sprintf("203.0.113.%d", id%256)
+}
+
+func (s *Server) createFloatingIP(r *request) (int, interface{}, error) {
+	var body schema.FloatingIPCreateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if body.Type != string(hcloud.FloatingIPTypeIPv4) && body.Type != string(hcloud.FloatingIPTypeIPv6) {
+		return 0, nil, invalidInput("type", "must be ipv4 or ipv6")
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != nil {
+		for _, floatingIP := range s.floatingIPs {
+			if floatingIP.Name == *body.Name {
+				return 0, nil, uniquenessError("name")
+			}
+		}
+	}
+
+	var (
+		location schema.Location
+		server   *schema.Server
+	)
+	switch {
+	case body.Server != nil:
+		server = s.servers[*body.Server]
+		if server == nil {
+			return 0, nil, invalidInput("server", "server not found")
+		}
+		location = server.Datacenter.Location
+	case body.HomeLocation != nil:
+		var ok bool
+		if location, ok = findLocation(*body.HomeLocation); !ok {
+			return 0, nil, invalidInput("home_location", "location not found")
+		}
+	default:
+		return 0, nil, invalidInput("home_location", "home_location or server is required")
+	}
+
+	id := s.newID("floating_ip")
+	floatingIP := &schema.FloatingIP{
+		ID:           id,
+		Description:  body.Description,
+		Created:      s.Clock.Now(),
+		IP:           floatingIPAddress(body.Type, id),
+		Type:         body.Type,
+		DNSPtr:       []schema.FloatingIPDNSPtr{},
+		HomeLocation: location,
+		Labels:       copyLabels(body.Labels),
+	}
+	if body.Name != nil {
+		floatingIP.Name = *body.Name
+	}
+	s.floatingIPs[id] = floatingIP
+
+	respBody := schema.FloatingIPCreateResponse{}
+	if server != nil {
+		serverID := server.ID
+		a := s.startAction("assign_floating_ip", func() {
+			s.assignFloatingIPToServer(floatingIP, serverID)
+		}, resourceRef("floating_ip", id), resourceRef("server", serverID))
+		action := s.renderAction(a)
+		respBody.Action = &action
+	}
+	respBody.FloatingIP = *floatingIP
+	return http.StatusCreated, respBody, nil
+}
+
+func (s *Server) assignFloatingIPToServer(floatingIP *schema.FloatingIP, serverID int) {
+	server := s.servers[serverID]
+	if server == nil || s.floatingIPs[floatingIP.ID] == nil {
+		return
+	}
+	s.unassignFloatingIPFromServer(floatingIP)
+	floatingIP.Server = &serverID
+	server.PublicNet.FloatingIPs = append(server.PublicNet.FloatingIPs, floatingIP.ID)
+}
+
+func (s *Server) unassignFloatingIPFromServer(floatingIP *schema.FloatingIP) {
+	if floatingIP.Server == nil {
+		return
+	}
+	if server := s.servers[*floatingIP.Server]; server != nil {
+		server.PublicNet.FloatingIPs = removeInt(server.PublicNet.FloatingIPs, floatingIP.ID)
+	}
+	floatingIP.Server = nil
+}
+
+func (s *Server) getFloatingIP(r *request, id int) (int, interface{}, error) {
+	return http.StatusOK, schema.FloatingIPGetResponse{FloatingIP: *s.floatingIPs[id]}, nil
+}
+
+func (s *Server) updateFloatingIP(r *request, id int) (int, interface{}, error) {
+	var body schema.FloatingIPUpdateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != "" {
+		for otherID, other := range s.floatingIPs {
+			if otherID != id && other.Name == body.Name {
+				return 0, nil, uniquenessError("name")
+			}
+		}
+	}
+
+	floatingIP := s.floatingIPs[id]
+	if body.Name != "" {
+		floatingIP.Name = body.Name
+	}
+	if body.Description != "" {
+		description := body.Description
+		floatingIP.Description = &description
+	}
+	if body.Labels != nil {
+		floatingIP.Labels = copyLabels(body.Labels)
+	}
+	return http.StatusOK, schema.FloatingIPUpdateResponse{FloatingIP: *floatingIP}, nil
+}
+
+func (s *Server) deleteFloatingIP(r *request, id int) (int, interface{}, error) {
+	floatingIP := s.floatingIPs[id]
+	if floatingIP.Protection.Delete {
+		return 0, nil, protectedError("floating_ip", id)
+	}
+	if err := s.checkUnlocked(resourceRef("floating_ip", id)); err != nil {
+		return 0, nil, err
+	}
+	s.unassignFloatingIPFromServer(floatingIP)
+	delete(s.floatingIPs, id)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) assignFloatingIP(r *request, id int) (int, interface{}, error) {
+	var body schema.FloatingIPActionAssignRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[body.Server]
+	if server == nil {
+		return 0, nil, invalidInput("server", "server not found")
+	}
+	refs := []schema.ActionResourceReference{resourceRef("floating_ip", id), resourceRef("server", server.ID)}
+	if err := s.checkUnlocked(refs...); err != nil {
+		return 0, nil, err
+	}
+	floatingIP, serverID := s.floatingIPs[id], server.ID
+	return s.actionResponse(s.startAction("assign_floating_ip", func() {
+		s.assignFloatingIPToServer(floatingIP, serverID)
+	}, refs...))
+}
+
+func (s *Server) unassignFloatingIP(r *request, id int) (int, interface{}, error) {
+	floatingIP := s.floatingIPs[id]
+	refs := []schema.ActionResourceReference{resourceRef("floating_ip", id)}
+	if floatingIP.Server != nil {
+		refs = append(refs, resourceRef("server", *floatingIP.Server))
+	}
+	if err := s.checkUnlocked(refs...); err != nil {
+		return 0, nil, err
+	}
+	return s.actionResponse(s.startAction("unassign_floating_ip", func() {
+		s.unassignFloatingIPFromServer(floatingIP)
+	}, refs...))
+}
+
+func (s *Server) changeFloatingIPDNSPtr(r *request, id int) (int, interface{}, error) {
+	var body schema.FloatingIPActionChangeDNSPtrRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	floatingIP := s.floatingIPs[id]
+	ip := net.ParseIP(body.IP)
+	if !floatingIPContains(floatingIP, ip) {
+		return 0, nil, invalidInput("ip", fmt.Sprintf("%s is not part of Floating IP %d", body.IP, id))
+	}
+	if err := s.checkUnlocked(resourceRef("floating_ip", id)); err != nil {
+		return 0, nil, err
+	}
+
+	var ptrs []schema.FloatingIPDNSPtr
+	for _, ptr := range floatingIP.DNSPtr {
+		if !net.ParseIP(ptr.IP).Equal(ip) {
+			ptrs = append(ptrs, ptr)
+		}
+	}
+	if body.DNSPtr != nil {
+		ptrs = append(ptrs, schema.FloatingIPDNSPtr{IP: body.IP, DNSPtr: *body.DNSPtr})
+	}
+	if ptrs == nil {
+		ptrs = []schema.FloatingIPDNSPtr{}
+	}
+	floatingIP.DNSPtr = ptrs
+	return s.actionResponse(s.startAction("change_dns_ptr", nil, resourceRef("floating_ip", id)))
+}
+
+func floatingIPContains(floatingIP *schema.FloatingIP, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if _, ipNet, err := net.ParseCIDR(floatingIP.IP); err == nil {
+		return ipNet.Contains(ip)
+	}
+	return net.ParseIP(floatingIP.IP).Equal(ip)
+}
+
+func (s *Server) changeFloatingIPProtection(r *request, id int) (int, interface{}, error) {
+	var body schema.FloatingIPActionChangeProtectionRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("floating_ip", id)); err != nil {
+		return 0, nil, err
+	}
+	if body.Delete != nil {
+		s.floatingIPs[id].Protection.Delete = *body.Delete
+	}
+	return s.actionResponse(s.startAction("change_protection", nil, resourceRef("floating_ip", id)))
+}
@@ -0,0 +1,75 @@
+package hcloudtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestFloatingIPsAssign(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	web := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+	backup := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "backup"})
+
+	result, _, err := client.FloatingIP.Create(ctx, hcloud.FloatingIPCreateOpts{
+		Type:         hcloud.FloatingIPTypeIPv4,
+		HomeLocation: &hcloud.Location{Name: "fsn1"},
+		Name:         hcloud.String("vip"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Action != nil {
+		t.Errorf("unexpected action for unassigned Floating IP: %v", result.Action)
+	}
+	floatingIP := result.FloatingIP
+
+	if _, _, err := client.FloatingIP.Assign(ctx, floatingIP, web); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.FloatingIP.Assign(ctx, floatingIP, backup); err != nil {
+		t.Fatal(err)
+	}
+
+	floatingIP, _, err = client.FloatingIP.GetByName(ctx, "vip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if floatingIP.Server == nil || floatingIP.Server.ID != backup.ID {
+		t.Errorf("unexpected server: %v", floatingIP.Server)
+	}
+	web, _, err = client.Server.GetByID(ctx, web.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(web.PublicNet.FloatingIPs) != 0 {
+		t.Errorf("expected Floating IP to move away from server, got %v", web.PublicNet.FloatingIPs)
+	}
+
+	if _, _, err := client.FloatingIP.ChangeDNSPtr(ctx, floatingIP, floatingIP.IP.String(), hcloud.String("vip.example.com")); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.FloatingIP.ChangeDNSPtr(ctx, floatingIP, "192.0.2.1", hcloud.String("x.example.com")); !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error, got %v", err)
+	}
+	floatingIP, _, err = client.FloatingIP.GetByID(ctx, floatingIP.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if floatingIP.DNSPtrForIP(floatingIP.IP) != "vip.example.com" {
+		t.Errorf("unexpected DNS pointers: %v", floatingIP.DNSPtr)
+	}
+
+	_, _, err = client.FloatingIP.ChangeProtection(ctx, floatingIP, hcloud.FloatingIPChangeProtectionOpts{Delete: hcloud.Bool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FloatingIP.Delete(ctx, floatingIP); !hcloud.IsError(err, hcloud.ErrorCodeProtected) {
+		t.Errorf("expected protected error, got %v", err)
+	}
+}
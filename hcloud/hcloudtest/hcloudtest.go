@@ -0,0 +1,564 @@
+// Package hcloudtest provides an in-memory fake of the Hetzner Cloud API for
+// tests.
+//
+// A Server keeps servers, volumes, Floating IPs, networks, images, SSH keys
+// and actions in memory and serves them like the real API does: names must
+// be unique, protected resources cannot be deleted, resources with running
+// actions are locked, and lists support label selectors and pagination.
+//
+// Actions progress on a Clock which only moves when it is advanced, so tests
+// decide when actions finish:
+//
+//	srv := hcloudtest.NewServer(hcloudtest.WithActionDuration(10 * time.Second))
+//	defer srv.Close()
+//	client := srv.Client()
+//
+//	result, _, err := client.Server.Create(ctx, opts)
+//	srv.Clock.Advance(10 * time.Second) // the create action finishes
+//
+// Errors can be injected with InjectFault to test error handling.
+//
+// Interactions with the real API can be recorded and replayed with a
+// Recorder, and a ChaosTransport injects latency and failures into requests
+// to test resilience. UnmarshalStrict decodes JSON fixtures and fails tests
+// when they contain fields the schema types do not model.
+package hcloudtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// Server is a fake Hetzner Cloud API server.
+type Server struct {
+	// URL is the endpoint of the fake API, to be used with hcloud.WithEndpoint.
+	URL string
+
+	// Clock is the clock actions progress on.
+	Clock *Clock
+
+	httpServer     *httptest.Server
+	token          string
+	actionDuration time.Duration
+
+	mu          sync.Mutex
+	ids         map[string]int
+	servers     map[int]*schema.Server
+	volumes     map[int]*schema.Volume
+	floatingIPs map[int]*schema.FloatingIP
+	networks    map[int]*schema.Network
+	images      map[int]*schema.Image
+	sshKeys     map[int]*schema.SSHKey
+	actions     map[int]*action
+	faults      []*Fault
+}
+
+// An Option configures a Server.
+type Option func(*Server)
+
+// WithToken configures a Server to only accept requests authenticated with
+// token. By default, any token is accepted.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithActionDuration configures how long actions run on the server's clock
+// before they succeed. By default, actions succeed immediately.
+func WithActionDuration(d time.Duration) Option {
+	return func(s *Server) {
+		s.actionDuration = d
+	}
+}
+
+// WithClock configures a Server to use the given clock.
+func WithClock(clock *Clock) Option {
+	return func(s *Server) {
+		s.Clock = clock
+	}
+}
+
+// NewServer starts a new fake API server. It knows the system images
+// "ubuntu-20.04" and "debian-11" and no other resources. The caller should
+// call Close when finished.
+func NewServer(options ...Option) *Server {
+	s := &Server{
+		ids:         map[string]int{},
+		servers:     map[int]*schema.Server{},
+		volumes:     map[int]*schema.Volume{},
+		floatingIPs: map[int]*schema.FloatingIP{},
+		networks:    map[int]*schema.Network{},
+		images:      map[int]*schema.Image{},
+		sshKeys:     map[int]*schema.SSHKey{},
+		actions:     map[int]*action{},
+	}
+	for _, option := range options {
+		option(s)
+	}
+	if s.Clock == nil {
+		s.Clock = NewClock(time.Now().UTC().Truncate(time.Second))
+	}
+	s.addSystemImages()
+
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Client returns a client for the server. It polls actions every millisecond
+// and retries rate limited requests without backoff. The options are applied
+// after the defaults.
+func (s *Server) Client(options ...hcloud.ClientOption) *hcloud.Client {
+	token := s.token
+	if token == "" {
+		token = "token"
+	}
+	defaults := []hcloud.ClientOption{
+		hcloud.WithEndpoint(s.URL),
+		hcloud.WithToken(token),
+		hcloud.WithPollInterval(time.Millisecond),
+		hcloud.WithBackoffFunc(hcloud.ConstantBackoff(0)),
+	}
+	return hcloud.NewClient(append(defaults, options...)...)
+}
+
+// Clock is a clock which only moves when told to. It is safe for concurrent
+// use.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a clock set to t.
+func NewClock(t time.Time) *Clock {
+	return &Clock{now: t}
+}
+
+// Now returns the current time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set sets the clock to t.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Fault describes an error the server returns instead of handling matching
+// requests.
+type Fault struct {
+	// Method is the HTTP method of matching requests. If empty, requests of
+	// all methods match.
+	Method string
+
+	// Path is a pattern as understood by path.Match which the request path
+	// must match, for example "/servers/*" or "/servers/*/actions/poweron".
+	// If empty, all paths match.
+	Path string
+
+	// Code is the error code to return. The HTTP status code is derived
+	// from it.
+	Code hcloud.ErrorCode
+
+	// Message is the error message. If empty, a generic message is used.
+	Message string
+
+	// Count is the number of requests to fail. If zero, all matching requests
+	// fail until ClearFaults is called.
+	Count int
+}
+
+// InjectFault makes the server fail requests matching f. Faults are matched
+// in the order they were injected.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && f.Method != r.Method {
+			continue
+		}
+		if f.Path != "" {
+			if ok, _ := path.Match(f.Path, r.URL.Path); !ok {
+				continue
+			}
+		}
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// apiError is an error response of the API.
+type apiError struct {
+	Status  int
+	Code    hcloud.ErrorCode
+	Message string
+	Details interface{}
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+var errorStatus = map[hcloud.ErrorCode]int{
+	hcloud.ErrorCodeServiceError:          http.StatusInternalServerError,
+	hcloud.ErrorCodeRateLimitExceeded:     http.StatusTooManyRequests,
+	hcloud.ErrorCodeUnknownError:          http.StatusInternalServerError,
+	hcloud.ErrorCodeNotFound:              http.StatusNotFound,
+	hcloud.ErrorCodeInvalidInput:          http.StatusBadRequest,
+	hcloud.ErrorCodeForbidden:             http.StatusForbidden,
+	hcloud.ErrorCodeJSONError:             http.StatusBadRequest,
+	hcloud.ErrorCodeLocked:                http.StatusLocked,
+	hcloud.ErrorCodeResourceLimitExceeded: http.StatusForbidden,
+	hcloud.ErrorCodeResourceUnavailable:   http.StatusServiceUnavailable,
+	hcloud.ErrorCodeUniquenessError:       http.StatusConflict,
+	hcloud.ErrorCodeProtected:             http.StatusForbidden,
+	hcloud.ErrorCodeMaintenance:           http.StatusServiceUnavailable,
+	hcloud.ErrorCodeConflict:              http.StatusConflict,
+	hcloud.ErrorCodeServerAlreadyAttached: http.StatusConflict,
+	"unauthorized":                        http.StatusUnauthorized,
+}
+
+func newError(code hcloud.ErrorCode, format string, args ...interface{}) *apiError {
+	status, ok := errorStatus[code]
+	if !ok {
+		status = http.StatusBadRequest
+	}
+	return &apiError{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(kind string, id int) *apiError {
+	return newError(hcloud.ErrorCodeNotFound, "%s with ID %d not found", kind, id)
+}
+
+func invalidInput(field, message string) *apiError {
+	err := newError(hcloud.ErrorCodeInvalidInput, "invalid input in field '%s'", field)
+	err.Details = schema.ErrorDetailsInvalidInput{
+		Fields: []struct {
+			Name     string   `json:"name"`
+			Messages []string `json:"messages"`
+		}{
+			{Name: field, Messages: []string{message}},
+		},
+	}
+	return err
+}
+
+func uniquenessError(field string) *apiError {
+	err := newError(hcloud.ErrorCodeUniquenessError, "%s is already used", field)
+	err.Details = schema.ErrorDetailsUniquenessError{
+		Fields: []schema.ErrorDetailsUniquenessErrorField{{Name: field}},
+	}
+	return err
+}
+
+func serverAlreadyAttachedError(serverID int, format string, args ...interface{}) *apiError {
+	err := newError(hcloud.ErrorCodeServerAlreadyAttached, format, args...)
+	err.Details = schema.ErrorDetailsServerAlreadyAttached{Server: serverID}
+	return err
+}
+
+func protectedError(kind string, id int) *apiError {
+	return newError(hcloud.ErrorCodeProtected, "%s %d is protected", kind, id)
+}
+
+func lockedError(kind string, id, actionID int) *apiError {
+	err := newError(hcloud.ErrorCodeLocked, "%s %d is locked by another action", kind, id)
+	err.Details = schema.ErrorDetailsLocked{Action: actionID}
+	return err
+}
+
+// request is a request to the fake API, split into path segments.
+type request struct {
+	*http.Request
+	segments []string
+}
+
+// id parses the path segment at index i as an ID.
+func (r *request) id(i int) (int, bool) {
+	if i >= len(r.segments) {
+		return 0, false
+	}
+	id, err := strconv.Atoi(r.segments[i])
+	return id, err == nil && id > 0
+}
+
+func (r *request) decode(v interface{}) error {
+	if r.Body == nil {
+		return nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		return newError(hcloud.ErrorCodeJSONError, "invalid JSON: %s", err)
+	}
+	return nil
+}
+
+// handlerFunc handles a request and returns the HTTP status code and body of
+// the response.
+type handlerFunc func(r *request) (int, interface{}, error)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	status, body, err := s.handle(r)
+	if err != nil {
+		apiErr, ok := err.(*apiError)
+		if !ok {
+			apiErr = newError(hcloud.ErrorCodeServiceError, "%s", err)
+		}
+		status = apiErr.Status
+		errBody := map[string]interface{}{
+			"code":    apiErr.Code,
+			"message": apiErr.Message,
+			"details": apiErr.Details,
+		}
+		body = map[string]interface{}{"error": errBody}
+	}
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (s *Server) handle(r *http.Request) (int, interface{}, error) {
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		return 0, nil, newError("unauthorized", "unable to authenticate")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f := s.matchFault(r); f != nil {
+		message := f.Message
+		if message == "" {
+			message = "injected fault"
+		}
+		return 0, nil, newError(f.Code, "%s", message)
+	}
+	s.settleActions()
+
+	req := &request{Request: r, segments: strings.Split(strings.Trim(r.URL.Path, "/"), "/")}
+	handlers := map[string]handlerFunc{
+		"actions":      s.handleActions,
+		"floating_ips": s.handleFloatingIPs,
+		"images":       s.handleImages,
+		"networks":     s.handleNetworks,
+		"servers":      s.handleServers,
+		"ssh_keys":     s.handleSSHKeys,
+		"volumes":      s.handleVolumes,
+	}
+	h, ok := handlers[req.segments[0]]
+	if !ok {
+		return 0, nil, newError(hcloud.ErrorCodeNotFound, "not found")
+	}
+	return h(req)
+}
+
+// route dispatches a request for a resource collection like /servers:
+//
+//	GET    /servers                  list
+//	POST   /servers                  create
+//	GET    /servers/{id}             get
+//	PUT    /servers/{id}             update
+//	DELETE /servers/{id}             delete
+//	GET    /servers/{id}/actions     list actions of the resource
+//	POST   /servers/{id}/actions/{x} run action x
+func route(r *request, kind string, exists func(id int) bool, handlers resourceHandlers) (int, interface{}, error) {
+	notAllowed := newError(hcloud.ErrorCodeNotFound, "not found")
+	if len(r.segments) == 1 {
+		switch {
+		case r.Method == "GET" && handlers.list != nil:
+			return handlers.list(r)
+		case r.Method == "POST" && handlers.create != nil:
+			return handlers.create(r)
+		}
+		return 0, nil, notAllowed
+	}
+
+	id, ok := r.id(1)
+	if !ok {
+		return 0, nil, notAllowed
+	}
+	if !exists(id) {
+		return 0, nil, notFound(kind, id)
+	}
+	switch {
+	case len(r.segments) == 2 && r.Method == "GET" && handlers.get != nil:
+		return handlers.get(r, id)
+	case len(r.segments) == 2 && r.Method == "PUT" && handlers.update != nil:
+		return handlers.update(r, id)
+	case len(r.segments) == 2 && r.Method == "DELETE" && handlers.delete != nil:
+		return handlers.delete(r, id)
+	case len(r.segments) == 3 && r.segments[2] == "actions" && r.Method == "GET" && handlers.listActions != nil:
+		return handlers.listActions(r, id)
+	case len(r.segments) == 4 && r.segments[2] == "actions" && r.Method == "POST":
+		h, ok := handlers.actions[r.segments[3]]
+		if !ok {
+			return 0, nil, notAllowed
+		}
+		return h(r, id)
+	}
+	return 0, nil, notAllowed
+}
+
+type resourceHandlers struct {
+	list        handlerFunc
+	create      handlerFunc
+	get         func(r *request, id int) (int, interface{}, error)
+	update      func(r *request, id int) (int, interface{}, error)
+	delete      func(r *request, id int) (int, interface{}, error)
+	listActions func(r *request, id int) (int, interface{}, error)
+	actions     map[string]func(r *request, id int) (int, interface{}, error)
+}
+
+func (s *Server) newID(kind string) int {
+	s.ids[kind]++
+	return s.ids[kind]
+}
+
+// listPage filters and paginates the resources with the given IDs according
+// to the page, per_page and label_selector query parameters and returns the
+// list response with the key name.
+func listPage(r *request, name string, ids []int, labels func(id int) map[string]string, item func(id int) interface{}) (int, interface{}, error) {
+	query := r.URL.Query()
+	if selector := query.Get("label_selector"); selector != "" {
+		sel, err := parseLabelSelector(selector)
+		if err != nil {
+			return 0, nil, invalidInput("label_selector", err.Error())
+		}
+		var matching []int
+		for _, id := range ids {
+			if sel.matches(labels(id)) {
+				matching = append(matching, id)
+			}
+		}
+		ids = matching
+	}
+	sort.Ints(ids)
+
+	page, perPage := 1, 25
+	if v := query.Get("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, nil, invalidInput("page", "must be a positive integer")
+		}
+		page = n
+	}
+	if v := query.Get("per_page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 50 {
+			return 0, nil, invalidInput("per_page", "must be between 1 and 50")
+		}
+		perPage = n
+	}
+
+	lastPage := (len(ids) + perPage - 1) / perPage
+	if lastPage == 0 {
+		lastPage = 1
+	}
+	pagination := schema.MetaPagination{
+		Page:         page,
+		PerPage:      perPage,
+		LastPage:     lastPage,
+		TotalEntries: len(ids),
+	}
+	if page > 1 {
+		pagination.PreviousPage = page - 1
+	}
+	if page < lastPage {
+		pagination.NextPage = page + 1
+	}
+
+	items := []interface{}{}
+	for i := (page - 1) * perPage; i < len(ids) && i < page*perPage; i++ {
+		items = append(items, item(ids[i]))
+	}
+	return http.StatusOK, map[string]interface{}{
+		name:   items,
+		"meta": schema.Meta{Pagination: &pagination},
+	}, nil
+}
+
+// validateLabels returns an invalid_input error if labels contains an
+// invalid key or value.
+func validateLabels(labels *map[string]string) error {
+	if labels == nil {
+		return nil
+	}
+	for k, v := range *labels {
+		if !validLabelKey(k) {
+			return invalidInput("labels", fmt.Sprintf("invalid label key %q", k))
+		}
+		if !validLabelValue(v) {
+			return invalidInput("labels", fmt.Sprintf("invalid label value %q", v))
+		}
+	}
+	return nil
+}
+
+func copyLabels(labels *map[string]string) map[string]string {
+	out := map[string]string{}
+	if labels != nil {
+		for k, v := range *labels {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// checkUnlocked returns a locked error if one of the resources has a running
+// action.
+func (s *Server) checkUnlocked(refs ...schema.ActionResourceReference) error {
+	for _, ref := range refs {
+		if actionID, ok := s.locked(ref.Type, ref.ID); ok {
+			return lockedError(ref.Type, ref.ID, actionID)
+		}
+	}
+	return nil
+}
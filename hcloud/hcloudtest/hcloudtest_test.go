@@ -0,0 +1,259 @@
+package hcloudtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestActionsProgressOnClock(t *testing.T) {
+	srv := NewServer(WithActionDuration(10 * time.Second))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	result, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "web",
+		ServerType: &hcloud.ServerType{Name: "cx11"},
+		Image:      &hcloud.Image{Name: "ubuntu-20.04"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Action.Status != hcloud.ActionStatusRunning || result.Action.Progress != 0 {
+		t.Errorf("unexpected action: %+v", result.Action)
+	}
+	if result.Server.Status != hcloud.ServerStatusInitializing || !result.Server.Locked {
+		t.Errorf("unexpected server: %+v", result.Server)
+	}
+
+	srv.Clock.Advance(5 * time.Second)
+	action, _, err := client.Action.GetByID(ctx, result.Action.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Status != hcloud.ActionStatusRunning || action.Progress != 50 {
+		t.Errorf("unexpected action: %+v", action)
+	}
+
+	if _, _, err := client.Server.Poweroff(ctx, result.Server); !hcloud.IsError(err, hcloud.ErrorCodeLocked) {
+		t.Errorf("expected locked error, got %v", err)
+	}
+
+	srv.Clock.Advance(5 * time.Second)
+	action, _, err = client.Action.GetByID(ctx, result.Action.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Status != hcloud.ActionStatusSuccess || action.Progress != 100 {
+		t.Errorf("unexpected action: %+v", action)
+	}
+	if !action.Finished.Equal(action.Started.Add(10 * time.Second)) {
+		t.Errorf("unexpected finish time: %v", action.Finished)
+	}
+
+	server, _, err := client.Server.GetByID(ctx, result.Server.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.Status != hcloud.ServerStatusRunning || server.Locked {
+		t.Errorf("unexpected server: %+v", server)
+	}
+}
+
+func TestActionsSucceedImmediately(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	result, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:       "web",
+		ServerType: &hcloud.ServerType{Name: "cx11"},
+		Image:      &hcloud.Image{Name: "ubuntu-20.04"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, errCh := client.Action.WatchProgress(ctx, result.Action)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if result.Server.Status != hcloud.ServerStatusRunning {
+		t.Errorf("unexpected server status: %s", result.Server.Status)
+	}
+
+	actions, err := client.Action.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actions) != 1 || actions[0].Command != "create_server" {
+		t.Errorf("unexpected actions: %v", actions)
+	}
+}
+
+func TestServerInjectFault(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	t.Run("count", func(t *testing.T) {
+		srv.InjectFault(Fault{Method: "GET", Path: "/ssh_keys", Code: hcloud.ErrorCodeServiceError, Count: 2})
+		for i := 0; i < 2; i++ {
+			_, _, err := client.SSHKey.List(ctx, hcloud.SSHKeyListOpts{})
+			if !hcloud.IsError(err, hcloud.ErrorCodeServiceError) {
+				t.Fatalf("expected service error, got %v", err)
+			}
+		}
+		if _, _, err := client.SSHKey.List(ctx, hcloud.SSHKeyListOpts{}); err != nil {
+			t.Fatalf("unexpected error after fault expired: %v", err)
+		}
+	})
+
+	t.Run("rate limit is retried", func(t *testing.T) {
+		srv.InjectFault(Fault{Path: "/images/*", Code: hcloud.ErrorCodeRateLimitExceeded, Count: 3})
+		image, resp, err := client.Image.GetByID(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if image == nil || resp.StatusCode != http.StatusOK {
+			t.Errorf("unexpected response: %v %v", image, resp.Status)
+		}
+	})
+
+	t.Run("until cleared", func(t *testing.T) {
+		srv.InjectFault(Fault{Path: "/images/*", Code: hcloud.ErrorCodeNotFound, Message: "gone"})
+		for i := 0; i < 3; i++ {
+			image, _, err := client.Image.GetByID(ctx, 1)
+			if err != nil || image != nil {
+				t.Fatalf("expected not found, got %v, %v", image, err)
+			}
+		}
+		_, _, err := client.Image.Update(ctx, &hcloud.Image{ID: 1}, hcloud.ImageUpdateOpts{})
+		if apiErr, ok := err.(hcloud.Error); !ok || apiErr.Code != hcloud.ErrorCodeNotFound || apiErr.Message != "gone" {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		srv.ClearFaults()
+		if image, _, err := client.Image.GetByID(ctx, 1); err != nil || image == nil {
+			t.Errorf("unexpected result after clearing faults: %v, %v", image, err)
+		}
+	})
+}
+
+func TestServerToken(t *testing.T) {
+	srv := NewServer(WithToken("secret"))
+	defer srv.Close()
+	ctx := context.Background()
+
+	if _, err := srv.Client().Image.All(ctx); err != nil {
+		t.Fatal(err)
+	}
+	_, err := srv.Client(hcloud.WithToken("wrong")).Image.All(ctx)
+	if !hcloud.IsError(err, "unauthorized") {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+			Name:    "net-" + string(rune('a'+i)),
+			IPRange: mustParseCIDR("10.0.0.0/16"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	networks, resp, err := client.Network.List(ctx, hcloud.NetworkListOpts{ListOpts: hcloud.ListOpts{Page: 2, PerPage: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(networks) != 2 || networks[0].Name != "net-c" || networks[1].Name != "net-d" {
+		t.Errorf("unexpected networks: %v", networks)
+	}
+	expected := hcloud.Pagination{Page: 2, PerPage: 2, PreviousPage: 1, NextPage: 3, LastPage: 3, TotalEntries: 5}
+	if resp.Meta.Pagination == nil || *resp.Meta.Pagination != expected {
+		t.Errorf("unexpected pagination: %+v", resp.Meta.Pagination)
+	}
+
+	all, err := client.Network.AllWithOpts(ctx, hcloud.NetworkListOpts{ListOpts: hcloud.ListOpts{PerPage: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 5 {
+		t.Errorf("expected 5 networks, got %d", len(all))
+	}
+
+	_, _, err = client.Network.List(ctx, hcloud.NetworkListOpts{ListOpts: hcloud.ListOpts{PerPage: 51}})
+	if !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error, got %v", err)
+	}
+}
+
+func TestServerLabelSelector(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	keys := []struct {
+		name, publicKey string
+		labels          map[string]string
+	}{
+		{"a", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAAA", map[string]string{"env": "prod", "tier": "web"}},
+		{"b", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBBB", map[string]string{"env": "staging", "tier": "db"}},
+		{"c", "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAICCC", map[string]string{"env": "prod"}},
+	}
+	for _, key := range keys {
+		_, _, err := client.SSHKey.Create(ctx, hcloud.SSHKeyCreateOpts{Name: key.name, PublicKey: key.publicKey, Labels: key.labels})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := map[string][]string{
+		"env=prod":             {"a", "c"},
+		"env=prod,tier":        {"a"},
+		"env=prod,!tier":       {"c"},
+		"tier in (web,db)":     {"a", "b"},
+		"env notin (staging)":  {"a", "c"},
+		"env!=prod":            {"b"},
+		"env==staging,tier=db": {"b"},
+	}
+	for selector, expected := range testCases {
+		sshKeys, _, err := client.SSHKey.List(ctx, hcloud.SSHKeyListOpts{ListOpts: hcloud.ListOpts{LabelSelector: selector}})
+		if err != nil {
+			t.Fatalf("%s: %v", selector, err)
+		}
+		var names []string
+		for _, key := range sshKeys {
+			names = append(names, key.Name)
+		}
+		if len(names) != len(expected) {
+			t.Errorf("%s: expected %v, got %v", selector, expected, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != expected[i] {
+				t.Errorf("%s: expected %v, got %v", selector, expected, names)
+				break
+			}
+		}
+	}
+
+	_, _, err := client.SSHKey.List(ctx, hcloud.SSHKeyListOpts{ListOpts: hcloud.ListOpts{LabelSelector: "env in prod"}})
+	if !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error, got %v", err)
+	}
+}
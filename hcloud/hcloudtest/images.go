@@ -0,0 +1,144 @@
+package hcloudtest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func (s *Server) addSystemImages() {
+	for _, image := range []struct {
+		name, flavor, version string
+	}{
+		{"ubuntu-20.04", "ubuntu", "20.04"},
+		{"debian-11", "debian", "11"},
+	} {
+		id := s.newID("image")
+		name, version := image.name, image.version
+		s.images[id] = &schema.Image{
+			ID:          id,
+			Status:      string(hcloud.ImageStatusAvailable),
+			Type:        string(hcloud.ImageTypeSystem),
+			Name:        &name,
+			Description: image.name,
+			DiskSize:    5,
+			Created:     s.Clock.Now(),
+			OSFlavor:    image.flavor,
+			OSVersion:   &version,
+			RapidDeploy: true,
+			Labels:      map[string]string{},
+		}
+	}
+}
+
+// findImage returns the image with the given ID or name.
+func (s *Server) findImage(idOrName interface{}) *schema.Image {
+	for _, image := range s.images {
+		var name string
+		if image.Name != nil {
+			name = *image.Name
+		}
+		if idOrNameMatches(idOrName, image.ID, name) {
+			return image
+		}
+	}
+	return nil
+}
+
+func (s *Server) handleImages(r *request) (int, interface{}, error) {
+	return route(r, "image", func(id int) bool { return s.images[id] != nil }, resourceHandlers{
+		list:        s.listImages,
+		get:         s.getImage,
+		update:      s.updateImage,
+		delete:      s.deleteImage,
+		listActions: s.resourceActions("image"),
+		actions: map[string]func(r *request, id int) (int, interface{}, error){
+			"change_protection": s.changeImageProtection,
+		},
+	})
+}
+
+func (s *Server) listImages(r *request) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, image := range s.images {
+		if name := query.Get("name"); name != "" && (image.Name == nil || *image.Name != name) {
+			continue
+		}
+		if types := query["type"]; len(types) > 0 && !containsString(types, image.Type) {
+			continue
+		}
+		if statuses := query["status"]; len(statuses) > 0 && !containsString(statuses, image.Status) {
+			continue
+		}
+		if boundTo := query.Get("bound_to"); boundTo != "" && (image.BoundTo == nil || strconv.Itoa(*image.BoundTo) != boundTo) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "images", ids,
+		func(id int) map[string]string { return s.images[id].Labels },
+		func(id int) interface{} { return *s.images[id] },
+	)
+}
+
+func (s *Server) getImage(r *request, id int) (int, interface{}, error) {
+	return http.StatusOK, schema.ImageGetResponse{Image: *s.images[id]}, nil
+}
+
+func (s *Server) updateImage(r *request, id int) (int, interface{}, error) {
+	var body schema.ImageUpdateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	image := s.images[id]
+	if body.Type != nil {
+		if image.Type != string(hcloud.ImageTypeBackup) || *body.Type != string(hcloud.ImageTypeSnapshot) {
+			return 0, nil, invalidInput("type", "only backups can be converted to snapshots")
+		}
+		image.Type = *body.Type
+		image.BoundTo = nil
+	}
+	if body.Description != nil {
+		image.Description = *body.Description
+	}
+	if body.Labels != nil {
+		image.Labels = copyLabels(body.Labels)
+	}
+	return http.StatusOK, schema.ImageUpdateResponse{Image: *image}, nil
+}
+
+func (s *Server) deleteImage(r *request, id int) (int, interface{}, error) {
+	image := s.images[id]
+	if image.Type == string(hcloud.ImageTypeSystem) {
+		return 0, nil, newError(hcloud.ErrorCodeForbidden, "system images cannot be deleted")
+	}
+	if image.Protection.Delete {
+		return 0, nil, protectedError("image", id)
+	}
+	if err := s.checkUnlocked(resourceRef("image", id)); err != nil {
+		return 0, nil, err
+	}
+	delete(s.images, id)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) changeImageProtection(r *request, id int) (int, interface{}, error) {
+	var body schema.ImageActionChangeProtectionRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	image := s.images[id]
+	if image.Type == string(hcloud.ImageTypeSystem) {
+		return 0, nil, newError(hcloud.ErrorCodeForbidden, "protection of system images cannot be changed")
+	}
+	if body.Delete != nil {
+		image.Protection.Delete = *body.Delete
+	}
+	return s.actionResponse(s.startAction("change_protection", nil, resourceRef("image", id)))
+}
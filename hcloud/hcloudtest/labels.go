@@ -0,0 +1,186 @@
+package hcloudtest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	labelKeyNameRegexp   = regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9_.]{0,61}[a-zA-Z0-9])?)$`)
+	labelKeyPrefixRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]{0,251}[a-z0-9])?$`)
+	labelValueRegexp     = regexp.MustCompile(`^(([a-zA-Z0-9]([-a-zA-Z0-9_.]{0,61}[a-zA-Z0-9])?)?)$`)
+)
+
+func validLabelKey(key string) bool {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		if !labelKeyPrefixRegexp.MatchString(key[:i]) {
+			return false
+		}
+		name = key[i+1:]
+	}
+	return labelKeyNameRegexp.MatchString(name)
+}
+
+func validLabelValue(value string) bool {
+	return labelValueRegexp.MatchString(value)
+}
+
+// labelSelector is a parsed label selector. All of its requirements must
+// be met for labels to match.
+type labelSelector []labelRequirement
+
+type labelOperator int
+
+const (
+	labelOpExists labelOperator = iota
+	labelOpNotExists
+	labelOpEquals
+	labelOpNotEquals
+	labelOpIn
+	labelOpNotIn
+)
+
+type labelRequirement struct {
+	key    string
+	op     labelOperator
+	values []string
+}
+
+// parseLabelSelector parses a selector like "env=prod,tier in (web,db),!legacy".
+func parseLabelSelector(selector string) (labelSelector, error) {
+	var sel labelSelector
+	for _, expr := range splitLabelSelector(selector) {
+		expr = strings.TrimSpace(expr)
+		if expr == "" {
+			return nil, errors.New("empty requirement in label selector")
+		}
+		req, err := parseLabelRequirement(expr)
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, req)
+	}
+	return sel, nil
+}
+
+// splitLabelSelector splits a selector at commas outside of parentheses.
+func splitLabelSelector(selector string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+func parseLabelRequirement(expr string) (labelRequirement, error) {
+	if strings.HasPrefix(expr, "!") {
+		key := strings.TrimSpace(expr[1:])
+		if !validLabelKey(key) {
+			return labelRequirement{}, fmt.Errorf("invalid label key %q", key)
+		}
+		return labelRequirement{key: key, op: labelOpNotExists}, nil
+	}
+
+	for _, op := range []struct {
+		token string
+		op    labelOperator
+	}{
+		{"!=", labelOpNotEquals},
+		{"==", labelOpEquals},
+		{"=", labelOpEquals},
+	} {
+		if i := strings.Index(expr, op.token); i >= 0 {
+			key := strings.TrimSpace(expr[:i])
+			value := strings.TrimSpace(expr[i+len(op.token):])
+			if !validLabelKey(key) {
+				return labelRequirement{}, fmt.Errorf("invalid label key %q", key)
+			}
+			if !validLabelValue(value) {
+				return labelRequirement{}, fmt.Errorf("invalid label value %q", value)
+			}
+			return labelRequirement{key: key, op: op.op, values: []string{value}}, nil
+		}
+	}
+
+	if fields := strings.Fields(expr); len(fields) >= 2 && (fields[1] == "in" || fields[1] == "notin") {
+		key := fields[0]
+		rest := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(expr[len(key):]), fields[1]))
+		if !strings.HasPrefix(rest, "(") || !strings.HasSuffix(rest, ")") {
+			return labelRequirement{}, fmt.Errorf("invalid set in label selector: %s", expr)
+		}
+		if !validLabelKey(key) {
+			return labelRequirement{}, fmt.Errorf("invalid label key %q", key)
+		}
+		req := labelRequirement{key: key, op: labelOpIn}
+		if fields[1] == "notin" {
+			req.op = labelOpNotIn
+		}
+		for _, value := range strings.Split(rest[1:len(rest)-1], ",") {
+			value = strings.TrimSpace(value)
+			if !validLabelValue(value) {
+				return labelRequirement{}, fmt.Errorf("invalid label value %q", value)
+			}
+			req.values = append(req.values, value)
+		}
+		return req, nil
+	}
+
+	if !validLabelKey(expr) {
+		return labelRequirement{}, fmt.Errorf("invalid label key %q", expr)
+	}
+	return labelRequirement{key: expr, op: labelOpExists}, nil
+}
+
+func (sel labelSelector) matches(labels map[string]string) bool {
+	for _, req := range sel {
+		if !req.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (req labelRequirement) matches(labels map[string]string) bool {
+	value, ok := labels[req.key]
+	switch req.op {
+	case labelOpExists:
+		return ok
+	case labelOpNotExists:
+		return !ok
+	case labelOpEquals:
+		return ok && value == req.values[0]
+	case labelOpNotEquals:
+		return !ok || value != req.values[0]
+	case labelOpIn:
+		return ok && containsString(req.values, value)
+	case labelOpNotIn:
+		return !ok || !containsString(req.values, value)
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
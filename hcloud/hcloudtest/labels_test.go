@@ -0,0 +1,43 @@
+package hcloudtest
+
+import "testing"
+
+func TestParseLabelSelector(t *testing.T) {
+	labels := map[string]string{"env": "prod", "example.com/tier": "web"}
+
+	testCases := map[string]struct {
+		Selector string
+		Matches  bool
+		Invalid  bool
+	}{
+		"equals":             {Selector: "env=prod", Matches: true},
+		"double equals":      {Selector: "env==staging", Matches: false},
+		"not equals missing": {Selector: "team!=ops", Matches: true},
+		"exists with prefix": {Selector: "example.com/tier", Matches: true},
+		"not exists":         {Selector: "!env", Matches: false},
+		"in":                 {Selector: "env in (staging, prod)", Matches: true},
+		"notin":              {Selector: "example.com/tier notin (web)", Matches: false},
+		"multiple":           {Selector: "env=prod, example.com/tier in (web,db), !team", Matches: true},
+		"empty requirement":  {Selector: "env=prod,", Invalid: true},
+		"invalid key":        {Selector: "-env=prod", Invalid: true},
+		"invalid value":      {Selector: "env=prod!", Invalid: true},
+		"unclosed set":       {Selector: "env in (prod", Invalid: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			sel, err := parseLabelSelector(testCase.Selector)
+			if testCase.Invalid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sel.matches(labels) != testCase.Matches {
+				t.Errorf("expected match %v", testCase.Matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,472 @@
+package hcloudtest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+var privateIPRanges = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return ipNet
+}
+
+func (s *Server) handleNetworks(r *request) (int, interface{}, error) {
+	return route(r, "network", func(id int) bool { return s.networks[id] != nil }, resourceHandlers{
+		list:        s.listNetworks,
+		create:      s.createNetwork,
+		get:         s.getNetwork,
+		update:      s.updateNetwork,
+		delete:      s.deleteNetwork,
+		listActions: s.resourceActions("network"),
+		actions: map[string]func(r *request, id int) (int, interface{}, error){
+			"add_subnet":        s.addNetworkSubnet,
+			"delete_subnet":     s.deleteNetworkSubnet,
+			"add_route":         s.addNetworkRoute,
+			"delete_route":      s.deleteNetworkRoute,
+			"change_ip_range":   s.changeNetworkIPRange,
+			"change_protection": s.changeNetworkProtection,
+		},
+	})
+}
+
+func (s *Server) listNetworks(r *request) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, network := range s.networks {
+		if name := query.Get("name"); name != "" && network.Name != name {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "networks", ids,
+		func(id int) map[string]string { return s.networks[id].Labels },
+		func(id int) interface{} { return *s.networks[id] },
+	)
+}
+
+// parsePrivateIPRange parses an IP range which must be part of one of the
+// private IPv4 address ranges.
+func parsePrivateIPRange(field, value string) (*net.IPNet, error) {
+	ip, ipNet, err := net.ParseCIDR(value)
+	if err != nil || ip.To4() == nil || !ip.Equal(ipNet.IP) {
+		return nil, invalidInput(field, "must be an IPv4 network in CIDR notation")
+	}
+	for _, private := range privateIPRanges {
+		privateOnes, _ := private.Mask.Size()
+		ones, _ := ipNet.Mask.Size()
+		if private.Contains(ipNet.IP) && ones >= privateOnes {
+			return ipNet, nil
+		}
+	}
+	return nil, invalidInput(field, "must be part of a private IP range")
+}
+
+func ipNetContains(outer, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && innerOnes >= outerOnes
+}
+
+func ipNetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func ipv4ToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uint32ToIPv4(n uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
+
+// newSubnet validates a subnet to be added to network and fills in its
+// gateway.
+func newSubnet(network *schema.Network, subnet schema.NetworkSubnet) (schema.NetworkSubnet, error) {
+	switch subnet.Type {
+	case string(hcloud.NetworkSubnetTypeCloud), string(hcloud.NetworkSubnetTypeServer), string(hcloud.NetworkSubnetTypeVSwitch):
+	default:
+		return subnet, invalidInput("type", "must be cloud, server or vswitch")
+	}
+	if subnet.NetworkZone == "" {
+		return subnet, invalidInput("network_zone", "is required")
+	}
+	if subnet.Type == string(hcloud.NetworkSubnetTypeVSwitch) && subnet.VSwitchID == 0 {
+		return subnet, invalidInput("vswitch_id", "is required for subnets of type vswitch")
+	}
+	networkRange := mustParseCIDR(network.IPRange)
+	ipRange, err := parsePrivateIPRange("ip_range", subnet.IPRange)
+	if err != nil {
+		return subnet, err
+	}
+	if !ipNetContains(networkRange, ipRange) {
+		return subnet, invalidInput("ip_range", "must be part of the IP range of the network")
+	}
+	for _, other := range network.Subnets {
+		if ipNetsOverlap(ipRange, mustParseCIDR(other.IPRange)) {
+			return subnet, invalidInput("ip_range", fmt.Sprintf("overlaps with subnet %s", other.IPRange))
+		}
+	}
+	subnet.IPRange = ipRange.String()
+	subnet.Gateway = uint32ToIPv4(ipv4ToUint32(ipRange.IP) + 1).String()
+	return subnet, nil
+}
+
+// nextFreeSubnet returns the first /24 of the network's IP range which does
+// not overlap with an existing subnet.
+func nextFreeSubnet(network *schema.Network) (string, bool) {
+	networkRange := mustParseCIDR(network.IPRange)
+	ones, _ := networkRange.Mask.Size()
+	if ones > 24 {
+		return "", false
+	}
+	first := ipv4ToUint32(networkRange.IP)
+	for n := uint32(0); n < 1<<uint(24-ones); n++ {
+		candidate := &net.IPNet{IP: uint32ToIPv4(first + n<<8), Mask: net.CIDRMask(24, 32)}
+		free := true
+		for _, subnet := range network.Subnets {
+			if ipNetsOverlap(candidate, mustParseCIDR(subnet.IPRange)) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return candidate.String(), true
+		}
+	}
+	return "", false
+}
+
+func newRoute(network *schema.Network, route schema.NetworkRoute) (schema.NetworkRoute, error) {
+	_, destination, err := net.ParseCIDR(route.Destination)
+	if err != nil || destination.IP.To4() == nil {
+		return route, invalidInput("destination", "must be an IPv4 network in CIDR notation")
+	}
+	gateway := net.ParseIP(route.Gateway)
+	if gateway == nil || !mustParseCIDR(network.IPRange).Contains(gateway) {
+		return route, invalidInput("gateway", "must be part of the IP range of the network")
+	}
+	for _, other := range network.Routes {
+		if other.Destination == destination.String() && other.Gateway == gateway.String() {
+			return route, uniquenessError("destination")
+		}
+	}
+	return schema.NetworkRoute{Destination: destination.String(), Gateway: gateway.String()}, nil
+}
+
+func (s *Server) createNetwork(r *request) (int, interface{}, error) {
+	var body schema.NetworkCreateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if body.Name == "" {
+		return 0, nil, invalidInput("name", "is required")
+	}
+	ipRange, err := parsePrivateIPRange("ip_range", body.IPRange)
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	for _, network := range s.networks {
+		if network.Name == body.Name {
+			return 0, nil, uniquenessError("name")
+		}
+	}
+
+	network := &schema.Network{
+		Name:                  body.Name,
+		Created:               s.Clock.Now(),
+		IPRange:               ipRange.String(),
+		Subnets:               []schema.NetworkSubnet{},
+		Routes:                []schema.NetworkRoute{},
+		Servers:               []int{},
+		Labels:                copyLabels(body.Labels),
+		ExposeRoutesToVSwitch: body.ExposeRoutesToVSwitch,
+	}
+	for _, subnet := range body.Subnets {
+		subnet, err := newSubnet(network, subnet)
+		if err != nil {
+			return 0, nil, err
+		}
+		network.Subnets = append(network.Subnets, subnet)
+	}
+	for _, route := range body.Routes {
+		route, err := newRoute(network, route)
+		if err != nil {
+			return 0, nil, err
+		}
+		network.Routes = append(network.Routes, route)
+	}
+	network.ID = s.newID("network")
+	s.networks[network.ID] = network
+	return http.StatusCreated, schema.NetworkCreateResponse{Network: *network}, nil
+}
+
+func (s *Server) getNetwork(r *request, id int) (int, interface{}, error) {
+	return http.StatusOK, schema.NetworkGetResponse{Network: *s.networks[id]}, nil
+}
+
+func (s *Server) updateNetwork(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkUpdateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != "" {
+		for otherID, other := range s.networks {
+			if otherID != id && other.Name == body.Name {
+				return 0, nil, uniquenessError("name")
+			}
+		}
+	}
+
+	network := s.networks[id]
+	if body.Name != "" {
+		network.Name = body.Name
+	}
+	if body.Labels != nil {
+		network.Labels = copyLabels(body.Labels)
+	}
+	if body.ExposeRoutesToVSwitch != nil {
+		network.ExposeRoutesToVSwitch = *body.ExposeRoutesToVSwitch
+	}
+	return http.StatusOK, schema.NetworkUpdateResponse{Network: *network}, nil
+}
+
+func (s *Server) deleteNetwork(r *request, id int) (int, interface{}, error) {
+	network := s.networks[id]
+	if network.Protection.Delete {
+		return 0, nil, protectedError("network", id)
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	for _, serverID := range network.Servers {
+		if server := s.servers[serverID]; server != nil {
+			s.detachServerFromNetwork(server, id)
+		}
+	}
+	delete(s.networks, id)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) addNetworkSubnet(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkActionAddSubnetRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	network := s.networks[id]
+	if body.IPRange == "" {
+		ipRange, ok := nextFreeSubnet(network)
+		if !ok {
+			return 0, nil, newError(hcloud.ErrorCodeResourceLimitExceeded, "no free subnet in network %d", id)
+		}
+		body.IPRange = ipRange
+	}
+	subnet, err := newSubnet(network, schema.NetworkSubnet{
+		Type:        body.Type,
+		IPRange:     body.IPRange,
+		NetworkZone: body.NetworkZone,
+		VSwitchID:   body.VSwitchID,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	network.Subnets = append(network.Subnets, subnet)
+	return s.actionResponse(s.startAction("add_subnet", nil, resourceRef("network", id)))
+}
+
+func (s *Server) deleteNetworkSubnet(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkActionDeleteSubnetRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	network := s.networks[id]
+	index := -1
+	for i, subnet := range network.Subnets {
+		if subnet.IPRange == body.IPRange {
+			index = i
+		}
+	}
+	if index < 0 {
+		return 0, nil, invalidInput("ip_range", "subnet not found")
+	}
+	ipRange := mustParseCIDR(body.IPRange)
+	for _, serverID := range network.Servers {
+		for _, privateNet := range s.servers[serverID].PrivateNet {
+			if privateNet.Network == id && ipRange.Contains(net.ParseIP(privateNet.IP)) {
+				return 0, nil, newError(hcloud.ErrorCodeConflict, "subnet %s has attached servers", body.IPRange)
+			}
+		}
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	network.Subnets = append(network.Subnets[:index], network.Subnets[index+1:]...)
+	return s.actionResponse(s.startAction("delete_subnet", nil, resourceRef("network", id)))
+}
+
+func (s *Server) addNetworkRoute(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkActionAddRouteRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	network := s.networks[id]
+	route, err := newRoute(network, schema.NetworkRoute{Destination: body.Destination, Gateway: body.Gateway})
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	network.Routes = append(network.Routes, route)
+	return s.actionResponse(s.startAction("add_route", nil, resourceRef("network", id)))
+}
+
+func (s *Server) deleteNetworkRoute(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkActionDeleteRouteRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	network := s.networks[id]
+	index := -1
+	for i, route := range network.Routes {
+		if route.Destination == body.Destination && route.Gateway == body.Gateway {
+			index = i
+		}
+	}
+	if index < 0 {
+		return 0, nil, invalidInput("destination", "route not found")
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	network.Routes = append(network.Routes[:index], network.Routes[index+1:]...)
+	return s.actionResponse(s.startAction("delete_route", nil, resourceRef("network", id)))
+}
+
+func (s *Server) changeNetworkIPRange(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkActionChangeIPRangeRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	network := s.networks[id]
+	ipRange, err := parsePrivateIPRange("ip_range", body.IPRange)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !ipNetContains(ipRange, mustParseCIDR(network.IPRange)) {
+		return 0, nil, invalidInput("ip_range", "can only be extended")
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	network.IPRange = ipRange.String()
+	return s.actionResponse(s.startAction("change_ip_range", nil, resourceRef("network", id)))
+}
+
+func (s *Server) changeNetworkProtection(r *request, id int) (int, interface{}, error) {
+	var body schema.NetworkActionChangeProtectionRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("network", id)); err != nil {
+		return 0, nil, err
+	}
+	if body.Delete != nil {
+		s.networks[id].Protection.Delete = *body.Delete
+	}
+	return s.actionResponse(s.startAction("change_protection", nil, resourceRef("network", id)))
+}
+
+// allocateNetworkIP returns the first free host address in a subnet of the
+// network which servers in the network zone can be attached to.
+func (s *Server) allocateNetworkIP(network *schema.Network, networkZone string) (string, bool) {
+	used := map[string]bool{}
+	for _, serverID := range network.Servers {
+		for _, privateNet := range s.servers[serverID].PrivateNet {
+			if privateNet.Network != network.ID {
+				continue
+			}
+			used[privateNet.IP] = true
+			for _, alias := range privateNet.AliasIPs {
+				used[alias] = true
+			}
+		}
+	}
+	for _, subnet := range network.Subnets {
+		if subnet.Type == string(hcloud.NetworkSubnetTypeVSwitch) || subnet.NetworkZone != networkZone {
+			continue
+		}
+		ipRange := mustParseCIDR(subnet.IPRange)
+		ones, bits := ipRange.Mask.Size()
+		first := ipv4ToUint32(ipRange.IP) + 2 // skip the network address and gateway
+		last := ipv4ToUint32(ipRange.IP) | (1<<uint(bits-ones) - 1) - 1
+		for n := first; n <= last; n++ {
+			if ip := uint32ToIPv4(n).String(); !used[ip] {
+				return ip, true
+			}
+		}
+	}
+	return "", false
+}
+
+// networkIPUsable reports whether ip is a free host address in a subnet of
+// the network which servers in the network zone can be attached to.
+func (s *Server) networkIPUsable(network *schema.Network, networkZone string, ip net.IP) bool {
+	if ip == nil || ip.To4() == nil {
+		return false
+	}
+	for _, serverID := range network.Servers {
+		for _, privateNet := range s.servers[serverID].PrivateNet {
+			if privateNet.Network != network.ID {
+				continue
+			}
+			if ip.Equal(net.ParseIP(privateNet.IP)) {
+				return false
+			}
+			for _, alias := range privateNet.AliasIPs {
+				if ip.Equal(net.ParseIP(alias)) {
+					return false
+				}
+			}
+		}
+	}
+	for _, subnet := range network.Subnets {
+		if subnet.Type == string(hcloud.NetworkSubnetTypeVSwitch) || subnet.NetworkZone != networkZone {
+			continue
+		}
+		ipRange := mustParseCIDR(subnet.IPRange)
+		if !ipRange.Contains(ip) || ip.Equal(ipRange.IP) || ip.Equal(net.ParseIP(subnet.Gateway)) {
+			continue
+		}
+		ones, bits := ipRange.Mask.Size()
+		if ipv4ToUint32(ip) == ipv4ToUint32(ipRange.IP)|(1<<uint(bits-ones)-1) {
+			continue
+		}
+		return true
+	}
+	return false
+}
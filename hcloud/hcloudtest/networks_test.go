@@ -0,0 +1,69 @@
+package hcloudtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestNetworksSubnetsAndRoutes(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	network, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+		Name:    "net",
+		IPRange: mustParseCIDR("10.0.0.0/16"),
+		Subnets: []hcloud.NetworkSubnet{
+			{Type: hcloud.NetworkSubnetTypeCloud, IPRange: mustParseCIDR("10.0.0.0/24"), NetworkZone: hcloud.NetworkZoneEUCentral},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = client.Network.AddSubnet(ctx, network, hcloud.NetworkAddSubnetOpts{
+		Subnet: hcloud.NetworkSubnet{Type: hcloud.NetworkSubnetTypeCloud, IPRange: mustParseCIDR("10.0.0.128/25"), NetworkZone: hcloud.NetworkZoneEUCentral},
+	})
+	if !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error for overlapping subnet, got %v", err)
+	}
+	_, _, err = client.Network.AddSubnet(ctx, network, hcloud.NetworkAddSubnetOpts{
+		Subnet: hcloud.NetworkSubnet{Type: hcloud.NetworkSubnetTypeCloud, NetworkZone: hcloud.NetworkZoneEUCentral},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Network.AddRoute(ctx, network, hcloud.NetworkAddRouteOpts{
+		Route: hcloud.NetworkRoute{Destination: mustParseCIDR("10.100.0.0/24"), Gateway: mustParseCIDR("10.0.0.2/32").IP},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Network.AddRoute(ctx, network, hcloud.NetworkAddRouteOpts{
+		Route: hcloud.NetworkRoute{Destination: mustParseCIDR("10.100.0.0/24"), Gateway: mustParseCIDR("192.168.0.1/32").IP},
+	})
+	if !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error for gateway outside network, got %v", err)
+	}
+
+	network, _, err = client.Network.GetByID(ctx, network.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(network.Subnets) != 2 || network.Subnets[1].IPRange.String() != "10.0.1.0/24" || network.Subnets[1].Gateway.String() != "10.0.1.1" {
+		t.Errorf("unexpected subnets: %+v", network.Subnets)
+	}
+	if len(network.Routes) != 1 || network.Routes[0].Destination.String() != "10.100.0.0/24" {
+		t.Errorf("unexpected routes: %+v", network.Routes)
+	}
+
+	if _, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{Name: "net", IPRange: mustParseCIDR("10.1.0.0/16")}); !hcloud.IsError(err, hcloud.ErrorCodeUniquenessError) {
+		t.Errorf("expected uniqueness error, got %v", err)
+	}
+	if _, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{Name: "public", IPRange: mustParseCIDR("8.8.0.0/16")}); !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error for public IP range, got %v", err)
+	}
+}
@@ -0,0 +1,631 @@
+package hcloudtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+var serverNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9.-]{0,61}[a-zA-Z0-9])?$`)
+
+func (s *Server) handleServers(r *request) (int, interface{}, error) {
+	return route(r, "server", func(id int) bool { return s.servers[id] != nil }, resourceHandlers{
+		list:        s.listServers,
+		create:      s.createServer,
+		get:         s.getServer,
+		update:      s.updateServer,
+		delete:      s.deleteServer,
+		listActions: s.resourceActions("server"),
+		actions: map[string]func(r *request, id int) (int, interface{}, error){
+			"poweron":             s.serverPowerAction("start_server", hcloud.ServerStatusStarting, hcloud.ServerStatusRunning),
+			"poweroff":            s.serverPowerAction("stop_server", hcloud.ServerStatusStopping, hcloud.ServerStatusOff),
+			"shutdown":            s.serverPowerAction("shutdown_server", hcloud.ServerStatusStopping, hcloud.ServerStatusOff),
+			"reboot":              s.serverPowerAction("reboot_server", hcloud.ServerStatusStarting, hcloud.ServerStatusRunning),
+			"reset":               s.serverPowerAction("reset_server", hcloud.ServerStatusStarting, hcloud.ServerStatusRunning),
+			"reset_password":      s.resetServerPassword,
+			"create_image":        s.createServerImage,
+			"enable_rescue":       s.enableServerRescue,
+			"disable_rescue":      s.disableServerRescue,
+			"rebuild":             s.rebuildServer,
+			"change_type":         s.changeServerType,
+			"enable_backup":       s.enableServerBackup,
+			"disable_backup":      s.disableServerBackup,
+			"change_protection":   s.changeServerProtection,
+			"change_dns_ptr":      s.changeServerDNSPtr,
+			"attach_to_network":   s.attachServerToNetwork,
+			"detach_from_network": s.detachServerFromNetworkAction,
+			"change_alias_ips":    s.changeServerAliasIPs,
+		},
+	})
+}
+
+func (s *Server) renderServer(id int) schema.Server {
+	server := *s.servers[id]
+	_, server.Locked = s.locked("server", id)
+	return server
+}
+
+func (s *Server) listServers(r *request) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, server := range s.servers {
+		if name := query.Get("name"); name != "" && server.Name != name {
+			continue
+		}
+		if statuses := query["status"]; len(statuses) > 0 && !containsString(statuses, server.Status) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "servers", ids,
+		func(id int) map[string]string { return s.servers[id].Labels },
+		func(id int) interface{} { return s.renderServer(id) },
+	)
+}
+
+func randomPassword() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (s *Server) createServer(r *request) (int, interface{}, error) {
+	var body schema.ServerCreateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if !serverNameRegexp.MatchString(body.Name) {
+		return 0, nil, invalidInput("name", "must be a valid hostname")
+	}
+	serverType, ok := findServerType(body.ServerType)
+	if !ok {
+		return 0, nil, invalidInput("server_type", "server type not found")
+	}
+	image := s.findImage(body.Image)
+	if image == nil || image.Status != string(hcloud.ImageStatusAvailable) {
+		return 0, nil, invalidInput("image", "image not found")
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+
+	datacenter := datacenters[0]
+	switch {
+	case body.Datacenter != "":
+		if datacenter, ok = findDatacenter(body.Datacenter); !ok {
+			return 0, nil, invalidInput("datacenter", "datacenter not found")
+		}
+	case body.Location != "":
+		location, ok := findLocation(body.Location)
+		if !ok {
+			return 0, nil, invalidInput("location", "location not found")
+		}
+		datacenter = datacenterInLocation(location)
+	}
+
+	for _, keyID := range body.SSHKeys {
+		if s.sshKeys[keyID] == nil {
+			return 0, nil, invalidInput("ssh_keys", fmt.Sprintf("SSH key %d not found", keyID))
+		}
+	}
+	for _, volumeID := range body.Volumes {
+		volume := s.volumes[volumeID]
+		switch {
+		case volume == nil:
+			return 0, nil, invalidInput("volumes", fmt.Sprintf("volume %d not found", volumeID))
+		case volume.Server != nil:
+			return 0, nil, serverAlreadyAttachedError(*volume.Server, "volume %d is already attached", volumeID)
+		case volume.Location.ID != datacenter.Location.ID:
+			return 0, nil, invalidInput("volumes", fmt.Sprintf("volume %d is in another location", volumeID))
+		}
+	}
+	for _, networkID := range body.Networks {
+		network := s.networks[networkID]
+		if network == nil {
+			return 0, nil, invalidInput("networks", fmt.Sprintf("network %d not found", networkID))
+		}
+		if _, ok := s.allocateNetworkIP(network, datacenter.Location.NetworkZone); !ok {
+			return 0, nil, invalidInput("networks", fmt.Sprintf("network %d has no free IP in network zone %s", networkID, datacenter.Location.NetworkZone))
+		}
+	}
+	for _, server := range s.servers {
+		if server.Name == body.Name {
+			return 0, nil, uniquenessError("name")
+		}
+	}
+
+	id := s.newID("server")
+	imageCopy := *image
+	server := &schema.Server{
+		ID:         id,
+		Name:       body.Name,
+		Status:     string(hcloud.ServerStatusInitializing),
+		Created:    s.Clock.Now(),
+		PrivateNet: []schema.ServerPrivateNet{},
+		ServerType: serverType,
+		Datacenter: datacenter,
+		Image:      &imageCopy,
+		Labels:     copyLabels(body.Labels),
+		Volumes:    []int{},
+		PublicNet: schema.ServerPublicNet{
+			FloatingIPs: []int{},
+			IPv6: schema.ServerPublicNetIPv6{
+				DNSPtr: []schema.ServerPublicNetIPv6DNSPtr{},
+			},
+		},
+	}
+	if body.PublicNet == nil || body.PublicNet.EnableIPv4 {
+		server.PublicNet.IPv4.IP = fmt.Sprintf("198.51.100.%d", id%256)
+	}
+	if body.PublicNet == nil || body.PublicNet.EnableIPv6 {
+		server.PublicNet.IPv6.IP = fmt.Sprintf("2001:db8:ffff:%x::/64", id)
+	}
+	s.servers[id] = server
+
+	respBody := schema.ServerCreateResponse{Server: *server, NextActions: []schema.Action{}}
+	if len(body.SSHKeys) == 0 {
+		password := randomPassword()
+		respBody.RootPassword = &password
+	}
+	status := hcloud.ServerStatusRunning
+	if body.StartAfterCreate != nil && !*body.StartAfterCreate {
+		status = hcloud.ServerStatusOff
+	}
+	respBody.Action = s.renderAction(s.startAction("create_server", func() {
+		server.Status = string(status)
+	}, resourceRef("server", id)))
+	for _, volumeID := range body.Volumes {
+		volume := s.volumes[volumeID]
+		a := s.startAction("attach_volume", func() {
+			s.attachVolumeToServer(volume, id)
+		}, resourceRef("volume", volumeID), resourceRef("server", id))
+		respBody.NextActions = append(respBody.NextActions, s.renderAction(a))
+	}
+	for _, networkID := range body.Networks {
+		network := s.networks[networkID]
+		ip, _ := s.allocateNetworkIP(network, datacenter.Location.NetworkZone)
+		s.attachServerToNetworkIP(server, network, ip, nil)
+		a := s.startAction("attach_to_network", nil, resourceRef("server", id), resourceRef("network", networkID))
+		respBody.NextActions = append(respBody.NextActions, s.renderAction(a))
+	}
+	respBody.Server = s.renderServer(id)
+	return http.StatusCreated, respBody, nil
+}
+
+func (s *Server) getServer(r *request, id int) (int, interface{}, error) {
+	return http.StatusOK, schema.ServerGetResponse{Server: s.renderServer(id)}, nil
+}
+
+func (s *Server) updateServer(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerUpdateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != "" && !serverNameRegexp.MatchString(body.Name) {
+		return 0, nil, invalidInput("name", "must be a valid hostname")
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != "" {
+		for otherID, other := range s.servers {
+			if otherID != id && other.Name == body.Name {
+				return 0, nil, uniquenessError("name")
+			}
+		}
+	}
+
+	server := s.servers[id]
+	if body.Name != "" {
+		server.Name = body.Name
+	}
+	if body.Labels != nil {
+		server.Labels = copyLabels(body.Labels)
+	}
+	return http.StatusOK, schema.ServerUpdateResponse{Server: s.renderServer(id)}, nil
+}
+
+func (s *Server) deleteServer(r *request, id int) (int, interface{}, error) {
+	server := s.servers[id]
+	if server.Protection.Delete {
+		return 0, nil, protectedError("server", id)
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	for _, volumeID := range server.Volumes {
+		if volume := s.volumes[volumeID]; volume != nil {
+			volume.Server = nil
+		}
+	}
+	for _, floatingIPID := range server.PublicNet.FloatingIPs {
+		if floatingIP := s.floatingIPs[floatingIPID]; floatingIP != nil {
+			floatingIP.Server = nil
+		}
+	}
+	for _, privateNet := range server.PrivateNet {
+		if network := s.networks[privateNet.Network]; network != nil {
+			network.Servers = removeInt(network.Servers, id)
+		}
+	}
+	delete(s.servers, id)
+	a := s.startAction("delete_server", nil, resourceRef("server", id))
+	return http.StatusOK, map[string]interface{}{"action": s.renderAction(a)}, nil
+}
+
+// serverPowerAction returns a handler for an action which changes the power
+// state of a server. The server has the status running while the action runs
+// and the status done once it has finished.
+func (s *Server) serverPowerAction(command string, running, done hcloud.ServerStatus) func(r *request, id int) (int, interface{}, error) {
+	return func(r *request, id int) (int, interface{}, error) {
+		if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+			return 0, nil, err
+		}
+		server := s.servers[id]
+		server.Status = string(running)
+		return s.actionResponse(s.startAction(command, func() {
+			server.Status = string(done)
+		}, resourceRef("server", id)))
+	}
+}
+
+func (s *Server) resetServerPassword(r *request, id int) (int, interface{}, error) {
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	a := s.startAction("reset_password", nil, resourceRef("server", id))
+	return http.StatusCreated, schema.ServerActionResetPasswordResponse{
+		Action:       s.renderAction(a),
+		RootPassword: randomPassword(),
+	}, nil
+}
+
+func (s *Server) createServerImage(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionCreateImageRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	typ := string(hcloud.ImageTypeSnapshot)
+	if body.Type != nil {
+		typ = *body.Type
+	}
+	if typ != string(hcloud.ImageTypeSnapshot) && typ != string(hcloud.ImageTypeBackup) {
+		return 0, nil, invalidInput("type", "must be snapshot or backup")
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+
+	server := s.servers[id]
+	imageID := s.newID("image")
+	image := &schema.Image{
+		ID:          imageID,
+		Status:      string(hcloud.ImageStatusCreating),
+		Type:        typ,
+		DiskSize:    float32(server.ServerType.Disk),
+		Created:     s.Clock.Now(),
+		CreatedFrom: &schema.ImageCreatedFrom{ID: id, Name: server.Name},
+		Labels:      copyLabels(body.Labels),
+	}
+	if server.Image != nil {
+		image.OSFlavor = server.Image.OSFlavor
+		image.OSVersion = server.Image.OSVersion
+	}
+	if body.Description != nil {
+		image.Description = *body.Description
+	}
+	if typ == string(hcloud.ImageTypeBackup) {
+		image.BoundTo = &id
+	}
+	s.images[imageID] = image
+
+	a := s.startAction("create_image", func() {
+		image.Status = string(hcloud.ImageStatusAvailable)
+	}, resourceRef("server", id), resourceRef("image", imageID))
+	return http.StatusCreated, schema.ServerActionCreateImageResponse{
+		Action: s.renderAction(a),
+		Image:  *image,
+	}, nil
+}
+
+func (s *Server) enableServerRescue(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionEnableRescueRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	for _, keyID := range body.SSHKeys {
+		if s.sshKeys[keyID] == nil {
+			return 0, nil, invalidInput("ssh_keys", fmt.Sprintf("SSH key %d not found", keyID))
+		}
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	a := s.startAction("enable_rescue", func() {
+		server.RescueEnabled = true
+	}, resourceRef("server", id))
+	return http.StatusCreated, schema.ServerActionEnableRescueResponse{
+		Action:       s.renderAction(a),
+		RootPassword: randomPassword(),
+	}, nil
+}
+
+func (s *Server) disableServerRescue(r *request, id int) (int, interface{}, error) {
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	return s.actionResponse(s.startAction("disable_rescue", func() {
+		server.RescueEnabled = false
+	}, resourceRef("server", id)))
+}
+
+func (s *Server) rebuildServer(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionRebuildRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	if server.Protection.Rebuild {
+		return 0, nil, protectedError("server", id)
+	}
+	image := s.findImage(body.Image)
+	if image == nil || image.Status != string(hcloud.ImageStatusAvailable) {
+		return 0, nil, invalidInput("image", "image not found")
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	imageCopy := *image
+	server.Status = string(hcloud.ServerStatusRebuilding)
+	server.Image = &imageCopy
+	return s.actionResponse(s.startAction("rebuild_server", func() {
+		server.Status = string(hcloud.ServerStatusRunning)
+	}, resourceRef("server", id)))
+}
+
+func (s *Server) changeServerType(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionChangeTypeRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	serverType, ok := findServerType(body.ServerType)
+	if !ok {
+		return 0, nil, invalidInput("server_type", "server type not found")
+	}
+	server := s.servers[id]
+	if server.Status != string(hcloud.ServerStatusOff) {
+		return 0, nil, newError(hcloud.ErrorCodeInvalidInput, "server %d must be powered off", id)
+	}
+	if body.UpgradeDisk && serverType.Disk < server.ServerType.Disk {
+		return 0, nil, invalidInput("server_type", "disk of the server type is too small")
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	return s.actionResponse(s.startAction("change_server_type", func() {
+		server.ServerType = serverType
+	}, resourceRef("server", id)))
+}
+
+func (s *Server) enableServerBackup(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionEnableBackupRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	window := "22-02"
+	if body.BackupWindow != nil {
+		window = *body.BackupWindow
+	}
+	s.servers[id].BackupWindow = &window
+	return s.actionResponse(s.startAction("enable_backup", nil, resourceRef("server", id)))
+}
+
+func (s *Server) disableServerBackup(r *request, id int) (int, interface{}, error) {
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	s.servers[id].BackupWindow = nil
+	return s.actionResponse(s.startAction("disable_backup", nil, resourceRef("server", id)))
+}
+
+func (s *Server) changeServerProtection(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionChangeProtectionRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	protection := server.Protection
+	if body.Delete != nil {
+		protection.Delete = *body.Delete
+	}
+	if body.Rebuild != nil {
+		protection.Rebuild = *body.Rebuild
+	}
+	if protection.Delete != protection.Rebuild {
+		return 0, nil, invalidInput("rebuild", "delete and rebuild protection must be the same")
+	}
+	if err := s.checkUnlocked(resourceRef("server", id)); err != nil {
+		return 0, nil, err
+	}
+	server.Protection = protection
+	return s.actionResponse(s.startAction("change_protection", nil, resourceRef("server", id)))
+}
+
+func (s *Server) changeServerDNSPtr(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionChangeDNSPtrRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	ip := net.ParseIP(body.IP)
+	switch {
+	case ip != nil && server.PublicNet.IPv4.IP != "" && ip.Equal(net.ParseIP(server.PublicNet.IPv4.IP)):
+		if body.DNSPtr != nil {
+			server.PublicNet.IPv4.DNSPtr = *body.DNSPtr
+		} else {
+			server.PublicNet.IPv4.DNSPtr = ""
+		}
+	case ip != nil && server.PublicNet.IPv6.IP != "" && mustParseCIDR(server.PublicNet.IPv6.IP).Contains(ip):
+		ptrs := []schema.ServerPublicNetIPv6DNSPtr{}
+		for _, ptr := range server.PublicNet.IPv6.DNSPtr {
+			if !net.ParseIP(ptr.IP).Equal(ip) {
+				ptrs = append(ptrs, ptr)
+			}
+		}
+		if body.DNSPtr != nil {
+			ptrs = append(ptrs, schema.ServerPublicNetIPv6DNSPtr{IP: body.IP, DNSPtr: *body.DNSPtr})
+		}
+		server.PublicNet.IPv6.DNSPtr = ptrs
+	default:
+		return 0, nil, invalidInput("ip", fmt.Sprintf("%s is not a public IP of server %d", body.IP, id))
+	}
+	return s.actionResponse(s.startAction("change_dns_ptr", nil, resourceRef("server", id)))
+}
+
+// attachServerToNetworkIP records that server is attached to network with
+// the given IP and alias IPs.
+func (s *Server) attachServerToNetworkIP(server *schema.Server, network *schema.Network, ip string, aliasIPs []string) {
+	if aliasIPs == nil {
+		aliasIPs = []string{}
+	}
+	server.PrivateNet = append(server.PrivateNet, schema.ServerPrivateNet{
+		Network:    network.ID,
+		IP:         ip,
+		AliasIPs:   aliasIPs,
+		MACAddress: fmt.Sprintf("86:00:00:%02x:%02x:%02x", byte(server.ID>>8), byte(server.ID), byte(network.ID)),
+	})
+	network.Servers = append(network.Servers, server.ID)
+}
+
+func (s *Server) detachServerFromNetwork(server *schema.Server, networkID int) {
+	privateNets := []schema.ServerPrivateNet{}
+	for _, privateNet := range server.PrivateNet {
+		if privateNet.Network != networkID {
+			privateNets = append(privateNets, privateNet)
+		}
+	}
+	server.PrivateNet = privateNets
+	if network := s.networks[networkID]; network != nil {
+		network.Servers = removeInt(network.Servers, server.ID)
+	}
+}
+
+func (s *Server) attachServerToNetwork(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionAttachToNetworkRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	network := s.networks[body.Network]
+	if network == nil {
+		return 0, nil, invalidInput("network", "network not found")
+	}
+	for _, privateNet := range server.PrivateNet {
+		if privateNet.Network == network.ID {
+			return 0, nil, serverAlreadyAttachedError(id, "server %d is already attached to network %d", id, network.ID)
+		}
+	}
+	zone := server.Datacenter.Location.NetworkZone
+	var ip string
+	if body.IP != nil {
+		if !s.networkIPUsable(network, zone, net.ParseIP(*body.IP)) {
+			return 0, nil, invalidInput("ip", fmt.Sprintf("%s is not available in network %d", *body.IP, network.ID))
+		}
+		ip = *body.IP
+	} else {
+		var ok bool
+		if ip, ok = s.allocateNetworkIP(network, zone); !ok {
+			return 0, nil, invalidInput("network", fmt.Sprintf("network %d has no free IP in network zone %s", network.ID, zone))
+		}
+	}
+	var aliasIPs []string
+	for _, alias := range body.AliasIPs {
+		if alias == nil {
+			continue
+		}
+		if *alias == ip || !s.networkIPUsable(network, zone, net.ParseIP(*alias)) {
+			return 0, nil, invalidInput("alias_ips", fmt.Sprintf("%s is not available in network %d", *alias, network.ID))
+		}
+		aliasIPs = append(aliasIPs, *alias)
+	}
+	refs := []schema.ActionResourceReference{resourceRef("server", id), resourceRef("network", network.ID)}
+	if err := s.checkUnlocked(refs...); err != nil {
+		return 0, nil, err
+	}
+	s.attachServerToNetworkIP(server, network, ip, aliasIPs)
+	return s.actionResponse(s.startAction("attach_to_network", nil, refs...))
+}
+
+func (s *Server) detachServerFromNetworkAction(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionDetachFromNetworkRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	attached := false
+	for _, privateNet := range server.PrivateNet {
+		if privateNet.Network == body.Network {
+			attached = true
+		}
+	}
+	if !attached {
+		return 0, nil, invalidInput("network", fmt.Sprintf("server %d is not attached to network %d", id, body.Network))
+	}
+	refs := []schema.ActionResourceReference{resourceRef("server", id), resourceRef("network", body.Network)}
+	if err := s.checkUnlocked(refs...); err != nil {
+		return 0, nil, err
+	}
+	s.detachServerFromNetwork(server, body.Network)
+	return s.actionResponse(s.startAction("detach_from_network", nil, refs...))
+}
+
+func (s *Server) changeServerAliasIPs(r *request, id int) (int, interface{}, error) {
+	var body schema.ServerActionChangeAliasIPsRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	server := s.servers[id]
+	network := s.networks[body.Network]
+	index := -1
+	for i, privateNet := range server.PrivateNet {
+		if privateNet.Network == body.Network {
+			index = i
+		}
+	}
+	if network == nil || index < 0 {
+		return 0, nil, invalidInput("network", fmt.Sprintf("server %d is not attached to network %d", id, body.Network))
+	}
+
+	// The current alias IPs of the server may be kept.
+	privateNet := &server.PrivateNet[index]
+	current := privateNet.AliasIPs
+	privateNet.AliasIPs = nil
+	zone := server.Datacenter.Location.NetworkZone
+	for _, alias := range body.AliasIPs {
+		if alias == privateNet.IP || !s.networkIPUsable(network, zone, net.ParseIP(alias)) {
+			privateNet.AliasIPs = current
+			return 0, nil, invalidInput("alias_ips", fmt.Sprintf("%s is not available in network %d", alias, network.ID))
+		}
+	}
+	refs := []schema.ActionResourceReference{resourceRef("server", id), resourceRef("network", network.ID)}
+	if err := s.checkUnlocked(refs...); err != nil {
+		privateNet.AliasIPs = current
+		return 0, nil, err
+	}
+	privateNet.AliasIPs = append([]string{}, body.AliasIPs...)
+	return s.actionResponse(s.startAction("change_alias_ips", nil, refs...))
+}
@@ -0,0 +1,322 @@
+package hcloudtest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func createTestServer(t *testing.T, client *hcloud.Client, opts hcloud.ServerCreateOpts) *hcloud.Server {
+	if opts.ServerType == nil {
+		opts.ServerType = &hcloud.ServerType{Name: "cx11"}
+	}
+	if opts.Image == nil {
+		opts.Image = &hcloud.Image{Name: "ubuntu-20.04"}
+	}
+	result, _, err := client.Server.Create(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result.Server
+}
+
+func TestServersCreate(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	sshKey, _, err := client.SSHKey.Create(ctx, hcloud.SSHKeyCreateOpts{
+		Name:      "key",
+		PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAAA",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, _, err := client.Volume.Create(ctx, hcloud.VolumeCreateOpts{
+		Name:     "data",
+		Size:     10,
+		Location: &hcloud.Location{Name: "nbg1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	network, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+		Name:    "net",
+		IPRange: mustParseCIDR("10.0.0.0/16"),
+		Subnets: []hcloud.NetworkSubnet{
+			{Type: hcloud.NetworkSubnetTypeCloud, IPRange: mustParseCIDR("10.0.1.0/24"), NetworkZone: hcloud.NetworkZoneEUCentral},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, _, err := client.Server.Create(ctx, hcloud.ServerCreateOpts{
+		Name:             "web",
+		ServerType:       &hcloud.ServerType{ID: 3},
+		Image:            &hcloud.Image{Name: "debian-11"},
+		Location:         &hcloud.Location{Name: "nbg1"},
+		SSHKeys:          []*hcloud.SSHKey{sshKey},
+		Volumes:          []*hcloud.Volume{volume.Volume},
+		Networks:         []*hcloud.Network{network},
+		StartAfterCreate: hcloud.Bool(false),
+		Labels:           map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RootPassword != "" {
+		t.Errorf("unexpected root password for server with SSH keys")
+	}
+	if len(result.NextActions) != 2 {
+		t.Errorf("unexpected next actions: %v", result.NextActions)
+	}
+
+	server, _, err := client.Server.GetByName(ctx, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.Status != hcloud.ServerStatusOff {
+		t.Errorf("unexpected status: %s", server.Status)
+	}
+	if server.ServerType.Name != "cx21" || server.Image.Name != "debian-11" || server.Datacenter.Name != "nbg1-dc3" {
+		t.Errorf("unexpected server: %+v", server)
+	}
+	if len(server.Volumes) != 1 || server.Volumes[0].ID != volume.Volume.ID {
+		t.Errorf("unexpected volumes: %v", server.Volumes)
+	}
+	if len(server.PrivateNet) != 1 || !server.PrivateNet[0].IP.Equal(net.ParseIP("10.0.1.2")) {
+		t.Errorf("unexpected private networks: %+v", server.PrivateNet)
+	}
+	if server.PublicNet.IPv4.IP == nil || server.PublicNet.IPv6.Network == nil {
+		t.Errorf("unexpected public network: %+v", server.PublicNet)
+	}
+
+	network, _, err = client.Network.GetByID(ctx, network.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(network.Servers) != 1 || network.Servers[0].ID != server.ID {
+		t.Errorf("unexpected network servers: %v", network.Servers)
+	}
+}
+
+func TestServersCreateInvalid(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+
+	testCases := map[string]struct {
+		Opts hcloud.ServerCreateOpts
+		Code hcloud.ErrorCode
+	}{
+		"duplicate name": {
+			Opts: hcloud.ServerCreateOpts{Name: "web", ServerType: &hcloud.ServerType{Name: "cx11"}, Image: &hcloud.Image{ID: 1}},
+			Code: hcloud.ErrorCodeUniquenessError,
+		},
+		"unknown server type": {
+			Opts: hcloud.ServerCreateOpts{Name: "other", ServerType: &hcloud.ServerType{Name: "cx99"}, Image: &hcloud.Image{ID: 1}},
+			Code: hcloud.ErrorCodeInvalidInput,
+		},
+		"unknown image": {
+			Opts: hcloud.ServerCreateOpts{Name: "other", ServerType: &hcloud.ServerType{Name: "cx11"}, Image: &hcloud.Image{Name: "windows"}},
+			Code: hcloud.ErrorCodeInvalidInput,
+		},
+		"invalid name": {
+			Opts: hcloud.ServerCreateOpts{Name: "web_1", ServerType: &hcloud.ServerType{Name: "cx11"}, Image: &hcloud.Image{ID: 1}},
+			Code: hcloud.ErrorCodeInvalidInput,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := client.Server.Create(ctx, testCase.Opts)
+			if !hcloud.IsError(err, testCase.Code) {
+				t.Errorf("expected %s error, got %v", testCase.Code, err)
+			}
+		})
+	}
+}
+
+func TestServersProtection(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	server := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+	_, _, err := client.Server.ChangeProtection(ctx, server, hcloud.ServerChangeProtectionOpts{
+		Delete:  hcloud.Bool(true),
+		Rebuild: hcloud.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Server.Delete(ctx, server); !hcloud.IsError(err, hcloud.ErrorCodeProtected) {
+		t.Errorf("expected protected error, got %v", err)
+	}
+	_, _, err = client.Server.Rebuild(ctx, server, hcloud.ServerRebuildOpts{Image: &hcloud.Image{Name: "debian-11"}})
+	if !hcloud.IsError(err, hcloud.ErrorCodeProtected) {
+		t.Errorf("expected protected error, got %v", err)
+	}
+
+	_, _, err = client.Server.ChangeProtection(ctx, server, hcloud.ServerChangeProtectionOpts{
+		Delete:  hcloud.Bool(false),
+		Rebuild: hcloud.Bool(false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Server.Delete(ctx, server); err != nil {
+		t.Fatal(err)
+	}
+	if server, _, err := client.Server.GetByID(ctx, server.ID); err != nil || server != nil {
+		t.Errorf("expected server to be deleted, got %v, %v", server, err)
+	}
+}
+
+func TestServersDeleteReleasesResources(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	server := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+	volume, _, err := client.Volume.Create(ctx, hcloud.VolumeCreateOpts{Name: "data", Size: 10, Server: server})
+	if err != nil {
+		t.Fatal(err)
+	}
+	floatingIP, _, err := client.FloatingIP.Create(ctx, hcloud.FloatingIPCreateOpts{Type: hcloud.FloatingIPTypeIPv4, Server: server})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Volume.Delete(ctx, volume.Volume); !hcloud.IsError(err, hcloud.ErrorCodeConflict) {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+	if _, err := client.Server.Delete(ctx, server); err != nil {
+		t.Fatal(err)
+	}
+
+	v, _, err := client.Volume.GetByID(ctx, volume.Volume.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Server != nil {
+		t.Errorf("expected volume to be detached, got server %d", v.Server.ID)
+	}
+	f, _, err := client.FloatingIP.GetByID(ctx, floatingIP.FloatingIP.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Server != nil {
+		t.Errorf("expected Floating IP to be unassigned, got server %d", f.Server.ID)
+	}
+}
+
+func TestServersCreateImage(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	server := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+	result, _, err := client.Server.CreateImage(ctx, server, &hcloud.ServerCreateImageOpts{
+		Type:        hcloud.ImageTypeSnapshot,
+		Description: hcloud.String("before upgrade"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshots, err := client.Image.AllWithOpts(ctx, hcloud.ImageListOpts{Type: []hcloud.ImageType{hcloud.ImageTypeSnapshot}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].ID != result.Image.ID || snapshots[0].Description != "before upgrade" {
+		t.Errorf("unexpected snapshots: %v", snapshots)
+	}
+	if snapshots[0].Status != hcloud.ImageStatusAvailable || snapshots[0].CreatedFrom.ID != server.ID {
+		t.Errorf("unexpected snapshot: %+v", snapshots[0])
+	}
+
+	if _, err := client.Image.Delete(ctx, &hcloud.Image{ID: 1}); !hcloud.IsError(err, hcloud.ErrorCodeForbidden) {
+		t.Errorf("expected forbidden error for system image, got %v", err)
+	}
+	if _, err := client.Image.Delete(ctx, result.Image); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServersNetworks(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	network, _, err := client.Network.Create(ctx, hcloud.NetworkCreateOpts{
+		Name:    "net",
+		IPRange: mustParseCIDR("10.0.0.0/16"),
+		Subnets: []hcloud.NetworkSubnet{
+			{Type: hcloud.NetworkSubnetTypeCloud, IPRange: mustParseCIDR("10.0.0.0/24"), NetworkZone: hcloud.NetworkZoneEUCentral},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+	other := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "db"})
+
+	_, _, err = client.Server.AttachToNetwork(ctx, server, hcloud.ServerAttachToNetworkOpts{
+		Network:  network,
+		IP:       net.ParseIP("10.0.0.10"),
+		AliasIPs: []net.IP{net.ParseIP("10.0.0.11")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Server.AttachToNetwork(ctx, server, hcloud.ServerAttachToNetworkOpts{Network: network})
+	if !hcloud.IsError(err, hcloud.ErrorCodeServerAlreadyAttached) {
+		t.Errorf("expected server_already_attached error, got %v", err)
+	}
+	_, _, err = client.Server.AttachToNetwork(ctx, other, hcloud.ServerAttachToNetworkOpts{
+		Network: network,
+		IP:      net.ParseIP("10.0.0.11"),
+	})
+	if !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error for used IP, got %v", err)
+	}
+	if _, _, err = client.Server.AttachToNetwork(ctx, other, hcloud.ServerAttachToNetworkOpts{Network: network}); err != nil {
+		t.Fatal(err)
+	}
+
+	other, _, err = client.Server.GetByID(ctx, other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(other.PrivateNet) != 1 || !other.PrivateNet[0].IP.Equal(net.ParseIP("10.0.0.2")) {
+		t.Errorf("unexpected private networks: %+v", other.PrivateNet)
+	}
+
+	_, _, err = client.Network.DeleteSubnet(ctx, network, hcloud.NetworkDeleteSubnetOpts{Subnet: network.Subnets[0]})
+	if !hcloud.IsError(err, hcloud.ErrorCodeConflict) {
+		t.Errorf("expected conflict error for subnet with servers, got %v", err)
+	}
+
+	if _, _, err = client.Server.DetachFromNetwork(ctx, other, hcloud.ServerDetachFromNetworkOpts{Network: network}); err != nil {
+		t.Fatal(err)
+	}
+	network, _, err = client.Network.GetByID(ctx, network.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(network.Servers) != 1 || network.Servers[0].ID != server.ID {
+		t.Errorf("unexpected network servers: %v", network.Servers)
+	}
+}
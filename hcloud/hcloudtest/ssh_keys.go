@@ -0,0 +1,128 @@
+package hcloudtest
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// sshKeyFingerprint returns the MD5 fingerprint of an OpenSSH public key.
+func sshKeyFingerprint(publicKey string) (string, bool) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", false
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil || len(blob) == 0 {
+		return "", false
+	}
+	sum := md5.Sum(blob)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":"), true
+}
+
+func (s *Server) handleSSHKeys(r *request) (int, interface{}, error) {
+	return route(r, "ssh_key", func(id int) bool { return s.sshKeys[id] != nil }, resourceHandlers{
+		list:   s.listSSHKeys,
+		create: s.createSSHKey,
+		get:    s.getSSHKey,
+		update: s.updateSSHKey,
+		delete: s.deleteSSHKey,
+	})
+}
+
+func (s *Server) listSSHKeys(r *request) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, key := range s.sshKeys {
+		if name := query.Get("name"); name != "" && key.Name != name {
+			continue
+		}
+		if fingerprint := query.Get("fingerprint"); fingerprint != "" && key.Fingerprint != fingerprint {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "ssh_keys", ids,
+		func(id int) map[string]string { return s.sshKeys[id].Labels },
+		func(id int) interface{} { return *s.sshKeys[id] },
+	)
+}
+
+func (s *Server) createSSHKey(r *request) (int, interface{}, error) {
+	var body schema.SSHKeyCreateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if body.Name == "" {
+		return 0, nil, invalidInput("name", "is required")
+	}
+	fingerprint, ok := sshKeyFingerprint(body.PublicKey)
+	if !ok {
+		return 0, nil, invalidInput("public_key", "invalid public key")
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	for _, key := range s.sshKeys {
+		if key.Name == body.Name {
+			return 0, nil, uniquenessError("name")
+		}
+		if key.Fingerprint == fingerprint {
+			return 0, nil, uniquenessError("public_key")
+		}
+	}
+
+	key := &schema.SSHKey{
+		ID:          s.newID("ssh_key"),
+		Name:        body.Name,
+		Fingerprint: fingerprint,
+		PublicKey:   body.PublicKey,
+		Labels:      copyLabels(body.Labels),
+		Created:     s.Clock.Now(),
+	}
+	s.sshKeys[key.ID] = key
+	return http.StatusCreated, schema.SSHKeyCreateResponse{SSHKey: *key}, nil
+}
+
+func (s *Server) getSSHKey(r *request, id int) (int, interface{}, error) {
+	return http.StatusOK, schema.SSHKeyGetResponse{SSHKey: *s.sshKeys[id]}, nil
+}
+
+func (s *Server) updateSSHKey(r *request, id int) (int, interface{}, error) {
+	var body schema.SSHKeyUpdateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != "" {
+		for otherID, other := range s.sshKeys {
+			if otherID != id && other.Name == body.Name {
+				return 0, nil, uniquenessError("name")
+			}
+		}
+	}
+
+	key := s.sshKeys[id]
+	if body.Name != "" {
+		key.Name = body.Name
+	}
+	if body.Labels != nil {
+		key.Labels = copyLabels(body.Labels)
+	}
+	return http.StatusOK, schema.SSHKeyUpdateResponse{SSHKey: *key}, nil
+}
+
+func (s *Server) deleteSSHKey(r *request, id int) (int, interface{}, error) {
+	delete(s.sshKeys, id)
+	return http.StatusNoContent, nil, nil
+}
@@ -0,0 +1,267 @@
+package hcloudtest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+const (
+	volumeMinSize = 10
+	volumeMaxSize = 10240
+)
+
+func (s *Server) handleVolumes(r *request) (int, interface{}, error) {
+	return route(r, "volume", func(id int) bool { return s.volumes[id] != nil }, resourceHandlers{
+		list:        s.listVolumes,
+		create:      s.createVolume,
+		get:         s.getVolume,
+		update:      s.updateVolume,
+		delete:      s.deleteVolume,
+		listActions: s.resourceActions("volume"),
+		actions: map[string]func(r *request, id int) (int, interface{}, error){
+			"attach":            s.attachVolume,
+			"detach":            s.detachVolume,
+			"resize":            s.resizeVolume,
+			"change_protection": s.changeVolumeProtection,
+		},
+	})
+}
+
+func (s *Server) listVolumes(r *request) (int, interface{}, error) {
+	query := r.URL.Query()
+	var ids []int
+	for id, volume := range s.volumes {
+		if name := query.Get("name"); name != "" && volume.Name != name {
+			continue
+		}
+		if statuses := query["status"]; len(statuses) > 0 && !containsString(statuses, volume.Status) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return listPage(r, "volumes", ids,
+		func(id int) map[string]string { return s.volumes[id].Labels },
+		func(id int) interface{} { return *s.volumes[id] },
+	)
+}
+
+func (s *Server) createVolume(r *request) (int, interface{}, error) {
+	var body schema.VolumeCreateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if body.Name == "" {
+		return 0, nil, invalidInput("name", "is required")
+	}
+	if body.Size < volumeMinSize || body.Size > volumeMaxSize {
+		return 0, nil, invalidInput("size", fmt.Sprintf("must be between %d and %d", volumeMinSize, volumeMaxSize))
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	for _, volume := range s.volumes {
+		if volume.Name == body.Name {
+			return 0, nil, uniquenessError("name")
+		}
+	}
+
+	var (
+		location schema.Location
+		server   *schema.Server
+	)
+	switch {
+	case body.Server != nil:
+		server = s.servers[*body.Server]
+		if server == nil {
+			return 0, nil, invalidInput("server", "server not found")
+		}
+		if err := s.checkUnlocked(resourceRef("server", server.ID)); err != nil {
+			return 0, nil, err
+		}
+		location = server.Datacenter.Location
+	case body.Location != nil:
+		var ok bool
+		if location, ok = findLocation(body.Location); !ok {
+			return 0, nil, invalidInput("location", "location not found")
+		}
+	default:
+		return 0, nil, invalidInput("location", "location or server is required")
+	}
+
+	id := s.newID("volume")
+	volume := &schema.Volume{
+		ID:          id,
+		Name:        body.Name,
+		Status:      string(hcloud.VolumeStatusCreating),
+		Location:    location,
+		Size:        body.Size,
+		Labels:      copyLabels(body.Labels),
+		LinuxDevice: fmt.Sprintf("/dev/disk/by-id/scsi-0HC_Volume_%d", id),
+		Created:     s.Clock.Now(),
+	}
+	s.volumes[id] = volume
+
+	respBody := schema.VolumeCreateResponse{NextActions: []schema.Action{}}
+	a := s.startAction("create_volume", func() {
+		volume.Status = string(hcloud.VolumeStatusAvailable)
+	}, resourceRef("volume", id))
+	if server != nil {
+		attach := s.startAction("attach_volume", func() {
+			s.attachVolumeToServer(volume, server.ID)
+		}, resourceRef("volume", id), resourceRef("server", server.ID))
+		respBody.NextActions = append(respBody.NextActions, s.renderAction(attach))
+	}
+	action := s.renderAction(a)
+	respBody.Action = &action
+	respBody.Volume = *volume
+	return http.StatusCreated, respBody, nil
+}
+
+// attachVolumeToServer attaches a volume to a server if both still exist.
+func (s *Server) attachVolumeToServer(volume *schema.Volume, serverID int) {
+	server := s.servers[serverID]
+	if server == nil || s.volumes[volume.ID] == nil {
+		return
+	}
+	volume.Server = &serverID
+	server.Volumes = append(server.Volumes, volume.ID)
+}
+
+func (s *Server) detachVolumeFromServer(volume *schema.Volume) {
+	if volume.Server == nil {
+		return
+	}
+	if server := s.servers[*volume.Server]; server != nil {
+		server.Volumes = removeInt(server.Volumes, volume.ID)
+	}
+	volume.Server = nil
+}
+
+func (s *Server) getVolume(r *request, id int) (int, interface{}, error) {
+	return http.StatusOK, schema.VolumeGetResponse{Volume: *s.volumes[id]}, nil
+}
+
+func (s *Server) updateVolume(r *request, id int) (int, interface{}, error) {
+	var body schema.VolumeUpdateRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := validateLabels(body.Labels); err != nil {
+		return 0, nil, err
+	}
+	if body.Name != "" {
+		for otherID, other := range s.volumes {
+			if otherID != id && other.Name == body.Name {
+				return 0, nil, uniquenessError("name")
+			}
+		}
+	}
+
+	volume := s.volumes[id]
+	if body.Name != "" {
+		volume.Name = body.Name
+	}
+	if body.Labels != nil {
+		volume.Labels = copyLabels(body.Labels)
+	}
+	return http.StatusOK, schema.VolumeUpdateResponse{Volume: *volume}, nil
+}
+
+func (s *Server) deleteVolume(r *request, id int) (int, interface{}, error) {
+	volume := s.volumes[id]
+	if volume.Protection.Delete {
+		return 0, nil, protectedError("volume", id)
+	}
+	if err := s.checkUnlocked(resourceRef("volume", id)); err != nil {
+		return 0, nil, err
+	}
+	if volume.Server != nil {
+		return 0, nil, newError(hcloud.ErrorCodeConflict, "volume %d is attached to server %d", id, *volume.Server)
+	}
+	delete(s.volumes, id)
+	return http.StatusNoContent, nil, nil
+}
+
+func (s *Server) attachVolume(r *request, id int) (int, interface{}, error) {
+	var body schema.VolumeActionAttachVolumeRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	volume := s.volumes[id]
+	server := s.servers[body.Server]
+	if server == nil {
+		return 0, nil, invalidInput("server", "server not found")
+	}
+	if volume.Server != nil {
+		return 0, nil, serverAlreadyAttachedError(*volume.Server, "volume %d is already attached to server %d", id, *volume.Server)
+	}
+	if server.Datacenter.Location.ID != volume.Location.ID {
+		return 0, nil, invalidInput("server", "server and volume must be in the same location")
+	}
+	refs := []schema.ActionResourceReference{resourceRef("volume", id), resourceRef("server", server.ID)}
+	if err := s.checkUnlocked(refs...); err != nil {
+		return 0, nil, err
+	}
+	serverID := server.ID
+	return s.actionResponse(s.startAction("attach_volume", func() {
+		s.attachVolumeToServer(volume, serverID)
+	}, refs...))
+}
+
+func (s *Server) detachVolume(r *request, id int) (int, interface{}, error) {
+	volume := s.volumes[id]
+	if volume.Server == nil {
+		return 0, nil, invalidInput("server", "volume is not attached")
+	}
+	refs := []schema.ActionResourceReference{resourceRef("volume", id), resourceRef("server", *volume.Server)}
+	if err := s.checkUnlocked(refs...); err != nil {
+		return 0, nil, err
+	}
+	return s.actionResponse(s.startAction("detach_volume", func() {
+		s.detachVolumeFromServer(volume)
+	}, refs...))
+}
+
+func (s *Server) resizeVolume(r *request, id int) (int, interface{}, error) {
+	var body schema.VolumeActionResizeVolumeRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	volume := s.volumes[id]
+	if body.Size < volume.Size || body.Size > volumeMaxSize {
+		return 0, nil, invalidInput("size", fmt.Sprintf("must be between %d and %d", volume.Size, volumeMaxSize))
+	}
+	if err := s.checkUnlocked(resourceRef("volume", id)); err != nil {
+		return 0, nil, err
+	}
+	return s.actionResponse(s.startAction("resize_volume", func() {
+		volume.Size = body.Size
+	}, resourceRef("volume", id)))
+}
+
+func (s *Server) changeVolumeProtection(r *request, id int) (int, interface{}, error) {
+	var body schema.VolumeActionChangeProtectionRequest
+	if err := r.decode(&body); err != nil {
+		return 0, nil, err
+	}
+	if err := s.checkUnlocked(resourceRef("volume", id)); err != nil {
+		return 0, nil, err
+	}
+	if body.Delete != nil {
+		s.volumes[id].Protection.Delete = *body.Delete
+	}
+	return s.actionResponse(s.startAction("change_protection", nil, resourceRef("volume", id)))
+}
+
+func removeInt(values []int, v int) []int {
+	out := values[:0]
+	for _, value := range values {
+		if value != v {
+			out = append(out, value)
+		}
+	}
+	return out
+}
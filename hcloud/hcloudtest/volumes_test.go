@@ -0,0 +1,108 @@
+package hcloudtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestVolumesLifecycle(t *testing.T) {
+	srv := NewServer(WithActionDuration(time.Second))
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	server := createTestServer(t, client, hcloud.ServerCreateOpts{Name: "web"})
+	srv.Clock.Advance(time.Second)
+
+	result, _, err := client.Volume.Create(ctx, hcloud.VolumeCreateOpts{
+		Name:     "data",
+		Size:     10,
+		Location: &hcloud.Location{Name: "fsn1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Volume.Status != hcloud.VolumeStatusCreating {
+		t.Errorf("unexpected status: %s", result.Volume.Status)
+	}
+	if _, _, err := client.Volume.Attach(ctx, result.Volume, server); !hcloud.IsError(err, hcloud.ErrorCodeLocked) {
+		t.Errorf("expected locked error, got %v", err)
+	}
+	srv.Clock.Advance(time.Second)
+
+	volume, _, err := client.Volume.GetByID(ctx, result.Volume.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Status != hcloud.VolumeStatusAvailable {
+		t.Errorf("unexpected status: %s", volume.Status)
+	}
+
+	action, _, err := client.Volume.Attach(ctx, volume, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Clock.Advance(time.Second)
+	if err := waitForAction(ctx, client, action); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = client.Volume.Attach(ctx, volume, server)
+	if !errors.Is(err, hcloud.ErrServerAlreadyAttached) {
+		t.Errorf("expected server_already_attached error, got %v", err)
+	}
+	var apiErr hcloud.Error
+	if errors.As(err, &apiErr) {
+		details, ok := apiErr.Details.(hcloud.ErrorDetailsServerAlreadyAttached)
+		if !ok || details.Server.ID != server.ID {
+			t.Errorf("unexpected details: %+v", apiErr.Details)
+		}
+	}
+
+	if _, _, err := client.Volume.Resize(ctx, volume, 5); !hcloud.IsError(err, hcloud.ErrorCodeInvalidInput) {
+		t.Errorf("expected invalid input error when shrinking, got %v", err)
+	}
+	if _, _, err := client.Volume.Resize(ctx, volume, 20); err != nil {
+		t.Fatal(err)
+	}
+	srv.Clock.Advance(time.Second)
+
+	if _, _, err := client.Volume.Detach(ctx, volume); err != nil {
+		t.Fatal(err)
+	}
+	srv.Clock.Advance(time.Second)
+
+	volume, _, err = client.Volume.GetByID(ctx, volume.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Server != nil || volume.Size != 20 {
+		t.Errorf("unexpected volume: %+v", volume)
+	}
+	if _, err := client.Volume.Delete(ctx, volume); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVolumesUniqueness(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	opts := hcloud.VolumeCreateOpts{Name: "data", Size: 10, Location: &hcloud.Location{Name: "fsn1"}}
+	if _, _, err := client.Volume.Create(ctx, opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Volume.Create(ctx, opts); !hcloud.IsError(err, hcloud.ErrorCodeUniquenessError) {
+		t.Errorf("expected uniqueness error, got %v", err)
+	}
+}
+
+func waitForAction(ctx context.Context, client *hcloud.Client, action *hcloud.Action) error {
+	_, errCh := client.Action.WatchProgress(ctx, action)
+	return <-errCh
+}
@@ -1,5 +1,7 @@
 package hcloud
 
+import "time"
+
 // String returns a pointer to the passed string s.
 func String(s string) *string { return &s }
 
@@ -8,3 +10,6 @@ func Int(i int) *int { return &i }
 
 // Bool returns a pointer to the passed bool b.
 func Bool(b bool) *bool { return &b }
+
+// Duration returns a pointer to the passed time.Duration d.
+func Duration(d time.Duration) *time.Duration { return &d }
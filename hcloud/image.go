@@ -256,3 +256,22 @@ func (c *ImageClient) ChangeProtection(ctx context.Context, image *Image, opts I
 	}
 	return ActionFromSchema(respBody.Action), resp, err
 }
+
+// waitForStatus polls the image until it has the given status and returns it.
+func (c *ImageClient) waitForStatus(ctx context.Context, image *Image, status ImageStatus) (*Image, error) {
+	for {
+		current, _, err := c.GetByID(ctx, image.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, fmt.Errorf("hcloud: image %d not found", image.ID)
+		}
+		if current.Status == status {
+			return current, nil
+		}
+		if err := sleep(ctx, c.client.pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
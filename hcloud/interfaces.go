@@ -0,0 +1,806 @@
+// Code generated by internal/mockgen; DO NOT EDIT.
+
+package hcloud
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ActionAPI is the interface implemented by ActionClient.
+type ActionAPI interface {
+	// GetByID retrieves an action by its ID. If the action does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Action, *Response, error)
+
+	// List returns a list of actions for a specific page.
+	List(ctx context.Context, opts ActionListOpts) ([]*Action, *Response, error)
+
+	// All returns all actions.
+	All(ctx context.Context) ([]*Action, error)
+
+	// WatchProgress watches the action's progress until it completes with success or error.
+	WatchProgress(ctx context.Context, action *Action) (<-chan int, <-chan error)
+}
+
+var _ ActionAPI = (*ActionClient)(nil)
+
+// CertificateAPI is the interface implemented by CertificateClient.
+type CertificateAPI interface {
+	// GetByID retrieves a certificate by its ID. If the certificate does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Certificate, *Response, error)
+
+	// GetByName retrieves a certificate by its name. If the certificate does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Certificate, *Response, error)
+
+	// Get retrieves a certificate by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a certificate by its name. If the certificate does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Certificate, *Response, error)
+
+	// List returns a list of certificates for a specific page.
+	List(ctx context.Context, opts CertificateListOpts) ([]*Certificate, *Response, error)
+
+	// All returns all certificates.
+	All(ctx context.Context) ([]*Certificate, error)
+
+	// AllWithOpts returns all certificates for the given options.
+	AllWithOpts(ctx context.Context, opts CertificateListOpts) ([]*Certificate, error)
+
+	// AllExpiringWithin returns all certificates matching the given options which
+	// are no longer valid after the window has passed, sorted by expiry. Expired
+	// certificates are included.
+	AllExpiringWithin(ctx context.Context, opts CertificateListOpts, window time.Duration) ([]*Certificate, error)
+
+	// Create creates a new certificate. Uploaded certificates are usable right
+	// away, while managed certificates are issued asynchronously and the returned
+	// action completes once the issuance finished.
+	Create(ctx context.Context, opts CertificateCreateOpts) (CertificateCreateResult, *Response, error)
+
+	// Update updates a certificate.
+	Update(ctx context.Context, certificate *Certificate, opts CertificateUpdateOpts) (*Certificate, *Response, error)
+
+	// Delete deletes a certificate.
+	Delete(ctx context.Context, certificate *Certificate) (*Response, error)
+
+	// RetryIssuance retries the issuance of a managed certificate whose issuance
+	// or renewal failed.
+	RetryIssuance(ctx context.Context, certificate *Certificate) (*Action, *Response, error)
+}
+
+var _ CertificateAPI = (*CertificateClient)(nil)
+
+// DatacenterAPI is the interface implemented by DatacenterClient.
+type DatacenterAPI interface {
+	// GetByID retrieves a datacenter by its ID. If the datacenter does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Datacenter, *Response, error)
+
+	// GetByName retrieves an datacenter by its name. If the datacenter does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Datacenter, *Response, error)
+
+	// Get retrieves a datacenter by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a datacenter by its name. If the datacenter does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Datacenter, *Response, error)
+
+	// List returns a list of datacenters for a specific page.
+	List(ctx context.Context, opts DatacenterListOpts) ([]*Datacenter, *Response, error)
+
+	// All returns all datacenters.
+	All(ctx context.Context) ([]*Datacenter, error)
+}
+
+var _ DatacenterAPI = (*DatacenterClient)(nil)
+
+// FirewallAPI is the interface implemented by FirewallClient.
+type FirewallAPI interface {
+	// GetByID retrieves a Firewall by its ID. If the Firewall does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Firewall, *Response, error)
+
+	// GetByName retrieves a Firewall by its name. If the Firewall does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Firewall, *Response, error)
+
+	// Get retrieves a Firewall by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a Firewall by its name. If the Firewall does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Firewall, *Response, error)
+
+	// List returns a list of Firewalls for a specific page.
+	List(ctx context.Context, opts FirewallListOpts) ([]*Firewall, *Response, error)
+
+	// All returns all Firewalls.
+	All(ctx context.Context) ([]*Firewall, error)
+
+	// AllWithOpts returns all Firewalls with the given options.
+	AllWithOpts(ctx context.Context, opts FirewallListOpts) ([]*Firewall, error)
+
+	// Create creates a new Firewall.
+	Create(ctx context.Context, opts FirewallCreateOpts) (FirewallCreateResult, *Response, error)
+
+	// Update updates a Firewall.
+	Update(ctx context.Context, firewall *Firewall, opts FirewallUpdateOpts) (*Firewall, *Response, error)
+
+	// Delete deletes a Firewall.
+	Delete(ctx context.Context, firewall *Firewall) (*Response, error)
+
+	// SetRules sets the rules of a Firewall. All existing rules are overwritten,
+	// passing no rules removes all of them.
+	SetRules(ctx context.Context, firewall *Firewall, opts FirewallSetRulesOpts) ([]*Action, *Response, error)
+
+	// ApplyResources applies a Firewall to servers or label selectors.
+	ApplyResources(ctx context.Context, firewall *Firewall, resources []FirewallResource) ([]*Action, *Response, error)
+
+	// RemoveResources removes a Firewall from servers or label selectors.
+	RemoveResources(ctx context.Context, firewall *Firewall, resources []FirewallResource) ([]*Action, *Response, error)
+}
+
+var _ FirewallAPI = (*FirewallClient)(nil)
+
+// FloatingIPAPI is the interface implemented by FloatingIPClient.
+type FloatingIPAPI interface {
+	// GetByID retrieves a Floating IP by its ID. If the Floating IP does not exist,
+	// nil is returned.
+	GetByID(ctx context.Context, id int) (*FloatingIP, *Response, error)
+
+	// GetByName retrieves a Floating IP by its name. If the Floating IP does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*FloatingIP, *Response, error)
+
+	// Get retrieves a Floating IP by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a Floating IP by its name. If the Floating IP does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*FloatingIP, *Response, error)
+
+	// List returns a list of Floating IPs for a specific page.
+	List(ctx context.Context, opts FloatingIPListOpts) ([]*FloatingIP, *Response, error)
+
+	// All returns all Floating IPs.
+	All(ctx context.Context) ([]*FloatingIP, error)
+
+	// AllWithOpts returns all Floating IPs for the given options.
+	AllWithOpts(ctx context.Context, opts FloatingIPListOpts) ([]*FloatingIP, error)
+
+	// Create creates a Floating IP.
+	Create(ctx context.Context, opts FloatingIPCreateOpts) (FloatingIPCreateResult, *Response, error)
+
+	// Delete deletes a Floating IP.
+	Delete(ctx context.Context, floatingIP *FloatingIP) (*Response, error)
+
+	// Update updates a Floating IP.
+	Update(ctx context.Context, floatingIP *FloatingIP, opts FloatingIPUpdateOpts) (*FloatingIP, *Response, error)
+
+	// Assign assigns a Floating IP to a server.
+	Assign(ctx context.Context, floatingIP *FloatingIP, server *Server) (*Action, *Response, error)
+
+	// Unassign unassigns a Floating IP from the currently assigned server.
+	Unassign(ctx context.Context, floatingIP *FloatingIP) (*Action, *Response, error)
+
+	// ChangeDNSPtr changes or resets the reverse DNS pointer for a Floating IP address.
+	// Pass a nil ptr to reset the reverse DNS pointer to its default value.
+	ChangeDNSPtr(ctx context.Context, floatingIP *FloatingIP, ip string, ptr *string) (*Action, *Response, error)
+
+	// ChangeProtection changes the resource protection level of a Floating IP.
+	ChangeProtection(ctx context.Context, floatingIP *FloatingIP, opts FloatingIPChangeProtectionOpts) (*Action, *Response, error)
+}
+
+var _ FloatingIPAPI = (*FloatingIPClient)(nil)
+
+// ImageAPI is the interface implemented by ImageClient.
+type ImageAPI interface {
+	// GetByID retrieves an image by its ID. If the image does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Image, *Response, error)
+
+	// GetByName retrieves an image by its name. If the image does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Image, *Response, error)
+
+	// Get retrieves an image by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves an image by its name. If the image does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Image, *Response, error)
+
+	// List returns a list of images for a specific page.
+	List(ctx context.Context, opts ImageListOpts) ([]*Image, *Response, error)
+
+	// All returns all images.
+	All(ctx context.Context) ([]*Image, error)
+
+	// AllWithOpts returns all images for the given options.
+	AllWithOpts(ctx context.Context, opts ImageListOpts) ([]*Image, error)
+
+	// Delete deletes an image.
+	Delete(ctx context.Context, image *Image) (*Response, error)
+
+	// Update updates an image.
+	Update(ctx context.Context, image *Image, opts ImageUpdateOpts) (*Image, *Response, error)
+
+	// ChangeProtection changes the resource protection level of an image.
+	ChangeProtection(ctx context.Context, image *Image, opts ImageChangeProtectionOpts) (*Action, *Response, error)
+}
+
+var _ ImageAPI = (*ImageClient)(nil)
+
+// ISOAPI is the interface implemented by ISOClient.
+type ISOAPI interface {
+	// GetByID retrieves an ISO by its ID.
+	GetByID(ctx context.Context, id int) (*ISO, *Response, error)
+
+	// GetByName retrieves an ISO by its name.
+	GetByName(ctx context.Context, name string) (*ISO, *Response, error)
+
+	// Get retrieves an ISO by its ID if the input can be parsed as an integer, otherwise it retrieves an ISO by its name.
+	Get(ctx context.Context, idOrName string) (*ISO, *Response, error)
+
+	// List returns a list of ISOs for a specific page.
+	List(ctx context.Context, opts ISOListOpts) ([]*ISO, *Response, error)
+
+	// All returns all ISOs.
+	All(ctx context.Context) ([]*ISO, error)
+}
+
+var _ ISOAPI = (*ISOClient)(nil)
+
+// LoadBalancerAPI is the interface implemented by LoadBalancerClient.
+type LoadBalancerAPI interface {
+	// GetByID retrieves a Load Balancer by its ID. If the Load Balancer does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*LoadBalancer, *Response, error)
+
+	// GetByName retrieves a Load Balancer by its name. If the Load Balancer does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*LoadBalancer, *Response, error)
+
+	// Get retrieves a Load Balancer by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a Load Balancer by its name. If the Load Balancer does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*LoadBalancer, *Response, error)
+
+	// List returns a list of Load Balancers for a specific page.
+	List(ctx context.Context, opts LoadBalancerListOpts) ([]*LoadBalancer, *Response, error)
+
+	// All returns all Load Balancers.
+	All(ctx context.Context) ([]*LoadBalancer, error)
+
+	// AllWithOpts returns all Load Balancers for the given options.
+	AllWithOpts(ctx context.Context, opts LoadBalancerListOpts) ([]*LoadBalancer, error)
+
+	// Update updates a Load Balancer.
+	Update(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerUpdateOpts) (*LoadBalancer, *Response, error)
+
+	// Create creates a new Load Balancer.
+	Create(ctx context.Context, opts LoadBalancerCreateOpts) (LoadBalancerCreateResult, *Response, error)
+
+	// Delete deletes a Load Balancer.
+	Delete(ctx context.Context, loadBalancer *LoadBalancer) (*Response, error)
+
+	// AddServerTarget adds a server target to a Load Balancer.
+	AddServerTarget(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddServerTargetOpts) (*Action, *Response, error)
+
+	// RemoveServerTarget removes a server target from a Load Balancer.
+	RemoveServerTarget(ctx context.Context, loadBalancer *LoadBalancer, server *Server) (*Action, *Response, error)
+
+	// AddLabelSelectorTarget adds a label selector target to a Load Balancer.
+	AddLabelSelectorTarget(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddLabelSelectorTargetOpts) (*Action, *Response, error)
+
+	// RemoveLabelSelectorTarget removes a label selector target from a Load Balancer.
+	RemoveLabelSelectorTarget(ctx context.Context, loadBalancer *LoadBalancer, labelSelector string) (*Action, *Response, error)
+
+	// AddIPTarget adds an IP target to a Load Balancer.
+	AddIPTarget(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddIPTargetOpts) (*Action, *Response, error)
+
+	// RemoveIPTarget removes an IP target from a Load Balancer.
+	RemoveIPTarget(ctx context.Context, loadBalancer *LoadBalancer, ip net.IP) (*Action, *Response, error)
+
+	// AddService adds a service to a Load Balancer.
+	AddService(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddServiceOpts) (*Action, *Response, error)
+
+	// UpdateService updates a Load Balancer service.
+	UpdateService(ctx context.Context, loadBalancer *LoadBalancer, listenPort int, opts LoadBalancerUpdateServiceOpts) (*Action, *Response, error)
+
+	// DeleteService deletes a Load Balancer service.
+	DeleteService(ctx context.Context, loadBalancer *LoadBalancer, listenPort int) (*Action, *Response, error)
+
+	// ChangeProtection changes the resource protection level of a Load Balancer.
+	ChangeProtection(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerChangeProtectionOpts) (*Action, *Response, error)
+
+	// ChangeAlgorithm changes the algorithm of a Load Balancer.
+	ChangeAlgorithm(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerChangeAlgorithmOpts) (*Action, *Response, error)
+
+	// AttachToNetwork attaches a Load Balancer to a network.
+	AttachToNetwork(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAttachToNetworkOpts) (*Action, *Response, error)
+
+	// DetachFromNetwork detaches a Load Balancer from a network.
+	DetachFromNetwork(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerDetachFromNetworkOpts) (*Action, *Response, error)
+
+	// EnablePublicInterface enables the Load Balancer's public network interface.
+	EnablePublicInterface(ctx context.Context, loadBalancer *LoadBalancer) (*Action, *Response, error)
+
+	// DisablePublicInterface disables the Load Balancer's public network interface.
+	DisablePublicInterface(ctx context.Context, loadBalancer *LoadBalancer) (*Action, *Response, error)
+
+	// ChangeType changes a Load Balancer's type.
+	ChangeType(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerChangeTypeOpts) (*Action, *Response, error)
+
+	// ChangeDNSPtr changes or resets the reverse DNS pointer for a Load Balancer.
+	// Pass a nil ptr to reset the reverse DNS pointer to its default value.
+	ChangeDNSPtr(ctx context.Context, loadBalancer *LoadBalancer, ip string, ptr *string) (*Action, *Response, error)
+}
+
+var _ LoadBalancerAPI = (*LoadBalancerClient)(nil)
+
+// LoadBalancerTypeAPI is the interface implemented by LoadBalancerTypeClient.
+type LoadBalancerTypeAPI interface {
+	// GetByID retrieves a Load Balancer type by its ID. If the Load Balancer type does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*LoadBalancerType, *Response, error)
+
+	// GetByName retrieves a Load Balancer type by its name. If the Load Balancer type does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*LoadBalancerType, *Response, error)
+
+	// Get retrieves a Load Balancer type by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a Load Balancer type by its name. If the Load Balancer type does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*LoadBalancerType, *Response, error)
+
+	// List returns a list of Load Balancer types for a specific page.
+	List(ctx context.Context, opts LoadBalancerTypeListOpts) ([]*LoadBalancerType, *Response, error)
+
+	// All returns all Load Balancer types.
+	All(ctx context.Context) ([]*LoadBalancerType, error)
+}
+
+var _ LoadBalancerTypeAPI = (*LoadBalancerTypeClient)(nil)
+
+// LocationAPI is the interface implemented by LocationClient.
+type LocationAPI interface {
+	// GetByID retrieves a location by its ID. If the location does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Location, *Response, error)
+
+	// GetByName retrieves an location by its name. If the location does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Location, *Response, error)
+
+	// Get retrieves a location by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a location by its name. If the location does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Location, *Response, error)
+
+	// List returns a list of locations for a specific page.
+	List(ctx context.Context, opts LocationListOpts) ([]*Location, *Response, error)
+
+	// All returns all locations.
+	All(ctx context.Context) ([]*Location, error)
+}
+
+var _ LocationAPI = (*LocationClient)(nil)
+
+// NetworkAPI is the interface implemented by NetworkClient.
+type NetworkAPI interface {
+	// GetByID retrieves a network by its ID. If the network does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Network, *Response, error)
+
+	// GetByName retrieves a network by its name. If the network does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Network, *Response, error)
+
+	// Get retrieves a network by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a network by its name. If the network does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Network, *Response, error)
+
+	// List returns a list of networks for a specific page.
+	List(ctx context.Context, opts NetworkListOpts) ([]*Network, *Response, error)
+
+	// All returns all networks.
+	All(ctx context.Context) ([]*Network, error)
+
+	// AllWithOpts returns all networks for the given options.
+	AllWithOpts(ctx context.Context, opts NetworkListOpts) ([]*Network, error)
+
+	// Delete deletes a network.
+	Delete(ctx context.Context, network *Network) (*Response, error)
+
+	// Update updates a network.
+	Update(ctx context.Context, network *Network, opts NetworkUpdateOpts) (*Network, *Response, error)
+
+	// Create creates a new network.
+	Create(ctx context.Context, opts NetworkCreateOpts) (*Network, *Response, error)
+
+	// ChangeIPRange changes the IP range of a network.
+	ChangeIPRange(ctx context.Context, network *Network, opts NetworkChangeIPRangeOpts) (*Action, *Response, error)
+
+	// AddSubnet adds a subnet to a network.
+	AddSubnet(ctx context.Context, network *Network, opts NetworkAddSubnetOpts) (*Action, *Response, error)
+
+	// DeleteSubnet deletes a subnet from a network.
+	DeleteSubnet(ctx context.Context, network *Network, opts NetworkDeleteSubnetOpts) (*Action, *Response, error)
+
+	// AddRoute adds a route to a network.
+	AddRoute(ctx context.Context, network *Network, opts NetworkAddRouteOpts) (*Action, *Response, error)
+
+	// DeleteRoute deletes a route from a network.
+	DeleteRoute(ctx context.Context, network *Network, opts NetworkDeleteRouteOpts) (*Action, *Response, error)
+
+	// ChangeProtection changes the resource protection level of a network.
+	ChangeProtection(ctx context.Context, network *Network, opts NetworkChangeProtectionOpts) (*Action, *Response, error)
+
+	// IPAM returns a NetworkIPAM for the current state of a network and the
+	// servers attached to it.
+	IPAM(ctx context.Context, network *Network) (*NetworkIPAM, error)
+
+	// Topology returns the topology of the current state of a network and the
+	// servers attached to it.
+	Topology(ctx context.Context, network *Network) (*NetworkTopology, error)
+}
+
+var _ NetworkAPI = (*NetworkClient)(nil)
+
+// PlacementGroupAPI is the interface implemented by PlacementGroupClient.
+type PlacementGroupAPI interface {
+	// GetByID retrieves a placement group by its ID. If the placement group does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*PlacementGroup, *Response, error)
+
+	// GetByName retrieves a placement group by its name. If the placement group does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*PlacementGroup, *Response, error)
+
+	// Get retrieves a placement group by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a placement group by its name. If the placement group does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*PlacementGroup, *Response, error)
+
+	// List returns a list of placement groups for a specific page.
+	List(ctx context.Context, opts PlacementGroupListOpts) ([]*PlacementGroup, *Response, error)
+
+	// All returns all placement groups.
+	All(ctx context.Context) ([]*PlacementGroup, error)
+
+	// AllWithOpts returns all placement groups for the given options.
+	AllWithOpts(ctx context.Context, opts PlacementGroupListOpts) ([]*PlacementGroup, error)
+
+	// Create creates a new placement group.
+	Create(ctx context.Context, opts PlacementGroupCreateOpts) (PlacementGroupCreateResult, *Response, error)
+
+	// Update updates a placement group.
+	Update(ctx context.Context, placementGroup *PlacementGroup, opts PlacementGroupUpdateOpts) (*PlacementGroup, *Response, error)
+
+	// Delete deletes a placement group.
+	Delete(ctx context.Context, placementGroup *PlacementGroup) (*Response, error)
+}
+
+var _ PlacementGroupAPI = (*PlacementGroupClient)(nil)
+
+// PricingAPI is the interface implemented by PricingClient.
+type PricingAPI interface {
+	// Get retrieves pricing information.
+	Get(ctx context.Context) (Pricing, *Response, error)
+}
+
+var _ PricingAPI = (*PricingClient)(nil)
+
+// PrimaryIPAPI is the interface implemented by PrimaryIPClient.
+type PrimaryIPAPI interface {
+	// GetByID retrieves a Primary IP by its ID. If the Primary IP does not exist,
+	// nil is returned.
+	GetByID(ctx context.Context, id int) (*PrimaryIP, *Response, error)
+
+	// GetByName retrieves a Primary IP by its name. If the Primary IP does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*PrimaryIP, *Response, error)
+
+	// GetByIP retrieves a Primary IP by its IP address. If the Primary IP does not exist, nil is returned.
+	GetByIP(ctx context.Context, ip string) (*PrimaryIP, *Response, error)
+
+	// Get retrieves a Primary IP by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a Primary IP by its name. If the Primary IP does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*PrimaryIP, *Response, error)
+
+	// List returns a list of Primary IPs for a specific page.
+	List(ctx context.Context, opts PrimaryIPListOpts) ([]*PrimaryIP, *Response, error)
+
+	// All returns all Primary IPs.
+	All(ctx context.Context) ([]*PrimaryIP, error)
+
+	// AllWithOpts returns all Primary IPs for the given options.
+	AllWithOpts(ctx context.Context, opts PrimaryIPListOpts) ([]*PrimaryIP, error)
+
+	// Create creates a Primary IP.
+	Create(ctx context.Context, opts PrimaryIPCreateOpts) (PrimaryIPCreateResult, *Response, error)
+
+	// Delete deletes a Primary IP.
+	Delete(ctx context.Context, primaryIP *PrimaryIP) (*Response, error)
+
+	// Update updates a Primary IP.
+	Update(ctx context.Context, primaryIP *PrimaryIP, opts PrimaryIPUpdateOpts) (*PrimaryIP, *Response, error)
+
+	// Assign assigns a Primary IP to a server. The server must be powered off
+	// and must not have a Primary IP of the same type assigned.
+	Assign(ctx context.Context, primaryIP *PrimaryIP, server *Server) (*Action, *Response, error)
+
+	// Unassign unassigns a Primary IP from the currently assigned server.
+	Unassign(ctx context.Context, primaryIP *PrimaryIP) (*Action, *Response, error)
+
+	// ChangeDNSPtr changes or resets the reverse DNS pointer for a Primary IP address.
+	// Pass a nil ptr to reset the reverse DNS pointer to its default value.
+	ChangeDNSPtr(ctx context.Context, primaryIP *PrimaryIP, ip string, ptr *string) (*Action, *Response, error)
+
+	// ChangeProtection changes the resource protection level of a Primary IP.
+	ChangeProtection(ctx context.Context, primaryIP *PrimaryIP, opts PrimaryIPChangeProtectionOpts) (*Action, *Response, error)
+}
+
+var _ PrimaryIPAPI = (*PrimaryIPClient)(nil)
+
+// ServerAPI is the interface implemented by ServerClient.
+type ServerAPI interface {
+	// GetByID retrieves a server by its ID. If the server does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Server, *Response, error)
+
+	// GetByName retrieves a server by its name. If the server does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Server, *Response, error)
+
+	// Get retrieves a server by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a server by its name. If the server does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Server, *Response, error)
+
+	// List returns a list of servers for a specific page.
+	List(ctx context.Context, opts ServerListOpts) ([]*Server, *Response, error)
+
+	// All returns all servers.
+	All(ctx context.Context) ([]*Server, error)
+
+	// AllWithOpts returns all servers for the given options.
+	AllWithOpts(ctx context.Context, opts ServerListOpts) ([]*Server, error)
+
+	// Create creates a new server.
+	Create(ctx context.Context, opts ServerCreateOpts) (ServerCreateResult, *Response, error)
+
+	// Delete deletes a server.
+	Delete(ctx context.Context, server *Server) (*Response, error)
+
+	// Update updates a server.
+	Update(ctx context.Context, server *Server, opts ServerUpdateOpts) (*Server, *Response, error)
+
+	// Poweron starts a server.
+	Poweron(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// Reboot reboots a server.
+	Reboot(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// Reset resets a server.
+	Reset(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// Shutdown shuts down a server.
+	Shutdown(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// Poweroff stops a server.
+	Poweroff(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// ResetPassword resets a server's password.
+	ResetPassword(ctx context.Context, server *Server) (ServerResetPasswordResult, *Response, error)
+
+	// CreateImage creates an image from a server.
+	CreateImage(ctx context.Context, server *Server, opts *ServerCreateImageOpts) (ServerCreateImageResult, *Response, error)
+
+	// EnableRescue enables rescue mode for a server.
+	EnableRescue(ctx context.Context, server *Server, opts ServerEnableRescueOpts) (ServerEnableRescueResult, *Response, error)
+
+	// DisableRescue disables rescue mode for a server.
+	DisableRescue(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// Rebuild rebuilds a server.
+	Rebuild(ctx context.Context, server *Server, opts ServerRebuildOpts) (*Action, *Response, error)
+
+	// AttachISO attaches an ISO to a server.
+	AttachISO(ctx context.Context, server *Server, iso *ISO) (*Action, *Response, error)
+
+	// DetachISO detaches the currently attached ISO from a server.
+	DetachISO(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// EnableBackup enables backup for a server. Pass in an empty backup window to let the
+	// API pick a window for you. See the API documentation at docs.hetzner.cloud for a list
+	// of valid backup windows.
+	EnableBackup(ctx context.Context, server *Server, window string) (*Action, *Response, error)
+
+	// DisableBackup disables backup for a server.
+	DisableBackup(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// ChangeType changes a server's type.
+	ChangeType(ctx context.Context, server *Server, opts ServerChangeTypeOpts) (*Action, *Response, error)
+
+	// ChangeDNSPtr changes or resets the reverse DNS pointer for a server IP address.
+	// Pass a nil ptr to reset the reverse DNS pointer to its default value.
+	ChangeDNSPtr(ctx context.Context, server *Server, ip string, ptr *string) (*Action, *Response, error)
+
+	// ChangeProtection changes the resource protection level of a server.
+	ChangeProtection(ctx context.Context, server *Server, opts ServerChangeProtectionOpts) (*Action, *Response, error)
+
+	// AttachToNetwork attaches a server to a network.
+	AttachToNetwork(ctx context.Context, server *Server, opts ServerAttachToNetworkOpts) (*Action, *Response, error)
+
+	// DetachFromNetwork detaches a server from a network.
+	DetachFromNetwork(ctx context.Context, server *Server, opts ServerDetachFromNetworkOpts) (*Action, *Response, error)
+
+	// ChangeAliasIPs changes a server's alias IPs in a network.
+	ChangeAliasIPs(ctx context.Context, server *Server, opts ServerChangeAliasIPsOpts) (*Action, *Response, error)
+
+	// AddToPlacementGroup adds a server to a placement group. The current state of
+	// the placement group is checked first; ErrPlacementGroupFull is returned
+	// without calling the action if the group has no room left.
+	AddToPlacementGroup(ctx context.Context, server *Server, placementGroup *PlacementGroup) (*Action, *Response, error)
+
+	// RemoveFromPlacementGroup removes a server from its placement group.
+	RemoveFromPlacementGroup(ctx context.Context, server *Server) (*Action, *Response, error)
+
+	// Clone creates copies of a server. It retrieves the current state of the source
+	// server, creates a snapshot of it, waits until the snapshot is available and
+	// creates the clones from it. The clones use the source server's type, location,
+	// labels, and networks unless overridden in opts. Clone waits until all clones
+	// have been created.
+	//
+	// If creating a clone fails, the clones created so far are returned along with
+	// the error. The snapshot is handled according to opts.SnapshotPolicy, also if
+	// it has been created but did not become available.
+	Clone(ctx context.Context, source *Server, opts ServerCloneOpts) (ServerCloneResult, error)
+
+	// RequestConsole requests a WebSocket VNC console for a server. The returned
+	// URL is only valid for a short time.
+	RequestConsole(ctx context.Context, server *Server) (ServerRequestConsoleResult, *Response, error)
+
+	// GetMetrics retrieves metrics of the given types for a server.
+	GetMetrics(ctx context.Context, server *Server, opts ServerGetMetricsOpts) (*ServerMetrics, *Response, error)
+
+	// PlanMigration prepares the migration of a server to another location. It checks
+	// that the server type is available in the target location and picks a
+	// datacenter for the target server. Nothing is changed until the migration is run.
+	PlanMigration(ctx context.Context, server *Server, opts ServerMigrationOpts) (*ServerMigration, error)
+
+	// ResumeMigration returns a migration continuing from the given state.
+	ResumeMigration(state ServerMigrationState) *ServerMigration
+
+	// StartRescue enables rescue mode for a server and boots it into the rescue
+	// system. A running server is reset, a server which is off is powered on. The
+	// call returns once both actions have completed.
+	//
+	// If the server cannot be booted into the rescue system, rescue mode is disabled
+	// again before the error is returned.
+	StartRescue(ctx context.Context, server *Server, opts ServerEnableRescueOpts) (*RescueSession, error)
+
+	// WithRescue boots a server into the rescue system, calls fn with the session and
+	// closes the session afterwards, regardless of whether fn succeeded or panicked.
+	// The session is closed with its own context, so it is also closed when ctx has
+	// been cancelled. The error returned by fn takes precedence over an error
+	// returned when closing the session.
+	WithRescue(ctx context.Context, server *Server, opts ServerEnableRescueOpts, fn func(*RescueSession) error) error
+
+	// RollingRebuild rebuilds all servers matching a label selector in batches.
+	// Servers which are protected against rebuilds are skipped.
+	RollingRebuild(ctx context.Context, opts ServerRollingRebuildOpts) (ServerRollingRebuildResult, error)
+}
+
+var _ ServerAPI = (*ServerClient)(nil)
+
+// ServerTypeAPI is the interface implemented by ServerTypeClient.
+type ServerTypeAPI interface {
+	// GetByID retrieves a server type by its ID. If the server type does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*ServerType, *Response, error)
+
+	// GetByName retrieves a server type by its name. If the server type does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*ServerType, *Response, error)
+
+	// Get retrieves a server type by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a server type by its name. If the server type does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*ServerType, *Response, error)
+
+	// List returns a list of server types for a specific page.
+	List(ctx context.Context, opts ServerTypeListOpts) ([]*ServerType, *Response, error)
+
+	// All returns all server types.
+	All(ctx context.Context) ([]*ServerType, error)
+}
+
+var _ ServerTypeAPI = (*ServerTypeClient)(nil)
+
+// SSHKeyAPI is the interface implemented by SSHKeyClient.
+type SSHKeyAPI interface {
+	// GetByID retrieves a SSH key by its ID. If the SSH key does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*SSHKey, *Response, error)
+
+	// GetByName retrieves a SSH key by its name. If the SSH key does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*SSHKey, *Response, error)
+
+	// GetByFingerprint retreives a SSH key by its fingerprint. If the SSH key does not exist, nil is returned.
+	GetByFingerprint(ctx context.Context, fingerprint string) (*SSHKey, *Response, error)
+
+	// Get retrieves a SSH key by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a SSH key by its name. If the SSH key does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*SSHKey, *Response, error)
+
+	// List returns a list of SSH keys for a specific page.
+	List(ctx context.Context, opts SSHKeyListOpts) ([]*SSHKey, *Response, error)
+
+	// All returns all SSH keys.
+	All(ctx context.Context) ([]*SSHKey, error)
+
+	// AllWithOpts returns all SSH keys with the given options.
+	AllWithOpts(ctx context.Context, opts SSHKeyListOpts) ([]*SSHKey, error)
+
+	// Create creates a new SSH key with the given options.
+	Create(ctx context.Context, opts SSHKeyCreateOpts) (*SSHKey, *Response, error)
+
+	// Delete deletes a SSH key.
+	Delete(ctx context.Context, sshKey *SSHKey) (*Response, error)
+
+	// Update updates a SSH key.
+	Update(ctx context.Context, sshKey *SSHKey, opts SSHKeyUpdateOpts) (*SSHKey, *Response, error)
+}
+
+var _ SSHKeyAPI = (*SSHKeyClient)(nil)
+
+// VolumeAPI is the interface implemented by VolumeClient.
+type VolumeAPI interface {
+	// GetByID retrieves a volume by its ID. If the volume does not exist, nil is returned.
+	GetByID(ctx context.Context, id int) (*Volume, *Response, error)
+
+	// GetByName retrieves a volume by its name. If the volume does not exist, nil is returned.
+	GetByName(ctx context.Context, name string) (*Volume, *Response, error)
+
+	// Get retrieves a volume by its ID if the input can be parsed as an integer, otherwise it
+	// retrieves a volume by its name. If the volume does not exist, nil is returned.
+	Get(ctx context.Context, idOrName string) (*Volume, *Response, error)
+
+	// List returns a list of volumes for a specific page.
+	List(ctx context.Context, opts VolumeListOpts) ([]*Volume, *Response, error)
+
+	// All returns all volumes.
+	All(ctx context.Context) ([]*Volume, error)
+
+	// AllWithOpts returns all volumes with the given options.
+	AllWithOpts(ctx context.Context, opts VolumeListOpts) ([]*Volume, error)
+
+	// Create creates a new volume with the given options.
+	Create(ctx context.Context, opts VolumeCreateOpts) (VolumeCreateResult, *Response, error)
+
+	// Delete deletes a volume.
+	Delete(ctx context.Context, volume *Volume) (*Response, error)
+
+	// Update updates a volume.
+	Update(ctx context.Context, volume *Volume, opts VolumeUpdateOpts) (*Volume, *Response, error)
+
+	// AttachWithOpts attaches a volume to a server.
+	AttachWithOpts(ctx context.Context, volume *Volume, opts VolumeAttachOpts) (*Action, *Response, error)
+
+	// Attach attaches a volume to a server.
+	Attach(ctx context.Context, volume *Volume, server *Server) (*Action, *Response, error)
+
+	// Detach detaches a volume from a server.
+	Detach(ctx context.Context, volume *Volume) (*Action, *Response, error)
+
+	// ChangeProtection changes the resource protection level of a volume.
+	ChangeProtection(ctx context.Context, volume *Volume, opts VolumeChangeProtectionOpts) (*Action, *Response, error)
+
+	// Resize changes the size of a volume.
+	Resize(ctx context.Context, volume *Volume, size int) (*Action, *Response, error)
+}
+
+var _ VolumeAPI = (*VolumeClient)(nil)
+
+// DNSServerAPI is the interface implemented by DNSServerClient.
+type DNSServerAPI interface {
+	// NewRequest creates a new request for the DNS server client.
+	NewRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error)
+
+	// GetAllRecords returns all records associated with user.
+	GetAllRecords(ctx context.Context, zoneID string, opts RecordListOpts) ([]*Record, *Response, error)
+
+	// GetRecord returns information about a single record.
+	GetRecord(ctx context.Context, recordID string) (*Record, *Response, error)
+
+	// CreateRecord creates a new record.
+	CreateRecord(ctx context.Context, record CreateOrUpdateRecord) (*Record, *Response, error)
+
+	// UpdateRecord updates a record.
+	UpdateRecord(ctx context.Context, record CreateOrUpdateRecord, recordID string) (*Record, *Response, error)
+
+	// DeleteRecord deletes a record.
+	DeleteRecord(ctx context.Context, recordID string) (*Response, error)
+
+	// GetAllZones returns all zones associated with the user.
+	GetAllZones(ctx context.Context, opts ZoneListOpts) ([]*Zone, *Response, error)
+
+	// GetZone returns an object containing all information about a zone. Zone to get is identified by 'ZoneID'.
+	GetZone(ctx context.Context, zoneID string) (*Zone, *Response, error)
+
+	// UpdateZone updates a zone.
+	UpdateZone(ctx context.Context, zoneID string, updateZoneData CreateOrUpdateZone) (*Zone, *Response, error)
+
+	// DeleteZone deletes a zone.
+	DeleteZone(ctx context.Context, zoneID string) (*Response, error)
+
+	// CreateZone creates a new zone.
+	CreateZone(ctx context.Context, zone CreateOrUpdateZone) (*Zone, *Response, error)
+}
+
+var _ DNSServerAPI = (*DNSServerClient)(nil)
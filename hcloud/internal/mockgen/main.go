@@ -0,0 +1,485 @@
+// Command mockgen generates the resource client interfaces of package hcloud
+// and their mock implementations in package hcloud/mock.
+//
+// It is run by go generate from the hcloud directory:
+//
+//	go run ./internal/mockgen
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const hcloudImportPath = "github.com/ptr1120/hcloud-go/hcloud"
+
+const (
+	interfacesFile = "interfaces.go"
+	mocksFile      = "mock/clients.go"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of package hcloud")
+	flag.Parse()
+
+	files, err := generate(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(*dir, name), src, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// generate returns the generated files, keyed by their path relative to dir.
+func generate(dir string) (map[string][]byte, error) {
+	clients, err := parseClients(dir)
+	if err != nil {
+		return nil, err
+	}
+	interfaces, err := format.Source(renderInterfaces(clients))
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %v", interfacesFile, err)
+	}
+	mocks, err := format.Source(renderMocks(clients))
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %v", mocksFile, err)
+	}
+	return map[string][]byte{
+		interfacesFile: interfaces,
+		mocksFile:      mocks,
+	}, nil
+}
+
+// client is a resource client type like ServerClient.
+type client struct {
+	Name    string
+	Methods []*method
+}
+
+// API returns the name of the client's interface.
+func (c *client) API() string {
+	return strings.TrimSuffix(c.Name, "Client") + "API"
+}
+
+type method struct {
+	Name    string
+	Doc     string
+	Params  []param
+	Results []string
+	Imports map[string]string // package name -> import path
+}
+
+type param struct {
+	Name     string
+	Type     string
+	Variadic bool
+}
+
+// parseClients returns the resource clients in the order of their fields in
+// the Client struct, each with its exported methods in source order.
+func parseClients(dir string) ([]*client, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != interfacesFile
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["hcloud"]
+	if !ok {
+		return nil, fmt.Errorf("no package hcloud in %s", dir)
+	}
+
+	var filenames []string
+	for filename := range pkg.Files {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+
+	var clients []*client
+	byName := map[string]*client{}
+	for _, filename := range filenames {
+		for _, decl := range pkg.Files[filename].Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Name.Name != "Client" {
+					continue
+				}
+				for _, field := range typeSpec.Type.(*ast.StructType).Fields.List {
+					ident, ok := field.Type.(*ast.Ident)
+					if !ok || !ast.IsExported(ident.Name) || !strings.HasSuffix(ident.Name, "Client") {
+						continue
+					}
+					c := &client{Name: ident.Name}
+					clients = append(clients, c)
+					byName[c.Name] = c
+				}
+			}
+		}
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("no resource clients found in %s", dir)
+	}
+
+	for _, filename := range filenames {
+		file := pkg.Files[filename]
+		imports := map[string]string{}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = path
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+				continue
+			}
+			star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			recv, ok := star.X.(*ast.Ident)
+			if !ok || byName[recv.Name] == nil {
+				continue
+			}
+			m, err := newMethod(fn, imports)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s.%s: %v", fset.Position(fn.Pos()), recv.Name, fn.Name.Name, err)
+			}
+			byName[recv.Name].Methods = append(byName[recv.Name].Methods, m)
+		}
+	}
+	return clients, nil
+}
+
+func newMethod(fn *ast.FuncDecl, imports map[string]string) (*method, error) {
+	m := &method{
+		Name:    fn.Name.Name,
+		Doc:     fn.Doc.Text(),
+		Imports: map[string]string{},
+	}
+	var err error
+	for i, field := range fn.Type.Params.List {
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent("_")}
+		}
+		for _, name := range names {
+			p := param{Name: name.Name}
+			if p.Name == "_" {
+				p.Name = fmt.Sprintf("arg%d", i)
+			}
+			typ := field.Type
+			if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+				p.Variadic = true
+				typ = ellipsis.Elt
+			}
+			if p.Type, err = m.typeString(typ, imports); err != nil {
+				return nil, err
+			}
+			m.Params = append(m.Params, p)
+		}
+	}
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			typ, err := m.typeString(field.Type, imports)
+			if err != nil {
+				return nil, err
+			}
+			for i := 0; i < len(field.Names) || i == 0; i++ {
+				m.Results = append(m.Results, typ)
+			}
+		}
+	}
+	return m, nil
+}
+
+// typeString renders a type expression. Identifiers declared in package
+// hcloud are written as "$.Name" so they can be qualified as needed.
+func (m *method) typeString(expr ast.Expr, imports map[string]string) (string, error) {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(expr.Name) {
+			return "$." + expr.Name, nil
+		}
+		return expr.Name, nil
+	case *ast.SelectorExpr:
+		pkg := expr.X.(*ast.Ident).Name
+		path, ok := imports[pkg]
+		if !ok {
+			return "", fmt.Errorf("unknown package %s", pkg)
+		}
+		m.Imports[pkg] = path
+		return pkg + "." + expr.Sel.Name, nil
+	case *ast.StarExpr:
+		elem, err := m.typeString(expr.X, imports)
+		return "*" + elem, err
+	case *ast.ArrayType:
+		if expr.Len != nil {
+			lit, ok := expr.Len.(*ast.BasicLit)
+			if !ok {
+				return "", fmt.Errorf("unsupported array length")
+			}
+			elem, err := m.typeString(expr.Elt, imports)
+			return "[" + lit.Value + "]" + elem, err
+		}
+		elem, err := m.typeString(expr.Elt, imports)
+		return "[]" + elem, err
+	case *ast.MapType:
+		key, err := m.typeString(expr.Key, imports)
+		if err != nil {
+			return "", err
+		}
+		value, err := m.typeString(expr.Value, imports)
+		return "map[" + key + "]" + value, err
+	case *ast.ChanType:
+		elem, err := m.typeString(expr.Value, imports)
+		switch expr.Dir {
+		case ast.SEND:
+			return "chan<- " + elem, err
+		case ast.RECV:
+			return "<-chan " + elem, err
+		}
+		return "chan " + elem, err
+	case *ast.FuncType:
+		var params, results []string
+		for _, field := range expr.Params.List {
+			typ, err := m.typeString(field.Type, imports)
+			if err != nil {
+				return "", err
+			}
+			for i := 0; i < len(field.Names) || i == 0; i++ {
+				params = append(params, typ)
+			}
+		}
+		if expr.Results != nil {
+			for _, field := range expr.Results.List {
+				typ, err := m.typeString(field.Type, imports)
+				if err != nil {
+					return "", err
+				}
+				for i := 0; i < len(field.Names) || i == 0; i++ {
+					results = append(results, typ)
+				}
+			}
+		}
+		s := "func(" + strings.Join(params, ", ") + ")"
+		switch len(results) {
+		case 0:
+		case 1:
+			s += " " + results[0]
+		default:
+			s += " (" + strings.Join(results, ", ") + ")"
+		}
+		return s, nil
+	case *ast.Ellipsis:
+		elem, err := m.typeString(expr.Elt, imports)
+		return "..." + elem, err
+	case *ast.InterfaceType:
+		if len(expr.Methods.List) == 0 {
+			return "interface{}", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %T", expr)
+}
+
+// qualify replaces the "$" placeholder of hcloud identifiers in s.
+func qualify(s, pkg string) string {
+	if pkg == "" {
+		return strings.Replace(s, "$.", "", -1)
+	}
+	return strings.Replace(s, "$.", pkg+".", -1)
+}
+
+// signature returns the method's parameters and results, qualifying hcloud
+// identifiers with pkg.
+func (m *method) signature(pkg string) string {
+	var params []string
+	for _, p := range m.Params {
+		typ := qualify(p.Type, pkg)
+		if p.Variadic {
+			typ = "..." + typ
+		}
+		params = append(params, p.Name+" "+typ)
+	}
+	var results []string
+	for _, r := range m.Results {
+		results = append(results, qualify(r, pkg))
+	}
+	s := "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		s += " " + results[0]
+	default:
+		s += " (" + strings.Join(results, ", ") + ")"
+	}
+	return s
+}
+
+// returnsError reports whether the method's last result is an error.
+func (m *method) returnsError() bool {
+	return len(m.Results) > 0 && m.Results[len(m.Results)-1] == "error"
+}
+
+const header = "// Code generated by internal/mockgen; DO NOT EDIT.\n\n"
+
+// isStd reports whether path is the import path of a standard library package.
+func isStd(path string) bool {
+	return !strings.Contains(path, ".")
+}
+
+func writeImports(buf *bytes.Buffer, imports map[string]string) {
+	var paths []string
+	for _, path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	buf.WriteString("import (\n")
+	for i, path := range paths {
+		// Standard library packages come first, separated from the others.
+		if i > 0 && isStd(path) != isStd(paths[i-1]) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(buf, "%q\n", path)
+	}
+	buf.WriteString(")\n\n")
+}
+
+func writeComment(buf *bytes.Buffer, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line == "" {
+			buf.WriteString("//\n")
+		} else {
+			buf.WriteString("// " + line + "\n")
+		}
+	}
+}
+
+func renderInterfaces(clients []*client) []byte {
+	imports := map[string]string{}
+	for _, c := range clients {
+		for _, m := range c.Methods {
+			for name, path := range m.Imports {
+				imports[name] = path
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package hcloud\n\n")
+	if len(imports) > 0 {
+		writeImports(&buf, imports)
+	}
+	for _, c := range clients {
+		fmt.Fprintf(&buf, "// %s is the interface implemented by %s.\n", c.API(), c.Name)
+		fmt.Fprintf(&buf, "type %s interface {\n", c.API())
+		for i, m := range c.Methods {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			if m.Doc != "" {
+				writeComment(&buf, m.Doc)
+			}
+			fmt.Fprintf(&buf, "%s%s\n", m.Name, m.signature(""))
+		}
+		buf.WriteString("}\n\n")
+		fmt.Fprintf(&buf, "var _ %s = (*%s)(nil)\n\n", c.API(), c.Name)
+	}
+	return buf.Bytes()
+}
+
+func renderMocks(clients []*client) []byte {
+	imports := map[string]string{"hcloud": hcloudImportPath}
+	for _, c := range clients {
+		for _, m := range c.Methods {
+			for name, path := range m.Imports {
+				imports[name] = path
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package mock\n\n")
+	writeImports(&buf, imports)
+	for _, c := range clients {
+		fmt.Fprintf(&buf, "// %s is a mock implementation of hcloud.%s.\n", c.Name, c.API())
+		fmt.Fprintf(&buf, "type %s struct {\n", c.Name)
+		buf.WriteString("Recorder\n\n")
+		for _, m := range c.Methods {
+			fmt.Fprintf(&buf, "%sFunc func%s\n", m.Name, m.signature("hcloud"))
+		}
+		buf.WriteString("}\n\n")
+		fmt.Fprintf(&buf, "var _ hcloud.%s = (*%s)(nil)\n\n", c.API(), c.Name)
+
+		for _, m := range c.Methods {
+			var args []string
+			for _, p := range m.Params {
+				if p.Variadic {
+					args = append(args, p.Name+"...")
+				} else {
+					args = append(args, p.Name)
+				}
+			}
+			var recorded []string
+			for _, p := range m.Params {
+				recorded = append(recorded, p.Name)
+			}
+
+			fmt.Fprintf(&buf, "// %s records the call and returns the result of %sFunc.\n", m.Name, m.Name)
+			fmt.Fprintf(&buf, "func (m *%s) %s%s {\n", c.Name, m.Name, m.signature("hcloud"))
+			fmt.Fprintf(&buf, "m.record(%s)\n", strings.Join(append([]string{strconv.Quote(m.Name)}, recorded...), ", "))
+			fmt.Fprintf(&buf, "if m.%sFunc == nil {\n", m.Name)
+			if m.returnsError() {
+				var zeros []string
+				for i, r := range m.Results[:len(m.Results)-1] {
+					name := fmt.Sprintf("r%d", i)
+					fmt.Fprintf(&buf, "var %s %s\n", name, qualify(r, "hcloud"))
+					zeros = append(zeros, name)
+				}
+				zeros = append(zeros, fmt.Sprintf("notProgrammed(%q, %q)", c.Name, m.Name))
+				fmt.Fprintf(&buf, "return %s\n", strings.Join(zeros, ", "))
+			} else {
+				fmt.Fprintf(&buf, "panic(notProgrammed(%q, %q))\n", c.Name, m.Name)
+			}
+			buf.WriteString("}\n")
+			call := fmt.Sprintf("m.%sFunc(%s)", m.Name, strings.Join(args, ", "))
+			if len(m.Results) > 0 {
+				fmt.Fprintf(&buf, "return %s\n", call)
+			} else {
+				fmt.Fprintf(&buf, "%s\n", call)
+			}
+			buf.WriteString("}\n\n")
+		}
+	}
+	return buf.Bytes()
+}
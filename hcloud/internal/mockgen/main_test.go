@@ -0,0 +1,25 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..")
+	files, err := generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, src := range files {
+		current, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(current, src) {
+			t.Errorf("%s is out of date, run go generate in the hcloud directory", name)
+		}
+	}
+}
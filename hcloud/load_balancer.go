@@ -0,0 +1,1090 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// LoadBalancer represents a Load Balancer in the Hetzner Cloud.
+type LoadBalancer struct {
+	ID               int
+	Name             string
+	PublicNet        LoadBalancerPublicNet
+	PrivateNet       []LoadBalancerPrivateNet
+	Location         *Location
+	LoadBalancerType *LoadBalancerType
+	Algorithm        LoadBalancerAlgorithm
+	Services         []LoadBalancerService
+	Targets          []LoadBalancerTarget
+	Protection       LoadBalancerProtection
+	Labels           map[string]string
+	Created          time.Time
+	IncludedTraffic  uint64
+	OutgoingTraffic  uint64
+	IngoingTraffic   uint64
+}
+
+// LoadBalancerPublicNet represents a Load Balancer's public network.
+type LoadBalancerPublicNet struct {
+	Enabled bool
+	IPv4    LoadBalancerPublicNetIPv4
+	IPv6    LoadBalancerPublicNetIPv6
+}
+
+// LoadBalancerPublicNetIPv4 represents a Load Balancer's public IPv4 address.
+type LoadBalancerPublicNetIPv4 struct {
+	IP     net.IP
+	DNSPtr string
+}
+
+// LoadBalancerPublicNetIPv6 represents a Load Balancer's public IPv6 address.
+type LoadBalancerPublicNetIPv6 struct {
+	IP     net.IP
+	DNSPtr string
+}
+
+// LoadBalancerPrivateNet represents a Load Balancer's private network.
+type LoadBalancerPrivateNet struct {
+	Network *Network
+	IP      net.IP
+}
+
+// LoadBalancerService represents a Load Balancer service.
+type LoadBalancerService struct {
+	Protocol        LoadBalancerServiceProtocol
+	ListenPort      int
+	DestinationPort int
+	Proxyprotocol   bool
+	HTTP            LoadBalancerServiceHTTP
+	HealthCheck     LoadBalancerServiceHealthCheck
+}
+
+// LoadBalancerServiceHTTP stores configuration for a service using the HTTP protocol.
+type LoadBalancerServiceHTTP struct {
+	CookieName     string
+	CookieLifetime time.Duration
+	Certificates   []*Certificate
+	RedirectHTTP   bool
+	StickySessions bool
+}
+
+// LoadBalancerServiceHealthCheck stores configuration for a service health check.
+type LoadBalancerServiceHealthCheck struct {
+	Protocol LoadBalancerServiceProtocol
+	Port     int
+	Interval time.Duration
+	Timeout  time.Duration
+	Retries  int
+	HTTP     *LoadBalancerServiceHealthCheckHTTP
+}
+
+// LoadBalancerServiceHealthCheckHTTP stores configuration for a service health check
+// using the HTTP protocol.
+type LoadBalancerServiceHealthCheckHTTP struct {
+	Domain      string
+	Path        string
+	Response    string
+	StatusCodes []string
+	TLS         bool
+}
+
+// LoadBalancerAlgorithmType specifies the algorithm type a Load Balancer
+// uses for distributing requests.
+type LoadBalancerAlgorithmType string
+
+const (
+	// LoadBalancerAlgorithmTypeRoundRobin is an algorithm which distributes
+	// requests to targets in a round robin fashion.
+	LoadBalancerAlgorithmTypeRoundRobin LoadBalancerAlgorithmType = "round_robin"
+
+	// LoadBalancerAlgorithmTypeLeastConnections is an algorithm which distributes
+	// requests to targets with the least number of connections.
+	LoadBalancerAlgorithmTypeLeastConnections LoadBalancerAlgorithmType = "least_connections"
+)
+
+// LoadBalancerAlgorithm configures the algorithm a Load Balancer uses
+// for distributing requests.
+type LoadBalancerAlgorithm struct {
+	Type LoadBalancerAlgorithmType
+}
+
+// LoadBalancerTargetType specifies the type of a Load Balancer target.
+type LoadBalancerTargetType string
+
+const (
+	// LoadBalancerTargetTypeServer is a target type which points to a specific
+	// server.
+	LoadBalancerTargetTypeServer LoadBalancerTargetType = "server"
+
+	// LoadBalancerTargetTypeLabelSelector is a target type which selects the
+	// servers a Load Balancer points to using labels assigned to the servers.
+	LoadBalancerTargetTypeLabelSelector LoadBalancerTargetType = "label_selector"
+
+	// LoadBalancerTargetTypeIP is a target type which points to a specific
+	// IP address.
+	LoadBalancerTargetTypeIP LoadBalancerTargetType = "ip"
+)
+
+// LoadBalancerServiceProtocol specifies the protocol of a Load Balancer service.
+type LoadBalancerServiceProtocol string
+
+// List of Load Balancer service protocols.
+const (
+	LoadBalancerServiceProtocolTCP   LoadBalancerServiceProtocol = "tcp"
+	LoadBalancerServiceProtocolHTTP  LoadBalancerServiceProtocol = "http"
+	LoadBalancerServiceProtocolHTTPS LoadBalancerServiceProtocol = "https"
+)
+
+// LoadBalancerTarget represents a Load Balancer target.
+type LoadBalancerTarget struct {
+	Type          LoadBalancerTargetType
+	Server        *LoadBalancerTargetServer
+	LabelSelector *LoadBalancerTargetLabelSelector
+	IP            *LoadBalancerTargetIP
+	HealthStatus  []LoadBalancerTargetHealthStatus
+	Targets       []LoadBalancerTarget // targets resolved from a label selector
+	UsePrivateIP  bool
+}
+
+// LoadBalancerTargetServer configures a Load Balancer target
+// pointing at a specific server.
+type LoadBalancerTargetServer struct {
+	Server *Server
+}
+
+// LoadBalancerTargetLabelSelector configures a Load Balancer target pointing
+// at the servers matching the selector. This includes the target pointing at
+// nothing, if no servers match the Selector.
+type LoadBalancerTargetLabelSelector struct {
+	Selector string
+}
+
+// LoadBalancerTargetIP configures a Load Balancer target pointing to a Hetzner
+// Online Robot server or another external IP address.
+type LoadBalancerTargetIP struct {
+	IP string
+}
+
+// LoadBalancerTargetHealthStatusStatus describes a target's health status.
+type LoadBalancerTargetHealthStatusStatus string
+
+// List of Load Balancer target health statuses.
+const (
+	LoadBalancerTargetHealthStatusStatusUnknown   LoadBalancerTargetHealthStatusStatus = "unknown"
+	LoadBalancerTargetHealthStatusStatusHealthy   LoadBalancerTargetHealthStatusStatus = "healthy"
+	LoadBalancerTargetHealthStatusStatusUnhealthy LoadBalancerTargetHealthStatusStatus = "unhealthy"
+)
+
+// LoadBalancerTargetHealthStatus describes a target's health for a specific service.
+type LoadBalancerTargetHealthStatus struct {
+	ListenPort int
+	Status     LoadBalancerTargetHealthStatusStatus
+}
+
+// LoadBalancerProtection represents the protection level of a Load Balancer.
+type LoadBalancerProtection struct {
+	Delete bool
+}
+
+// PrivateNetFor returns the Load Balancer's private network information for
+// the given network, or nil if it is not attached to the network.
+func (lb *LoadBalancer) PrivateNetFor(network *Network) *LoadBalancerPrivateNet {
+	for _, n := range lb.PrivateNet {
+		if n.Network.ID == network.ID {
+			return &n
+		}
+	}
+	return nil
+}
+
+// LoadBalancerClient is a client for the Load Balancers API.
+type LoadBalancerClient struct {
+	client *Client
+}
+
+// GetByID retrieves a Load Balancer by its ID. If the Load Balancer does not exist, nil is returned.
+func (c *LoadBalancerClient) GetByID(ctx context.Context, id int) (*LoadBalancer, *Response, error) {
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("/load_balancers/%d", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.LoadBalancerGetResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, nil, err
+	}
+	return LoadBalancerFromSchema(body.LoadBalancer), resp, nil
+}
+
+// GetByName retrieves a Load Balancer by its name. If the Load Balancer does not exist, nil is returned.
+func (c *LoadBalancerClient) GetByName(ctx context.Context, name string) (*LoadBalancer, *Response, error) {
+	loadBalancers, response, err := c.List(ctx, LoadBalancerListOpts{Name: name})
+	if len(loadBalancers) == 0 {
+		return nil, response, err
+	}
+	return loadBalancers[0], response, err
+}
+
+// Get retrieves a Load Balancer by its ID if the input can be parsed as an integer, otherwise it
+// retrieves a Load Balancer by its name. If the Load Balancer does not exist, nil is returned.
+func (c *LoadBalancerClient) Get(ctx context.Context, idOrName string) (*LoadBalancer, *Response, error) {
+	if id, err := strconv.Atoi(idOrName); err == nil {
+		return c.GetByID(ctx, int(id))
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// LoadBalancerListOpts specifies options for listing Load Balancers.
+type LoadBalancerListOpts struct {
+	ListOpts
+	Name string
+}
+
+func (l LoadBalancerListOpts) values() url.Values {
+	vals := l.ListOpts.values()
+	if l.Name != "" {
+		vals.Add("name", l.Name)
+	}
+	return vals
+}
+
+// List returns a list of Load Balancers for a specific page.
+func (c *LoadBalancerClient) List(ctx context.Context, opts LoadBalancerListOpts) ([]*LoadBalancer, *Response, error) {
+	path := "/load_balancers?" + opts.values().Encode()
+	req, err := c.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.LoadBalancerListResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+	loadBalancers := make([]*LoadBalancer, 0, len(body.LoadBalancers))
+	for _, s := range body.LoadBalancers {
+		loadBalancers = append(loadBalancers, LoadBalancerFromSchema(s))
+	}
+	return loadBalancers, resp, nil
+}
+
+// All returns all Load Balancers.
+func (c *LoadBalancerClient) All(ctx context.Context) ([]*LoadBalancer, error) {
+	return c.AllWithOpts(ctx, LoadBalancerListOpts{ListOpts: ListOpts{PerPage: 50}})
+}
+
+// AllWithOpts returns all Load Balancers for the given options.
+func (c *LoadBalancerClient) AllWithOpts(ctx context.Context, opts LoadBalancerListOpts) ([]*LoadBalancer, error) {
+	allLoadBalancers := []*LoadBalancer{}
+
+	_, err := c.client.all(func(page int) (*Response, error) {
+		opts.Page = page
+		loadBalancers, resp, err := c.List(ctx, opts)
+		if err != nil {
+			return resp, err
+		}
+		allLoadBalancers = append(allLoadBalancers, loadBalancers...)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allLoadBalancers, nil
+}
+
+// LoadBalancerUpdateOpts specifies options for updating a Load Balancer.
+type LoadBalancerUpdateOpts struct {
+	Name   string
+	Labels map[string]string
+}
+
+// Update updates a Load Balancer.
+func (c *LoadBalancerClient) Update(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerUpdateOpts) (*LoadBalancer, *Response, error) {
+	reqBody := schema.LoadBalancerUpdateRequest{}
+	if opts.Name != "" {
+		reqBody.Name = &opts.Name
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "PUT", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerUpdateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return LoadBalancerFromSchema(respBody.LoadBalancer), resp, nil
+}
+
+// LoadBalancerCreateOpts specifies options for creating a new Load Balancer.
+type LoadBalancerCreateOpts struct {
+	Name             string
+	LoadBalancerType *LoadBalancerType
+	Algorithm        *LoadBalancerAlgorithm
+	Location         *Location
+	NetworkZone      NetworkZone
+	Labels           map[string]string
+	Targets          []LoadBalancerCreateOptsTarget
+	Services         []LoadBalancerAddServiceOpts
+	PublicInterface  *bool
+	Network          *Network
+}
+
+// LoadBalancerCreateOptsTarget holds options for specifying a target
+// when creating a new Load Balancer.
+type LoadBalancerCreateOptsTarget struct {
+	Type          LoadBalancerTargetType
+	Server        *Server
+	LabelSelector string
+	IP            string
+	UsePrivateIP  *bool
+}
+
+// Validate checks if options are valid.
+func (o LoadBalancerCreateOpts) Validate() error {
+	if o.Name == "" {
+		return errors.New("missing name")
+	}
+	if o.LoadBalancerType == nil || (o.LoadBalancerType.ID == 0 && o.LoadBalancerType.Name == "") {
+		return errors.New("missing Load Balancer type")
+	}
+	if o.Location != nil && o.NetworkZone != "" {
+		return errors.New("location and network zone are mutually exclusive")
+	}
+	if o.Location == nil && o.NetworkZone == "" {
+		return errors.New("one of location or network zone is required")
+	}
+	for i, target := range o.Targets {
+		if err := validateLoadBalancerTarget(target.Type, target.Server, target.LabelSelector, target.IP); err != nil {
+			return fmt.Errorf("target %d: %s", i, err)
+		}
+	}
+	for i, service := range o.Services {
+		if err := service.Validate(); err != nil {
+			return fmt.Errorf("service %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+// LoadBalancerCreateResult is the result of a create Load Balancer operation.
+type LoadBalancerCreateResult struct {
+	LoadBalancer *LoadBalancer
+	Action       *Action
+}
+
+// Create creates a new Load Balancer.
+func (c *LoadBalancerClient) Create(ctx context.Context, opts LoadBalancerCreateOpts) (LoadBalancerCreateResult, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return LoadBalancerCreateResult{}, nil, fmt.Errorf("invalid options: %s", err)
+	}
+
+	reqBody := schema.LoadBalancerCreateRequest{
+		Name:            opts.Name,
+		PublicInterface: opts.PublicInterface,
+	}
+	if opts.LoadBalancerType.ID != 0 {
+		reqBody.LoadBalancerType = opts.LoadBalancerType.ID
+	} else {
+		reqBody.LoadBalancerType = opts.LoadBalancerType.Name
+	}
+	if opts.Algorithm != nil {
+		reqBody.Algorithm = &schema.LoadBalancerCreateRequestAlgorithm{
+			Type: string(opts.Algorithm.Type),
+		}
+	}
+	if opts.Location != nil {
+		if opts.Location.ID != 0 {
+			reqBody.Location = String(strconv.Itoa(opts.Location.ID))
+		} else {
+			reqBody.Location = String(opts.Location.Name)
+		}
+	}
+	if opts.NetworkZone != "" {
+		reqBody.NetworkZone = String(string(opts.NetworkZone))
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	if opts.Network != nil {
+		reqBody.Network = Int(opts.Network.ID)
+	}
+	for _, target := range opts.Targets {
+		schemaTarget := schema.LoadBalancerCreateRequestTarget{
+			Type:         string(target.Type),
+			UsePrivateIP: target.UsePrivateIP,
+		}
+		schemaTarget.Server, schemaTarget.LabelSelector, schemaTarget.IP = loadBalancerTargetToSchema(target.Type, target.Server, target.LabelSelector, target.IP)
+		reqBody.Targets = append(reqBody.Targets, schemaTarget)
+	}
+	for _, service := range opts.Services {
+		reqBody.Services = append(reqBody.Services, schema.LoadBalancerCreateRequestService{
+			Protocol:        string(service.Protocol),
+			ListenPort:      service.ListenPort,
+			DestinationPort: service.DestinationPort,
+			Proxyprotocol:   service.Proxyprotocol,
+			HTTP:            service.HTTP.toSchema(),
+			HealthCheck:     service.HealthCheck.toSchema(),
+		})
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return LoadBalancerCreateResult{}, nil, err
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", "/load_balancers", bytes.NewReader(reqBodyData))
+	if err != nil {
+		return LoadBalancerCreateResult{}, nil, err
+	}
+
+	respBody := schema.LoadBalancerCreateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return LoadBalancerCreateResult{}, resp, err
+	}
+	return LoadBalancerCreateResult{
+		LoadBalancer: LoadBalancerFromSchema(respBody.LoadBalancer),
+		Action:       ActionFromSchema(respBody.Action),
+	}, resp, nil
+}
+
+// Delete deletes a Load Balancer.
+func (c *LoadBalancerClient) Delete(ctx context.Context, loadBalancer *LoadBalancer) (*Response, error) {
+	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("/load_balancers/%d", loadBalancer.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req, nil)
+}
+
+func (c *LoadBalancerClient) addTarget(ctx context.Context, loadBalancer *LoadBalancer, reqBody schema.LoadBalancerActionAddTargetRequest) (*Action, *Response, error) {
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/add_target", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.LoadBalancerActionAddTargetResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+func (c *LoadBalancerClient) removeTarget(ctx context.Context, loadBalancer *LoadBalancer, reqBody schema.LoadBalancerActionRemoveTargetRequest) (*Action, *Response, error) {
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/remove_target", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.LoadBalancerActionRemoveTargetResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// LoadBalancerAddServerTargetOpts specifies options for adding a server target
+// to a Load Balancer.
+type LoadBalancerAddServerTargetOpts struct {
+	Server       *Server
+	UsePrivateIP *bool
+}
+
+// AddServerTarget adds a server target to a Load Balancer.
+func (c *LoadBalancerClient) AddServerTarget(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddServerTargetOpts) (*Action, *Response, error) {
+	if opts.Server == nil {
+		return nil, nil, errors.New("invalid options: missing server")
+	}
+	return c.addTarget(ctx, loadBalancer, schema.LoadBalancerActionAddTargetRequest{
+		Type:         string(LoadBalancerTargetTypeServer),
+		Server:       &schema.LoadBalancerActionTargetServer{ID: opts.Server.ID},
+		UsePrivateIP: opts.UsePrivateIP,
+	})
+}
+
+// RemoveServerTarget removes a server target from a Load Balancer.
+func (c *LoadBalancerClient) RemoveServerTarget(ctx context.Context, loadBalancer *LoadBalancer, server *Server) (*Action, *Response, error) {
+	return c.removeTarget(ctx, loadBalancer, schema.LoadBalancerActionRemoveTargetRequest{
+		Type:   string(LoadBalancerTargetTypeServer),
+		Server: &schema.LoadBalancerActionTargetServer{ID: server.ID},
+	})
+}
+
+// LoadBalancerAddLabelSelectorTargetOpts specifies options for adding a label
+// selector target to a Load Balancer.
+type LoadBalancerAddLabelSelectorTargetOpts struct {
+	Selector     string
+	UsePrivateIP *bool
+}
+
+// AddLabelSelectorTarget adds a label selector target to a Load Balancer.
+func (c *LoadBalancerClient) AddLabelSelectorTarget(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddLabelSelectorTargetOpts) (*Action, *Response, error) {
+	if opts.Selector == "" {
+		return nil, nil, errors.New("invalid options: missing selector")
+	}
+	return c.addTarget(ctx, loadBalancer, schema.LoadBalancerActionAddTargetRequest{
+		Type:          string(LoadBalancerTargetTypeLabelSelector),
+		LabelSelector: &schema.LoadBalancerActionTargetLabelSelector{Selector: opts.Selector},
+		UsePrivateIP:  opts.UsePrivateIP,
+	})
+}
+
+// RemoveLabelSelectorTarget removes a label selector target from a Load Balancer.
+func (c *LoadBalancerClient) RemoveLabelSelectorTarget(ctx context.Context, loadBalancer *LoadBalancer, labelSelector string) (*Action, *Response, error) {
+	return c.removeTarget(ctx, loadBalancer, schema.LoadBalancerActionRemoveTargetRequest{
+		Type:          string(LoadBalancerTargetTypeLabelSelector),
+		LabelSelector: &schema.LoadBalancerActionTargetLabelSelector{Selector: labelSelector},
+	})
+}
+
+// LoadBalancerAddIPTargetOpts specifies options for adding an IP target to a
+// Load Balancer.
+type LoadBalancerAddIPTargetOpts struct {
+	IP net.IP
+}
+
+// AddIPTarget adds an IP target to a Load Balancer.
+func (c *LoadBalancerClient) AddIPTarget(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddIPTargetOpts) (*Action, *Response, error) {
+	if opts.IP == nil {
+		return nil, nil, errors.New("invalid options: missing IP")
+	}
+	return c.addTarget(ctx, loadBalancer, schema.LoadBalancerActionAddTargetRequest{
+		Type: string(LoadBalancerTargetTypeIP),
+		IP:   &schema.LoadBalancerActionTargetIP{IP: opts.IP.String()},
+	})
+}
+
+// RemoveIPTarget removes an IP target from a Load Balancer.
+func (c *LoadBalancerClient) RemoveIPTarget(ctx context.Context, loadBalancer *LoadBalancer, ip net.IP) (*Action, *Response, error) {
+	return c.removeTarget(ctx, loadBalancer, schema.LoadBalancerActionRemoveTargetRequest{
+		Type: string(LoadBalancerTargetTypeIP),
+		IP:   &schema.LoadBalancerActionTargetIP{IP: ip.String()},
+	})
+}
+
+// LoadBalancerAddServiceOpts specifies options for adding a service to a Load Balancer.
+type LoadBalancerAddServiceOpts struct {
+	Protocol        LoadBalancerServiceProtocol
+	ListenPort      *int
+	DestinationPort *int
+	Proxyprotocol   *bool
+	HTTP            *LoadBalancerServiceOptsHTTP
+	HealthCheck     *LoadBalancerServiceOptsHealthCheck
+}
+
+// Validate checks if options are valid.
+func (o LoadBalancerAddServiceOpts) Validate() error {
+	switch o.Protocol {
+	case LoadBalancerServiceProtocolTCP:
+		if o.ListenPort == nil {
+			return errors.New("missing listen port")
+		}
+		if o.HTTP != nil {
+			return errors.New("HTTP configuration is not supported for protocol tcp")
+		}
+	case LoadBalancerServiceProtocolHTTP:
+		if o.HTTP != nil && len(o.HTTP.Certificates) > 0 {
+			return errors.New("certificates are not supported for protocol http")
+		}
+	case LoadBalancerServiceProtocolHTTPS:
+		if o.HTTP == nil || len(o.HTTP.Certificates) == 0 {
+			return errors.New("missing certificates")
+		}
+	default:
+		return errors.New("missing or invalid protocol")
+	}
+	return o.HealthCheck.validate()
+}
+
+// LoadBalancerServiceOptsHTTP holds options for specifying the HTTP
+// configuration when adding or updating a service.
+type LoadBalancerServiceOptsHTTP struct {
+	CookieName     *string
+	CookieLifetime *time.Duration
+	Certificates   []*Certificate
+	RedirectHTTP   *bool
+	StickySessions *bool
+}
+
+func (o *LoadBalancerServiceOptsHTTP) toSchema() *schema.LoadBalancerActionAddServiceRequestHTTP {
+	if o == nil {
+		return nil
+	}
+	s := &schema.LoadBalancerActionAddServiceRequestHTTP{
+		CookieName:     o.CookieName,
+		RedirectHTTP:   o.RedirectHTTP,
+		StickySessions: o.StickySessions,
+	}
+	if o.CookieLifetime != nil {
+		s.CookieLifetime = Int(int(o.CookieLifetime.Seconds()))
+	}
+	if o.Certificates != nil {
+		certificates := []int{}
+		for _, certificate := range o.Certificates {
+			certificates = append(certificates, certificate.ID)
+		}
+		s.Certificates = &certificates
+	}
+	return s
+}
+
+// LoadBalancerServiceOptsHealthCheck holds options for specifying the health
+// check when adding or updating a service.
+type LoadBalancerServiceOptsHealthCheck struct {
+	Protocol LoadBalancerServiceProtocol
+	Port     *int
+	Interval *time.Duration
+	Timeout  *time.Duration
+	Retries  *int
+	HTTP     *LoadBalancerServiceOptsHealthCheckHTTP
+}
+
+func (o *LoadBalancerServiceOptsHealthCheck) validate() error {
+	if o == nil {
+		return nil
+	}
+	switch o.Protocol {
+	case "", LoadBalancerServiceProtocolHTTP:
+	case LoadBalancerServiceProtocolTCP:
+		if o.HTTP != nil {
+			return errors.New("HTTP health check configuration is not supported for protocol tcp")
+		}
+	default:
+		return errors.New("invalid health check protocol")
+	}
+	if o.Interval != nil && o.Timeout != nil && *o.Timeout > *o.Interval {
+		return errors.New("health check timeout must not exceed interval")
+	}
+	return nil
+}
+
+func (o *LoadBalancerServiceOptsHealthCheck) toSchema() *schema.LoadBalancerActionServiceHealthCheck {
+	if o == nil {
+		return nil
+	}
+	s := &schema.LoadBalancerActionServiceHealthCheck{
+		Port:    o.Port,
+		Retries: o.Retries,
+	}
+	if o.Protocol != "" {
+		s.Protocol = String(string(o.Protocol))
+	}
+	if o.Interval != nil {
+		s.Interval = Int(int(o.Interval.Seconds()))
+	}
+	if o.Timeout != nil {
+		s.Timeout = Int(int(o.Timeout.Seconds()))
+	}
+	if o.HTTP != nil {
+		s.HTTP = &schema.LoadBalancerActionServiceHealthCheckHTTP{
+			Domain:   o.HTTP.Domain,
+			Path:     o.HTTP.Path,
+			Response: o.HTTP.Response,
+			TLS:      o.HTTP.TLS,
+		}
+		if o.HTTP.StatusCodes != nil {
+			s.HTTP.StatusCodes = &o.HTTP.StatusCodes
+		}
+	}
+	return s
+}
+
+// LoadBalancerServiceOptsHealthCheckHTTP holds options for specifying the HTTP
+// configuration of a health check.
+type LoadBalancerServiceOptsHealthCheckHTTP struct {
+	Domain      *string
+	Path        *string
+	Response    *string
+	StatusCodes []string
+	TLS         *bool
+}
+
+// AddService adds a service to a Load Balancer.
+func (c *LoadBalancerClient) AddService(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAddServiceOpts) (*Action, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.LoadBalancerActionAddServiceRequest{
+		Protocol:        string(opts.Protocol),
+		ListenPort:      opts.ListenPort,
+		DestinationPort: opts.DestinationPort,
+		Proxyprotocol:   opts.Proxyprotocol,
+		HTTP:            opts.HTTP.toSchema(),
+		HealthCheck:     opts.HealthCheck.toSchema(),
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/add_service", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.LoadBalancerActionAddServiceResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// LoadBalancerUpdateServiceOpts specifies options for updating a service.
+type LoadBalancerUpdateServiceOpts struct {
+	Protocol        LoadBalancerServiceProtocol
+	DestinationPort *int
+	Proxyprotocol   *bool
+	HTTP            *LoadBalancerServiceOptsHTTP
+	HealthCheck     *LoadBalancerServiceOptsHealthCheck
+}
+
+// UpdateService updates a Load Balancer service.
+func (c *LoadBalancerClient) UpdateService(ctx context.Context, loadBalancer *LoadBalancer, listenPort int, opts LoadBalancerUpdateServiceOpts) (*Action, *Response, error) {
+	if err := opts.HealthCheck.validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.LoadBalancerActionUpdateServiceRequest{
+		ListenPort:      listenPort,
+		DestinationPort: opts.DestinationPort,
+		Proxyprotocol:   opts.Proxyprotocol,
+		HealthCheck:     opts.HealthCheck.toSchema(),
+	}
+	if opts.Protocol != "" {
+		reqBody.Protocol = String(string(opts.Protocol))
+	}
+	if http := opts.HTTP.toSchema(); http != nil {
+		reqBody.HTTP = &schema.LoadBalancerActionUpdateServiceRequestHTTP{
+			CookieName:     http.CookieName,
+			CookieLifetime: http.CookieLifetime,
+			Certificates:   http.Certificates,
+			RedirectHTTP:   http.RedirectHTTP,
+			StickySessions: http.StickySessions,
+		}
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/update_service", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.LoadBalancerActionUpdateServiceResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// DeleteService deletes a Load Balancer service.
+func (c *LoadBalancerClient) DeleteService(ctx context.Context, loadBalancer *LoadBalancer, listenPort int) (*Action, *Response, error) {
+	reqBody := schema.LoadBalancerDeleteServiceRequest{
+		ListenPort: listenPort,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/delete_service", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.LoadBalancerDeleteServiceResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// LoadBalancerChangeProtectionOpts specifies options for changing the resource protection level of a Load Balancer.
+type LoadBalancerChangeProtectionOpts struct {
+	Delete *bool
+}
+
+// ChangeProtection changes the resource protection level of a Load Balancer.
+func (c *LoadBalancerClient) ChangeProtection(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerChangeProtectionOpts) (*Action, *Response, error) {
+	reqBody := schema.LoadBalancerActionChangeProtectionRequest{
+		Delete: opts.Delete,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/change_protection", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerActionChangeProtectionResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, err
+}
+
+// LoadBalancerChangeAlgorithmOpts specifies options for changing the algorithm of a Load Balancer.
+type LoadBalancerChangeAlgorithmOpts struct {
+	Type LoadBalancerAlgorithmType
+}
+
+// ChangeAlgorithm changes the algorithm of a Load Balancer.
+func (c *LoadBalancerClient) ChangeAlgorithm(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerChangeAlgorithmOpts) (*Action, *Response, error) {
+	switch opts.Type {
+	case LoadBalancerAlgorithmTypeRoundRobin, LoadBalancerAlgorithmTypeLeastConnections:
+	default:
+		return nil, nil, errors.New("invalid options: missing or invalid algorithm type")
+	}
+	reqBody := schema.LoadBalancerActionChangeAlgorithmRequest{
+		Type: string(opts.Type),
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/change_algorithm", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerActionChangeAlgorithmResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, err
+}
+
+// LoadBalancerAttachToNetworkOpts specifies options for attaching a Load Balancer to a network.
+type LoadBalancerAttachToNetworkOpts struct {
+	Network *Network
+	IP      net.IP
+}
+
+// AttachToNetwork attaches a Load Balancer to a network.
+func (c *LoadBalancerClient) AttachToNetwork(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerAttachToNetworkOpts) (*Action, *Response, error) {
+	reqBody := schema.LoadBalancerActionAttachToNetworkRequest{
+		Network: opts.Network.ID,
+	}
+	if opts.IP != nil {
+		reqBody.IP = String(opts.IP.String())
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/attach_to_network", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerActionAttachToNetworkResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, err
+}
+
+// LoadBalancerDetachFromNetworkOpts specifies options for detaching a Load Balancer from a network.
+type LoadBalancerDetachFromNetworkOpts struct {
+	Network *Network
+}
+
+// DetachFromNetwork detaches a Load Balancer from a network.
+func (c *LoadBalancerClient) DetachFromNetwork(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerDetachFromNetworkOpts) (*Action, *Response, error) {
+	reqBody := schema.LoadBalancerActionDetachFromNetworkRequest{
+		Network: opts.Network.ID,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/detach_from_network", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerActionDetachFromNetworkResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, err
+}
+
+// EnablePublicInterface enables the Load Balancer's public network interface.
+func (c *LoadBalancerClient) EnablePublicInterface(ctx context.Context, loadBalancer *LoadBalancer) (*Action, *Response, error) {
+	path := fmt.Sprintf("/load_balancers/%d/actions/enable_public_interface", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	respBody := schema.LoadBalancerActionEnablePublicInterfaceResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, err
+}
+
+// DisablePublicInterface disables the Load Balancer's public network interface.
+func (c *LoadBalancerClient) DisablePublicInterface(ctx context.Context, loadBalancer *LoadBalancer) (*Action, *Response, error) {
+	path := fmt.Sprintf("/load_balancers/%d/actions/disable_public_interface", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	respBody := schema.LoadBalancerActionDisablePublicInterfaceResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, err
+}
+
+// LoadBalancerChangeTypeOpts specifies options for changing a Load Balancer's type.
+type LoadBalancerChangeTypeOpts struct {
+	LoadBalancerType *LoadBalancerType // new Load Balancer type
+}
+
+// ChangeType changes a Load Balancer's type.
+func (c *LoadBalancerClient) ChangeType(ctx context.Context, loadBalancer *LoadBalancer, opts LoadBalancerChangeTypeOpts) (*Action, *Response, error) {
+	reqBody := schema.LoadBalancerActionChangeTypeRequest{}
+	if opts.LoadBalancerType.ID != 0 {
+		reqBody.LoadBalancerType = opts.LoadBalancerType.ID
+	} else {
+		reqBody.LoadBalancerType = opts.LoadBalancerType.Name
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/change_type", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerActionChangeTypeResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// ChangeDNSPtr changes or resets the reverse DNS pointer for a Load Balancer.
+// Pass a nil ptr to reset the reverse DNS pointer to its default value.
+func (c *LoadBalancerClient) ChangeDNSPtr(ctx context.Context, loadBalancer *LoadBalancer, ip string, ptr *string) (*Action, *Response, error) {
+	reqBody := schema.LoadBalancerActionChangeDNSPtrRequest{
+		IP:     ip,
+		DNSPtr: ptr,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/load_balancers/%d/actions/change_dns_ptr", loadBalancer.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.LoadBalancerActionChangeDNSPtrResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+func validateLoadBalancerTarget(targetType LoadBalancerTargetType, server *Server, labelSelector, ip string) error {
+	switch targetType {
+	case LoadBalancerTargetTypeServer:
+		if server == nil {
+			return errors.New("missing server")
+		}
+	case LoadBalancerTargetTypeLabelSelector:
+		if labelSelector == "" {
+			return errors.New("missing label selector")
+		}
+	case LoadBalancerTargetTypeIP:
+		if net.ParseIP(ip) == nil {
+			return errors.New("missing or invalid IP")
+		}
+	default:
+		return errors.New("missing or invalid target type")
+	}
+	return nil
+}
+
+func loadBalancerTargetToSchema(targetType LoadBalancerTargetType, server *Server, labelSelector, ip string) (*schema.LoadBalancerActionTargetServer, *schema.LoadBalancerActionTargetLabelSelector, *schema.LoadBalancerActionTargetIP) {
+	switch targetType {
+	case LoadBalancerTargetTypeServer:
+		return &schema.LoadBalancerActionTargetServer{ID: server.ID}, nil, nil
+	case LoadBalancerTargetTypeLabelSelector:
+		return nil, &schema.LoadBalancerActionTargetLabelSelector{Selector: labelSelector}, nil
+	case LoadBalancerTargetTypeIP:
+		return nil, nil, &schema.LoadBalancerActionTargetIP{IP: ip}
+	}
+	return nil, nil, nil
+}
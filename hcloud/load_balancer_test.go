@@ -0,0 +1,437 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestLoadBalancerClientGetByID(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancers/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.LoadBalancerGetResponse{
+			LoadBalancer: schema.LoadBalancer{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	loadBalancer, _, err := env.Client.LoadBalancer.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadBalancer == nil {
+		t.Fatal("no Load Balancer")
+	}
+	if loadBalancer.ID != 1 {
+		t.Errorf("unexpected Load Balancer ID: %v", loadBalancer.ID)
+	}
+
+	t.Run("via Get", func(t *testing.T) {
+		loadBalancer, _, err := env.Client.LoadBalancer.Get(ctx, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loadBalancer == nil {
+			t.Fatal("no Load Balancer")
+		}
+		if loadBalancer.ID != 1 {
+			t.Errorf("unexpected Load Balancer ID: %v", loadBalancer.ID)
+		}
+	})
+}
+
+func TestLoadBalancerClientGetByIDNotFound(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancers/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(schema.ErrorResponse{
+			Error: schema.Error{
+				Code: string(ErrorCodeNotFound),
+			},
+		})
+	})
+
+	ctx := context.Background()
+	loadBalancer, _, err := env.Client.LoadBalancer.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadBalancer != nil {
+		t.Fatal("expected no Load Balancer")
+	}
+}
+
+func TestLoadBalancerClientGetByName(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancers", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "name=mylb" {
+			t.Fatal("missing name query")
+		}
+		json.NewEncoder(w).Encode(schema.LoadBalancerListResponse{
+			LoadBalancers: []schema.LoadBalancer{{ID: 1, Name: "mylb"}},
+		})
+	})
+
+	ctx := context.Background()
+	loadBalancer, _, err := env.Client.LoadBalancer.Get(ctx, "mylb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadBalancer == nil {
+		t.Fatal("no Load Balancer")
+	}
+	if loadBalancer.ID != 1 {
+		t.Errorf("unexpected Load Balancer ID: %v", loadBalancer.ID)
+	}
+}
+
+func TestLoadBalancerClientCreate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancers", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		expected := `{"algorithm":{"type":"least_connections"},"labels":{"key":"value"},"load_balancer_type":"lb11","location":"fsn1","name":"mylb","network":4,` +
+			`"services":[{"destination_port":8080,"health_check":{"http":{"path":"/health","status_codes":["2??"]},"interval":15,"protocol":"http","timeout":10},` +
+			`"http":{"certificates":[7],"cookie_lifetime":300,"sticky_sessions":true},"listen_port":443,"protocol":"https"}],` +
+			`"targets":[{"server":{"id":5},"type":"server","use_private_ip":true},{"ip":{"ip":"1.2.3.4"},"type":"ip"}]}`
+		if data, _ := json.Marshal(reqBody); string(data) != expected {
+			t.Errorf("unexpected request body:\n%s\nexpected:\n%s", data, expected)
+		}
+		json.NewEncoder(w).Encode(schema.LoadBalancerCreateResponse{
+			LoadBalancer: schema.LoadBalancer{ID: 1},
+			Action:       schema.Action{ID: 2},
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.LoadBalancer.Create(ctx, LoadBalancerCreateOpts{
+		Name:             "mylb",
+		LoadBalancerType: &LoadBalancerType{Name: "lb11"},
+		Algorithm:        &LoadBalancerAlgorithm{Type: LoadBalancerAlgorithmTypeLeastConnections},
+		Location:         &Location{Name: "fsn1"},
+		Labels:           map[string]string{"key": "value"},
+		Network:          &Network{ID: 4},
+		Targets: []LoadBalancerCreateOptsTarget{
+			{Type: LoadBalancerTargetTypeServer, Server: &Server{ID: 5}, UsePrivateIP: Bool(true)},
+			{Type: LoadBalancerTargetTypeIP, IP: "1.2.3.4"},
+		},
+		Services: []LoadBalancerAddServiceOpts{{
+			Protocol:        LoadBalancerServiceProtocolHTTPS,
+			ListenPort:      Int(443),
+			DestinationPort: Int(8080),
+			HTTP: &LoadBalancerServiceOptsHTTP{
+				CookieLifetime: Duration(5 * time.Minute),
+				Certificates:   []*Certificate{{ID: 7}},
+				StickySessions: Bool(true),
+			},
+			HealthCheck: &LoadBalancerServiceOptsHealthCheck{
+				Protocol: LoadBalancerServiceProtocolHTTP,
+				Interval: Duration(15 * time.Second),
+				Timeout:  Duration(10 * time.Second),
+				HTTP: &LoadBalancerServiceOptsHealthCheckHTTP{
+					Path:        String("/health"),
+					StatusCodes: []string{"2??"},
+				},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.LoadBalancer.ID != 1 {
+		t.Errorf("unexpected Load Balancer ID: %v", result.LoadBalancer.ID)
+	}
+	if result.Action.ID != 2 {
+		t.Errorf("unexpected action ID: %v", result.Action.ID)
+	}
+}
+
+func TestLoadBalancerCreateOptsValidate(t *testing.T) {
+	lbType := &LoadBalancerType{Name: "lb11"}
+	location := &Location{Name: "fsn1"}
+	testCases := map[string]LoadBalancerCreateOpts{
+		"missing name":           {LoadBalancerType: lbType, Location: location},
+		"missing type":           {Name: "lb", Location: location},
+		"missing location":       {Name: "lb", LoadBalancerType: lbType},
+		"location and zone":      {Name: "lb", LoadBalancerType: lbType, Location: location, NetworkZone: NetworkZoneEUCentral},
+		"invalid target":         {Name: "lb", LoadBalancerType: lbType, Location: location, Targets: []LoadBalancerCreateOptsTarget{{Type: LoadBalancerTargetTypeIP, IP: "foo"}}},
+		"tcp without port":       {Name: "lb", LoadBalancerType: lbType, Location: location, Services: []LoadBalancerAddServiceOpts{{Protocol: LoadBalancerServiceProtocolTCP}}},
+		"https without certs":    {Name: "lb", LoadBalancerType: lbType, Location: location, Services: []LoadBalancerAddServiceOpts{{Protocol: LoadBalancerServiceProtocolHTTPS}}},
+		"tcp with http config":   {Name: "lb", LoadBalancerType: lbType, Location: location, Services: []LoadBalancerAddServiceOpts{{Protocol: LoadBalancerServiceProtocolTCP, ListenPort: Int(80), HTTP: &LoadBalancerServiceOptsHTTP{}}}},
+		"invalid health check":   {Name: "lb", LoadBalancerType: lbType, Location: location, Services: []LoadBalancerAddServiceOpts{{Protocol: LoadBalancerServiceProtocolHTTP, HealthCheck: &LoadBalancerServiceOptsHealthCheck{Protocol: "udp"}}}},
+		"timeout above interval": {Name: "lb", LoadBalancerType: lbType, Location: location, Services: []LoadBalancerAddServiceOpts{{Protocol: LoadBalancerServiceProtocolHTTP, HealthCheck: &LoadBalancerServiceOptsHealthCheck{Interval: Duration(time.Second), Timeout: Duration(2 * time.Second)}}}},
+	}
+	for name, opts := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := opts.Validate(); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadBalancerClientUpdate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancers/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Error("expected PUT")
+		}
+		var reqBody schema.LoadBalancerUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name == nil || *reqBody.Name != "test" {
+			t.Errorf("unexpected name: %v", reqBody.Name)
+		}
+		json.NewEncoder(w).Encode(schema.LoadBalancerUpdateResponse{
+			LoadBalancer: schema.LoadBalancer{ID: 1, Name: "test"},
+		})
+	})
+
+	ctx := context.Background()
+	loadBalancer, _, err := env.Client.LoadBalancer.Update(ctx, &LoadBalancer{ID: 1}, LoadBalancerUpdateOpts{Name: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadBalancer.Name != "test" {
+		t.Errorf("unexpected name: %v", loadBalancer.Name)
+	}
+}
+
+func TestLoadBalancerClientDelete(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancers/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Error("expected DELETE")
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := env.Client.LoadBalancer.Delete(ctx, &LoadBalancer{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBalancerClientActions(t *testing.T) {
+	loadBalancer := &LoadBalancer{ID: 1}
+	testCases := []struct {
+		name     string
+		path     string
+		expected string
+		call     func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error)
+	}{
+		{
+			name:     "add server target",
+			path:     "add_target",
+			expected: `{"type":"server","server":{"id":2},"use_private_ip":true}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.AddServerTarget(ctx, loadBalancer, LoadBalancerAddServerTargetOpts{Server: &Server{ID: 2}, UsePrivateIP: Bool(true)})
+			},
+		},
+		{
+			name:     "remove server target",
+			path:     "remove_target",
+			expected: `{"type":"server","server":{"id":2}}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.RemoveServerTarget(ctx, loadBalancer, &Server{ID: 2})
+			},
+		},
+		{
+			name:     "add label selector target",
+			path:     "add_target",
+			expected: `{"type":"label_selector","label_selector":{"selector":"role=web"}}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.AddLabelSelectorTarget(ctx, loadBalancer, LoadBalancerAddLabelSelectorTargetOpts{Selector: "role=web"})
+			},
+		},
+		{
+			name:     "remove label selector target",
+			path:     "remove_target",
+			expected: `{"type":"label_selector","label_selector":{"selector":"role=web"}}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.RemoveLabelSelectorTarget(ctx, loadBalancer, "role=web")
+			},
+		},
+		{
+			name:     "add IP target",
+			path:     "add_target",
+			expected: `{"type":"ip","ip":{"ip":"1.2.3.4"}}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.AddIPTarget(ctx, loadBalancer, LoadBalancerAddIPTargetOpts{IP: net.ParseIP("1.2.3.4")})
+			},
+		},
+		{
+			name:     "remove IP target",
+			path:     "remove_target",
+			expected: `{"type":"ip","ip":{"ip":"1.2.3.4"}}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.RemoveIPTarget(ctx, loadBalancer, net.ParseIP("1.2.3.4"))
+			},
+		},
+		{
+			name:     "add service",
+			path:     "add_service",
+			expected: `{"protocol":"tcp","listen_port":22,"destination_port":2222,"proxyprotocol":true}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.AddService(ctx, loadBalancer, LoadBalancerAddServiceOpts{
+					Protocol:        LoadBalancerServiceProtocolTCP,
+					ListenPort:      Int(22),
+					DestinationPort: Int(2222),
+					Proxyprotocol:   Bool(true),
+				})
+			},
+		},
+		{
+			name:     "update service",
+			path:     "update_service",
+			expected: `{"listen_port":80,"protocol":"http","http":{"redirect_http":true},"health_check":{"retries":5}}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.UpdateService(ctx, loadBalancer, 80, LoadBalancerUpdateServiceOpts{
+					Protocol:    LoadBalancerServiceProtocolHTTP,
+					HTTP:        &LoadBalancerServiceOptsHTTP{RedirectHTTP: Bool(true)},
+					HealthCheck: &LoadBalancerServiceOptsHealthCheck{Retries: Int(5)},
+				})
+			},
+		},
+		{
+			name:     "delete service",
+			path:     "delete_service",
+			expected: `{"listen_port":80}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.DeleteService(ctx, loadBalancer, 80)
+			},
+		},
+		{
+			name:     "change protection",
+			path:     "change_protection",
+			expected: `{"delete":true}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.ChangeProtection(ctx, loadBalancer, LoadBalancerChangeProtectionOpts{Delete: Bool(true)})
+			},
+		},
+		{
+			name:     "change algorithm",
+			path:     "change_algorithm",
+			expected: `{"type":"round_robin"}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.ChangeAlgorithm(ctx, loadBalancer, LoadBalancerChangeAlgorithmOpts{Type: LoadBalancerAlgorithmTypeRoundRobin})
+			},
+		},
+		{
+			name:     "attach to network",
+			path:     "attach_to_network",
+			expected: `{"network":4,"ip":"10.0.0.5"}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.AttachToNetwork(ctx, loadBalancer, LoadBalancerAttachToNetworkOpts{Network: &Network{ID: 4}, IP: net.ParseIP("10.0.0.5")})
+			},
+		},
+		{
+			name:     "detach from network",
+			path:     "detach_from_network",
+			expected: `{"network":4}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.DetachFromNetwork(ctx, loadBalancer, LoadBalancerDetachFromNetworkOpts{Network: &Network{ID: 4}})
+			},
+		},
+		{
+			name: "enable public interface",
+			path: "enable_public_interface",
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.EnablePublicInterface(ctx, loadBalancer)
+			},
+		},
+		{
+			name: "disable public interface",
+			path: "disable_public_interface",
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.DisablePublicInterface(ctx, loadBalancer)
+			},
+		},
+		{
+			name:     "change type",
+			path:     "change_type",
+			expected: `{"load_balancer_type":"lb21"}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.ChangeType(ctx, loadBalancer, LoadBalancerChangeTypeOpts{LoadBalancerType: &LoadBalancerType{Name: "lb21"}})
+			},
+		},
+		{
+			name:     "change DNS ptr",
+			path:     "change_dns_ptr",
+			expected: `{"ip":"1.2.3.4","dns_ptr":"lb.example.com"}`,
+			call: func(ctx context.Context, c *LoadBalancerClient) (*Action, *Response, error) {
+				return c.ChangeDNSPtr(ctx, loadBalancer, "1.2.3.4", String("lb.example.com"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			env := newTestEnv()
+			defer env.Teardown()
+
+			env.Mux.HandleFunc("/load_balancers/1/actions/"+testCase.path, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Error("expected POST")
+				}
+				if testCase.expected != "" {
+					var buf bytes.Buffer
+					buf.ReadFrom(r.Body)
+					if got := strings.TrimSpace(buf.String()); got != testCase.expected {
+						t.Errorf("unexpected request body:\n%s\nexpected:\n%s", got, testCase.expected)
+					}
+				}
+				json.NewEncoder(w).Encode(struct {
+					Action schema.Action `json:"action"`
+				}{
+					Action: schema.Action{ID: 1},
+				})
+			})
+
+			action, _, err := testCase.call(context.Background(), &env.Client.LoadBalancer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if action.ID != 1 {
+				t.Errorf("unexpected action ID: %d", action.ID)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerPrivateNetFor(t *testing.T) {
+	loadBalancer := &LoadBalancer{
+		PrivateNet: []LoadBalancerPrivateNet{
+			{Network: &Network{ID: 1}, IP: net.ParseIP("10.0.0.2")},
+			{Network: &Network{ID: 2}, IP: net.ParseIP("10.1.0.2")},
+		},
+	}
+	if n := loadBalancer.PrivateNetFor(&Network{ID: 2}); n == nil || n.IP.String() != "10.1.0.2" {
+		t.Errorf("unexpected private network: %v", n)
+	}
+	if n := loadBalancer.PrivateNetFor(&Network{ID: 3}); n != nil {
+		t.Errorf("unexpected private network: %v", n)
+	}
+}
@@ -0,0 +1,59 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestLoadBalancerTypeClientGetByID(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancer_types/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.LoadBalancerTypeGetResponse{
+			LoadBalancerType: schema.LoadBalancerType{ID: 1, Name: "lb11"},
+		})
+	})
+
+	ctx := context.Background()
+	loadBalancerType, _, err := env.Client.LoadBalancerType.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadBalancerType == nil {
+		t.Fatal("no Load Balancer type")
+	}
+	if loadBalancerType.ID != 1 {
+		t.Errorf("unexpected Load Balancer type ID: %v", loadBalancerType.ID)
+	}
+}
+
+func TestLoadBalancerTypeClientGetByName(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/load_balancer_types", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "name=lb11" {
+			t.Fatal("missing name query")
+		}
+		json.NewEncoder(w).Encode(schema.LoadBalancerTypeListResponse{
+			LoadBalancerTypes: []schema.LoadBalancerType{{ID: 1, Name: "lb11"}},
+		})
+	})
+
+	ctx := context.Background()
+	loadBalancerType, _, err := env.Client.LoadBalancerType.Get(ctx, "lb11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loadBalancerType == nil {
+		t.Fatal("no Load Balancer type")
+	}
+	if loadBalancerType.ID != 1 {
+		t.Errorf("unexpected Load Balancer type ID: %v", loadBalancerType.ID)
+	}
+}
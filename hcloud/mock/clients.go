@@ -0,0 +1,2690 @@
+// Code generated by internal/mockgen; DO NOT EDIT.
+
+package mock
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+// ActionClient is a mock implementation of hcloud.ActionAPI.
+type ActionClient struct {
+	Recorder
+
+	GetByIDFunc       func(ctx context.Context, id int) (*hcloud.Action, *hcloud.Response, error)
+	ListFunc          func(ctx context.Context, opts hcloud.ActionListOpts) ([]*hcloud.Action, *hcloud.Response, error)
+	AllFunc           func(ctx context.Context) ([]*hcloud.Action, error)
+	WatchProgressFunc func(ctx context.Context, action *hcloud.Action) (<-chan int, <-chan error)
+}
+
+var _ hcloud.ActionAPI = (*ActionClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *ActionClient) GetByID(ctx context.Context, id int) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ActionClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *ActionClient) List(ctx context.Context, opts hcloud.ActionListOpts) ([]*hcloud.Action, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ActionClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *ActionClient) All(ctx context.Context) ([]*hcloud.Action, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Action
+		return r0, notProgrammed("ActionClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// WatchProgress records the call and returns the result of WatchProgressFunc.
+func (m *ActionClient) WatchProgress(ctx context.Context, action *hcloud.Action) (<-chan int, <-chan error) {
+	m.record("WatchProgress", ctx, action)
+	if m.WatchProgressFunc == nil {
+		panic(notProgrammed("ActionClient", "WatchProgress"))
+	}
+	return m.WatchProgressFunc(ctx, action)
+}
+
+// CertificateClient is a mock implementation of hcloud.CertificateAPI.
+type CertificateClient struct {
+	Recorder
+
+	GetByIDFunc           func(ctx context.Context, id int) (*hcloud.Certificate, *hcloud.Response, error)
+	GetByNameFunc         func(ctx context.Context, name string) (*hcloud.Certificate, *hcloud.Response, error)
+	GetFunc               func(ctx context.Context, idOrName string) (*hcloud.Certificate, *hcloud.Response, error)
+	ListFunc              func(ctx context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, *hcloud.Response, error)
+	AllFunc               func(ctx context.Context) ([]*hcloud.Certificate, error)
+	AllWithOptsFunc       func(ctx context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, error)
+	AllExpiringWithinFunc func(ctx context.Context, opts hcloud.CertificateListOpts, window time.Duration) ([]*hcloud.Certificate, error)
+	CreateFunc            func(ctx context.Context, opts hcloud.CertificateCreateOpts) (hcloud.CertificateCreateResult, *hcloud.Response, error)
+	UpdateFunc            func(ctx context.Context, certificate *hcloud.Certificate, opts hcloud.CertificateUpdateOpts) (*hcloud.Certificate, *hcloud.Response, error)
+	DeleteFunc            func(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Response, error)
+	RetryIssuanceFunc     func(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.CertificateAPI = (*CertificateClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *CertificateClient) GetByID(ctx context.Context, id int) (*hcloud.Certificate, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Certificate
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *CertificateClient) GetByName(ctx context.Context, name string) (*hcloud.Certificate, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Certificate
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *CertificateClient) Get(ctx context.Context, idOrName string) (*hcloud.Certificate, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Certificate
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *CertificateClient) List(ctx context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Certificate
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *CertificateClient) All(ctx context.Context) ([]*hcloud.Certificate, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Certificate
+		return r0, notProgrammed("CertificateClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *CertificateClient) AllWithOpts(ctx context.Context, opts hcloud.CertificateListOpts) ([]*hcloud.Certificate, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.Certificate
+		return r0, notProgrammed("CertificateClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// AllExpiringWithin records the call and returns the result of AllExpiringWithinFunc.
+func (m *CertificateClient) AllExpiringWithin(ctx context.Context, opts hcloud.CertificateListOpts, window time.Duration) ([]*hcloud.Certificate, error) {
+	m.record("AllExpiringWithin", ctx, opts, window)
+	if m.AllExpiringWithinFunc == nil {
+		var r0 []*hcloud.Certificate
+		return r0, notProgrammed("CertificateClient", "AllExpiringWithin")
+	}
+	return m.AllExpiringWithinFunc(ctx, opts, window)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *CertificateClient) Create(ctx context.Context, opts hcloud.CertificateCreateOpts) (hcloud.CertificateCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.CertificateCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *CertificateClient) Update(ctx context.Context, certificate *hcloud.Certificate, opts hcloud.CertificateUpdateOpts) (*hcloud.Certificate, *hcloud.Response, error) {
+	m.record("Update", ctx, certificate, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.Certificate
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "Update")
+	}
+	return m.UpdateFunc(ctx, certificate, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *CertificateClient) Delete(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Response, error) {
+	m.record("Delete", ctx, certificate)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("CertificateClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, certificate)
+}
+
+// RetryIssuance records the call and returns the result of RetryIssuanceFunc.
+func (m *CertificateClient) RetryIssuance(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("RetryIssuance", ctx, certificate)
+	if m.RetryIssuanceFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("CertificateClient", "RetryIssuance")
+	}
+	return m.RetryIssuanceFunc(ctx, certificate)
+}
+
+// DatacenterClient is a mock implementation of hcloud.DatacenterAPI.
+type DatacenterClient struct {
+	Recorder
+
+	GetByIDFunc   func(ctx context.Context, id int) (*hcloud.Datacenter, *hcloud.Response, error)
+	GetByNameFunc func(ctx context.Context, name string) (*hcloud.Datacenter, *hcloud.Response, error)
+	GetFunc       func(ctx context.Context, idOrName string) (*hcloud.Datacenter, *hcloud.Response, error)
+	ListFunc      func(ctx context.Context, opts hcloud.DatacenterListOpts) ([]*hcloud.Datacenter, *hcloud.Response, error)
+	AllFunc       func(ctx context.Context) ([]*hcloud.Datacenter, error)
+}
+
+var _ hcloud.DatacenterAPI = (*DatacenterClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *DatacenterClient) GetByID(ctx context.Context, id int) (*hcloud.Datacenter, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Datacenter
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DatacenterClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *DatacenterClient) GetByName(ctx context.Context, name string) (*hcloud.Datacenter, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Datacenter
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DatacenterClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *DatacenterClient) Get(ctx context.Context, idOrName string) (*hcloud.Datacenter, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Datacenter
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DatacenterClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *DatacenterClient) List(ctx context.Context, opts hcloud.DatacenterListOpts) ([]*hcloud.Datacenter, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Datacenter
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DatacenterClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *DatacenterClient) All(ctx context.Context) ([]*hcloud.Datacenter, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Datacenter
+		return r0, notProgrammed("DatacenterClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// FirewallClient is a mock implementation of hcloud.FirewallAPI.
+type FirewallClient struct {
+	Recorder
+
+	GetByIDFunc         func(ctx context.Context, id int) (*hcloud.Firewall, *hcloud.Response, error)
+	GetByNameFunc       func(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error)
+	GetFunc             func(ctx context.Context, idOrName string) (*hcloud.Firewall, *hcloud.Response, error)
+	ListFunc            func(ctx context.Context, opts hcloud.FirewallListOpts) ([]*hcloud.Firewall, *hcloud.Response, error)
+	AllFunc             func(ctx context.Context) ([]*hcloud.Firewall, error)
+	AllWithOptsFunc     func(ctx context.Context, opts hcloud.FirewallListOpts) ([]*hcloud.Firewall, error)
+	CreateFunc          func(ctx context.Context, opts hcloud.FirewallCreateOpts) (hcloud.FirewallCreateResult, *hcloud.Response, error)
+	UpdateFunc          func(ctx context.Context, firewall *hcloud.Firewall, opts hcloud.FirewallUpdateOpts) (*hcloud.Firewall, *hcloud.Response, error)
+	DeleteFunc          func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error)
+	SetRulesFunc        func(ctx context.Context, firewall *hcloud.Firewall, opts hcloud.FirewallSetRulesOpts) ([]*hcloud.Action, *hcloud.Response, error)
+	ApplyResourcesFunc  func(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error)
+	RemoveResourcesFunc func(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.FirewallAPI = (*FirewallClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *FirewallClient) GetByID(ctx context.Context, id int) (*hcloud.Firewall, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Firewall
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *FirewallClient) GetByName(ctx context.Context, name string) (*hcloud.Firewall, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Firewall
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *FirewallClient) Get(ctx context.Context, idOrName string) (*hcloud.Firewall, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Firewall
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *FirewallClient) List(ctx context.Context, opts hcloud.FirewallListOpts) ([]*hcloud.Firewall, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Firewall
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *FirewallClient) All(ctx context.Context) ([]*hcloud.Firewall, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Firewall
+		return r0, notProgrammed("FirewallClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *FirewallClient) AllWithOpts(ctx context.Context, opts hcloud.FirewallListOpts) ([]*hcloud.Firewall, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.Firewall
+		return r0, notProgrammed("FirewallClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *FirewallClient) Create(ctx context.Context, opts hcloud.FirewallCreateOpts) (hcloud.FirewallCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.FirewallCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *FirewallClient) Update(ctx context.Context, firewall *hcloud.Firewall, opts hcloud.FirewallUpdateOpts) (*hcloud.Firewall, *hcloud.Response, error) {
+	m.record("Update", ctx, firewall, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.Firewall
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "Update")
+	}
+	return m.UpdateFunc(ctx, firewall, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *FirewallClient) Delete(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Response, error) {
+	m.record("Delete", ctx, firewall)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("FirewallClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, firewall)
+}
+
+// SetRules records the call and returns the result of SetRulesFunc.
+func (m *FirewallClient) SetRules(ctx context.Context, firewall *hcloud.Firewall, opts hcloud.FirewallSetRulesOpts) ([]*hcloud.Action, *hcloud.Response, error) {
+	m.record("SetRules", ctx, firewall, opts)
+	if m.SetRulesFunc == nil {
+		var r0 []*hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "SetRules")
+	}
+	return m.SetRulesFunc(ctx, firewall, opts)
+}
+
+// ApplyResources records the call and returns the result of ApplyResourcesFunc.
+func (m *FirewallClient) ApplyResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	m.record("ApplyResources", ctx, firewall, resources)
+	if m.ApplyResourcesFunc == nil {
+		var r0 []*hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "ApplyResources")
+	}
+	return m.ApplyResourcesFunc(ctx, firewall, resources)
+}
+
+// RemoveResources records the call and returns the result of RemoveResourcesFunc.
+func (m *FirewallClient) RemoveResources(ctx context.Context, firewall *hcloud.Firewall, resources []hcloud.FirewallResource) ([]*hcloud.Action, *hcloud.Response, error) {
+	m.record("RemoveResources", ctx, firewall, resources)
+	if m.RemoveResourcesFunc == nil {
+		var r0 []*hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FirewallClient", "RemoveResources")
+	}
+	return m.RemoveResourcesFunc(ctx, firewall, resources)
+}
+
+// FloatingIPClient is a mock implementation of hcloud.FloatingIPAPI.
+type FloatingIPClient struct {
+	Recorder
+
+	GetByIDFunc          func(ctx context.Context, id int) (*hcloud.FloatingIP, *hcloud.Response, error)
+	GetByNameFunc        func(ctx context.Context, name string) (*hcloud.FloatingIP, *hcloud.Response, error)
+	GetFunc              func(ctx context.Context, idOrName string) (*hcloud.FloatingIP, *hcloud.Response, error)
+	ListFunc             func(ctx context.Context, opts hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, *hcloud.Response, error)
+	AllFunc              func(ctx context.Context) ([]*hcloud.FloatingIP, error)
+	AllWithOptsFunc      func(ctx context.Context, opts hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, error)
+	CreateFunc           func(ctx context.Context, opts hcloud.FloatingIPCreateOpts) (hcloud.FloatingIPCreateResult, *hcloud.Response, error)
+	DeleteFunc           func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Response, error)
+	UpdateFunc           func(ctx context.Context, floatingIP *hcloud.FloatingIP, opts hcloud.FloatingIPUpdateOpts) (*hcloud.FloatingIP, *hcloud.Response, error)
+	AssignFunc           func(ctx context.Context, floatingIP *hcloud.FloatingIP, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	UnassignFunc         func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, *hcloud.Response, error)
+	ChangeDNSPtrFunc     func(ctx context.Context, floatingIP *hcloud.FloatingIP, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error)
+	ChangeProtectionFunc func(ctx context.Context, floatingIP *hcloud.FloatingIP, opts hcloud.FloatingIPChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.FloatingIPAPI = (*FloatingIPClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *FloatingIPClient) GetByID(ctx context.Context, id int) (*hcloud.FloatingIP, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.FloatingIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *FloatingIPClient) GetByName(ctx context.Context, name string) (*hcloud.FloatingIP, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.FloatingIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *FloatingIPClient) Get(ctx context.Context, idOrName string) (*hcloud.FloatingIP, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.FloatingIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *FloatingIPClient) List(ctx context.Context, opts hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.FloatingIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *FloatingIPClient) All(ctx context.Context) ([]*hcloud.FloatingIP, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.FloatingIP
+		return r0, notProgrammed("FloatingIPClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *FloatingIPClient) AllWithOpts(ctx context.Context, opts hcloud.FloatingIPListOpts) ([]*hcloud.FloatingIP, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.FloatingIP
+		return r0, notProgrammed("FloatingIPClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *FloatingIPClient) Create(ctx context.Context, opts hcloud.FloatingIPCreateOpts) (hcloud.FloatingIPCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.FloatingIPCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *FloatingIPClient) Delete(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Response, error) {
+	m.record("Delete", ctx, floatingIP)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("FloatingIPClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, floatingIP)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *FloatingIPClient) Update(ctx context.Context, floatingIP *hcloud.FloatingIP, opts hcloud.FloatingIPUpdateOpts) (*hcloud.FloatingIP, *hcloud.Response, error) {
+	m.record("Update", ctx, floatingIP, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.FloatingIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "Update")
+	}
+	return m.UpdateFunc(ctx, floatingIP, opts)
+}
+
+// Assign records the call and returns the result of AssignFunc.
+func (m *FloatingIPClient) Assign(ctx context.Context, floatingIP *hcloud.FloatingIP, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Assign", ctx, floatingIP, server)
+	if m.AssignFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "Assign")
+	}
+	return m.AssignFunc(ctx, floatingIP, server)
+}
+
+// Unassign records the call and returns the result of UnassignFunc.
+func (m *FloatingIPClient) Unassign(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Unassign", ctx, floatingIP)
+	if m.UnassignFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "Unassign")
+	}
+	return m.UnassignFunc(ctx, floatingIP)
+}
+
+// ChangeDNSPtr records the call and returns the result of ChangeDNSPtrFunc.
+func (m *FloatingIPClient) ChangeDNSPtr(ctx context.Context, floatingIP *hcloud.FloatingIP, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeDNSPtr", ctx, floatingIP, ip, ptr)
+	if m.ChangeDNSPtrFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "ChangeDNSPtr")
+	}
+	return m.ChangeDNSPtrFunc(ctx, floatingIP, ip, ptr)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *FloatingIPClient) ChangeProtection(ctx context.Context, floatingIP *hcloud.FloatingIP, opts hcloud.FloatingIPChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, floatingIP, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("FloatingIPClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, floatingIP, opts)
+}
+
+// ImageClient is a mock implementation of hcloud.ImageAPI.
+type ImageClient struct {
+	Recorder
+
+	GetByIDFunc          func(ctx context.Context, id int) (*hcloud.Image, *hcloud.Response, error)
+	GetByNameFunc        func(ctx context.Context, name string) (*hcloud.Image, *hcloud.Response, error)
+	GetFunc              func(ctx context.Context, idOrName string) (*hcloud.Image, *hcloud.Response, error)
+	ListFunc             func(ctx context.Context, opts hcloud.ImageListOpts) ([]*hcloud.Image, *hcloud.Response, error)
+	AllFunc              func(ctx context.Context) ([]*hcloud.Image, error)
+	AllWithOptsFunc      func(ctx context.Context, opts hcloud.ImageListOpts) ([]*hcloud.Image, error)
+	DeleteFunc           func(ctx context.Context, image *hcloud.Image) (*hcloud.Response, error)
+	UpdateFunc           func(ctx context.Context, image *hcloud.Image, opts hcloud.ImageUpdateOpts) (*hcloud.Image, *hcloud.Response, error)
+	ChangeProtectionFunc func(ctx context.Context, image *hcloud.Image, opts hcloud.ImageChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.ImageAPI = (*ImageClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *ImageClient) GetByID(ctx context.Context, id int) (*hcloud.Image, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Image
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ImageClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *ImageClient) GetByName(ctx context.Context, name string) (*hcloud.Image, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Image
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ImageClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *ImageClient) Get(ctx context.Context, idOrName string) (*hcloud.Image, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Image
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ImageClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *ImageClient) List(ctx context.Context, opts hcloud.ImageListOpts) ([]*hcloud.Image, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Image
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ImageClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *ImageClient) All(ctx context.Context) ([]*hcloud.Image, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Image
+		return r0, notProgrammed("ImageClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *ImageClient) AllWithOpts(ctx context.Context, opts hcloud.ImageListOpts) ([]*hcloud.Image, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.Image
+		return r0, notProgrammed("ImageClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *ImageClient) Delete(ctx context.Context, image *hcloud.Image) (*hcloud.Response, error) {
+	m.record("Delete", ctx, image)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("ImageClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, image)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *ImageClient) Update(ctx context.Context, image *hcloud.Image, opts hcloud.ImageUpdateOpts) (*hcloud.Image, *hcloud.Response, error) {
+	m.record("Update", ctx, image, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.Image
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ImageClient", "Update")
+	}
+	return m.UpdateFunc(ctx, image, opts)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *ImageClient) ChangeProtection(ctx context.Context, image *hcloud.Image, opts hcloud.ImageChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, image, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ImageClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, image, opts)
+}
+
+// ISOClient is a mock implementation of hcloud.ISOAPI.
+type ISOClient struct {
+	Recorder
+
+	GetByIDFunc   func(ctx context.Context, id int) (*hcloud.ISO, *hcloud.Response, error)
+	GetByNameFunc func(ctx context.Context, name string) (*hcloud.ISO, *hcloud.Response, error)
+	GetFunc       func(ctx context.Context, idOrName string) (*hcloud.ISO, *hcloud.Response, error)
+	ListFunc      func(ctx context.Context, opts hcloud.ISOListOpts) ([]*hcloud.ISO, *hcloud.Response, error)
+	AllFunc       func(ctx context.Context) ([]*hcloud.ISO, error)
+}
+
+var _ hcloud.ISOAPI = (*ISOClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *ISOClient) GetByID(ctx context.Context, id int) (*hcloud.ISO, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.ISO
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ISOClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *ISOClient) GetByName(ctx context.Context, name string) (*hcloud.ISO, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.ISO
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ISOClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *ISOClient) Get(ctx context.Context, idOrName string) (*hcloud.ISO, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.ISO
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ISOClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *ISOClient) List(ctx context.Context, opts hcloud.ISOListOpts) ([]*hcloud.ISO, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.ISO
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ISOClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *ISOClient) All(ctx context.Context) ([]*hcloud.ISO, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.ISO
+		return r0, notProgrammed("ISOClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// LoadBalancerClient is a mock implementation of hcloud.LoadBalancerAPI.
+type LoadBalancerClient struct {
+	Recorder
+
+	GetByIDFunc                   func(ctx context.Context, id int) (*hcloud.LoadBalancer, *hcloud.Response, error)
+	GetByNameFunc                 func(ctx context.Context, name string) (*hcloud.LoadBalancer, *hcloud.Response, error)
+	GetFunc                       func(ctx context.Context, idOrName string) (*hcloud.LoadBalancer, *hcloud.Response, error)
+	ListFunc                      func(ctx context.Context, opts hcloud.LoadBalancerListOpts) ([]*hcloud.LoadBalancer, *hcloud.Response, error)
+	AllFunc                       func(ctx context.Context) ([]*hcloud.LoadBalancer, error)
+	AllWithOptsFunc               func(ctx context.Context, opts hcloud.LoadBalancerListOpts) ([]*hcloud.LoadBalancer, error)
+	UpdateFunc                    func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerUpdateOpts) (*hcloud.LoadBalancer, *hcloud.Response, error)
+	CreateFunc                    func(ctx context.Context, opts hcloud.LoadBalancerCreateOpts) (hcloud.LoadBalancerCreateResult, *hcloud.Response, error)
+	DeleteFunc                    func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Response, error)
+	AddServerTargetFunc           func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddServerTargetOpts) (*hcloud.Action, *hcloud.Response, error)
+	RemoveServerTargetFunc        func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	AddLabelSelectorTargetFunc    func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddLabelSelectorTargetOpts) (*hcloud.Action, *hcloud.Response, error)
+	RemoveLabelSelectorTargetFunc func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, labelSelector string) (*hcloud.Action, *hcloud.Response, error)
+	AddIPTargetFunc               func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddIPTargetOpts) (*hcloud.Action, *hcloud.Response, error)
+	RemoveIPTargetFunc            func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, ip net.IP) (*hcloud.Action, *hcloud.Response, error)
+	AddServiceFunc                func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddServiceOpts) (*hcloud.Action, *hcloud.Response, error)
+	UpdateServiceFunc             func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, listenPort int, opts hcloud.LoadBalancerUpdateServiceOpts) (*hcloud.Action, *hcloud.Response, error)
+	DeleteServiceFunc             func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, listenPort int) (*hcloud.Action, *hcloud.Response, error)
+	ChangeProtectionFunc          func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+	ChangeAlgorithmFunc           func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerChangeAlgorithmOpts) (*hcloud.Action, *hcloud.Response, error)
+	AttachToNetworkFunc           func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAttachToNetworkOpts) (*hcloud.Action, *hcloud.Response, error)
+	DetachFromNetworkFunc         func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerDetachFromNetworkOpts) (*hcloud.Action, *hcloud.Response, error)
+	EnablePublicInterfaceFunc     func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, *hcloud.Response, error)
+	DisablePublicInterfaceFunc    func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, *hcloud.Response, error)
+	ChangeTypeFunc                func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error)
+	ChangeDNSPtrFunc              func(ctx context.Context, loadBalancer *hcloud.LoadBalancer, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.LoadBalancerAPI = (*LoadBalancerClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *LoadBalancerClient) GetByID(ctx context.Context, id int) (*hcloud.LoadBalancer, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.LoadBalancer
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *LoadBalancerClient) GetByName(ctx context.Context, name string) (*hcloud.LoadBalancer, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.LoadBalancer
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *LoadBalancerClient) Get(ctx context.Context, idOrName string) (*hcloud.LoadBalancer, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.LoadBalancer
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *LoadBalancerClient) List(ctx context.Context, opts hcloud.LoadBalancerListOpts) ([]*hcloud.LoadBalancer, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.LoadBalancer
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *LoadBalancerClient) All(ctx context.Context) ([]*hcloud.LoadBalancer, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.LoadBalancer
+		return r0, notProgrammed("LoadBalancerClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *LoadBalancerClient) AllWithOpts(ctx context.Context, opts hcloud.LoadBalancerListOpts) ([]*hcloud.LoadBalancer, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.LoadBalancer
+		return r0, notProgrammed("LoadBalancerClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *LoadBalancerClient) Update(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerUpdateOpts) (*hcloud.LoadBalancer, *hcloud.Response, error) {
+	m.record("Update", ctx, loadBalancer, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.LoadBalancer
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "Update")
+	}
+	return m.UpdateFunc(ctx, loadBalancer, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *LoadBalancerClient) Create(ctx context.Context, opts hcloud.LoadBalancerCreateOpts) (hcloud.LoadBalancerCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.LoadBalancerCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *LoadBalancerClient) Delete(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Response, error) {
+	m.record("Delete", ctx, loadBalancer)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("LoadBalancerClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, loadBalancer)
+}
+
+// AddServerTarget records the call and returns the result of AddServerTargetFunc.
+func (m *LoadBalancerClient) AddServerTarget(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddServerTargetOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddServerTarget", ctx, loadBalancer, opts)
+	if m.AddServerTargetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "AddServerTarget")
+	}
+	return m.AddServerTargetFunc(ctx, loadBalancer, opts)
+}
+
+// RemoveServerTarget records the call and returns the result of RemoveServerTargetFunc.
+func (m *LoadBalancerClient) RemoveServerTarget(ctx context.Context, loadBalancer *hcloud.LoadBalancer, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("RemoveServerTarget", ctx, loadBalancer, server)
+	if m.RemoveServerTargetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "RemoveServerTarget")
+	}
+	return m.RemoveServerTargetFunc(ctx, loadBalancer, server)
+}
+
+// AddLabelSelectorTarget records the call and returns the result of AddLabelSelectorTargetFunc.
+func (m *LoadBalancerClient) AddLabelSelectorTarget(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddLabelSelectorTargetOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddLabelSelectorTarget", ctx, loadBalancer, opts)
+	if m.AddLabelSelectorTargetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "AddLabelSelectorTarget")
+	}
+	return m.AddLabelSelectorTargetFunc(ctx, loadBalancer, opts)
+}
+
+// RemoveLabelSelectorTarget records the call and returns the result of RemoveLabelSelectorTargetFunc.
+func (m *LoadBalancerClient) RemoveLabelSelectorTarget(ctx context.Context, loadBalancer *hcloud.LoadBalancer, labelSelector string) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("RemoveLabelSelectorTarget", ctx, loadBalancer, labelSelector)
+	if m.RemoveLabelSelectorTargetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "RemoveLabelSelectorTarget")
+	}
+	return m.RemoveLabelSelectorTargetFunc(ctx, loadBalancer, labelSelector)
+}
+
+// AddIPTarget records the call and returns the result of AddIPTargetFunc.
+func (m *LoadBalancerClient) AddIPTarget(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddIPTargetOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddIPTarget", ctx, loadBalancer, opts)
+	if m.AddIPTargetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "AddIPTarget")
+	}
+	return m.AddIPTargetFunc(ctx, loadBalancer, opts)
+}
+
+// RemoveIPTarget records the call and returns the result of RemoveIPTargetFunc.
+func (m *LoadBalancerClient) RemoveIPTarget(ctx context.Context, loadBalancer *hcloud.LoadBalancer, ip net.IP) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("RemoveIPTarget", ctx, loadBalancer, ip)
+	if m.RemoveIPTargetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "RemoveIPTarget")
+	}
+	return m.RemoveIPTargetFunc(ctx, loadBalancer, ip)
+}
+
+// AddService records the call and returns the result of AddServiceFunc.
+func (m *LoadBalancerClient) AddService(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAddServiceOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddService", ctx, loadBalancer, opts)
+	if m.AddServiceFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "AddService")
+	}
+	return m.AddServiceFunc(ctx, loadBalancer, opts)
+}
+
+// UpdateService records the call and returns the result of UpdateServiceFunc.
+func (m *LoadBalancerClient) UpdateService(ctx context.Context, loadBalancer *hcloud.LoadBalancer, listenPort int, opts hcloud.LoadBalancerUpdateServiceOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("UpdateService", ctx, loadBalancer, listenPort, opts)
+	if m.UpdateServiceFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "UpdateService")
+	}
+	return m.UpdateServiceFunc(ctx, loadBalancer, listenPort, opts)
+}
+
+// DeleteService records the call and returns the result of DeleteServiceFunc.
+func (m *LoadBalancerClient) DeleteService(ctx context.Context, loadBalancer *hcloud.LoadBalancer, listenPort int) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DeleteService", ctx, loadBalancer, listenPort)
+	if m.DeleteServiceFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "DeleteService")
+	}
+	return m.DeleteServiceFunc(ctx, loadBalancer, listenPort)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *LoadBalancerClient) ChangeProtection(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, loadBalancer, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, loadBalancer, opts)
+}
+
+// ChangeAlgorithm records the call and returns the result of ChangeAlgorithmFunc.
+func (m *LoadBalancerClient) ChangeAlgorithm(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerChangeAlgorithmOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeAlgorithm", ctx, loadBalancer, opts)
+	if m.ChangeAlgorithmFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "ChangeAlgorithm")
+	}
+	return m.ChangeAlgorithmFunc(ctx, loadBalancer, opts)
+}
+
+// AttachToNetwork records the call and returns the result of AttachToNetworkFunc.
+func (m *LoadBalancerClient) AttachToNetwork(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerAttachToNetworkOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AttachToNetwork", ctx, loadBalancer, opts)
+	if m.AttachToNetworkFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "AttachToNetwork")
+	}
+	return m.AttachToNetworkFunc(ctx, loadBalancer, opts)
+}
+
+// DetachFromNetwork records the call and returns the result of DetachFromNetworkFunc.
+func (m *LoadBalancerClient) DetachFromNetwork(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerDetachFromNetworkOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DetachFromNetwork", ctx, loadBalancer, opts)
+	if m.DetachFromNetworkFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "DetachFromNetwork")
+	}
+	return m.DetachFromNetworkFunc(ctx, loadBalancer, opts)
+}
+
+// EnablePublicInterface records the call and returns the result of EnablePublicInterfaceFunc.
+func (m *LoadBalancerClient) EnablePublicInterface(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("EnablePublicInterface", ctx, loadBalancer)
+	if m.EnablePublicInterfaceFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "EnablePublicInterface")
+	}
+	return m.EnablePublicInterfaceFunc(ctx, loadBalancer)
+}
+
+// DisablePublicInterface records the call and returns the result of DisablePublicInterfaceFunc.
+func (m *LoadBalancerClient) DisablePublicInterface(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DisablePublicInterface", ctx, loadBalancer)
+	if m.DisablePublicInterfaceFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "DisablePublicInterface")
+	}
+	return m.DisablePublicInterfaceFunc(ctx, loadBalancer)
+}
+
+// ChangeType records the call and returns the result of ChangeTypeFunc.
+func (m *LoadBalancerClient) ChangeType(ctx context.Context, loadBalancer *hcloud.LoadBalancer, opts hcloud.LoadBalancerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeType", ctx, loadBalancer, opts)
+	if m.ChangeTypeFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "ChangeType")
+	}
+	return m.ChangeTypeFunc(ctx, loadBalancer, opts)
+}
+
+// ChangeDNSPtr records the call and returns the result of ChangeDNSPtrFunc.
+func (m *LoadBalancerClient) ChangeDNSPtr(ctx context.Context, loadBalancer *hcloud.LoadBalancer, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeDNSPtr", ctx, loadBalancer, ip, ptr)
+	if m.ChangeDNSPtrFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerClient", "ChangeDNSPtr")
+	}
+	return m.ChangeDNSPtrFunc(ctx, loadBalancer, ip, ptr)
+}
+
+// LoadBalancerTypeClient is a mock implementation of hcloud.LoadBalancerTypeAPI.
+type LoadBalancerTypeClient struct {
+	Recorder
+
+	GetByIDFunc   func(ctx context.Context, id int) (*hcloud.LoadBalancerType, *hcloud.Response, error)
+	GetByNameFunc func(ctx context.Context, name string) (*hcloud.LoadBalancerType, *hcloud.Response, error)
+	GetFunc       func(ctx context.Context, idOrName string) (*hcloud.LoadBalancerType, *hcloud.Response, error)
+	ListFunc      func(ctx context.Context, opts hcloud.LoadBalancerTypeListOpts) ([]*hcloud.LoadBalancerType, *hcloud.Response, error)
+	AllFunc       func(ctx context.Context) ([]*hcloud.LoadBalancerType, error)
+}
+
+var _ hcloud.LoadBalancerTypeAPI = (*LoadBalancerTypeClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *LoadBalancerTypeClient) GetByID(ctx context.Context, id int) (*hcloud.LoadBalancerType, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.LoadBalancerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerTypeClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *LoadBalancerTypeClient) GetByName(ctx context.Context, name string) (*hcloud.LoadBalancerType, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.LoadBalancerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerTypeClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *LoadBalancerTypeClient) Get(ctx context.Context, idOrName string) (*hcloud.LoadBalancerType, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.LoadBalancerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerTypeClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *LoadBalancerTypeClient) List(ctx context.Context, opts hcloud.LoadBalancerTypeListOpts) ([]*hcloud.LoadBalancerType, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.LoadBalancerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LoadBalancerTypeClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *LoadBalancerTypeClient) All(ctx context.Context) ([]*hcloud.LoadBalancerType, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.LoadBalancerType
+		return r0, notProgrammed("LoadBalancerTypeClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// LocationClient is a mock implementation of hcloud.LocationAPI.
+type LocationClient struct {
+	Recorder
+
+	GetByIDFunc   func(ctx context.Context, id int) (*hcloud.Location, *hcloud.Response, error)
+	GetByNameFunc func(ctx context.Context, name string) (*hcloud.Location, *hcloud.Response, error)
+	GetFunc       func(ctx context.Context, idOrName string) (*hcloud.Location, *hcloud.Response, error)
+	ListFunc      func(ctx context.Context, opts hcloud.LocationListOpts) ([]*hcloud.Location, *hcloud.Response, error)
+	AllFunc       func(ctx context.Context) ([]*hcloud.Location, error)
+}
+
+var _ hcloud.LocationAPI = (*LocationClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *LocationClient) GetByID(ctx context.Context, id int) (*hcloud.Location, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Location
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LocationClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *LocationClient) GetByName(ctx context.Context, name string) (*hcloud.Location, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Location
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LocationClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *LocationClient) Get(ctx context.Context, idOrName string) (*hcloud.Location, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Location
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LocationClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *LocationClient) List(ctx context.Context, opts hcloud.LocationListOpts) ([]*hcloud.Location, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Location
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("LocationClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *LocationClient) All(ctx context.Context) ([]*hcloud.Location, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Location
+		return r0, notProgrammed("LocationClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// NetworkClient is a mock implementation of hcloud.NetworkAPI.
+type NetworkClient struct {
+	Recorder
+
+	GetByIDFunc          func(ctx context.Context, id int) (*hcloud.Network, *hcloud.Response, error)
+	GetByNameFunc        func(ctx context.Context, name string) (*hcloud.Network, *hcloud.Response, error)
+	GetFunc              func(ctx context.Context, idOrName string) (*hcloud.Network, *hcloud.Response, error)
+	ListFunc             func(ctx context.Context, opts hcloud.NetworkListOpts) ([]*hcloud.Network, *hcloud.Response, error)
+	AllFunc              func(ctx context.Context) ([]*hcloud.Network, error)
+	AllWithOptsFunc      func(ctx context.Context, opts hcloud.NetworkListOpts) ([]*hcloud.Network, error)
+	DeleteFunc           func(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error)
+	UpdateFunc           func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkUpdateOpts) (*hcloud.Network, *hcloud.Response, error)
+	CreateFunc           func(ctx context.Context, opts hcloud.NetworkCreateOpts) (*hcloud.Network, *hcloud.Response, error)
+	ChangeIPRangeFunc    func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkChangeIPRangeOpts) (*hcloud.Action, *hcloud.Response, error)
+	AddSubnetFunc        func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkAddSubnetOpts) (*hcloud.Action, *hcloud.Response, error)
+	DeleteSubnetFunc     func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkDeleteSubnetOpts) (*hcloud.Action, *hcloud.Response, error)
+	AddRouteFunc         func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkAddRouteOpts) (*hcloud.Action, *hcloud.Response, error)
+	DeleteRouteFunc      func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkDeleteRouteOpts) (*hcloud.Action, *hcloud.Response, error)
+	ChangeProtectionFunc func(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+	IPAMFunc             func(ctx context.Context, network *hcloud.Network) (*hcloud.NetworkIPAM, error)
+	TopologyFunc         func(ctx context.Context, network *hcloud.Network) (*hcloud.NetworkTopology, error)
+}
+
+var _ hcloud.NetworkAPI = (*NetworkClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *NetworkClient) GetByID(ctx context.Context, id int) (*hcloud.Network, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Network
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *NetworkClient) GetByName(ctx context.Context, name string) (*hcloud.Network, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Network
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *NetworkClient) Get(ctx context.Context, idOrName string) (*hcloud.Network, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Network
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *NetworkClient) List(ctx context.Context, opts hcloud.NetworkListOpts) ([]*hcloud.Network, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Network
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *NetworkClient) All(ctx context.Context) ([]*hcloud.Network, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Network
+		return r0, notProgrammed("NetworkClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *NetworkClient) AllWithOpts(ctx context.Context, opts hcloud.NetworkListOpts) ([]*hcloud.Network, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.Network
+		return r0, notProgrammed("NetworkClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *NetworkClient) Delete(ctx context.Context, network *hcloud.Network) (*hcloud.Response, error) {
+	m.record("Delete", ctx, network)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("NetworkClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, network)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *NetworkClient) Update(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkUpdateOpts) (*hcloud.Network, *hcloud.Response, error) {
+	m.record("Update", ctx, network, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.Network
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "Update")
+	}
+	return m.UpdateFunc(ctx, network, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *NetworkClient) Create(ctx context.Context, opts hcloud.NetworkCreateOpts) (*hcloud.Network, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 *hcloud.Network
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// ChangeIPRange records the call and returns the result of ChangeIPRangeFunc.
+func (m *NetworkClient) ChangeIPRange(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkChangeIPRangeOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeIPRange", ctx, network, opts)
+	if m.ChangeIPRangeFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "ChangeIPRange")
+	}
+	return m.ChangeIPRangeFunc(ctx, network, opts)
+}
+
+// AddSubnet records the call and returns the result of AddSubnetFunc.
+func (m *NetworkClient) AddSubnet(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkAddSubnetOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddSubnet", ctx, network, opts)
+	if m.AddSubnetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "AddSubnet")
+	}
+	return m.AddSubnetFunc(ctx, network, opts)
+}
+
+// DeleteSubnet records the call and returns the result of DeleteSubnetFunc.
+func (m *NetworkClient) DeleteSubnet(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkDeleteSubnetOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DeleteSubnet", ctx, network, opts)
+	if m.DeleteSubnetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "DeleteSubnet")
+	}
+	return m.DeleteSubnetFunc(ctx, network, opts)
+}
+
+// AddRoute records the call and returns the result of AddRouteFunc.
+func (m *NetworkClient) AddRoute(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkAddRouteOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddRoute", ctx, network, opts)
+	if m.AddRouteFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "AddRoute")
+	}
+	return m.AddRouteFunc(ctx, network, opts)
+}
+
+// DeleteRoute records the call and returns the result of DeleteRouteFunc.
+func (m *NetworkClient) DeleteRoute(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkDeleteRouteOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DeleteRoute", ctx, network, opts)
+	if m.DeleteRouteFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "DeleteRoute")
+	}
+	return m.DeleteRouteFunc(ctx, network, opts)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *NetworkClient) ChangeProtection(ctx context.Context, network *hcloud.Network, opts hcloud.NetworkChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, network, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("NetworkClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, network, opts)
+}
+
+// IPAM records the call and returns the result of IPAMFunc.
+func (m *NetworkClient) IPAM(ctx context.Context, network *hcloud.Network) (*hcloud.NetworkIPAM, error) {
+	m.record("IPAM", ctx, network)
+	if m.IPAMFunc == nil {
+		var r0 *hcloud.NetworkIPAM
+		return r0, notProgrammed("NetworkClient", "IPAM")
+	}
+	return m.IPAMFunc(ctx, network)
+}
+
+// Topology records the call and returns the result of TopologyFunc.
+func (m *NetworkClient) Topology(ctx context.Context, network *hcloud.Network) (*hcloud.NetworkTopology, error) {
+	m.record("Topology", ctx, network)
+	if m.TopologyFunc == nil {
+		var r0 *hcloud.NetworkTopology
+		return r0, notProgrammed("NetworkClient", "Topology")
+	}
+	return m.TopologyFunc(ctx, network)
+}
+
+// PlacementGroupClient is a mock implementation of hcloud.PlacementGroupAPI.
+type PlacementGroupClient struct {
+	Recorder
+
+	GetByIDFunc     func(ctx context.Context, id int) (*hcloud.PlacementGroup, *hcloud.Response, error)
+	GetByNameFunc   func(ctx context.Context, name string) (*hcloud.PlacementGroup, *hcloud.Response, error)
+	GetFunc         func(ctx context.Context, idOrName string) (*hcloud.PlacementGroup, *hcloud.Response, error)
+	ListFunc        func(ctx context.Context, opts hcloud.PlacementGroupListOpts) ([]*hcloud.PlacementGroup, *hcloud.Response, error)
+	AllFunc         func(ctx context.Context) ([]*hcloud.PlacementGroup, error)
+	AllWithOptsFunc func(ctx context.Context, opts hcloud.PlacementGroupListOpts) ([]*hcloud.PlacementGroup, error)
+	CreateFunc      func(ctx context.Context, opts hcloud.PlacementGroupCreateOpts) (hcloud.PlacementGroupCreateResult, *hcloud.Response, error)
+	UpdateFunc      func(ctx context.Context, placementGroup *hcloud.PlacementGroup, opts hcloud.PlacementGroupUpdateOpts) (*hcloud.PlacementGroup, *hcloud.Response, error)
+	DeleteFunc      func(ctx context.Context, placementGroup *hcloud.PlacementGroup) (*hcloud.Response, error)
+}
+
+var _ hcloud.PlacementGroupAPI = (*PlacementGroupClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *PlacementGroupClient) GetByID(ctx context.Context, id int) (*hcloud.PlacementGroup, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.PlacementGroup
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PlacementGroupClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *PlacementGroupClient) GetByName(ctx context.Context, name string) (*hcloud.PlacementGroup, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.PlacementGroup
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PlacementGroupClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *PlacementGroupClient) Get(ctx context.Context, idOrName string) (*hcloud.PlacementGroup, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.PlacementGroup
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PlacementGroupClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *PlacementGroupClient) List(ctx context.Context, opts hcloud.PlacementGroupListOpts) ([]*hcloud.PlacementGroup, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.PlacementGroup
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PlacementGroupClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *PlacementGroupClient) All(ctx context.Context) ([]*hcloud.PlacementGroup, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.PlacementGroup
+		return r0, notProgrammed("PlacementGroupClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *PlacementGroupClient) AllWithOpts(ctx context.Context, opts hcloud.PlacementGroupListOpts) ([]*hcloud.PlacementGroup, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.PlacementGroup
+		return r0, notProgrammed("PlacementGroupClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *PlacementGroupClient) Create(ctx context.Context, opts hcloud.PlacementGroupCreateOpts) (hcloud.PlacementGroupCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.PlacementGroupCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PlacementGroupClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *PlacementGroupClient) Update(ctx context.Context, placementGroup *hcloud.PlacementGroup, opts hcloud.PlacementGroupUpdateOpts) (*hcloud.PlacementGroup, *hcloud.Response, error) {
+	m.record("Update", ctx, placementGroup, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.PlacementGroup
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PlacementGroupClient", "Update")
+	}
+	return m.UpdateFunc(ctx, placementGroup, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *PlacementGroupClient) Delete(ctx context.Context, placementGroup *hcloud.PlacementGroup) (*hcloud.Response, error) {
+	m.record("Delete", ctx, placementGroup)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("PlacementGroupClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, placementGroup)
+}
+
+// PricingClient is a mock implementation of hcloud.PricingAPI.
+type PricingClient struct {
+	Recorder
+
+	GetFunc func(ctx context.Context) (hcloud.Pricing, *hcloud.Response, error)
+}
+
+var _ hcloud.PricingAPI = (*PricingClient)(nil)
+
+// Get records the call and returns the result of GetFunc.
+func (m *PricingClient) Get(ctx context.Context) (hcloud.Pricing, *hcloud.Response, error) {
+	m.record("Get", ctx)
+	if m.GetFunc == nil {
+		var r0 hcloud.Pricing
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PricingClient", "Get")
+	}
+	return m.GetFunc(ctx)
+}
+
+// PrimaryIPClient is a mock implementation of hcloud.PrimaryIPAPI.
+type PrimaryIPClient struct {
+	Recorder
+
+	GetByIDFunc          func(ctx context.Context, id int) (*hcloud.PrimaryIP, *hcloud.Response, error)
+	GetByNameFunc        func(ctx context.Context, name string) (*hcloud.PrimaryIP, *hcloud.Response, error)
+	GetByIPFunc          func(ctx context.Context, ip string) (*hcloud.PrimaryIP, *hcloud.Response, error)
+	GetFunc              func(ctx context.Context, idOrName string) (*hcloud.PrimaryIP, *hcloud.Response, error)
+	ListFunc             func(ctx context.Context, opts hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, *hcloud.Response, error)
+	AllFunc              func(ctx context.Context) ([]*hcloud.PrimaryIP, error)
+	AllWithOptsFunc      func(ctx context.Context, opts hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, error)
+	CreateFunc           func(ctx context.Context, opts hcloud.PrimaryIPCreateOpts) (hcloud.PrimaryIPCreateResult, *hcloud.Response, error)
+	DeleteFunc           func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Response, error)
+	UpdateFunc           func(ctx context.Context, primaryIP *hcloud.PrimaryIP, opts hcloud.PrimaryIPUpdateOpts) (*hcloud.PrimaryIP, *hcloud.Response, error)
+	AssignFunc           func(ctx context.Context, primaryIP *hcloud.PrimaryIP, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	UnassignFunc         func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, *hcloud.Response, error)
+	ChangeDNSPtrFunc     func(ctx context.Context, primaryIP *hcloud.PrimaryIP, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error)
+	ChangeProtectionFunc func(ctx context.Context, primaryIP *hcloud.PrimaryIP, opts hcloud.PrimaryIPChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.PrimaryIPAPI = (*PrimaryIPClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *PrimaryIPClient) GetByID(ctx context.Context, id int) (*hcloud.PrimaryIP, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.PrimaryIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *PrimaryIPClient) GetByName(ctx context.Context, name string) (*hcloud.PrimaryIP, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.PrimaryIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// GetByIP records the call and returns the result of GetByIPFunc.
+func (m *PrimaryIPClient) GetByIP(ctx context.Context, ip string) (*hcloud.PrimaryIP, *hcloud.Response, error) {
+	m.record("GetByIP", ctx, ip)
+	if m.GetByIPFunc == nil {
+		var r0 *hcloud.PrimaryIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "GetByIP")
+	}
+	return m.GetByIPFunc(ctx, ip)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *PrimaryIPClient) Get(ctx context.Context, idOrName string) (*hcloud.PrimaryIP, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.PrimaryIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *PrimaryIPClient) List(ctx context.Context, opts hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.PrimaryIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *PrimaryIPClient) All(ctx context.Context) ([]*hcloud.PrimaryIP, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.PrimaryIP
+		return r0, notProgrammed("PrimaryIPClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *PrimaryIPClient) AllWithOpts(ctx context.Context, opts hcloud.PrimaryIPListOpts) ([]*hcloud.PrimaryIP, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.PrimaryIP
+		return r0, notProgrammed("PrimaryIPClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *PrimaryIPClient) Create(ctx context.Context, opts hcloud.PrimaryIPCreateOpts) (hcloud.PrimaryIPCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.PrimaryIPCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *PrimaryIPClient) Delete(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Response, error) {
+	m.record("Delete", ctx, primaryIP)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("PrimaryIPClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, primaryIP)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *PrimaryIPClient) Update(ctx context.Context, primaryIP *hcloud.PrimaryIP, opts hcloud.PrimaryIPUpdateOpts) (*hcloud.PrimaryIP, *hcloud.Response, error) {
+	m.record("Update", ctx, primaryIP, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.PrimaryIP
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "Update")
+	}
+	return m.UpdateFunc(ctx, primaryIP, opts)
+}
+
+// Assign records the call and returns the result of AssignFunc.
+func (m *PrimaryIPClient) Assign(ctx context.Context, primaryIP *hcloud.PrimaryIP, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Assign", ctx, primaryIP, server)
+	if m.AssignFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "Assign")
+	}
+	return m.AssignFunc(ctx, primaryIP, server)
+}
+
+// Unassign records the call and returns the result of UnassignFunc.
+func (m *PrimaryIPClient) Unassign(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Unassign", ctx, primaryIP)
+	if m.UnassignFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "Unassign")
+	}
+	return m.UnassignFunc(ctx, primaryIP)
+}
+
+// ChangeDNSPtr records the call and returns the result of ChangeDNSPtrFunc.
+func (m *PrimaryIPClient) ChangeDNSPtr(ctx context.Context, primaryIP *hcloud.PrimaryIP, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeDNSPtr", ctx, primaryIP, ip, ptr)
+	if m.ChangeDNSPtrFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "ChangeDNSPtr")
+	}
+	return m.ChangeDNSPtrFunc(ctx, primaryIP, ip, ptr)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *PrimaryIPClient) ChangeProtection(ctx context.Context, primaryIP *hcloud.PrimaryIP, opts hcloud.PrimaryIPChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, primaryIP, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("PrimaryIPClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, primaryIP, opts)
+}
+
+// ServerClient is a mock implementation of hcloud.ServerAPI.
+type ServerClient struct {
+	Recorder
+
+	GetByIDFunc                  func(ctx context.Context, id int) (*hcloud.Server, *hcloud.Response, error)
+	GetByNameFunc                func(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error)
+	GetFunc                      func(ctx context.Context, idOrName string) (*hcloud.Server, *hcloud.Response, error)
+	ListFunc                     func(ctx context.Context, opts hcloud.ServerListOpts) ([]*hcloud.Server, *hcloud.Response, error)
+	AllFunc                      func(ctx context.Context) ([]*hcloud.Server, error)
+	AllWithOptsFunc              func(ctx context.Context, opts hcloud.ServerListOpts) ([]*hcloud.Server, error)
+	CreateFunc                   func(ctx context.Context, opts hcloud.ServerCreateOpts) (hcloud.ServerCreateResult, *hcloud.Response, error)
+	DeleteFunc                   func(ctx context.Context, server *hcloud.Server) (*hcloud.Response, error)
+	UpdateFunc                   func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerUpdateOpts) (*hcloud.Server, *hcloud.Response, error)
+	PoweronFunc                  func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	RebootFunc                   func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ResetFunc                    func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ShutdownFunc                 func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	PoweroffFunc                 func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ResetPasswordFunc            func(ctx context.Context, server *hcloud.Server) (hcloud.ServerResetPasswordResult, *hcloud.Response, error)
+	CreateImageFunc              func(ctx context.Context, server *hcloud.Server, opts *hcloud.ServerCreateImageOpts) (hcloud.ServerCreateImageResult, *hcloud.Response, error)
+	EnableRescueFunc             func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (hcloud.ServerEnableRescueResult, *hcloud.Response, error)
+	DisableRescueFunc            func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	RebuildFunc                  func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerRebuildOpts) (*hcloud.Action, *hcloud.Response, error)
+	AttachISOFunc                func(ctx context.Context, server *hcloud.Server, iso *hcloud.ISO) (*hcloud.Action, *hcloud.Response, error)
+	DetachISOFunc                func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	EnableBackupFunc             func(ctx context.Context, server *hcloud.Server, window string) (*hcloud.Action, *hcloud.Response, error)
+	DisableBackupFunc            func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	ChangeTypeFunc               func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error)
+	ChangeDNSPtrFunc             func(ctx context.Context, server *hcloud.Server, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error)
+	ChangeProtectionFunc         func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+	AttachToNetworkFunc          func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerAttachToNetworkOpts) (*hcloud.Action, *hcloud.Response, error)
+	DetachFromNetworkFunc        func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerDetachFromNetworkOpts) (*hcloud.Action, *hcloud.Response, error)
+	ChangeAliasIPsFunc           func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeAliasIPsOpts) (*hcloud.Action, *hcloud.Response, error)
+	AddToPlacementGroupFunc      func(ctx context.Context, server *hcloud.Server, placementGroup *hcloud.PlacementGroup) (*hcloud.Action, *hcloud.Response, error)
+	RemoveFromPlacementGroupFunc func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	CloneFunc                    func(ctx context.Context, source *hcloud.Server, opts hcloud.ServerCloneOpts) (hcloud.ServerCloneResult, error)
+	RequestConsoleFunc           func(ctx context.Context, server *hcloud.Server) (hcloud.ServerRequestConsoleResult, *hcloud.Response, error)
+	GetMetricsFunc               func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerGetMetricsOpts) (*hcloud.ServerMetrics, *hcloud.Response, error)
+	PlanMigrationFunc            func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerMigrationOpts) (*hcloud.ServerMigration, error)
+	ResumeMigrationFunc          func(state hcloud.ServerMigrationState) *hcloud.ServerMigration
+	StartRescueFunc              func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.RescueSession, error)
+	WithRescueFunc               func(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts, fn func(*hcloud.RescueSession) error) error
+	RollingRebuildFunc           func(ctx context.Context, opts hcloud.ServerRollingRebuildOpts) (hcloud.ServerRollingRebuildResult, error)
+}
+
+var _ hcloud.ServerAPI = (*ServerClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *ServerClient) GetByID(ctx context.Context, id int) (*hcloud.Server, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Server
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *ServerClient) GetByName(ctx context.Context, name string) (*hcloud.Server, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Server
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *ServerClient) Get(ctx context.Context, idOrName string) (*hcloud.Server, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Server
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *ServerClient) List(ctx context.Context, opts hcloud.ServerListOpts) ([]*hcloud.Server, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Server
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *ServerClient) All(ctx context.Context) ([]*hcloud.Server, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Server
+		return r0, notProgrammed("ServerClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *ServerClient) AllWithOpts(ctx context.Context, opts hcloud.ServerListOpts) ([]*hcloud.Server, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.Server
+		return r0, notProgrammed("ServerClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *ServerClient) Create(ctx context.Context, opts hcloud.ServerCreateOpts) (hcloud.ServerCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.ServerCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *ServerClient) Delete(ctx context.Context, server *hcloud.Server) (*hcloud.Response, error) {
+	m.record("Delete", ctx, server)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("ServerClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, server)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *ServerClient) Update(ctx context.Context, server *hcloud.Server, opts hcloud.ServerUpdateOpts) (*hcloud.Server, *hcloud.Response, error) {
+	m.record("Update", ctx, server, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.Server
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Update")
+	}
+	return m.UpdateFunc(ctx, server, opts)
+}
+
+// Poweron records the call and returns the result of PoweronFunc.
+func (m *ServerClient) Poweron(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Poweron", ctx, server)
+	if m.PoweronFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Poweron")
+	}
+	return m.PoweronFunc(ctx, server)
+}
+
+// Reboot records the call and returns the result of RebootFunc.
+func (m *ServerClient) Reboot(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Reboot", ctx, server)
+	if m.RebootFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Reboot")
+	}
+	return m.RebootFunc(ctx, server)
+}
+
+// Reset records the call and returns the result of ResetFunc.
+func (m *ServerClient) Reset(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Reset", ctx, server)
+	if m.ResetFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Reset")
+	}
+	return m.ResetFunc(ctx, server)
+}
+
+// Shutdown records the call and returns the result of ShutdownFunc.
+func (m *ServerClient) Shutdown(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Shutdown", ctx, server)
+	if m.ShutdownFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Shutdown")
+	}
+	return m.ShutdownFunc(ctx, server)
+}
+
+// Poweroff records the call and returns the result of PoweroffFunc.
+func (m *ServerClient) Poweroff(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Poweroff", ctx, server)
+	if m.PoweroffFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Poweroff")
+	}
+	return m.PoweroffFunc(ctx, server)
+}
+
+// ResetPassword records the call and returns the result of ResetPasswordFunc.
+func (m *ServerClient) ResetPassword(ctx context.Context, server *hcloud.Server) (hcloud.ServerResetPasswordResult, *hcloud.Response, error) {
+	m.record("ResetPassword", ctx, server)
+	if m.ResetPasswordFunc == nil {
+		var r0 hcloud.ServerResetPasswordResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "ResetPassword")
+	}
+	return m.ResetPasswordFunc(ctx, server)
+}
+
+// CreateImage records the call and returns the result of CreateImageFunc.
+func (m *ServerClient) CreateImage(ctx context.Context, server *hcloud.Server, opts *hcloud.ServerCreateImageOpts) (hcloud.ServerCreateImageResult, *hcloud.Response, error) {
+	m.record("CreateImage", ctx, server, opts)
+	if m.CreateImageFunc == nil {
+		var r0 hcloud.ServerCreateImageResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "CreateImage")
+	}
+	return m.CreateImageFunc(ctx, server, opts)
+}
+
+// EnableRescue records the call and returns the result of EnableRescueFunc.
+func (m *ServerClient) EnableRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (hcloud.ServerEnableRescueResult, *hcloud.Response, error) {
+	m.record("EnableRescue", ctx, server, opts)
+	if m.EnableRescueFunc == nil {
+		var r0 hcloud.ServerEnableRescueResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "EnableRescue")
+	}
+	return m.EnableRescueFunc(ctx, server, opts)
+}
+
+// DisableRescue records the call and returns the result of DisableRescueFunc.
+func (m *ServerClient) DisableRescue(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DisableRescue", ctx, server)
+	if m.DisableRescueFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "DisableRescue")
+	}
+	return m.DisableRescueFunc(ctx, server)
+}
+
+// Rebuild records the call and returns the result of RebuildFunc.
+func (m *ServerClient) Rebuild(ctx context.Context, server *hcloud.Server, opts hcloud.ServerRebuildOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Rebuild", ctx, server, opts)
+	if m.RebuildFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "Rebuild")
+	}
+	return m.RebuildFunc(ctx, server, opts)
+}
+
+// AttachISO records the call and returns the result of AttachISOFunc.
+func (m *ServerClient) AttachISO(ctx context.Context, server *hcloud.Server, iso *hcloud.ISO) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AttachISO", ctx, server, iso)
+	if m.AttachISOFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "AttachISO")
+	}
+	return m.AttachISOFunc(ctx, server, iso)
+}
+
+// DetachISO records the call and returns the result of DetachISOFunc.
+func (m *ServerClient) DetachISO(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DetachISO", ctx, server)
+	if m.DetachISOFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "DetachISO")
+	}
+	return m.DetachISOFunc(ctx, server)
+}
+
+// EnableBackup records the call and returns the result of EnableBackupFunc.
+func (m *ServerClient) EnableBackup(ctx context.Context, server *hcloud.Server, window string) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("EnableBackup", ctx, server, window)
+	if m.EnableBackupFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "EnableBackup")
+	}
+	return m.EnableBackupFunc(ctx, server, window)
+}
+
+// DisableBackup records the call and returns the result of DisableBackupFunc.
+func (m *ServerClient) DisableBackup(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DisableBackup", ctx, server)
+	if m.DisableBackupFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "DisableBackup")
+	}
+	return m.DisableBackupFunc(ctx, server)
+}
+
+// ChangeType records the call and returns the result of ChangeTypeFunc.
+func (m *ServerClient) ChangeType(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeTypeOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeType", ctx, server, opts)
+	if m.ChangeTypeFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "ChangeType")
+	}
+	return m.ChangeTypeFunc(ctx, server, opts)
+}
+
+// ChangeDNSPtr records the call and returns the result of ChangeDNSPtrFunc.
+func (m *ServerClient) ChangeDNSPtr(ctx context.Context, server *hcloud.Server, ip string, ptr *string) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeDNSPtr", ctx, server, ip, ptr)
+	if m.ChangeDNSPtrFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "ChangeDNSPtr")
+	}
+	return m.ChangeDNSPtrFunc(ctx, server, ip, ptr)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *ServerClient) ChangeProtection(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, server, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, server, opts)
+}
+
+// AttachToNetwork records the call and returns the result of AttachToNetworkFunc.
+func (m *ServerClient) AttachToNetwork(ctx context.Context, server *hcloud.Server, opts hcloud.ServerAttachToNetworkOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AttachToNetwork", ctx, server, opts)
+	if m.AttachToNetworkFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "AttachToNetwork")
+	}
+	return m.AttachToNetworkFunc(ctx, server, opts)
+}
+
+// DetachFromNetwork records the call and returns the result of DetachFromNetworkFunc.
+func (m *ServerClient) DetachFromNetwork(ctx context.Context, server *hcloud.Server, opts hcloud.ServerDetachFromNetworkOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("DetachFromNetwork", ctx, server, opts)
+	if m.DetachFromNetworkFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "DetachFromNetwork")
+	}
+	return m.DetachFromNetworkFunc(ctx, server, opts)
+}
+
+// ChangeAliasIPs records the call and returns the result of ChangeAliasIPsFunc.
+func (m *ServerClient) ChangeAliasIPs(ctx context.Context, server *hcloud.Server, opts hcloud.ServerChangeAliasIPsOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeAliasIPs", ctx, server, opts)
+	if m.ChangeAliasIPsFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "ChangeAliasIPs")
+	}
+	return m.ChangeAliasIPsFunc(ctx, server, opts)
+}
+
+// AddToPlacementGroup records the call and returns the result of AddToPlacementGroupFunc.
+func (m *ServerClient) AddToPlacementGroup(ctx context.Context, server *hcloud.Server, placementGroup *hcloud.PlacementGroup) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AddToPlacementGroup", ctx, server, placementGroup)
+	if m.AddToPlacementGroupFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "AddToPlacementGroup")
+	}
+	return m.AddToPlacementGroupFunc(ctx, server, placementGroup)
+}
+
+// RemoveFromPlacementGroup records the call and returns the result of RemoveFromPlacementGroupFunc.
+func (m *ServerClient) RemoveFromPlacementGroup(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("RemoveFromPlacementGroup", ctx, server)
+	if m.RemoveFromPlacementGroupFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "RemoveFromPlacementGroup")
+	}
+	return m.RemoveFromPlacementGroupFunc(ctx, server)
+}
+
+// Clone records the call and returns the result of CloneFunc.
+func (m *ServerClient) Clone(ctx context.Context, source *hcloud.Server, opts hcloud.ServerCloneOpts) (hcloud.ServerCloneResult, error) {
+	m.record("Clone", ctx, source, opts)
+	if m.CloneFunc == nil {
+		var r0 hcloud.ServerCloneResult
+		return r0, notProgrammed("ServerClient", "Clone")
+	}
+	return m.CloneFunc(ctx, source, opts)
+}
+
+// RequestConsole records the call and returns the result of RequestConsoleFunc.
+func (m *ServerClient) RequestConsole(ctx context.Context, server *hcloud.Server) (hcloud.ServerRequestConsoleResult, *hcloud.Response, error) {
+	m.record("RequestConsole", ctx, server)
+	if m.RequestConsoleFunc == nil {
+		var r0 hcloud.ServerRequestConsoleResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "RequestConsole")
+	}
+	return m.RequestConsoleFunc(ctx, server)
+}
+
+// GetMetrics records the call and returns the result of GetMetricsFunc.
+func (m *ServerClient) GetMetrics(ctx context.Context, server *hcloud.Server, opts hcloud.ServerGetMetricsOpts) (*hcloud.ServerMetrics, *hcloud.Response, error) {
+	m.record("GetMetrics", ctx, server, opts)
+	if m.GetMetricsFunc == nil {
+		var r0 *hcloud.ServerMetrics
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerClient", "GetMetrics")
+	}
+	return m.GetMetricsFunc(ctx, server, opts)
+}
+
+// PlanMigration records the call and returns the result of PlanMigrationFunc.
+func (m *ServerClient) PlanMigration(ctx context.Context, server *hcloud.Server, opts hcloud.ServerMigrationOpts) (*hcloud.ServerMigration, error) {
+	m.record("PlanMigration", ctx, server, opts)
+	if m.PlanMigrationFunc == nil {
+		var r0 *hcloud.ServerMigration
+		return r0, notProgrammed("ServerClient", "PlanMigration")
+	}
+	return m.PlanMigrationFunc(ctx, server, opts)
+}
+
+// ResumeMigration records the call and returns the result of ResumeMigrationFunc.
+func (m *ServerClient) ResumeMigration(state hcloud.ServerMigrationState) *hcloud.ServerMigration {
+	m.record("ResumeMigration", state)
+	if m.ResumeMigrationFunc == nil {
+		panic(notProgrammed("ServerClient", "ResumeMigration"))
+	}
+	return m.ResumeMigrationFunc(state)
+}
+
+// StartRescue records the call and returns the result of StartRescueFunc.
+func (m *ServerClient) StartRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts) (*hcloud.RescueSession, error) {
+	m.record("StartRescue", ctx, server, opts)
+	if m.StartRescueFunc == nil {
+		var r0 *hcloud.RescueSession
+		return r0, notProgrammed("ServerClient", "StartRescue")
+	}
+	return m.StartRescueFunc(ctx, server, opts)
+}
+
+// WithRescue records the call and returns the result of WithRescueFunc.
+func (m *ServerClient) WithRescue(ctx context.Context, server *hcloud.Server, opts hcloud.ServerEnableRescueOpts, fn func(*hcloud.RescueSession) error) error {
+	m.record("WithRescue", ctx, server, opts, fn)
+	if m.WithRescueFunc == nil {
+		return notProgrammed("ServerClient", "WithRescue")
+	}
+	return m.WithRescueFunc(ctx, server, opts, fn)
+}
+
+// RollingRebuild records the call and returns the result of RollingRebuildFunc.
+func (m *ServerClient) RollingRebuild(ctx context.Context, opts hcloud.ServerRollingRebuildOpts) (hcloud.ServerRollingRebuildResult, error) {
+	m.record("RollingRebuild", ctx, opts)
+	if m.RollingRebuildFunc == nil {
+		var r0 hcloud.ServerRollingRebuildResult
+		return r0, notProgrammed("ServerClient", "RollingRebuild")
+	}
+	return m.RollingRebuildFunc(ctx, opts)
+}
+
+// ServerTypeClient is a mock implementation of hcloud.ServerTypeAPI.
+type ServerTypeClient struct {
+	Recorder
+
+	GetByIDFunc   func(ctx context.Context, id int) (*hcloud.ServerType, *hcloud.Response, error)
+	GetByNameFunc func(ctx context.Context, name string) (*hcloud.ServerType, *hcloud.Response, error)
+	GetFunc       func(ctx context.Context, idOrName string) (*hcloud.ServerType, *hcloud.Response, error)
+	ListFunc      func(ctx context.Context, opts hcloud.ServerTypeListOpts) ([]*hcloud.ServerType, *hcloud.Response, error)
+	AllFunc       func(ctx context.Context) ([]*hcloud.ServerType, error)
+}
+
+var _ hcloud.ServerTypeAPI = (*ServerTypeClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *ServerTypeClient) GetByID(ctx context.Context, id int) (*hcloud.ServerType, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.ServerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerTypeClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *ServerTypeClient) GetByName(ctx context.Context, name string) (*hcloud.ServerType, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.ServerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerTypeClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *ServerTypeClient) Get(ctx context.Context, idOrName string) (*hcloud.ServerType, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.ServerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerTypeClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *ServerTypeClient) List(ctx context.Context, opts hcloud.ServerTypeListOpts) ([]*hcloud.ServerType, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.ServerType
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("ServerTypeClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *ServerTypeClient) All(ctx context.Context) ([]*hcloud.ServerType, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.ServerType
+		return r0, notProgrammed("ServerTypeClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// SSHKeyClient is a mock implementation of hcloud.SSHKeyAPI.
+type SSHKeyClient struct {
+	Recorder
+
+	GetByIDFunc          func(ctx context.Context, id int) (*hcloud.SSHKey, *hcloud.Response, error)
+	GetByNameFunc        func(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error)
+	GetByFingerprintFunc func(ctx context.Context, fingerprint string) (*hcloud.SSHKey, *hcloud.Response, error)
+	GetFunc              func(ctx context.Context, idOrName string) (*hcloud.SSHKey, *hcloud.Response, error)
+	ListFunc             func(ctx context.Context, opts hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, *hcloud.Response, error)
+	AllFunc              func(ctx context.Context) ([]*hcloud.SSHKey, error)
+	AllWithOptsFunc      func(ctx context.Context, opts hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error)
+	CreateFunc           func(ctx context.Context, opts hcloud.SSHKeyCreateOpts) (*hcloud.SSHKey, *hcloud.Response, error)
+	DeleteFunc           func(ctx context.Context, sshKey *hcloud.SSHKey) (*hcloud.Response, error)
+	UpdateFunc           func(ctx context.Context, sshKey *hcloud.SSHKey, opts hcloud.SSHKeyUpdateOpts) (*hcloud.SSHKey, *hcloud.Response, error)
+}
+
+var _ hcloud.SSHKeyAPI = (*SSHKeyClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *SSHKeyClient) GetByID(ctx context.Context, id int) (*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *SSHKeyClient) GetByName(ctx context.Context, name string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// GetByFingerprint records the call and returns the result of GetByFingerprintFunc.
+func (m *SSHKeyClient) GetByFingerprint(ctx context.Context, fingerprint string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("GetByFingerprint", ctx, fingerprint)
+	if m.GetByFingerprintFunc == nil {
+		var r0 *hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "GetByFingerprint")
+	}
+	return m.GetByFingerprintFunc(ctx, fingerprint)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *SSHKeyClient) Get(ctx context.Context, idOrName string) (*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *SSHKeyClient) List(ctx context.Context, opts hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *SSHKeyClient) All(ctx context.Context) ([]*hcloud.SSHKey, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.SSHKey
+		return r0, notProgrammed("SSHKeyClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *SSHKeyClient) AllWithOpts(ctx context.Context, opts hcloud.SSHKeyListOpts) ([]*hcloud.SSHKey, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.SSHKey
+		return r0, notProgrammed("SSHKeyClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *SSHKeyClient) Create(ctx context.Context, opts hcloud.SSHKeyCreateOpts) (*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 *hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *SSHKeyClient) Delete(ctx context.Context, sshKey *hcloud.SSHKey) (*hcloud.Response, error) {
+	m.record("Delete", ctx, sshKey)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("SSHKeyClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, sshKey)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *SSHKeyClient) Update(ctx context.Context, sshKey *hcloud.SSHKey, opts hcloud.SSHKeyUpdateOpts) (*hcloud.SSHKey, *hcloud.Response, error) {
+	m.record("Update", ctx, sshKey, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.SSHKey
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("SSHKeyClient", "Update")
+	}
+	return m.UpdateFunc(ctx, sshKey, opts)
+}
+
+// VolumeClient is a mock implementation of hcloud.VolumeAPI.
+type VolumeClient struct {
+	Recorder
+
+	GetByIDFunc          func(ctx context.Context, id int) (*hcloud.Volume, *hcloud.Response, error)
+	GetByNameFunc        func(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error)
+	GetFunc              func(ctx context.Context, idOrName string) (*hcloud.Volume, *hcloud.Response, error)
+	ListFunc             func(ctx context.Context, opts hcloud.VolumeListOpts) ([]*hcloud.Volume, *hcloud.Response, error)
+	AllFunc              func(ctx context.Context) ([]*hcloud.Volume, error)
+	AllWithOptsFunc      func(ctx context.Context, opts hcloud.VolumeListOpts) ([]*hcloud.Volume, error)
+	CreateFunc           func(ctx context.Context, opts hcloud.VolumeCreateOpts) (hcloud.VolumeCreateResult, *hcloud.Response, error)
+	DeleteFunc           func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error)
+	UpdateFunc           func(ctx context.Context, volume *hcloud.Volume, opts hcloud.VolumeUpdateOpts) (*hcloud.Volume, *hcloud.Response, error)
+	AttachWithOptsFunc   func(ctx context.Context, volume *hcloud.Volume, opts hcloud.VolumeAttachOpts) (*hcloud.Action, *hcloud.Response, error)
+	AttachFunc           func(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)
+	DetachFunc           func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error)
+	ChangeProtectionFunc func(ctx context.Context, volume *hcloud.Volume, opts hcloud.VolumeChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error)
+	ResizeFunc           func(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error)
+}
+
+var _ hcloud.VolumeAPI = (*VolumeClient)(nil)
+
+// GetByID records the call and returns the result of GetByIDFunc.
+func (m *VolumeClient) GetByID(ctx context.Context, id int) (*hcloud.Volume, *hcloud.Response, error) {
+	m.record("GetByID", ctx, id)
+	if m.GetByIDFunc == nil {
+		var r0 *hcloud.Volume
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "GetByID")
+	}
+	return m.GetByIDFunc(ctx, id)
+}
+
+// GetByName records the call and returns the result of GetByNameFunc.
+func (m *VolumeClient) GetByName(ctx context.Context, name string) (*hcloud.Volume, *hcloud.Response, error) {
+	m.record("GetByName", ctx, name)
+	if m.GetByNameFunc == nil {
+		var r0 *hcloud.Volume
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "GetByName")
+	}
+	return m.GetByNameFunc(ctx, name)
+}
+
+// Get records the call and returns the result of GetFunc.
+func (m *VolumeClient) Get(ctx context.Context, idOrName string) (*hcloud.Volume, *hcloud.Response, error) {
+	m.record("Get", ctx, idOrName)
+	if m.GetFunc == nil {
+		var r0 *hcloud.Volume
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "Get")
+	}
+	return m.GetFunc(ctx, idOrName)
+}
+
+// List records the call and returns the result of ListFunc.
+func (m *VolumeClient) List(ctx context.Context, opts hcloud.VolumeListOpts) ([]*hcloud.Volume, *hcloud.Response, error) {
+	m.record("List", ctx, opts)
+	if m.ListFunc == nil {
+		var r0 []*hcloud.Volume
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "List")
+	}
+	return m.ListFunc(ctx, opts)
+}
+
+// All records the call and returns the result of AllFunc.
+func (m *VolumeClient) All(ctx context.Context) ([]*hcloud.Volume, error) {
+	m.record("All", ctx)
+	if m.AllFunc == nil {
+		var r0 []*hcloud.Volume
+		return r0, notProgrammed("VolumeClient", "All")
+	}
+	return m.AllFunc(ctx)
+}
+
+// AllWithOpts records the call and returns the result of AllWithOptsFunc.
+func (m *VolumeClient) AllWithOpts(ctx context.Context, opts hcloud.VolumeListOpts) ([]*hcloud.Volume, error) {
+	m.record("AllWithOpts", ctx, opts)
+	if m.AllWithOptsFunc == nil {
+		var r0 []*hcloud.Volume
+		return r0, notProgrammed("VolumeClient", "AllWithOpts")
+	}
+	return m.AllWithOptsFunc(ctx, opts)
+}
+
+// Create records the call and returns the result of CreateFunc.
+func (m *VolumeClient) Create(ctx context.Context, opts hcloud.VolumeCreateOpts) (hcloud.VolumeCreateResult, *hcloud.Response, error) {
+	m.record("Create", ctx, opts)
+	if m.CreateFunc == nil {
+		var r0 hcloud.VolumeCreateResult
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "Create")
+	}
+	return m.CreateFunc(ctx, opts)
+}
+
+// Delete records the call and returns the result of DeleteFunc.
+func (m *VolumeClient) Delete(ctx context.Context, volume *hcloud.Volume) (*hcloud.Response, error) {
+	m.record("Delete", ctx, volume)
+	if m.DeleteFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("VolumeClient", "Delete")
+	}
+	return m.DeleteFunc(ctx, volume)
+}
+
+// Update records the call and returns the result of UpdateFunc.
+func (m *VolumeClient) Update(ctx context.Context, volume *hcloud.Volume, opts hcloud.VolumeUpdateOpts) (*hcloud.Volume, *hcloud.Response, error) {
+	m.record("Update", ctx, volume, opts)
+	if m.UpdateFunc == nil {
+		var r0 *hcloud.Volume
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "Update")
+	}
+	return m.UpdateFunc(ctx, volume, opts)
+}
+
+// AttachWithOpts records the call and returns the result of AttachWithOptsFunc.
+func (m *VolumeClient) AttachWithOpts(ctx context.Context, volume *hcloud.Volume, opts hcloud.VolumeAttachOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("AttachWithOpts", ctx, volume, opts)
+	if m.AttachWithOptsFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "AttachWithOpts")
+	}
+	return m.AttachWithOptsFunc(ctx, volume, opts)
+}
+
+// Attach records the call and returns the result of AttachFunc.
+func (m *VolumeClient) Attach(ctx context.Context, volume *hcloud.Volume, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Attach", ctx, volume, server)
+	if m.AttachFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "Attach")
+	}
+	return m.AttachFunc(ctx, volume, server)
+}
+
+// Detach records the call and returns the result of DetachFunc.
+func (m *VolumeClient) Detach(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Detach", ctx, volume)
+	if m.DetachFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "Detach")
+	}
+	return m.DetachFunc(ctx, volume)
+}
+
+// ChangeProtection records the call and returns the result of ChangeProtectionFunc.
+func (m *VolumeClient) ChangeProtection(ctx context.Context, volume *hcloud.Volume, opts hcloud.VolumeChangeProtectionOpts) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("ChangeProtection", ctx, volume, opts)
+	if m.ChangeProtectionFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "ChangeProtection")
+	}
+	return m.ChangeProtectionFunc(ctx, volume, opts)
+}
+
+// Resize records the call and returns the result of ResizeFunc.
+func (m *VolumeClient) Resize(ctx context.Context, volume *hcloud.Volume, size int) (*hcloud.Action, *hcloud.Response, error) {
+	m.record("Resize", ctx, volume, size)
+	if m.ResizeFunc == nil {
+		var r0 *hcloud.Action
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("VolumeClient", "Resize")
+	}
+	return m.ResizeFunc(ctx, volume, size)
+}
+
+// DNSServerClient is a mock implementation of hcloud.DNSServerAPI.
+type DNSServerClient struct {
+	Recorder
+
+	NewRequestFunc    func(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error)
+	GetAllRecordsFunc func(ctx context.Context, zoneID string, opts hcloud.RecordListOpts) ([]*hcloud.Record, *hcloud.Response, error)
+	GetRecordFunc     func(ctx context.Context, recordID string) (*hcloud.Record, *hcloud.Response, error)
+	CreateRecordFunc  func(ctx context.Context, record hcloud.CreateOrUpdateRecord) (*hcloud.Record, *hcloud.Response, error)
+	UpdateRecordFunc  func(ctx context.Context, record hcloud.CreateOrUpdateRecord, recordID string) (*hcloud.Record, *hcloud.Response, error)
+	DeleteRecordFunc  func(ctx context.Context, recordID string) (*hcloud.Response, error)
+	GetAllZonesFunc   func(ctx context.Context, opts hcloud.ZoneListOpts) ([]*hcloud.Zone, *hcloud.Response, error)
+	GetZoneFunc       func(ctx context.Context, zoneID string) (*hcloud.Zone, *hcloud.Response, error)
+	UpdateZoneFunc    func(ctx context.Context, zoneID string, updateZoneData hcloud.CreateOrUpdateZone) (*hcloud.Zone, *hcloud.Response, error)
+	DeleteZoneFunc    func(ctx context.Context, zoneID string) (*hcloud.Response, error)
+	CreateZoneFunc    func(ctx context.Context, zone hcloud.CreateOrUpdateZone) (*hcloud.Zone, *hcloud.Response, error)
+}
+
+var _ hcloud.DNSServerAPI = (*DNSServerClient)(nil)
+
+// NewRequest records the call and returns the result of NewRequestFunc.
+func (m *DNSServerClient) NewRequest(ctx context.Context, method string, path string, body io.Reader) (*http.Request, error) {
+	m.record("NewRequest", ctx, method, path, body)
+	if m.NewRequestFunc == nil {
+		var r0 *http.Request
+		return r0, notProgrammed("DNSServerClient", "NewRequest")
+	}
+	return m.NewRequestFunc(ctx, method, path, body)
+}
+
+// GetAllRecords records the call and returns the result of GetAllRecordsFunc.
+func (m *DNSServerClient) GetAllRecords(ctx context.Context, zoneID string, opts hcloud.RecordListOpts) ([]*hcloud.Record, *hcloud.Response, error) {
+	m.record("GetAllRecords", ctx, zoneID, opts)
+	if m.GetAllRecordsFunc == nil {
+		var r0 []*hcloud.Record
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "GetAllRecords")
+	}
+	return m.GetAllRecordsFunc(ctx, zoneID, opts)
+}
+
+// GetRecord records the call and returns the result of GetRecordFunc.
+func (m *DNSServerClient) GetRecord(ctx context.Context, recordID string) (*hcloud.Record, *hcloud.Response, error) {
+	m.record("GetRecord", ctx, recordID)
+	if m.GetRecordFunc == nil {
+		var r0 *hcloud.Record
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "GetRecord")
+	}
+	return m.GetRecordFunc(ctx, recordID)
+}
+
+// CreateRecord records the call and returns the result of CreateRecordFunc.
+func (m *DNSServerClient) CreateRecord(ctx context.Context, record hcloud.CreateOrUpdateRecord) (*hcloud.Record, *hcloud.Response, error) {
+	m.record("CreateRecord", ctx, record)
+	if m.CreateRecordFunc == nil {
+		var r0 *hcloud.Record
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "CreateRecord")
+	}
+	return m.CreateRecordFunc(ctx, record)
+}
+
+// UpdateRecord records the call and returns the result of UpdateRecordFunc.
+func (m *DNSServerClient) UpdateRecord(ctx context.Context, record hcloud.CreateOrUpdateRecord, recordID string) (*hcloud.Record, *hcloud.Response, error) {
+	m.record("UpdateRecord", ctx, record, recordID)
+	if m.UpdateRecordFunc == nil {
+		var r0 *hcloud.Record
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "UpdateRecord")
+	}
+	return m.UpdateRecordFunc(ctx, record, recordID)
+}
+
+// DeleteRecord records the call and returns the result of DeleteRecordFunc.
+func (m *DNSServerClient) DeleteRecord(ctx context.Context, recordID string) (*hcloud.Response, error) {
+	m.record("DeleteRecord", ctx, recordID)
+	if m.DeleteRecordFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("DNSServerClient", "DeleteRecord")
+	}
+	return m.DeleteRecordFunc(ctx, recordID)
+}
+
+// GetAllZones records the call and returns the result of GetAllZonesFunc.
+func (m *DNSServerClient) GetAllZones(ctx context.Context, opts hcloud.ZoneListOpts) ([]*hcloud.Zone, *hcloud.Response, error) {
+	m.record("GetAllZones", ctx, opts)
+	if m.GetAllZonesFunc == nil {
+		var r0 []*hcloud.Zone
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "GetAllZones")
+	}
+	return m.GetAllZonesFunc(ctx, opts)
+}
+
+// GetZone records the call and returns the result of GetZoneFunc.
+func (m *DNSServerClient) GetZone(ctx context.Context, zoneID string) (*hcloud.Zone, *hcloud.Response, error) {
+	m.record("GetZone", ctx, zoneID)
+	if m.GetZoneFunc == nil {
+		var r0 *hcloud.Zone
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "GetZone")
+	}
+	return m.GetZoneFunc(ctx, zoneID)
+}
+
+// UpdateZone records the call and returns the result of UpdateZoneFunc.
+func (m *DNSServerClient) UpdateZone(ctx context.Context, zoneID string, updateZoneData hcloud.CreateOrUpdateZone) (*hcloud.Zone, *hcloud.Response, error) {
+	m.record("UpdateZone", ctx, zoneID, updateZoneData)
+	if m.UpdateZoneFunc == nil {
+		var r0 *hcloud.Zone
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "UpdateZone")
+	}
+	return m.UpdateZoneFunc(ctx, zoneID, updateZoneData)
+}
+
+// DeleteZone records the call and returns the result of DeleteZoneFunc.
+func (m *DNSServerClient) DeleteZone(ctx context.Context, zoneID string) (*hcloud.Response, error) {
+	m.record("DeleteZone", ctx, zoneID)
+	if m.DeleteZoneFunc == nil {
+		var r0 *hcloud.Response
+		return r0, notProgrammed("DNSServerClient", "DeleteZone")
+	}
+	return m.DeleteZoneFunc(ctx, zoneID)
+}
+
+// CreateZone records the call and returns the result of CreateZoneFunc.
+func (m *DNSServerClient) CreateZone(ctx context.Context, zone hcloud.CreateOrUpdateZone) (*hcloud.Zone, *hcloud.Response, error) {
+	m.record("CreateZone", ctx, zone)
+	if m.CreateZoneFunc == nil {
+		var r0 *hcloud.Zone
+		var r1 *hcloud.Response
+		return r0, r1, notProgrammed("DNSServerClient", "CreateZone")
+	}
+	return m.CreateZoneFunc(ctx, zone)
+}
@@ -0,0 +1,92 @@
+// Package mock provides mock implementations of the hcloud resource client
+// interfaces.
+//
+// Every mock has a function field for each method, named after the method
+// with a Func suffix. Calling a method records the call and returns the
+// result of its function field:
+//
+//	servers := &mock.ServerClient{
+//	    GetByIDFunc: func(ctx context.Context, id int) (*hcloud.Server, *hcloud.Response, error) {
+//	        return &hcloud.Server{ID: id}, nil, nil
+//	    },
+//	}
+//	useServers(servers) // func useServers(servers hcloud.ServerAPI)
+//	calls := servers.CallsTo("GetByID")
+//
+// Methods whose function field is nil return an error matching
+// ErrNotProgrammed, or panic with it if the method has no error result.
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNotProgrammed is returned by mock methods without a function.
+var ErrNotProgrammed = errors.New("mock: method not programmed")
+
+// notProgrammedError is the error of a mock method without a function. It
+// matches ErrNotProgrammed with errors.Is.
+type notProgrammedError struct {
+	typ, method string
+}
+
+func (e notProgrammedError) Error() string {
+	return fmt.Sprintf("%s: %s.%s", ErrNotProgrammed, e.typ, e.method)
+}
+
+func (e notProgrammedError) Is(target error) bool {
+	return target == ErrNotProgrammed
+}
+
+func notProgrammed(typ, method string) error {
+	return notProgrammedError{typ: typ, method: method}
+}
+
+// Call is a recorded method call.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder records the calls made to a mock. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in the order they were made.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// CallsTo returns the recorded calls of a method in the order they were made.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// ResetCalls removes all recorded calls.
+func (r *Recorder) ResetCalls() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
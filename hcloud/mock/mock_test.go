@@ -0,0 +1,69 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud"
+)
+
+func TestServerClient(t *testing.T) {
+	ctx := context.Background()
+	servers := &ServerClient{
+		GetByIDFunc: func(ctx context.Context, id int) (*hcloud.Server, *hcloud.Response, error) {
+			return &hcloud.Server{ID: id}, nil, nil
+		},
+	}
+	var api hcloud.ServerAPI = servers
+
+	server, _, err := api.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if server.ID != 1 {
+		t.Errorf("unexpected server ID: %d", server.ID)
+	}
+
+	_, _, err = api.Poweron(ctx, server)
+	if _, ok := err.(notProgrammedError); !ok {
+		t.Errorf("expected ErrNotProgrammed, got %v", err)
+	}
+	if err.Error() != "mock: method not programmed: ServerClient.Poweron" {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	if _, _, err := api.GetByID(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := servers.Calls()
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %d", len(calls))
+	}
+	if calls[1].Method != "Poweron" || calls[1].Args[1] != server {
+		t.Errorf("unexpected call: %+v", calls[1])
+	}
+	getCalls := servers.CallsTo("GetByID")
+	if len(getCalls) != 2 || getCalls[0].Args[1] != 1 || getCalls[1].Args[1] != 2 {
+		t.Errorf("unexpected GetByID calls: %+v", getCalls)
+	}
+
+	servers.ResetCalls()
+	if calls := servers.Calls(); len(calls) != 0 {
+		t.Errorf("expected no calls after reset, got %+v", calls)
+	}
+}
+
+func TestNotProgrammedPanic(t *testing.T) {
+	actions := &ActionClient{}
+	defer func() {
+		err := recover()
+		if _, ok := err.(notProgrammedError); !ok {
+			t.Errorf("expected panic with ErrNotProgrammed, got %v", err)
+		}
+		if len(actions.CallsTo("WatchProgress")) != 1 {
+			t.Error("expected call to be recorded")
+		}
+	}()
+	actions.WatchProgress(context.Background(), &hcloud.Action{ID: 1})
+}
@@ -19,15 +19,21 @@ type NetworkZone string
 
 // List of available Network Zones.
 const (
-	NetworkZoneEUCentral NetworkZone = "eu-central"
+	NetworkZoneEUCentral   NetworkZone = "eu-central"
+	NetworkZoneUSEast      NetworkZone = "us-east"
+	NetworkZoneUSWest      NetworkZone = "us-west"
+	NetworkZoneAPSoutheast NetworkZone = "ap-southeast"
 )
 
 // NetworkSubnetType specifies a type of a subnet.
 type NetworkSubnetType string
 
-// List of available network subnet types.
+// List of available network subnet types. NetworkSubnetTypeServer is
+// deprecated in favor of NetworkSubnetTypeCloud.
 const (
-	NetworkSubnetTypeServer NetworkSubnetType = "server"
+	NetworkSubnetTypeCloud   NetworkSubnetType = "cloud"
+	NetworkSubnetTypeServer  NetworkSubnetType = "server"
+	NetworkSubnetTypeVSwitch NetworkSubnetType = "vswitch"
 )
 
 // Network represents a network in the Hetzner Cloud.
@@ -41,14 +47,51 @@ type Network struct {
 	Servers    []*Server
 	Protection NetworkProtection
 	Labels     map[string]string
+
+	// ExposeRoutesToVSwitch indicates whether the routes of the network are
+	// exposed to the vSwitch connected via a vSwitch subnet.
+	ExposeRoutesToVSwitch bool
 }
 
 // NetworkSubnet represents a subnet of a network in the Hetzner Cloud.
+// VSwitchID is only set for subnets of type NetworkSubnetTypeVSwitch.
 type NetworkSubnet struct {
 	Type        NetworkSubnetType
 	IPRange     *net.IPNet
 	NetworkZone NetworkZone
 	Gateway     net.IP
+	VSwitchID   int
+}
+
+// Validate checks if the subnet is valid for adding it to a network.
+func (s NetworkSubnet) Validate() error {
+	switch s.Type {
+	case NetworkSubnetTypeCloud, NetworkSubnetTypeServer:
+		if s.VSwitchID != 0 {
+			return fmt.Errorf("vSwitch ID is only allowed for subnets of type %s", NetworkSubnetTypeVSwitch)
+		}
+	case NetworkSubnetTypeVSwitch:
+		if s.VSwitchID == 0 {
+			return errors.New("missing vSwitch ID")
+		}
+	default:
+		return errors.New("missing or invalid type")
+	}
+	if s.NetworkZone == "" {
+		return errors.New("missing network zone")
+	}
+	return nil
+}
+
+// hasNetworkZone reports whether the network has a subnet in the given
+// network zone.
+func (n *Network) hasNetworkZone(zone NetworkZone) bool {
+	for _, subnet := range n.Subnets {
+		if subnet.NetworkZone == zone {
+			return true
+		}
+	}
+	return false
 }
 
 // NetworkRoute represents a route of a network.
@@ -173,14 +216,16 @@ func (c *NetworkClient) Delete(ctx context.Context, network *Network) (*Response
 
 // NetworkUpdateOpts specifies options for updating a network.
 type NetworkUpdateOpts struct {
-	Name   string
-	Labels map[string]string
+	Name                  string
+	Labels                map[string]string
+	ExposeRoutesToVSwitch *bool
 }
 
 // Update updates a network.
 func (c *NetworkClient) Update(ctx context.Context, network *Network, opts NetworkUpdateOpts) (*Network, *Response, error) {
 	reqBody := schema.NetworkUpdateRequest{
-		Name: opts.Name,
+		Name:                  opts.Name,
+		ExposeRoutesToVSwitch: opts.ExposeRoutesToVSwitch,
 	}
 	if opts.Labels != nil {
 		reqBody.Labels = &opts.Labels
@@ -206,11 +251,12 @@ func (c *NetworkClient) Update(ctx context.Context, network *Network, opts Netwo
 
 // NetworkCreateOpts specifies options for creating a new network.
 type NetworkCreateOpts struct {
-	Name    string
-	IPRange *net.IPNet
-	Subnets []NetworkSubnet
-	Routes  []NetworkRoute
-	Labels  map[string]string
+	Name                  string
+	IPRange               *net.IPNet
+	Subnets               []NetworkSubnet
+	Routes                []NetworkRoute
+	Labels                map[string]string
+	ExposeRoutesToVSwitch bool
 }
 
 // Validate checks if options are valid.
@@ -221,6 +267,11 @@ func (o NetworkCreateOpts) Validate() error {
 	if o.IPRange == nil || o.IPRange.String() == "" {
 		return errors.New("missing IP range")
 	}
+	for _, subnet := range o.Subnets {
+		if err := subnet.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -230,14 +281,16 @@ func (c *NetworkClient) Create(ctx context.Context, opts NetworkCreateOpts) (*Ne
 		return nil, nil, err
 	}
 	reqBody := schema.NetworkCreateRequest{
-		Name:    opts.Name,
-		IPRange: opts.IPRange.String(),
+		Name:                  opts.Name,
+		IPRange:               opts.IPRange.String(),
+		ExposeRoutesToVSwitch: opts.ExposeRoutesToVSwitch,
 	}
 	for _, subnet := range opts.Subnets {
 		reqBody.Subnets = append(reqBody.Subnets, schema.NetworkSubnet{
 			Type:        string(subnet.Type),
 			IPRange:     subnet.IPRange.String(),
 			NetworkZone: string(subnet.NetworkZone),
+			VSwitchID:   subnet.VSwitchID,
 		})
 	}
 	for _, route := range opts.Routes {
@@ -300,11 +353,20 @@ type NetworkAddSubnetOpts struct {
 	Subnet NetworkSubnet
 }
 
+// Validate checks if options are valid.
+func (o NetworkAddSubnetOpts) Validate() error {
+	return o.Subnet.Validate()
+}
+
 // AddSubnet adds a subnet to a network.
 func (c *NetworkClient) AddSubnet(ctx context.Context, network *Network, opts NetworkAddSubnetOpts) (*Action, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
 	reqBody := schema.NetworkActionAddSubnetRequest{
 		Type:        string(opts.Subnet.Type),
 		NetworkZone: string(opts.Subnet.NetworkZone),
+		VSwitchID:   opts.Subnet.VSwitchID,
 	}
 	if opts.Subnet.IPRange != nil {
 		reqBody.IPRange = opts.Subnet.IPRange.String()
@@ -445,3 +507,26 @@ func (c *NetworkClient) ChangeProtection(ctx context.Context, network *Network,
 	}
 	return ActionFromSchema(respBody.Action), resp, err
 }
+
+// getWithServers retrieves the current state of a network and the servers
+// attached to it.
+func (c *NetworkClient) getWithServers(ctx context.Context, network *Network) (*Network, []*Server, error) {
+	network, _, err := c.GetByID(ctx, network.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if network == nil {
+		return nil, nil, errors.New("hcloud: network not found")
+	}
+	servers := make([]*Server, 0, len(network.Servers))
+	for _, s := range network.Servers {
+		server, _, err := c.client.Server.GetByID(ctx, s.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if server != nil {
+			servers = append(servers, server)
+		}
+	}
+	return network, servers, nil
+}
@@ -0,0 +1,238 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Errors returned by NetworkIPAM when the address space is used up.
+var (
+	ErrNoFreeSubnet  = errors.New("hcloud: no free subnet in network")
+	ErrNoFreeAddress = errors.New("hcloud: no free address in subnet")
+)
+
+// NetworkIPAM allocates subnets and host addresses in a network. It knows
+// the subnets of the network and the addresses used by attached servers,
+// including their alias IPs. In every subnet, the network address, the
+// gateway and the broadcast address are reserved.
+//
+// Allocations are reserved immediately, so a NetworkIPAM can be shared by
+// concurrent goroutines without handing out the same subnet or address
+// twice. Release returns an address that was not used after all.
+type NetworkIPAM struct {
+	mu      sync.Mutex
+	ipRange *net.IPNet
+	subnets []NetworkSubnet
+	used    map[uint32]bool
+}
+
+// NewNetworkIPAM returns a NetworkIPAM for network. The private network
+// addresses of servers attached to other networks are ignored.
+func NewNetworkIPAM(network *Network, servers []*Server) (*NetworkIPAM, error) {
+	if network.IPRange == nil || network.IPRange.IP.To4() == nil {
+		return nil, errors.New("hcloud: network has no IPv4 IP range")
+	}
+	ipam := &NetworkIPAM{
+		ipRange: ipv4Net(network.IPRange),
+		used:    map[uint32]bool{},
+	}
+	for _, subnet := range network.Subnets {
+		if subnet.IPRange == nil || subnet.IPRange.IP.To4() == nil {
+			continue
+		}
+		subnet.IPRange = ipv4Net(subnet.IPRange)
+		ipam.addSubnet(subnet)
+	}
+	for _, server := range servers {
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network == nil || privateNet.Network.ID != network.ID {
+				continue
+			}
+			ipam.reserve(privateNet.IP)
+			for _, aliasIP := range privateNet.Aliases {
+				ipam.reserve(aliasIP)
+			}
+		}
+	}
+	return ipam, nil
+}
+
+// IPAM returns a NetworkIPAM for the current state of a network and the
+// servers attached to it.
+func (c *NetworkClient) IPAM(ctx context.Context, network *Network) (*NetworkIPAM, error) {
+	network, servers, err := c.getWithServers(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetworkIPAM(network, servers)
+}
+
+// NextSubnet allocates the first free subnet of the network's IP range with
+// the given prefix length. The returned subnet only exists locally until it
+// is added to the network, for example with NetworkClient.AddSubnet.
+func (m *NetworkIPAM) NextSubnet(prefixLen int) (*net.IPNet, error) {
+	ones, bits := m.ipRange.Mask.Size()
+	if prefixLen < ones || prefixLen > bits-2 {
+		return nil, fmt.Errorf("hcloud: invalid prefix length %d for IP range %s", prefixLen, m.ipRange)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	first, last := ipv4ToUint32(m.ipRange.IP.Mask(m.ipRange.Mask)), lastIPv4(m.ipRange)
+	size := uint32(1) << uint(bits-prefixLen)
+	mask := net.CIDRMask(prefixLen, bits)
+	for start := first; start <= last-size+1 && start >= first; start += size {
+		candidate := &net.IPNet{IP: uint32ToIPv4(start), Mask: mask}
+		if !m.overlaps(candidate) {
+			m.addSubnet(NetworkSubnet{IPRange: candidate})
+			return candidate, nil
+		}
+	}
+	return nil, ErrNoFreeSubnet
+}
+
+// NextAddress allocates the first free host address in subnet. If subnet is
+// nil, the subnets of the network are searched in order, skipping vSwitch
+// subnets which servers cannot be attached to.
+func (m *NetworkIPAM) NextAddress(subnet *net.IPNet) (net.IP, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if subnet != nil {
+		if !m.hasSubnet(subnet) {
+			return nil, fmt.Errorf("hcloud: %s is not a subnet of the network", subnet)
+		}
+		return m.nextAddress(subnet)
+	}
+	for _, s := range m.subnets {
+		if s.Type == NetworkSubnetTypeVSwitch {
+			continue
+		}
+		if ip, err := m.nextAddress(s.IPRange); err == nil {
+			return ip, nil
+		}
+	}
+	return nil, ErrNoFreeAddress
+}
+
+// Reserve marks ip as used, for example for the address of a Load Balancer.
+// An error is returned if ip is outside the network or already in use.
+func (m *NetworkIPAM) Reserve(ip net.IP) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ip.To4() == nil || !m.ipRange.Contains(ip) {
+		return fmt.Errorf("hcloud: %s is not in IP range %s", ip, m.ipRange)
+	}
+	if m.used[ipv4ToUint32(ip)] {
+		return fmt.Errorf("hcloud: %s is already in use", ip)
+	}
+	m.reserve(ip)
+	return nil
+}
+
+// Release marks ip as free again. Reserved network, gateway and broadcast
+// addresses cannot be released.
+func (m *NetworkIPAM) Release(ip net.IP) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if ip.To4() == nil {
+		return
+	}
+	for _, s := range m.subnets {
+		if !s.IPRange.Contains(ip) {
+			continue
+		}
+		if ip.Equal(s.IPRange.IP) || ip.Equal(subnetGateway(s)) || ip.Equal(uint32ToIPv4(lastIPv4(s.IPRange))) {
+			return
+		}
+	}
+	delete(m.used, ipv4ToUint32(ip))
+}
+
+func (m *NetworkIPAM) nextAddress(subnet *net.IPNet) (net.IP, error) {
+	first, last := ipv4Bounds(subnet)
+	for n := first; n <= last && n >= first; n++ {
+		if !m.used[n] {
+			m.used[n] = true
+			return uint32ToIPv4(n), nil
+		}
+	}
+	return nil, ErrNoFreeAddress
+}
+
+func (m *NetworkIPAM) addSubnet(subnet NetworkSubnet) {
+	m.subnets = append(m.subnets, subnet)
+	m.reserve(subnet.IPRange.IP.Mask(subnet.IPRange.Mask))
+	m.reserve(subnetGateway(subnet))
+	m.reserve(uint32ToIPv4(lastIPv4(subnet.IPRange)))
+}
+
+func (m *NetworkIPAM) hasSubnet(subnet *net.IPNet) bool {
+	for _, s := range m.subnets {
+		if s.IPRange.String() == subnet.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *NetworkIPAM) overlaps(subnet *net.IPNet) bool {
+	for _, s := range m.subnets {
+		if s.IPRange.Contains(subnet.IP) || subnet.Contains(s.IPRange.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *NetworkIPAM) reserve(ip net.IP) {
+	if ip.To4() != nil {
+		m.used[ipv4ToUint32(ip)] = true
+	}
+}
+
+// ipv4Net returns n with a 4-byte address and mask.
+func ipv4Net(n *net.IPNet) *net.IPNet {
+	ones, bits := n.Mask.Size()
+	return &net.IPNet{
+		IP:   n.IP.To4().Mask(net.CIDRMask(ones-(bits-32), 32)),
+		Mask: net.CIDRMask(ones-(bits-32), 32),
+	}
+}
+
+// subnetGateway returns the gateway of a subnet, which defaults to the first
+// host address.
+func subnetGateway(subnet NetworkSubnet) net.IP {
+	if subnet.Gateway != nil && subnet.Gateway.To4() != nil && !subnet.Gateway.IsUnspecified() {
+		return subnet.Gateway
+	}
+	return uint32ToIPv4(ipv4ToUint32(subnet.IPRange.IP.Mask(subnet.IPRange.Mask)) + 1)
+}
+
+// ipv4Bounds returns the first and last host address of an IPv4 network,
+// excluding the network and broadcast address.
+func ipv4Bounds(n *net.IPNet) (uint32, uint32) {
+	return ipv4ToUint32(n.IP.Mask(n.Mask)) + 1, lastIPv4(n) - 1
+}
+
+func lastIPv4(n *net.IPNet) uint32 {
+	ones, bits := n.Mask.Size()
+	return ipv4ToUint32(n.IP.Mask(n.Mask)) | (uint32(1)<<uint(bits-ones) - 1)
+}
+
+func ipv4ToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uint32ToIPv4(n uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, n)
+	return ip
+}
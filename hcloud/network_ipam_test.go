@@ -0,0 +1,220 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func newTestNetworkIPAM(t *testing.T) *NetworkIPAM {
+	network := &Network{
+		ID:      1,
+		IPRange: mustParseCIDR(t, "10.0.0.0/16"),
+		Subnets: []NetworkSubnet{
+			{Type: NetworkSubnetTypeCloud, IPRange: mustParseCIDR(t, "10.0.0.0/24"), Gateway: net.ParseIP("10.0.0.1")},
+			{Type: NetworkSubnetTypeVSwitch, IPRange: mustParseCIDR(t, "10.0.2.0/24"), VSwitchID: 1000},
+		},
+	}
+	servers := []*Server{
+		{
+			ID: 1,
+			PrivateNet: []ServerPrivateNet{
+				{Network: &Network{ID: 1}, IP: net.ParseIP("10.0.0.2"), Aliases: []net.IP{net.ParseIP("10.0.0.3")}},
+				{Network: &Network{ID: 2}, IP: net.ParseIP("10.0.0.4")},
+			},
+		},
+	}
+	ipam, err := NewNetworkIPAM(network, servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ipam
+}
+
+func TestNetworkIPAMNextSubnet(t *testing.T) {
+	ipam := newTestNetworkIPAM(t)
+
+	expected := []string{"10.0.1.0/24", "10.0.3.0/24", "10.0.4.0/24"}
+	for _, want := range expected {
+		subnet, err := ipam.NextSubnet(24)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subnet.String() != want {
+			t.Errorf("expected subnet %s, got %s", want, subnet)
+		}
+	}
+
+	subnet, err := ipam.NextSubnet(23)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subnet.String() != "10.0.6.0/23" {
+		t.Errorf("unexpected subnet: %s", subnet)
+	}
+
+	if _, err := ipam.NextSubnet(8); err == nil {
+		t.Error("expected an error for a prefix shorter than the IP range")
+	}
+	if _, err := ipam.NextSubnet(16); err != ErrNoFreeSubnet {
+		t.Errorf("expected ErrNoFreeSubnet, got %v", err)
+	}
+}
+
+func TestNetworkIPAMNextAddress(t *testing.T) {
+	ipam := newTestNetworkIPAM(t)
+
+	ip, err := ipam.NextAddress(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.0.4" {
+		t.Errorf("unexpected address: %s", ip)
+	}
+
+	subnet, err := ipam.NextSubnet(30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subnet.String() != "10.0.1.0/30" {
+		t.Fatalf("unexpected subnet: %s", subnet)
+	}
+	// A /30 has two host addresses, the first of which is the gateway.
+	ip, err = ipam.NextAddress(subnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.1.2" {
+		t.Errorf("unexpected address: %s", ip)
+	}
+	if _, err := ipam.NextAddress(subnet); err != ErrNoFreeAddress {
+		t.Errorf("expected ErrNoFreeAddress, got %v", err)
+	}
+
+	ipam.Release(ip)
+	ipam.Release(net.ParseIP("10.0.1.1"))
+	ip, err = ipam.NextAddress(subnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.1.2" {
+		t.Errorf("unexpected address after release: %s", ip)
+	}
+
+	if _, err := ipam.NextAddress(mustParseCIDR(t, "10.0.9.0/24")); err == nil {
+		t.Error("expected an error for an unknown subnet")
+	}
+}
+
+func TestNetworkIPAMReserve(t *testing.T) {
+	ipam := newTestNetworkIPAM(t)
+
+	if err := ipam.Reserve(net.ParseIP("10.0.0.4")); err != nil {
+		t.Fatal(err)
+	}
+	if err := ipam.Reserve(net.ParseIP("10.0.0.3")); err == nil {
+		t.Error("expected an error for an alias IP in use")
+	}
+	if err := ipam.Reserve(net.ParseIP("192.168.0.1")); err == nil {
+		t.Error("expected an error for an address outside the network")
+	}
+	ip, err := ipam.NextAddress(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.0.5" {
+		t.Errorf("unexpected address: %s", ip)
+	}
+}
+
+func TestNetworkIPAMConcurrent(t *testing.T) {
+	ipam := newTestNetworkIPAM(t)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		ips     = map[string]bool{}
+		subnets = map[string]bool{}
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ip, err := ipam.NextAddress(nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			subnet, err := ipam.NextSubnet(28)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if ips[ip.String()] {
+				t.Errorf("address %s allocated twice", ip)
+			}
+			ips[ip.String()] = true
+			if subnets[subnet.String()] {
+				t.Errorf("subnet %s allocated twice", subnet)
+			}
+			subnets[subnet.String()] = true
+		}()
+	}
+	wg.Wait()
+}
+
+func TestNetworkClientIPAM(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/networks/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.NetworkGetResponse{
+			Network: schema.Network{
+				ID:      1,
+				IPRange: "10.0.0.0/16",
+				Subnets: []schema.NetworkSubnet{
+					{Type: "cloud", IPRange: "10.0.0.0/24", NetworkZone: "eu-central", Gateway: "10.0.0.1"},
+				},
+				Servers: []int{2},
+			},
+		})
+	})
+	env.Mux.HandleFunc("/servers/2", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{
+				ID: 2,
+				PrivateNet: []schema.ServerPrivateNet{
+					{Network: 1, IP: "10.0.0.2", AliasIPs: []string{"10.0.0.3"}},
+				},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	ipam, err := env.Client.Network.IPAM(ctx, &Network{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := ipam.NextAddress(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.0.4" {
+		t.Errorf("unexpected address: %s", ip)
+	}
+}
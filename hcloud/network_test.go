@@ -327,6 +327,39 @@ func TestNetworkClientUpdate(t *testing.T) {
 			t.Errorf("unexpected network ID: %v", updatedNetwork.ID)
 		}
 	})
+
+	t.Run("expose routes to vSwitch", func(t *testing.T) {
+		env := newTestEnv()
+		defer env.Teardown()
+
+		env.Mux.HandleFunc("/networks/1", func(w http.ResponseWriter, r *http.Request) {
+			var reqBody schema.NetworkUpdateRequest
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Fatal(err)
+			}
+			if reqBody.ExposeRoutesToVSwitch == nil || !*reqBody.ExposeRoutesToVSwitch {
+				t.Errorf("unexpected ExposeRoutesToVSwitch: %v", reqBody.ExposeRoutesToVSwitch)
+			}
+			json.NewEncoder(w).Encode(schema.NetworkUpdateResponse{
+				Network: schema.Network{
+					ID:                    1,
+					ExposeRoutesToVSwitch: true,
+				},
+			})
+		})
+
+		opts := NetworkUpdateOpts{
+			ExposeRoutesToVSwitch: Bool(true),
+		}
+		updatedNetwork, _, err := env.Client.Network.Update(ctx, network, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !updatedNetwork.ExposeRoutesToVSwitch {
+			t.Error("expected routes to be exposed to vSwitch")
+		}
+	})
 }
 
 func TestNetworkClientChangeIPRange(t *testing.T) {
@@ -446,6 +479,102 @@ func TestNetworkClientAddSubnet(t *testing.T) {
 			t.Errorf("unexpected action ID: %d", action.ID)
 		}
 	})
+
+	t.Run("type vswitch", func(t *testing.T) {
+		env := newTestEnv()
+		defer env.Teardown()
+
+		env.Mux.HandleFunc("/networks/1/actions/add_subnet", func(w http.ResponseWriter, r *http.Request) {
+			var reqBody schema.NetworkActionAddSubnetRequest
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Fatal(err)
+			}
+			if reqBody.Type != "vswitch" {
+				t.Errorf("unexpected Type: %v", reqBody.Type)
+			}
+			if reqBody.VSwitchID != 1000 {
+				t.Errorf("unexpected VSwitchID: %v", reqBody.VSwitchID)
+			}
+			json.NewEncoder(w).Encode(schema.NetworkActionAddSubnetResponse{
+				Action: schema.Action{
+					ID: 1,
+				},
+			})
+		})
+
+		ctx := context.Background()
+		_, ipRange, _ := net.ParseCIDR("10.0.2.0/24")
+		opts := NetworkAddSubnetOpts{
+			Subnet: NetworkSubnet{
+				Type:        NetworkSubnetTypeVSwitch,
+				IPRange:     ipRange,
+				NetworkZone: NetworkZoneEUCentral,
+				VSwitchID:   1000,
+			},
+		}
+		action, _, err := env.Client.Network.AddSubnet(ctx, &Network{ID: 1}, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if action.ID != 1 {
+			t.Errorf("unexpected action ID: %d", action.ID)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		env := newTestEnv()
+		defer env.Teardown()
+
+		ctx := context.Background()
+		opts := NetworkAddSubnetOpts{
+			Subnet: NetworkSubnet{
+				Type:        NetworkSubnetTypeVSwitch,
+				NetworkZone: NetworkZoneEUCentral,
+			},
+		}
+		if _, _, err := env.Client.Network.AddSubnet(ctx, &Network{ID: 1}, opts); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestNetworkSubnetValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Subnet NetworkSubnet
+		Valid  bool
+	}{
+		"cloud": {
+			Subnet: NetworkSubnet{Type: NetworkSubnetTypeCloud, NetworkZone: NetworkZoneUSEast},
+			Valid:  true,
+		},
+		"vswitch": {
+			Subnet: NetworkSubnet{Type: NetworkSubnetTypeVSwitch, NetworkZone: NetworkZoneEUCentral, VSwitchID: 1},
+			Valid:  true,
+		},
+		"vswitch without ID": {
+			Subnet: NetworkSubnet{Type: NetworkSubnetTypeVSwitch, NetworkZone: NetworkZoneEUCentral},
+		},
+		"cloud with vSwitch ID": {
+			Subnet: NetworkSubnet{Type: NetworkSubnetTypeCloud, NetworkZone: NetworkZoneEUCentral, VSwitchID: 1},
+		},
+		"missing type": {
+			Subnet: NetworkSubnet{NetworkZone: NetworkZoneEUCentral},
+		},
+		"missing network zone": {
+			Subnet: NetworkSubnet{Type: NetworkSubnetTypeCloud},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.Subnet.Validate()
+			if testCase.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
 }
 
 func TestNetworkClientDeleteSubnet(t *testing.T) {
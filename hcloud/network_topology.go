@@ -0,0 +1,345 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// NetworkTopology is a graph of a network, its subnets, routes and the
+// servers attached to it.
+type NetworkTopology struct {
+	Network *Network
+	Subnets []*NetworkTopologySubnet
+	Routes  []*NetworkTopologyRoute
+	Servers []*NetworkTopologyServer
+}
+
+// NetworkTopologySubnet is a subnet of a network topology along with the
+// servers whose private IP is part of the subnet.
+type NetworkTopologySubnet struct {
+	NetworkSubnet
+	Servers []*NetworkTopologyServer
+}
+
+// NetworkTopologyRoute is a route of a network topology. GatewayServer is the
+// server owning the gateway IP, if it is attached to the network.
+type NetworkTopologyRoute struct {
+	NetworkRoute
+	GatewayServer *NetworkTopologyServer
+}
+
+// NetworkTopologyServer is a server attached to the network of a topology.
+// Subnet is the subnet containing the server's private IP, if any. IP is nil
+// for servers whose details were not available.
+type NetworkTopologyServer struct {
+	ID      int
+	Name    string
+	IP      net.IP
+	Aliases []net.IP
+	Subnet  *NetworkTopologySubnet
+}
+
+// NetworkTopologyIssueType is the type of a problem found in a network topology.
+type NetworkTopologyIssueType string
+
+// Types of problems found by NetworkTopology.Check.
+const (
+	NetworkTopologyIssueRouteGatewayOutsideSubnet NetworkTopologyIssueType = "route_gateway_outside_subnet"
+	NetworkTopologyIssueOverlappingSubnets        NetworkTopologyIssueType = "overlapping_subnets"
+	NetworkTopologyIssueRouteShadowedBySubnet     NetworkTopologyIssueType = "route_shadowed_by_subnet"
+	NetworkTopologyIssueAliasIPOutsideSubnet      NetworkTopologyIssueType = "alias_ip_outside_subnet"
+	NetworkTopologyIssueUnusedSubnet              NetworkTopologyIssueType = "unused_subnet"
+)
+
+// NetworkTopologyIssue is a problem found in a network topology.
+type NetworkTopologyIssue struct {
+	Type    NetworkTopologyIssueType `json:"type"`
+	Message string                   `json:"message"`
+}
+
+// NewNetworkTopology builds the topology of network from the given servers.
+// Servers listed in network.Servers but missing from servers are included
+// with their ID only.
+func NewNetworkTopology(network *Network, servers []*Server) *NetworkTopology {
+	t := &NetworkTopology{Network: network}
+	for _, subnet := range network.Subnets {
+		t.Subnets = append(t.Subnets, &NetworkTopologySubnet{NetworkSubnet: subnet})
+	}
+
+	known := map[int]bool{}
+	for _, server := range servers {
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network == nil || privateNet.Network.ID != network.ID {
+				continue
+			}
+			known[server.ID] = true
+			node := &NetworkTopologyServer{
+				ID:      server.ID,
+				Name:    server.Name,
+				IP:      privateNet.IP,
+				Aliases: privateNet.Aliases,
+			}
+			if subnet := t.subnetFor(node.IP); subnet != nil {
+				node.Subnet = subnet
+				subnet.Servers = append(subnet.Servers, node)
+			}
+			t.Servers = append(t.Servers, node)
+		}
+	}
+	for _, server := range network.Servers {
+		if !known[server.ID] {
+			t.Servers = append(t.Servers, &NetworkTopologyServer{ID: server.ID, Name: server.Name})
+		}
+	}
+
+	for _, route := range network.Routes {
+		node := &NetworkTopologyRoute{NetworkRoute: route}
+		for _, server := range t.Servers {
+			if server.IP != nil && server.IP.Equal(route.Gateway) {
+				node.GatewayServer = server
+				break
+			}
+		}
+		t.Routes = append(t.Routes, node)
+	}
+	return t
+}
+
+// Topology returns the topology of the current state of a network and the
+// servers attached to it.
+func (c *NetworkClient) Topology(ctx context.Context, network *Network) (*NetworkTopology, error) {
+	network, servers, err := c.getWithServers(ctx, network)
+	if err != nil {
+		return nil, err
+	}
+	return NewNetworkTopology(network, servers), nil
+}
+
+func (t *NetworkTopology) subnetFor(ip net.IP) *NetworkTopologySubnet {
+	if ip == nil {
+		return nil
+	}
+	for _, subnet := range t.Subnets {
+		if subnet.IPRange != nil && subnet.IPRange.Contains(ip) {
+			return subnet
+		}
+	}
+	return nil
+}
+
+// Check returns the problems found in the topology.
+func (t *NetworkTopology) Check() []NetworkTopologyIssue {
+	var issues []NetworkTopologyIssue
+	add := func(typ NetworkTopologyIssueType, format string, args ...interface{}) {
+		issues = append(issues, NetworkTopologyIssue{Type: typ, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i, a := range t.Subnets {
+		for _, b := range t.Subnets[i+1:] {
+			if a.IPRange != nil && b.IPRange != nil && ipNetsOverlap(a.IPRange, b.IPRange) {
+				add(NetworkTopologyIssueOverlappingSubnets, "subnets %s and %s overlap", a.IPRange, b.IPRange)
+			}
+		}
+	}
+
+	for _, route := range t.Routes {
+		if t.subnetFor(route.Gateway) == nil {
+			add(NetworkTopologyIssueRouteGatewayOutsideSubnet, "gateway %s of route to %s is outside of all subnets", route.Gateway, route.Destination)
+		}
+		if route.Destination == nil {
+			continue
+		}
+		for _, subnet := range t.Subnets {
+			if subnet.IPRange != nil && ipNetsOverlap(route.Destination, subnet.IPRange) {
+				add(NetworkTopologyIssueRouteShadowedBySubnet, "route to %s is shadowed by subnet %s", route.Destination, subnet.IPRange)
+			}
+		}
+	}
+
+	for _, server := range t.Servers {
+		for _, alias := range server.Aliases {
+			if server.Subnet == nil || !server.Subnet.IPRange.Contains(alias) {
+				add(NetworkTopologyIssueAliasIPOutsideSubnet, "alias IP %s of server %d is outside the subnet of its IP %s", alias, server.ID, server.IP)
+			}
+		}
+	}
+
+	for _, subnet := range t.Subnets {
+		if subnet.Type == NetworkSubnetTypeVSwitch || len(subnet.Servers) > 0 {
+			continue
+		}
+		used := false
+		for _, route := range t.Routes {
+			if subnet.IPRange != nil && subnet.IPRange.Contains(route.Gateway) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			add(NetworkTopologyIssueUnusedSubnet, "subnet %s is not used by any server or route", subnet.IPRange)
+		}
+	}
+	return issues
+}
+
+// DOT renders the topology as a Graphviz DOT graph.
+func (t *NetworkTopology) DOT() string {
+	var b bytes.Buffer
+	q := strconv.Quote
+
+	fmt.Fprintf(&b, "digraph %s {\n", q("network "+t.Network.Name))
+	fmt.Fprintf(&b, "\t%s [label=%s, shape=box];\n", q("network"), q(t.Network.Name+"\n"+ipNetString(t.Network.IPRange)))
+	for _, subnet := range t.Subnets {
+		id := q("subnet " + ipNetString(subnet.IPRange))
+		label := string(subnet.Type) + " " + ipNetString(subnet.IPRange) + "\n" + string(subnet.NetworkZone)
+		if subnet.VSwitchID != 0 {
+			label += "\nvSwitch " + strconv.Itoa(subnet.VSwitchID)
+		}
+		fmt.Fprintf(&b, "\t%s [label=%s, shape=folder];\n", id, q(label))
+		fmt.Fprintf(&b, "\t%s -> %s;\n", q("network"), id)
+	}
+	for _, server := range t.Servers {
+		id := q("server " + strconv.Itoa(server.ID))
+		label := server.Name
+		if label == "" {
+			label = "server " + strconv.Itoa(server.ID)
+		}
+		if server.IP != nil {
+			label += "\n" + server.IP.String()
+		}
+		for _, alias := range server.Aliases {
+			label += "\n" + alias.String()
+		}
+		fmt.Fprintf(&b, "\t%s [label=%s, shape=ellipse];\n", id, q(label))
+		if server.Subnet != nil {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", q("subnet "+ipNetString(server.Subnet.IPRange)), id)
+		} else {
+			fmt.Fprintf(&b, "\t%s -> %s [style=dashed];\n", q("network"), id)
+		}
+	}
+	for _, route := range t.Routes {
+		id := q("route " + ipNetString(route.Destination))
+		fmt.Fprintf(&b, "\t%s [label=%s, shape=diamond];\n", id, q(ipNetString(route.Destination)))
+		from := q("network")
+		if route.GatewayServer != nil {
+			from = q("server " + strconv.Itoa(route.GatewayServer.ID))
+		}
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", from, id, q("via "+route.Gateway.String()))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+type networkTopologyJSON struct {
+	Network struct {
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		IPRange string `json:"ip_range"`
+	} `json:"network"`
+	Subnets []networkTopologySubnetJSON `json:"subnets"`
+	Routes  []networkTopologyRouteJSON  `json:"routes"`
+	Servers []networkTopologyServerJSON `json:"servers"`
+	Issues  []NetworkTopologyIssue      `json:"issues"`
+}
+
+type networkTopologySubnetJSON struct {
+	Type        string `json:"type"`
+	IPRange     string `json:"ip_range"`
+	NetworkZone string `json:"network_zone"`
+	Gateway     string `json:"gateway,omitempty"`
+	VSwitchID   int    `json:"vswitch_id,omitempty"`
+	Servers     []int  `json:"servers"`
+}
+
+type networkTopologyRouteJSON struct {
+	Destination   string `json:"destination"`
+	Gateway       string `json:"gateway"`
+	GatewayServer *int   `json:"gateway_server"`
+}
+
+type networkTopologyServerJSON struct {
+	ID       int      `json:"id"`
+	Name     string   `json:"name"`
+	IP       string   `json:"ip,omitempty"`
+	AliasIPs []string `json:"alias_ips"`
+	Subnet   string   `json:"subnet,omitempty"`
+}
+
+// MarshalJSON renders the topology, including the problems found by Check,
+// as JSON.
+func (t *NetworkTopology) MarshalJSON() ([]byte, error) {
+	var out networkTopologyJSON
+	out.Network.ID = t.Network.ID
+	out.Network.Name = t.Network.Name
+	out.Network.IPRange = ipNetString(t.Network.IPRange)
+
+	out.Subnets = []networkTopologySubnetJSON{}
+	for _, subnet := range t.Subnets {
+		s := networkTopologySubnetJSON{
+			Type:        string(subnet.Type),
+			IPRange:     ipNetString(subnet.IPRange),
+			NetworkZone: string(subnet.NetworkZone),
+			VSwitchID:   subnet.VSwitchID,
+			Servers:     []int{},
+		}
+		if subnet.Gateway != nil {
+			s.Gateway = subnet.Gateway.String()
+		}
+		for _, server := range subnet.Servers {
+			s.Servers = append(s.Servers, server.ID)
+		}
+		out.Subnets = append(out.Subnets, s)
+	}
+
+	out.Routes = []networkTopologyRouteJSON{}
+	for _, route := range t.Routes {
+		r := networkTopologyRouteJSON{
+			Destination: ipNetString(route.Destination),
+			Gateway:     route.Gateway.String(),
+		}
+		if route.GatewayServer != nil {
+			r.GatewayServer = Int(route.GatewayServer.ID)
+		}
+		out.Routes = append(out.Routes, r)
+	}
+
+	out.Servers = []networkTopologyServerJSON{}
+	for _, server := range t.Servers {
+		s := networkTopologyServerJSON{
+			ID:       server.ID,
+			Name:     server.Name,
+			AliasIPs: []string{},
+		}
+		if server.IP != nil {
+			s.IP = server.IP.String()
+		}
+		for _, alias := range server.Aliases {
+			s.AliasIPs = append(s.AliasIPs, alias.String())
+		}
+		if server.Subnet != nil {
+			s.Subnet = ipNetString(server.Subnet.IPRange)
+		}
+		out.Servers = append(out.Servers, s)
+	}
+
+	out.Issues = t.Check()
+	if out.Issues == nil {
+		out.Issues = []NetworkTopologyIssue{}
+	}
+	return json.Marshal(out)
+}
+
+func ipNetsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func ipNetString(n *net.IPNet) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
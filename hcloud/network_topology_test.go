@@ -0,0 +1,303 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func newTestNetworkTopologyInput(t *testing.T) (*Network, []*Server) {
+	network := &Network{
+		ID:      1,
+		Name:    "mynet",
+		IPRange: mustParseCIDR(t, "10.0.0.0/16"),
+		Subnets: []NetworkSubnet{
+			{Type: NetworkSubnetTypeCloud, IPRange: mustParseCIDR(t, "10.0.0.0/24"), NetworkZone: NetworkZoneEUCentral, Gateway: net.ParseIP("10.0.0.1")},
+			{Type: NetworkSubnetTypeCloud, IPRange: mustParseCIDR(t, "10.0.1.0/24"), NetworkZone: NetworkZoneEUCentral, Gateway: net.ParseIP("10.0.1.1")},
+			{Type: NetworkSubnetTypeVSwitch, IPRange: mustParseCIDR(t, "10.0.2.0/24"), NetworkZone: NetworkZoneEUCentral, VSwitchID: 1000},
+		},
+		Routes: []NetworkRoute{
+			{Destination: mustParseCIDR(t, "10.100.0.0/24"), Gateway: net.ParseIP("10.0.0.2")},
+		},
+		Servers: []*Server{{ID: 1}, {ID: 2}, {ID: 3}},
+	}
+	servers := []*Server{
+		{
+			ID:   1,
+			Name: "router",
+			PrivateNet: []ServerPrivateNet{
+				{Network: &Network{ID: 1}, IP: net.ParseIP("10.0.0.2")},
+			},
+		},
+		{
+			ID:   2,
+			Name: "app",
+			PrivateNet: []ServerPrivateNet{
+				{Network: &Network{ID: 2}, IP: net.ParseIP("10.0.1.2")},
+				{Network: &Network{ID: 1}, IP: net.ParseIP("10.0.1.2"), Aliases: []net.IP{net.ParseIP("10.0.1.3")}},
+			},
+		},
+	}
+	return network, servers
+}
+
+func newTestNetworkTopology(t *testing.T) *NetworkTopology {
+	return NewNetworkTopology(newTestNetworkTopologyInput(t))
+}
+
+func TestNewNetworkTopology(t *testing.T) {
+	topology := newTestNetworkTopology(t)
+
+	if len(topology.Subnets) != 3 {
+		t.Fatalf("unexpected number of subnets: %d", len(topology.Subnets))
+	}
+	if len(topology.Servers) != 3 {
+		t.Fatalf("unexpected number of servers: %d", len(topology.Servers))
+	}
+
+	router, app, unknown := topology.Servers[0], topology.Servers[1], topology.Servers[2]
+	if router.Name != "router" || router.Subnet != topology.Subnets[0] {
+		t.Errorf("unexpected router: %+v", router)
+	}
+	if app.Name != "app" || app.Subnet != topology.Subnets[1] || len(app.Aliases) != 1 {
+		t.Errorf("unexpected app server: %+v", app)
+	}
+	if unknown.ID != 3 || unknown.IP != nil || unknown.Subnet != nil {
+		t.Errorf("unexpected unknown server: %+v", unknown)
+	}
+
+	if len(topology.Subnets[0].Servers) != 1 || topology.Subnets[0].Servers[0] != router {
+		t.Errorf("unexpected servers in first subnet: %v", topology.Subnets[0].Servers)
+	}
+	if len(topology.Subnets[2].Servers) != 0 {
+		t.Errorf("unexpected servers in vSwitch subnet: %v", topology.Subnets[2].Servers)
+	}
+
+	if len(topology.Routes) != 1 || topology.Routes[0].GatewayServer != router {
+		t.Errorf("unexpected routes: %+v", topology.Routes)
+	}
+}
+
+func TestNetworkTopologyCheck(t *testing.T) {
+	t.Run("no issues", func(t *testing.T) {
+		topology := newTestNetworkTopology(t)
+		if issues := topology.Check(); len(issues) != 0 {
+			t.Errorf("unexpected issues: %v", issues)
+		}
+	})
+
+	testCases := map[string]struct {
+		Modify  func(t *testing.T, network *Network, servers []*Server)
+		Type    NetworkTopologyIssueType
+		Message string
+	}{
+		"route gateway outside subnet": {
+			Modify: func(t *testing.T, network *Network, servers []*Server) {
+				network.Routes = append(network.Routes, NetworkRoute{
+					Destination: mustParseCIDR(t, "10.200.0.0/24"),
+					Gateway:     net.ParseIP("10.0.5.1"),
+				})
+			},
+			Type:    NetworkTopologyIssueRouteGatewayOutsideSubnet,
+			Message: "gateway 10.0.5.1 of route to 10.200.0.0/24 is outside of all subnets",
+		},
+		"overlapping subnets": {
+			Modify: func(t *testing.T, network *Network, servers []*Server) {
+				network.Subnets[1].IPRange = mustParseCIDR(t, "10.0.0.0/23")
+			},
+			Type:    NetworkTopologyIssueOverlappingSubnets,
+			Message: "subnets 10.0.0.0/24 and 10.0.0.0/23 overlap",
+		},
+		"route shadowed by subnet": {
+			Modify: func(t *testing.T, network *Network, servers []*Server) {
+				network.Routes[0].Destination = mustParseCIDR(t, "10.0.2.0/25")
+			},
+			Type:    NetworkTopologyIssueRouteShadowedBySubnet,
+			Message: "route to 10.0.2.0/25 is shadowed by subnet 10.0.2.0/24",
+		},
+		"alias IP outside subnet": {
+			Modify: func(t *testing.T, network *Network, servers []*Server) {
+				servers[1].PrivateNet[1].Aliases = []net.IP{net.ParseIP("10.0.0.10")}
+			},
+			Type:    NetworkTopologyIssueAliasIPOutsideSubnet,
+			Message: "alias IP 10.0.0.10 of server 2 is outside the subnet of its IP 10.0.1.2",
+		},
+		"unused subnet": {
+			Modify: func(t *testing.T, network *Network, servers []*Server) {
+				network.Subnets = append(network.Subnets, NetworkSubnet{
+					Type:    NetworkSubnetTypeCloud,
+					IPRange: mustParseCIDR(t, "10.0.3.0/24"),
+				})
+			},
+			Type:    NetworkTopologyIssueUnusedSubnet,
+			Message: "subnet 10.0.3.0/24 is not used by any server or route",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			network, servers := newTestNetworkTopologyInput(t)
+			testCase.Modify(t, network, servers)
+
+			issues := NewNetworkTopology(network, servers).Check()
+			if len(issues) != 1 {
+				t.Fatalf("expected 1 issue, got %v", issues)
+			}
+			if issues[0].Type != testCase.Type {
+				t.Errorf("unexpected issue type: %s", issues[0].Type)
+			}
+			if issues[0].Message != testCase.Message {
+				t.Errorf("unexpected issue message: %s", issues[0].Message)
+			}
+		})
+	}
+}
+
+func TestNetworkTopologyDOT(t *testing.T) {
+	dot := newTestNetworkTopology(t).DOT()
+
+	if !strings.HasPrefix(dot, "digraph \"network mynet\" {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("unexpected graph:\n%s", dot)
+	}
+	expected := []string{
+		`"network" [label="mynet\n10.0.0.0/16", shape=box];`,
+		`"subnet 10.0.2.0/24" [label="vswitch 10.0.2.0/24\neu-central\nvSwitch 1000", shape=folder];`,
+		`"network" -> "subnet 10.0.0.0/24";`,
+		`"server 2" [label="app\n10.0.1.2\n10.0.1.3", shape=ellipse];`,
+		`"subnet 10.0.1.0/24" -> "server 2";`,
+		`"network" -> "server 3" [style=dashed];`,
+		`"route 10.100.0.0/24" [label="10.100.0.0/24", shape=diamond];`,
+		`"server 1" -> "route 10.100.0.0/24" [label="via 10.0.0.2"];`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(dot, "\t"+line+"\n") {
+			t.Errorf("missing line %s in graph:\n%s", line, dot)
+		}
+	}
+}
+
+func TestNetworkTopologyMarshalJSON(t *testing.T) {
+	network, servers := newTestNetworkTopologyInput(t)
+	network.Subnets = append(network.Subnets, NetworkSubnet{
+		Type:    NetworkSubnetTypeCloud,
+		IPRange: mustParseCIDR(t, "10.0.3.0/24"),
+	})
+	topology := NewNetworkTopology(network, servers[:1])
+
+	data, err := json.Marshal(topology)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Network struct {
+			ID      int    `json:"id"`
+			Name    string `json:"name"`
+			IPRange string `json:"ip_range"`
+		} `json:"network"`
+		Subnets []struct {
+			Type      string `json:"type"`
+			IPRange   string `json:"ip_range"`
+			VSwitchID int    `json:"vswitch_id"`
+			Servers   []int  `json:"servers"`
+		} `json:"subnets"`
+		Routes []struct {
+			Destination   string `json:"destination"`
+			Gateway       string `json:"gateway"`
+			GatewayServer *int   `json:"gateway_server"`
+		} `json:"routes"`
+		Servers []struct {
+			ID     int    `json:"id"`
+			IP     string `json:"ip"`
+			Subnet string `json:"subnet"`
+		} `json:"servers"`
+		Issues []NetworkTopologyIssue `json:"issues"`
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Network.ID != 1 || out.Network.Name != "mynet" || out.Network.IPRange != "10.0.0.0/16" {
+		t.Errorf("unexpected network: %+v", out.Network)
+	}
+	if len(out.Subnets) != 4 {
+		t.Fatalf("unexpected number of subnets: %d", len(out.Subnets))
+	}
+	if out.Subnets[0].IPRange != "10.0.0.0/24" || len(out.Subnets[0].Servers) != 1 || out.Subnets[0].Servers[0] != 1 {
+		t.Errorf("unexpected first subnet: %+v", out.Subnets[0])
+	}
+	if out.Subnets[2].Type != "vswitch" || out.Subnets[2].VSwitchID != 1000 {
+		t.Errorf("unexpected vSwitch subnet: %+v", out.Subnets[2])
+	}
+	if len(out.Routes) != 1 || out.Routes[0].GatewayServer == nil || *out.Routes[0].GatewayServer != 1 {
+		t.Errorf("unexpected routes: %+v", out.Routes)
+	}
+	if len(out.Servers) != 3 || out.Servers[0].IP != "10.0.0.2" || out.Servers[0].Subnet != "10.0.0.0/24" {
+		t.Errorf("unexpected servers: %+v", out.Servers)
+	}
+
+	var unused []string
+	for _, issue := range out.Issues {
+		if issue.Type != NetworkTopologyIssueUnusedSubnet {
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+		unused = append(unused, issue.Message)
+	}
+	if !equalStrings(unused, []string{
+		"subnet 10.0.1.0/24 is not used by any server or route",
+		"subnet 10.0.3.0/24 is not used by any server or route",
+	}) {
+		t.Errorf("unexpected issues: %v", out.Issues)
+	}
+}
+
+func TestNetworkClientTopology(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/networks/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.NetworkGetResponse{
+			Network: schema.Network{
+				ID:      1,
+				Name:    "mynet",
+				IPRange: "10.0.0.0/16",
+				Subnets: []schema.NetworkSubnet{
+					{Type: "cloud", IPRange: "10.0.0.0/24", NetworkZone: "eu-central", Gateway: "10.0.0.1"},
+				},
+				Routes: []schema.NetworkRoute{
+					{Destination: "10.100.0.0/24", Gateway: "10.0.0.2"},
+				},
+				Servers: []int{2},
+			},
+		})
+	})
+	env.Mux.HandleFunc("/servers/2", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{
+				ID:   2,
+				Name: "router",
+				PrivateNet: []schema.ServerPrivateNet{
+					{Network: 1, IP: "10.0.0.2"},
+				},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	topology, err := env.Client.Network.Topology(ctx, &Network{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topology.Servers) != 1 || topology.Servers[0].Name != "router" {
+		t.Fatalf("unexpected servers: %+v", topology.Servers)
+	}
+	if len(topology.Routes) != 1 || topology.Routes[0].GatewayServer != topology.Servers[0] {
+		t.Errorf("unexpected routes: %+v", topology.Routes)
+	}
+	if issues := topology.Check(); len(issues) != 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
@@ -0,0 +1,288 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// PlacementGroup represents a placement group in the Hetzner Cloud.
+type PlacementGroup struct {
+	ID      int
+	Name    string
+	Labels  map[string]string
+	Created time.Time
+	Servers []int
+	Type    PlacementGroupType
+}
+
+// PlacementGroupType specifies the type of a placement group.
+type PlacementGroupType string
+
+// PlacementGroupTypeSpread places all servers of a placement group on
+// different physical hosts.
+const PlacementGroupTypeSpread PlacementGroupType = "spread"
+
+// PlacementGroupSpreadMaxServers is the maximum number of servers in a
+// placement group of type spread.
+const PlacementGroupSpreadMaxServers = 10
+
+// ErrPlacementGroupFull is returned when adding a server to a spread placement
+// group would exceed the number of hosts the servers can be spread across.
+var ErrPlacementGroupFull = errors.New("hcloud: placement group is full")
+
+// PlacementGroupClient is a client for the placement groups API.
+type PlacementGroupClient struct {
+	client *Client
+}
+
+// GetByID retrieves a placement group by its ID. If the placement group does not exist, nil is returned.
+func (c *PlacementGroupClient) GetByID(ctx context.Context, id int) (*PlacementGroup, *Response, error) {
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("/placement_groups/%d", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.PlacementGroupGetResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, nil, err
+	}
+	return PlacementGroupFromSchema(body.PlacementGroup), resp, nil
+}
+
+// GetByName retrieves a placement group by its name. If the placement group does not exist, nil is returned.
+func (c *PlacementGroupClient) GetByName(ctx context.Context, name string) (*PlacementGroup, *Response, error) {
+	placementGroups, response, err := c.List(ctx, PlacementGroupListOpts{Name: name})
+	if len(placementGroups) == 0 {
+		return nil, response, err
+	}
+	return placementGroups[0], response, err
+}
+
+// Get retrieves a placement group by its ID if the input can be parsed as an integer, otherwise it
+// retrieves a placement group by its name. If the placement group does not exist, nil is returned.
+func (c *PlacementGroupClient) Get(ctx context.Context, idOrName string) (*PlacementGroup, *Response, error) {
+	if id, err := strconv.Atoi(idOrName); err == nil {
+		return c.GetByID(ctx, int(id))
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// PlacementGroupListOpts specifies options for listing placement groups.
+type PlacementGroupListOpts struct {
+	ListOpts
+	Name string
+	Type PlacementGroupType
+}
+
+func (l PlacementGroupListOpts) values() url.Values {
+	vals := l.ListOpts.values()
+	if l.Name != "" {
+		vals.Add("name", l.Name)
+	}
+	if l.Type != "" {
+		vals.Add("type", string(l.Type))
+	}
+	return vals
+}
+
+// List returns a list of placement groups for a specific page.
+func (c *PlacementGroupClient) List(ctx context.Context, opts PlacementGroupListOpts) ([]*PlacementGroup, *Response, error) {
+	path := "/placement_groups?" + opts.values().Encode()
+	req, err := c.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.PlacementGroupListResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+	placementGroups := make([]*PlacementGroup, 0, len(body.PlacementGroups))
+	for _, s := range body.PlacementGroups {
+		placementGroups = append(placementGroups, PlacementGroupFromSchema(s))
+	}
+	return placementGroups, resp, nil
+}
+
+// All returns all placement groups.
+func (c *PlacementGroupClient) All(ctx context.Context) ([]*PlacementGroup, error) {
+	return c.AllWithOpts(ctx, PlacementGroupListOpts{ListOpts: ListOpts{PerPage: 50}})
+}
+
+// AllWithOpts returns all placement groups for the given options.
+func (c *PlacementGroupClient) AllWithOpts(ctx context.Context, opts PlacementGroupListOpts) ([]*PlacementGroup, error) {
+	allPlacementGroups := []*PlacementGroup{}
+
+	_, err := c.client.all(func(page int) (*Response, error) {
+		opts.Page = page
+		placementGroups, resp, err := c.List(ctx, opts)
+		if err != nil {
+			return resp, err
+		}
+		allPlacementGroups = append(allPlacementGroups, placementGroups...)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allPlacementGroups, nil
+}
+
+// PlacementGroupCreateOpts specifies options for creating a new placement group.
+type PlacementGroupCreateOpts struct {
+	Name   string
+	Labels map[string]string
+	Type   PlacementGroupType
+}
+
+// Validate checks if options are valid.
+func (o PlacementGroupCreateOpts) Validate() error {
+	if o.Name == "" {
+		return errors.New("missing name")
+	}
+	if o.Type != PlacementGroupTypeSpread {
+		return errors.New("missing or invalid type")
+	}
+	return nil
+}
+
+// PlacementGroupCreateResult is the result of creating a placement group.
+type PlacementGroupCreateResult struct {
+	PlacementGroup *PlacementGroup
+	Action         *Action
+}
+
+// Create creates a new placement group.
+func (c *PlacementGroupClient) Create(ctx context.Context, opts PlacementGroupCreateOpts) (PlacementGroupCreateResult, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return PlacementGroupCreateResult{}, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	reqBody := schema.PlacementGroupCreateRequest{
+		Name: opts.Name,
+		Type: string(opts.Type),
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return PlacementGroupCreateResult{}, nil, err
+	}
+	req, err := c.client.NewRequest(ctx, "POST", "/placement_groups", bytes.NewReader(reqBodyData))
+	if err != nil {
+		return PlacementGroupCreateResult{}, nil, err
+	}
+
+	respBody := schema.PlacementGroupCreateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return PlacementGroupCreateResult{}, resp, err
+	}
+	result := PlacementGroupCreateResult{
+		PlacementGroup: PlacementGroupFromSchema(respBody.PlacementGroup),
+	}
+	if respBody.Action != nil {
+		result.Action = ActionFromSchema(*respBody.Action)
+	}
+	return result, resp, nil
+}
+
+// PlacementGroupUpdateOpts specifies options for updating a placement group.
+type PlacementGroupUpdateOpts struct {
+	Name   string
+	Labels map[string]string
+}
+
+// Update updates a placement group.
+func (c *PlacementGroupClient) Update(ctx context.Context, placementGroup *PlacementGroup, opts PlacementGroupUpdateOpts) (*PlacementGroup, *Response, error) {
+	reqBody := schema.PlacementGroupUpdateRequest{}
+	if opts.Name != "" {
+		reqBody.Name = &opts.Name
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/placement_groups/%d", placementGroup.ID)
+	req, err := c.client.NewRequest(ctx, "PUT", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.PlacementGroupUpdateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return PlacementGroupFromSchema(respBody.PlacementGroup), resp, nil
+}
+
+// Delete deletes a placement group.
+func (c *PlacementGroupClient) Delete(ctx context.Context, placementGroup *PlacementGroup) (*Response, error) {
+	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("/placement_groups/%d", placementGroup.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req, nil)
+}
+
+// reserve fetches the current state of a placement group and checks whether
+// server can be added to it. A nil server stands for a server which is about
+// to be created. ErrPlacementGroupFull is returned if the group has no room
+// left.
+func (c *PlacementGroupClient) reserve(ctx context.Context, placementGroup *PlacementGroup, server *Server) (*PlacementGroup, error) {
+	var (
+		current *PlacementGroup
+		err     error
+	)
+	if placementGroup.ID != 0 {
+		current, _, err = c.GetByID(ctx, placementGroup.ID)
+	} else {
+		current, _, err = c.GetByName(ctx, placementGroup.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("hcloud: placement group %s not found", placementGroupRef(placementGroup))
+	}
+	if current.Type != PlacementGroupTypeSpread {
+		return current, nil
+	}
+	if server != nil {
+		for _, id := range current.Servers {
+			if id == server.ID {
+				return current, nil
+			}
+		}
+	}
+	if len(current.Servers) >= PlacementGroupSpreadMaxServers {
+		return nil, ErrPlacementGroupFull
+	}
+	return current, nil
+}
+
+func placementGroupRef(placementGroup *PlacementGroup) string {
+	if placementGroup.ID != 0 {
+		return strconv.Itoa(placementGroup.ID)
+	}
+	return strconv.Quote(placementGroup.Name)
+}
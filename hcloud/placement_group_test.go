@@ -0,0 +1,216 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestPlacementGroupClientGetByID(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.PlacementGroupGetResponse{
+			PlacementGroup: schema.PlacementGroup{ID: 1, Type: "spread"},
+		})
+	})
+
+	ctx := context.Background()
+	placementGroup, _, err := env.Client.PlacementGroup.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placementGroup == nil {
+		t.Fatal("no placement group")
+	}
+	if placementGroup.ID != 1 || placementGroup.Type != PlacementGroupTypeSpread {
+		t.Errorf("unexpected placement group: %v", placementGroup)
+	}
+
+	t.Run("via Get", func(t *testing.T) {
+		placementGroup, _, err := env.Client.PlacementGroup.Get(ctx, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if placementGroup == nil {
+			t.Fatal("no placement group")
+		}
+		if placementGroup.ID != 1 {
+			t.Errorf("unexpected placement group ID: %v", placementGroup.ID)
+		}
+	})
+}
+
+func TestPlacementGroupClientGetByIDNotFound(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(schema.ErrorResponse{
+			Error: schema.Error{
+				Code: string(ErrorCodeNotFound),
+			},
+		})
+	})
+
+	ctx := context.Background()
+	placementGroup, _, err := env.Client.PlacementGroup.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placementGroup != nil {
+		t.Fatal("expected no placement group")
+	}
+}
+
+func TestPlacementGroupClientList(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "name=mygroup&type=spread" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(schema.PlacementGroupListResponse{
+			PlacementGroups: []schema.PlacementGroup{{ID: 1, Name: "mygroup"}},
+		})
+	})
+
+	ctx := context.Background()
+	opts := PlacementGroupListOpts{Name: "mygroup", Type: PlacementGroupTypeSpread}
+	placementGroups, _, err := env.Client.PlacementGroup.List(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(placementGroups) != 1 || placementGroups[0].ID != 1 {
+		t.Errorf("unexpected placement groups: %v", placementGroups)
+	}
+}
+
+func TestPlacementGroupClientCreate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("expected POST")
+		}
+		var reqBody schema.PlacementGroupCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name != "mygroup" || reqBody.Type != "spread" {
+			t.Errorf("unexpected request: %v", reqBody)
+		}
+		if reqBody.Labels == nil || (*reqBody.Labels)["key"] != "value" {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		json.NewEncoder(w).Encode(schema.PlacementGroupCreateResponse{
+			PlacementGroup: schema.PlacementGroup{ID: 1, Name: "mygroup", Type: "spread"},
+		})
+	})
+
+	ctx := context.Background()
+	opts := PlacementGroupCreateOpts{
+		Name:   "mygroup",
+		Labels: map[string]string{"key": "value"},
+		Type:   PlacementGroupTypeSpread,
+	}
+	result, _, err := env.Client.PlacementGroup.Create(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PlacementGroup == nil || result.PlacementGroup.ID != 1 {
+		t.Errorf("unexpected placement group: %v", result.PlacementGroup)
+	}
+	if result.Action != nil {
+		t.Errorf("unexpected action: %v", result.Action)
+	}
+}
+
+func TestPlacementGroupCreateOptsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Opts  PlacementGroupCreateOpts
+		Valid bool
+	}{
+		"valid": {
+			Opts:  PlacementGroupCreateOpts{Name: "mygroup", Type: PlacementGroupTypeSpread},
+			Valid: true,
+		},
+		"missing name": {
+			Opts: PlacementGroupCreateOpts{Type: PlacementGroupTypeSpread},
+		},
+		"missing type": {
+			Opts: PlacementGroupCreateOpts{Name: "mygroup"},
+		},
+		"invalid type": {
+			Opts: PlacementGroupCreateOpts{Name: "mygroup", Type: "cluster"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.Opts.Validate()
+			if testCase.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestPlacementGroupClientUpdate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Error("expected PUT")
+		}
+		var reqBody schema.PlacementGroupUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name == nil || *reqBody.Name != "renamed" {
+			t.Errorf("unexpected name: %v", reqBody.Name)
+		}
+		if reqBody.Labels != nil {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		json.NewEncoder(w).Encode(schema.PlacementGroupUpdateResponse{
+			PlacementGroup: schema.PlacementGroup{ID: 1, Name: "renamed"},
+		})
+	})
+
+	ctx := context.Background()
+	placementGroup, _, err := env.Client.PlacementGroup.Update(ctx, &PlacementGroup{ID: 1}, PlacementGroupUpdateOpts{Name: "renamed"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if placementGroup.Name != "renamed" {
+		t.Errorf("unexpected name: %v", placementGroup.Name)
+	}
+}
+
+func TestPlacementGroupClientDelete(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Error("expected DELETE")
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := env.Client.PlacementGroup.Delete(ctx, &PlacementGroup{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
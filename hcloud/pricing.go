@@ -59,6 +59,14 @@ type ServerTypeLocationPricing struct {
 	Monthly  Price
 }
 
+// LoadBalancerTypeLocationPricing provides pricing information for a Load
+// Balancer type at a location.
+type LoadBalancerTypeLocationPricing struct {
+	Location *Location
+	Hourly   Price
+	Monthly  Price
+}
+
 // PricingClient is a client for the pricing API.
 type PricingClient struct {
 	client *Client
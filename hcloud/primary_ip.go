@@ -0,0 +1,411 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// PrimaryIP represents a Primary IP in the Hetzner Cloud. Unlike the
+// addresses assigned on server creation, a Primary IP can outlive the
+// server it is assigned to.
+type PrimaryIP struct {
+	ID           int
+	Name         string
+	IP           net.IP
+	Network      *net.IPNet
+	Type         PrimaryIPType
+	AssigneeID   int
+	AssigneeType PrimaryIPAssigneeType
+	AutoDelete   bool
+	Blocked      bool
+	Created      time.Time
+	Datacenter   *Datacenter
+	DNSPtr       map[string]string
+	Labels       map[string]string
+	Protection   PrimaryIPProtection
+}
+
+// DNSPtrForIP returns the reverse DNS pointer of the IP address.
+func (p *PrimaryIP) DNSPtrForIP(ip net.IP) string {
+	return p.DNSPtr[ip.String()]
+}
+
+// IsAssigned returns whether the Primary IP is currently assigned.
+func (p *PrimaryIP) IsAssigned() bool {
+	return p.AssigneeID != 0
+}
+
+// PrimaryIPProtection represents the protection level of a Primary IP.
+type PrimaryIPProtection struct {
+	Delete bool
+}
+
+// PrimaryIPType represents the type of a Primary IP.
+type PrimaryIPType string
+
+// Primary IP types.
+const (
+	PrimaryIPTypeIPv4 PrimaryIPType = "ipv4"
+	PrimaryIPTypeIPv6 PrimaryIPType = "ipv6"
+)
+
+// PrimaryIPAssigneeType represents the type of resource a Primary IP
+// can be assigned to.
+type PrimaryIPAssigneeType string
+
+// PrimaryIPAssigneeTypeServer is the assignee type of Primary IPs assigned
+// to a server.
+const PrimaryIPAssigneeTypeServer PrimaryIPAssigneeType = "server"
+
+// PrimaryIPClient is a client for the Primary IP API.
+type PrimaryIPClient struct {
+	client *Client
+}
+
+// GetByID retrieves a Primary IP by its ID. If the Primary IP does not exist,
+// nil is returned.
+func (c *PrimaryIPClient) GetByID(ctx context.Context, id int) (*PrimaryIP, *Response, error) {
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("/primary_ips/%d", id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.PrimaryIPGetResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, nil, err
+	}
+	return PrimaryIPFromSchema(body.PrimaryIP), resp, nil
+}
+
+// GetByName retrieves a Primary IP by its name. If the Primary IP does not exist, nil is returned.
+func (c *PrimaryIPClient) GetByName(ctx context.Context, name string) (*PrimaryIP, *Response, error) {
+	primaryIPs, response, err := c.List(ctx, PrimaryIPListOpts{Name: name})
+	if len(primaryIPs) == 0 {
+		return nil, response, err
+	}
+	return primaryIPs[0], response, err
+}
+
+// GetByIP retrieves a Primary IP by its IP address. If the Primary IP does not exist, nil is returned.
+func (c *PrimaryIPClient) GetByIP(ctx context.Context, ip string) (*PrimaryIP, *Response, error) {
+	primaryIPs, response, err := c.List(ctx, PrimaryIPListOpts{IP: ip})
+	if len(primaryIPs) == 0 {
+		return nil, response, err
+	}
+	return primaryIPs[0], response, err
+}
+
+// Get retrieves a Primary IP by its ID if the input can be parsed as an integer, otherwise it
+// retrieves a Primary IP by its name. If the Primary IP does not exist, nil is returned.
+func (c *PrimaryIPClient) Get(ctx context.Context, idOrName string) (*PrimaryIP, *Response, error) {
+	if id, err := strconv.Atoi(idOrName); err == nil {
+		return c.GetByID(ctx, int(id))
+	}
+	return c.GetByName(ctx, idOrName)
+}
+
+// PrimaryIPListOpts specifies options for listing Primary IPs.
+type PrimaryIPListOpts struct {
+	ListOpts
+	Name string
+	IP   string
+}
+
+func (l PrimaryIPListOpts) values() url.Values {
+	vals := l.ListOpts.values()
+	if l.Name != "" {
+		vals.Add("name", l.Name)
+	}
+	if l.IP != "" {
+		vals.Add("ip", l.IP)
+	}
+	return vals
+}
+
+// List returns a list of Primary IPs for a specific page.
+func (c *PrimaryIPClient) List(ctx context.Context, opts PrimaryIPListOpts) ([]*PrimaryIP, *Response, error) {
+	path := "/primary_ips?" + opts.values().Encode()
+	req, err := c.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var body schema.PrimaryIPListResponse
+	resp, err := c.client.Do(req, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+	primaryIPs := make([]*PrimaryIP, 0, len(body.PrimaryIPs))
+	for _, s := range body.PrimaryIPs {
+		primaryIPs = append(primaryIPs, PrimaryIPFromSchema(s))
+	}
+	return primaryIPs, resp, nil
+}
+
+// All returns all Primary IPs.
+func (c *PrimaryIPClient) All(ctx context.Context) ([]*PrimaryIP, error) {
+	return c.AllWithOpts(ctx, PrimaryIPListOpts{ListOpts: ListOpts{PerPage: 50}})
+}
+
+// AllWithOpts returns all Primary IPs for the given options.
+func (c *PrimaryIPClient) AllWithOpts(ctx context.Context, opts PrimaryIPListOpts) ([]*PrimaryIP, error) {
+	allPrimaryIPs := []*PrimaryIP{}
+
+	_, err := c.client.all(func(page int) (*Response, error) {
+		opts.Page = page
+		primaryIPs, resp, err := c.List(ctx, opts)
+		if err != nil {
+			return resp, err
+		}
+		allPrimaryIPs = append(allPrimaryIPs, primaryIPs...)
+		return resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allPrimaryIPs, nil
+}
+
+// PrimaryIPCreateOpts specifies options for creating a Primary IP. Either a
+// datacenter or a server to assign the Primary IP to must be given.
+type PrimaryIPCreateOpts struct {
+	Name       string
+	Type       PrimaryIPType
+	Datacenter *Datacenter
+	Server     *Server
+	AutoDelete *bool
+	Labels     map[string]string
+}
+
+// Validate checks if options are valid.
+func (o PrimaryIPCreateOpts) Validate() error {
+	if o.Name == "" {
+		return errors.New("missing name")
+	}
+	switch o.Type {
+	case PrimaryIPTypeIPv4, PrimaryIPTypeIPv6:
+		break
+	default:
+		return errors.New("missing or invalid type")
+	}
+	if o.Datacenter == nil && o.Server == nil {
+		return errors.New("one of datacenter or server is required")
+	}
+	if o.Datacenter != nil && o.Server != nil {
+		return errors.New("datacenter and server are mutually exclusive")
+	}
+	return nil
+}
+
+// PrimaryIPCreateResult is the result of creating a Primary IP.
+type PrimaryIPCreateResult struct {
+	PrimaryIP *PrimaryIP
+	Action    *Action
+}
+
+// Create creates a Primary IP.
+func (c *PrimaryIPClient) Create(ctx context.Context, opts PrimaryIPCreateOpts) (PrimaryIPCreateResult, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return PrimaryIPCreateResult{}, nil, fmt.Errorf("invalid options: %s", err)
+	}
+
+	reqBody := schema.PrimaryIPCreateRequest{
+		Name:         opts.Name,
+		Type:         string(opts.Type),
+		AssigneeType: string(PrimaryIPAssigneeTypeServer),
+		AutoDelete:   opts.AutoDelete,
+	}
+	if opts.Datacenter != nil {
+		if opts.Datacenter.ID != 0 {
+			reqBody.Datacenter = strconv.Itoa(opts.Datacenter.ID)
+		} else {
+			reqBody.Datacenter = opts.Datacenter.Name
+		}
+	}
+	if opts.Server != nil {
+		reqBody.AssigneeID = Int(opts.Server.ID)
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return PrimaryIPCreateResult{}, nil, err
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", "/primary_ips", bytes.NewReader(reqBodyData))
+	if err != nil {
+		return PrimaryIPCreateResult{}, nil, err
+	}
+
+	var respBody schema.PrimaryIPCreateResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return PrimaryIPCreateResult{}, resp, err
+	}
+	var action *Action
+	if respBody.Action != nil {
+		action = ActionFromSchema(*respBody.Action)
+	}
+	return PrimaryIPCreateResult{
+		PrimaryIP: PrimaryIPFromSchema(respBody.PrimaryIP),
+		Action:    action,
+	}, resp, nil
+}
+
+// Delete deletes a Primary IP.
+func (c *PrimaryIPClient) Delete(ctx context.Context, primaryIP *PrimaryIP) (*Response, error) {
+	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("/primary_ips/%d", primaryIP.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.client.Do(req, nil)
+}
+
+// PrimaryIPUpdateOpts specifies options for updating a Primary IP.
+// AutoDelete controls whether the Primary IP is deleted together with
+// the server it is assigned to.
+type PrimaryIPUpdateOpts struct {
+	Name       string
+	Labels     map[string]string
+	AutoDelete *bool
+}
+
+// Update updates a Primary IP.
+func (c *PrimaryIPClient) Update(ctx context.Context, primaryIP *PrimaryIP, opts PrimaryIPUpdateOpts) (*PrimaryIP, *Response, error) {
+	reqBody := schema.PrimaryIPUpdateRequest{
+		Name:       opts.Name,
+		AutoDelete: opts.AutoDelete,
+	}
+	if opts.Labels != nil {
+		reqBody.Labels = &opts.Labels
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/primary_ips/%d", primaryIP.ID)
+	req, err := c.client.NewRequest(ctx, "PUT", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.PrimaryIPUpdateResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return PrimaryIPFromSchema(respBody.PrimaryIP), resp, nil
+}
+
+// Assign assigns a Primary IP to a server. The server must be powered off
+// and must not have a Primary IP of the same type assigned.
+func (c *PrimaryIPClient) Assign(ctx context.Context, primaryIP *PrimaryIP, server *Server) (*Action, *Response, error) {
+	reqBody := schema.PrimaryIPActionAssignRequest{
+		AssigneeID:   server.ID,
+		AssigneeType: string(PrimaryIPAssigneeTypeServer),
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/primary_ips/%d/actions/assign", primaryIP.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.PrimaryIPActionAssignResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// Unassign unassigns a Primary IP from the currently assigned server.
+func (c *PrimaryIPClient) Unassign(ctx context.Context, primaryIP *PrimaryIP) (*Action, *Response, error) {
+	path := fmt.Sprintf("/primary_ips/%d/actions/unassign", primaryIP.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.PrimaryIPActionUnassignResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// ChangeDNSPtr changes or resets the reverse DNS pointer for a Primary IP address.
+// Pass a nil ptr to reset the reverse DNS pointer to its default value.
+func (c *PrimaryIPClient) ChangeDNSPtr(ctx context.Context, primaryIP *PrimaryIP, ip string, ptr *string) (*Action, *Response, error) {
+	reqBody := schema.PrimaryIPActionChangeDNSPtrRequest{
+		IP:     ip,
+		DNSPtr: ptr,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/primary_ips/%d/actions/change_dns_ptr", primaryIP.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.PrimaryIPActionChangeDNSPtrResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// PrimaryIPChangeProtectionOpts specifies options for changing the resource protection level of a Primary IP.
+type PrimaryIPChangeProtectionOpts struct {
+	Delete *bool
+}
+
+// ChangeProtection changes the resource protection level of a Primary IP.
+func (c *PrimaryIPClient) ChangeProtection(ctx context.Context, primaryIP *PrimaryIP, opts PrimaryIPChangeProtectionOpts) (*Action, *Response, error) {
+	reqBody := schema.PrimaryIPActionChangeProtectionRequest{
+		Delete: opts.Delete,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/primary_ips/%d/actions/change_protection", primaryIP.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.PrimaryIPActionChangeProtectionResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
@@ -0,0 +1,327 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestPrimaryIPClientGetByID(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.PrimaryIPGetResponse{
+			PrimaryIP: schema.PrimaryIP{ID: 1, Type: "ipv4", IP: "131.232.99.1"},
+		})
+	})
+
+	ctx := context.Background()
+	primaryIP, _, err := env.Client.PrimaryIP.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primaryIP == nil {
+		t.Fatal("no Primary IP")
+	}
+	if primaryIP.ID != 1 || primaryIP.IP.String() != "131.232.99.1" {
+		t.Errorf("unexpected Primary IP: %v", primaryIP)
+	}
+	if primaryIP.IsAssigned() {
+		t.Error("expected Primary IP not to be assigned")
+	}
+
+	t.Run("via Get", func(t *testing.T) {
+		primaryIP, _, err := env.Client.PrimaryIP.Get(ctx, "1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if primaryIP == nil {
+			t.Fatal("no Primary IP")
+		}
+		if primaryIP.ID != 1 {
+			t.Errorf("unexpected Primary IP ID: %v", primaryIP.ID)
+		}
+	})
+}
+
+func TestPrimaryIPClientGetByIDNotFound(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(schema.ErrorResponse{
+			Error: schema.Error{
+				Code: string(ErrorCodeNotFound),
+			},
+		})
+	})
+
+	ctx := context.Background()
+	primaryIP, _, err := env.Client.PrimaryIP.GetByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primaryIP != nil {
+		t.Fatal("expected no Primary IP")
+	}
+}
+
+func TestPrimaryIPClientGetByIP(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "ip=131.232.99.1" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(schema.PrimaryIPListResponse{
+			PrimaryIPs: []schema.PrimaryIP{{ID: 1, Type: "ipv4", IP: "131.232.99.1"}},
+		})
+	})
+
+	ctx := context.Background()
+	primaryIP, _, err := env.Client.PrimaryIP.GetByIP(ctx, "131.232.99.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primaryIP == nil || primaryIP.ID != 1 {
+		t.Errorf("unexpected Primary IP: %v", primaryIP)
+	}
+}
+
+func TestPrimaryIPClientCreate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("expected POST")
+		}
+		var reqBody schema.PrimaryIPCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Name != "my-ip" || reqBody.Type != "ipv6" || reqBody.AssigneeType != "server" {
+			t.Errorf("unexpected request: %v", reqBody)
+		}
+		if reqBody.Datacenter != "fsn1-dc14" || reqBody.AssigneeID != nil {
+			t.Errorf("unexpected datacenter or assignee: %v %v", reqBody.Datacenter, reqBody.AssigneeID)
+		}
+		if reqBody.AutoDelete == nil || *reqBody.AutoDelete {
+			t.Errorf("unexpected auto delete: %v", reqBody.AutoDelete)
+		}
+		json.NewEncoder(w).Encode(schema.PrimaryIPCreateResponse{
+			PrimaryIP: schema.PrimaryIP{ID: 1, Type: "ipv6", IP: "2001:db8::/64"},
+			Action:    &schema.Action{ID: 2},
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.PrimaryIP.Create(ctx, PrimaryIPCreateOpts{
+		Name:       "my-ip",
+		Type:       PrimaryIPTypeIPv6,
+		Datacenter: &Datacenter{Name: "fsn1-dc14"},
+		AutoDelete: Bool(false),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PrimaryIP == nil || result.PrimaryIP.Network == nil || result.PrimaryIP.Network.String() != "2001:db8::/64" {
+		t.Errorf("unexpected Primary IP: %v", result.PrimaryIP)
+	}
+	if result.Action == nil || result.Action.ID != 2 {
+		t.Errorf("unexpected action: %v", result.Action)
+	}
+}
+
+func TestPrimaryIPCreateOptsValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Opts  PrimaryIPCreateOpts
+		Valid bool
+	}{
+		"with datacenter": {
+			Opts:  PrimaryIPCreateOpts{Name: "my-ip", Type: PrimaryIPTypeIPv4, Datacenter: &Datacenter{ID: 1}},
+			Valid: true,
+		},
+		"with server": {
+			Opts:  PrimaryIPCreateOpts{Name: "my-ip", Type: PrimaryIPTypeIPv4, Server: &Server{ID: 1}},
+			Valid: true,
+		},
+		"missing name": {
+			Opts: PrimaryIPCreateOpts{Type: PrimaryIPTypeIPv4, Server: &Server{ID: 1}},
+		},
+		"invalid type": {
+			Opts: PrimaryIPCreateOpts{Name: "my-ip", Type: "ipv5", Server: &Server{ID: 1}},
+		},
+		"missing datacenter and server": {
+			Opts: PrimaryIPCreateOpts{Name: "my-ip", Type: PrimaryIPTypeIPv4},
+		},
+		"datacenter and server": {
+			Opts: PrimaryIPCreateOpts{Name: "my-ip", Type: PrimaryIPTypeIPv4, Datacenter: &Datacenter{ID: 1}, Server: &Server{ID: 1}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.Opts.Validate()
+			if testCase.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestPrimaryIPClientUpdate(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Error("expected PUT")
+		}
+		var reqBody schema.PrimaryIPUpdateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.AutoDelete == nil || !*reqBody.AutoDelete {
+			t.Errorf("unexpected auto delete: %v", reqBody.AutoDelete)
+		}
+		json.NewEncoder(w).Encode(schema.PrimaryIPUpdateResponse{
+			PrimaryIP: schema.PrimaryIP{ID: 1, AutoDelete: true},
+		})
+	})
+
+	ctx := context.Background()
+	primaryIP, _, err := env.Client.PrimaryIP.Update(ctx, &PrimaryIP{ID: 1}, PrimaryIPUpdateOpts{AutoDelete: Bool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !primaryIP.AutoDelete {
+		t.Error("expected auto delete")
+	}
+}
+
+func TestPrimaryIPClientDelete(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Error("expected DELETE")
+		}
+	})
+
+	ctx := context.Background()
+	if _, err := env.Client.PrimaryIP.Delete(ctx, &PrimaryIP{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPrimaryIPClientAssign(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1/actions/assign", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.PrimaryIPActionAssignRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.AssigneeID != 5 || reqBody.AssigneeType != "server" {
+			t.Errorf("unexpected request: %v", reqBody)
+		}
+		json.NewEncoder(w).Encode(schema.PrimaryIPActionAssignResponse{
+			Action: schema.Action{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	action, _, err := env.Client.PrimaryIP.Assign(ctx, &PrimaryIP{ID: 1}, &Server{ID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ID != 1 {
+		t.Errorf("unexpected action ID: %d", action.ID)
+	}
+}
+
+func TestPrimaryIPClientUnassign(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1/actions/unassign", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.PrimaryIPActionUnassignResponse{
+			Action: schema.Action{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	action, _, err := env.Client.PrimaryIP.Unassign(ctx, &PrimaryIP{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ID != 1 {
+		t.Errorf("unexpected action ID: %d", action.ID)
+	}
+}
+
+func TestPrimaryIPClientChangeDNSPtr(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1/actions/change_dns_ptr", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.PrimaryIPActionChangeDNSPtrRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.IP != "131.232.99.1" || reqBody.DNSPtr == nil || *reqBody.DNSPtr != "example.com" {
+			t.Errorf("unexpected request: %v", reqBody)
+		}
+		json.NewEncoder(w).Encode(schema.PrimaryIPActionChangeDNSPtrResponse{
+			Action: schema.Action{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	action, _, err := env.Client.PrimaryIP.ChangeDNSPtr(ctx, &PrimaryIP{ID: 1}, "131.232.99.1", String("example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ID != 1 {
+		t.Errorf("unexpected action ID: %d", action.ID)
+	}
+}
+
+func TestPrimaryIPClientChangeProtection(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/primary_ips/1/actions/change_protection", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.PrimaryIPActionChangeProtectionRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Delete == nil || !*reqBody.Delete {
+			t.Errorf("unexpected delete: %v", reqBody.Delete)
+		}
+		json.NewEncoder(w).Encode(schema.PrimaryIPActionChangeProtectionResponse{
+			Action: schema.Action{ID: 1},
+		})
+	})
+
+	ctx := context.Background()
+	action, _, err := env.Client.PrimaryIP.ChangeProtection(ctx, &PrimaryIP{ID: 1}, PrimaryIPChangeProtectionOpts{Delete: Bool(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ID != 1 {
+		t.Errorf("unexpected action ID: %d", action.ID)
+	}
+}
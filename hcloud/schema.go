@@ -1,12 +1,19 @@
 package hcloud
 
 import (
-	"github.com/ptr1120/hcloud-go/hcloud/schema"
+	"encoding/json"
+	"fmt"
+	"math"
 	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
 )
 
 // This file provides converter functions to convert models in the
-// schema package to models in the hcloud package.
+// schema package to models in the hcloud package and back.
 
 // ActionFromSchema converts a schema.Action to an Action.
 func ActionFromSchema(s schema.Action) *Action {
@@ -78,6 +85,40 @@ func FloatingIPFromSchema(s schema.FloatingIP) *FloatingIP {
 	return f
 }
 
+// PrimaryIPFromSchema converts a schema.PrimaryIP to a PrimaryIP.
+func PrimaryIPFromSchema(s schema.PrimaryIP) *PrimaryIP {
+	p := &PrimaryIP{
+		ID:           s.ID,
+		Name:         s.Name,
+		Type:         PrimaryIPType(s.Type),
+		AssigneeType: PrimaryIPAssigneeType(s.AssigneeType),
+		AutoDelete:   s.AutoDelete,
+		Blocked:      s.Blocked,
+		Created:      s.Created,
+		Datacenter:   DatacenterFromSchema(s.Datacenter),
+		Protection: PrimaryIPProtection{
+			Delete: s.Protection.Delete,
+		},
+	}
+	if s.AssigneeID != nil {
+		p.AssigneeID = *s.AssigneeID
+	}
+	if p.Type == PrimaryIPTypeIPv4 {
+		p.IP = net.ParseIP(s.IP)
+	} else {
+		p.IP, p.Network, _ = net.ParseCIDR(s.IP)
+	}
+	p.DNSPtr = map[string]string{}
+	for _, entry := range s.DNSPtr {
+		p.DNSPtr[entry.IP] = entry.DNSPtr
+	}
+	p.Labels = map[string]string{}
+	for key, value := range s.Labels {
+		p.Labels[key] = value
+	}
+	return p
+}
+
 // ISOFromSchema converts a schema.ISO to an ISO.
 func ISOFromSchema(s schema.ISO) *ISO {
 	return &ISO{
@@ -167,6 +208,9 @@ func ServerFromSchema(s schema.Server) *Server {
 	for _, privNet := range s.PrivateNet {
 		server.PrivateNet = append(server.PrivateNet, ServerPrivateNetFromSchema(privNet))
 	}
+	if s.PlacementGroup != nil {
+		server.PlacementGroup = PlacementGroupFromSchema(*s.PlacementGroup)
+	}
 	return server
 }
 
@@ -186,6 +230,7 @@ func ServerPublicNetFromSchema(s schema.ServerPublicNet) ServerPublicNet {
 // a ServerPublicNetIPv4.
 func ServerPublicNetIPv4FromSchema(s schema.ServerPublicNetIPv4) ServerPublicNetIPv4 {
 	return ServerPublicNetIPv4{
+		ID:      s.ID,
 		IP:      net.ParseIP(s.IP),
 		Blocked: s.Blocked,
 		DNSPtr:  s.DNSPtr,
@@ -196,6 +241,7 @@ func ServerPublicNetIPv4FromSchema(s schema.ServerPublicNetIPv4) ServerPublicNet
 // a ServerPublicNetIPv6.
 func ServerPublicNetIPv6FromSchema(s schema.ServerPublicNetIPv6) ServerPublicNetIPv6 {
 	ipv6 := ServerPublicNetIPv6{
+		ID:      s.ID,
 		Blocked: s.Blocked,
 		DNSPtr:  map[string]string{},
 	}
@@ -340,7 +386,8 @@ func NetworkFromSchema(s schema.Network) *Network {
 		Protection: NetworkProtection{
 			Delete: s.Protection.Delete,
 		},
-		Labels: map[string]string{},
+		Labels:                map[string]string{},
+		ExposeRoutesToVSwitch: s.ExposeRoutesToVSwitch,
 	}
 
 	_, n.IPRange, _ = net.ParseCIDR(s.IPRange)
@@ -367,6 +414,7 @@ func NetworkSubnetFromSchema(s schema.NetworkSubnet) NetworkSubnet {
 		Type:        NetworkSubnetType(s.Type),
 		NetworkZone: NetworkZone(s.NetworkZone),
 		Gateway:     net.ParseIP(s.Gateway),
+		VSwitchID:   s.VSwitchID,
 	}
 	_, sn.IPRange, _ = net.ParseCIDR(s.IPRange)
 	return sn
@@ -381,6 +429,261 @@ func NetworkRouteFromSchema(s schema.NetworkRoute) NetworkRoute {
 	return r
 }
 
+// FirewallFromSchema converts a schema.Firewall to a Firewall.
+func FirewallFromSchema(s schema.Firewall) *Firewall {
+	f := &Firewall{
+		ID:      s.ID,
+		Name:    s.Name,
+		Labels:  map[string]string{},
+		Created: s.Created,
+	}
+	for key, value := range s.Labels {
+		f.Labels[key] = value
+	}
+	for _, rule := range s.Rules {
+		f.Rules = append(f.Rules, FirewallRuleFromSchema(rule))
+	}
+	for _, res := range s.AppliedTo {
+		f.AppliedTo = append(f.AppliedTo, FirewallResourceFromSchema(res))
+	}
+	return f
+}
+
+// FirewallRuleFromSchema converts a schema.FirewallRule to a FirewallRule.
+func FirewallRuleFromSchema(s schema.FirewallRule) FirewallRule {
+	r := FirewallRule{
+		Direction:   FirewallRuleDirection(s.Direction),
+		Protocol:    FirewallRuleProtocol(s.Protocol),
+		Port:        s.Port,
+		Description: s.Description,
+	}
+	for _, sourceIP := range s.SourceIPs {
+		if _, ipNet, err := net.ParseCIDR(sourceIP); err == nil {
+			r.SourceIPs = append(r.SourceIPs, *ipNet)
+		}
+	}
+	for _, destinationIP := range s.DestinationIPs {
+		if _, ipNet, err := net.ParseCIDR(destinationIP); err == nil {
+			r.DestinationIPs = append(r.DestinationIPs, *ipNet)
+		}
+	}
+	return r
+}
+
+// FirewallResourceFromSchema converts a schema.FirewallResource to a FirewallResource.
+func FirewallResourceFromSchema(s schema.FirewallResource) FirewallResource {
+	r := FirewallResource{Type: FirewallResourceType(s.Type)}
+	if s.Server != nil {
+		r.Server = &FirewallResourceServer{ID: s.Server.ID}
+	}
+	if s.LabelSelector != nil {
+		r.LabelSelector = &FirewallResourceLabelSelector{Selector: s.LabelSelector.Selector}
+	}
+	return r
+}
+
+// LoadBalancerTypeFromSchema converts a schema.LoadBalancerType to a LoadBalancerType.
+func LoadBalancerTypeFromSchema(s schema.LoadBalancerType) *LoadBalancerType {
+	lt := &LoadBalancerType{
+		ID:                      s.ID,
+		Name:                    s.Name,
+		Description:             s.Description,
+		MaxConnections:          s.MaxConnections,
+		MaxServices:             s.MaxServices,
+		MaxTargets:              s.MaxTargets,
+		MaxAssignedCertificates: s.MaxAssignedCertificates,
+	}
+	for _, price := range s.Prices {
+		lt.Pricings = append(lt.Pricings, LoadBalancerTypeLocationPricing{
+			Location: &Location{Name: price.Location},
+			Hourly: Price{
+				Net:   price.PriceHourly.Net,
+				Gross: price.PriceHourly.Gross,
+			},
+			Monthly: Price{
+				Net:   price.PriceMonthly.Net,
+				Gross: price.PriceMonthly.Gross,
+			},
+		})
+	}
+	return lt
+}
+
+// LoadBalancerFromSchema converts a schema.LoadBalancer to a LoadBalancer.
+func LoadBalancerFromSchema(s schema.LoadBalancer) *LoadBalancer {
+	l := &LoadBalancer{
+		ID:   s.ID,
+		Name: s.Name,
+		PublicNet: LoadBalancerPublicNet{
+			Enabled: s.PublicNet.Enabled,
+			IPv4: LoadBalancerPublicNetIPv4{
+				IP:     net.ParseIP(s.PublicNet.IPv4.IP),
+				DNSPtr: s.PublicNet.IPv4.DNSPtr,
+			},
+			IPv6: LoadBalancerPublicNetIPv6{
+				IP:     net.ParseIP(s.PublicNet.IPv6.IP),
+				DNSPtr: s.PublicNet.IPv6.DNSPtr,
+			},
+		},
+		Location:         LocationFromSchema(s.Location),
+		LoadBalancerType: LoadBalancerTypeFromSchema(s.LoadBalancerType),
+		Algorithm:        LoadBalancerAlgorithm{Type: LoadBalancerAlgorithmType(s.Algorithm.Type)},
+		Protection: LoadBalancerProtection{
+			Delete: s.Protection.Delete,
+		},
+		Labels:          map[string]string{},
+		Created:         s.Created,
+		IncludedTraffic: s.IncludedTraffic,
+	}
+	for _, privateNet := range s.PrivateNet {
+		l.PrivateNet = append(l.PrivateNet, LoadBalancerPrivateNet{
+			Network: &Network{ID: privateNet.Network},
+			IP:      net.ParseIP(privateNet.IP),
+		})
+	}
+	if s.OutgoingTraffic != nil {
+		l.OutgoingTraffic = *s.OutgoingTraffic
+	}
+	if s.IngoingTraffic != nil {
+		l.IngoingTraffic = *s.IngoingTraffic
+	}
+	for _, service := range s.Services {
+		l.Services = append(l.Services, LoadBalancerServiceFromSchema(service))
+	}
+	for _, target := range s.Targets {
+		l.Targets = append(l.Targets, LoadBalancerTargetFromSchema(target))
+	}
+	for key, value := range s.Labels {
+		l.Labels[key] = value
+	}
+	return l
+}
+
+// LoadBalancerServiceFromSchema converts a schema.LoadBalancerService to a LoadBalancerService.
+func LoadBalancerServiceFromSchema(s schema.LoadBalancerService) LoadBalancerService {
+	ls := LoadBalancerService{
+		Protocol:        LoadBalancerServiceProtocol(s.Protocol),
+		ListenPort:      s.ListenPort,
+		DestinationPort: s.DestinationPort,
+		Proxyprotocol:   s.Proxyprotocol,
+	}
+	if s.HTTP != nil {
+		ls.HTTP = LoadBalancerServiceHTTP{
+			CookieName:     s.HTTP.CookieName,
+			CookieLifetime: time.Duration(s.HTTP.CookieLifetime) * time.Second,
+			RedirectHTTP:   s.HTTP.RedirectHTTP,
+			StickySessions: s.HTTP.StickySessions,
+		}
+		for _, certificateID := range s.HTTP.Certificates {
+			ls.HTTP.Certificates = append(ls.HTTP.Certificates, &Certificate{ID: certificateID})
+		}
+	}
+	if s.HealthCheck != nil {
+		ls.HealthCheck = LoadBalancerServiceHealthCheck{
+			Protocol: LoadBalancerServiceProtocol(s.HealthCheck.Protocol),
+			Port:     s.HealthCheck.Port,
+			Interval: time.Duration(s.HealthCheck.Interval) * time.Second,
+			Timeout:  time.Duration(s.HealthCheck.Timeout) * time.Second,
+			Retries:  s.HealthCheck.Retries,
+		}
+		if s.HealthCheck.HTTP != nil {
+			ls.HealthCheck.HTTP = &LoadBalancerServiceHealthCheckHTTP{
+				Domain:      s.HealthCheck.HTTP.Domain,
+				Path:        s.HealthCheck.HTTP.Path,
+				Response:    s.HealthCheck.HTTP.Response,
+				StatusCodes: s.HealthCheck.HTTP.StatusCodes,
+				TLS:         s.HealthCheck.HTTP.TLS,
+			}
+		}
+	}
+	return ls
+}
+
+// LoadBalancerTargetFromSchema converts a schema.LoadBalancerTarget to a LoadBalancerTarget.
+func LoadBalancerTargetFromSchema(s schema.LoadBalancerTarget) LoadBalancerTarget {
+	lt := LoadBalancerTarget{
+		Type:         LoadBalancerTargetType(s.Type),
+		UsePrivateIP: s.UsePrivateIP,
+	}
+	if s.Server != nil {
+		lt.Server = &LoadBalancerTargetServer{
+			Server: &Server{ID: s.Server.ID},
+		}
+	}
+	if s.LabelSelector != nil {
+		lt.LabelSelector = &LoadBalancerTargetLabelSelector{
+			Selector: s.LabelSelector.Selector,
+		}
+	}
+	if s.IP != nil {
+		lt.IP = &LoadBalancerTargetIP{IP: s.IP.IP}
+	}
+	for _, healthStatus := range s.HealthStatus {
+		lt.HealthStatus = append(lt.HealthStatus, LoadBalancerTargetHealthStatus{
+			ListenPort: healthStatus.ListenPort,
+			Status:     LoadBalancerTargetHealthStatusStatus(healthStatus.Status),
+		})
+	}
+	for _, target := range s.Targets {
+		lt.Targets = append(lt.Targets, LoadBalancerTargetFromSchema(target))
+	}
+	return lt
+}
+
+// CertificateFromSchema converts a schema.Certificate to a Certificate. Fields
+// the API did not report are filled in from the PEM encoded certificate, if
+// it can be parsed.
+func CertificateFromSchema(s schema.Certificate) *Certificate {
+	c := &Certificate{
+		ID:             s.ID,
+		Name:           s.Name,
+		Type:           CertificateType(s.Type),
+		Certificate:    s.Certificate,
+		Created:        s.Created,
+		NotValidBefore: s.NotValidBefore,
+		NotValidAfter:  s.NotValidAfter,
+		DomainNames:    s.DomainNames,
+		Fingerprint:    s.Fingerprint,
+		Labels:         map[string]string{},
+	}
+	for key, value := range s.Labels {
+		c.Labels[key] = value
+	}
+	if s.Status != nil {
+		c.Status = &CertificateStatus{
+			Issuance: CertificateStatusType(s.Status.Issuance),
+			Renewal:  CertificateStatusType(s.Status.Renewal),
+		}
+		if s.Status.Error != nil {
+			certErr := ErrorFromSchema(*s.Status.Error)
+			c.Status.Error = &certErr
+		}
+	}
+	for _, ref := range s.UsedBy {
+		c.UsedBy = append(c.UsedBy, CertificateUsedByRef{
+			ID:   ref.ID,
+			Type: CertificateUsedByRefType(ref.Type),
+		})
+	}
+	if s.Certificate != "" && (c.NotValidAfter.IsZero() || c.Fingerprint == "" || len(c.DomainNames) == 0) {
+		if parsed, err := ParseCertificatePEM(s.Certificate); err == nil {
+			if c.NotValidBefore.IsZero() {
+				c.NotValidBefore = parsed.NotValidBefore
+			}
+			if c.NotValidAfter.IsZero() {
+				c.NotValidAfter = parsed.NotValidAfter
+			}
+			if c.Fingerprint == "" {
+				c.Fingerprint = parsed.Fingerprint
+			}
+			if len(c.DomainNames) == 0 {
+				c.DomainNames = parsed.DomainNames
+			}
+		}
+	}
+	return c
+}
+
 // PaginationFromSchema converts a schema.MetaPagination to a Pagination.
 func PaginationFromSchema(s schema.MetaPagination) Pagination {
 	return Pagination{
@@ -412,6 +715,26 @@ func ErrorFromSchema(s schema.Error) Error {
 			})
 		}
 		e.Details = details
+	case schema.ErrorDetailsUniquenessError:
+		details := ErrorDetailsUniquenessError{
+			Fields: []ErrorDetailsUniquenessErrorField{},
+		}
+		for _, field := range d.Fields {
+			details.Fields = append(details.Fields, ErrorDetailsUniquenessErrorField{Name: field.Name})
+		}
+		e.Details = details
+	case schema.ErrorDetailsResourceLimitExceeded:
+		details := ErrorDetailsResourceLimitExceeded{
+			Limits: []ErrorDetailsResourceLimitExceededLimit{},
+		}
+		for _, limit := range d.Limits {
+			details.Limits = append(details.Limits, ErrorDetailsResourceLimitExceededLimit{Name: limit.Name})
+		}
+		e.Details = details
+	case schema.ErrorDetailsServerAlreadyAttached:
+		e.Details = ErrorDetailsServerAlreadyAttached{Server: &Server{ID: d.Server}}
+	case schema.ErrorDetailsLocked:
+		e.Details = ErrorDetailsLocked{Action: &Action{ID: d.Action}}
 	}
 	return e
 }
@@ -543,3 +866,928 @@ func ZoneTypeFromSchema(z schema.ZoneType) *ZoneType {
 		Prices:      prices,
 	}
 }
+
+// PlacementGroupFromSchema converts a schema.PlacementGroup to a PlacementGroup.
+func PlacementGroupFromSchema(s schema.PlacementGroup) *PlacementGroup {
+	placementGroup := &PlacementGroup{
+		ID:      s.ID,
+		Name:    s.Name,
+		Created: s.Created,
+		Servers: s.Servers,
+		Type:    PlacementGroupType(s.Type),
+	}
+	placementGroup.Labels = map[string]string{}
+	for key, value := range s.Labels {
+		placementGroup.Labels[key] = value
+	}
+	return placementGroup
+}
+
+// ServerMetricsFromSchema converts a schema.ServerMetrics to ServerMetrics.
+// An error is returned if a value of a time series is malformed.
+func ServerMetricsFromSchema(s schema.ServerMetrics) (*ServerMetrics, error) {
+	metrics := &ServerMetrics{
+		Start:      s.Start,
+		End:        s.End,
+		Step:       time.Duration(s.Step * float64(time.Second)),
+		TimeSeries: make(map[string]ServerMetricsSeries, len(s.TimeSeries)),
+	}
+	for name, vals := range s.TimeSeries {
+		series := make(ServerMetricsSeries, 0, len(vals.Values))
+		for _, pair := range vals.Values {
+			ts, ok := pair[0].(float64)
+			if !ok {
+				return nil, fmt.Errorf("time series %s: invalid timestamp %v", name, pair[0])
+			}
+			str, ok := pair[1].(string)
+			if !ok {
+				return nil, fmt.Errorf("time series %s: invalid value %v", name, pair[1])
+			}
+			value, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, fmt.Errorf("time series %s: %s", name, err)
+			}
+			sec, frac := math.Modf(ts)
+			series = append(series, ServerMetricsValue{
+				Timestamp: time.Unix(int64(sec), int64(frac*float64(time.Second))).UTC(),
+				Value:     value,
+			})
+		}
+		metrics.TimeSeries[name] = series
+	}
+	return metrics, nil
+}
+
+// SchemaFromAction converts an Action to a schema.Action.
+func SchemaFromAction(a *Action) schema.Action {
+	action := schema.Action{
+		ID:        a.ID,
+		Status:    string(a.Status),
+		Command:   a.Command,
+		Progress:  a.Progress,
+		Started:   a.Started,
+		Resources: []schema.ActionResourceReference{},
+	}
+	if !a.Finished.IsZero() {
+		finished := a.Finished
+		action.Finished = &finished
+	}
+	if a.ErrorCode != "" || a.ErrorMessage != "" {
+		action.Error = &schema.ActionError{
+			Code:    a.ErrorCode,
+			Message: a.ErrorMessage,
+		}
+	}
+	for _, r := range a.Resources {
+		action.Resources = append(action.Resources, schema.ActionResourceReference{
+			ID:   r.ID,
+			Type: string(r.Type),
+		})
+	}
+	return action
+}
+
+// SchemaFromActions converts a slice of Action to a slice of schema.Action.
+func SchemaFromActions(a []*Action) []schema.Action {
+	var actions []schema.Action
+	for _, action := range a {
+		actions = append(actions, SchemaFromAction(action))
+	}
+	return actions
+}
+
+// SchemaFromFloatingIP converts a FloatingIP to a schema.FloatingIP.
+func SchemaFromFloatingIP(f *FloatingIP) schema.FloatingIP {
+	s := schema.FloatingIP{
+		ID:      f.ID,
+		IP:      ipWithPrefix(f.IP, f.Network),
+		Type:    string(f.Type),
+		Created: f.Created,
+		Blocked: f.Blocked,
+		Protection: schema.FloatingIPProtection{
+			Delete: f.Protection.Delete,
+		},
+		Labels: copyLabels(f.Labels),
+		Name:   f.Name,
+	}
+	if f.Description != "" {
+		description := f.Description
+		s.Description = &description
+	}
+	if f.Server != nil {
+		serverID := f.Server.ID
+		s.Server = &serverID
+	}
+	if f.HomeLocation != nil {
+		s.HomeLocation = SchemaFromLocation(f.HomeLocation)
+	}
+	for _, ip := range sortedKeys(f.DNSPtr) {
+		s.DNSPtr = append(s.DNSPtr, schema.FloatingIPDNSPtr{
+			IP:     ip,
+			DNSPtr: f.DNSPtr[ip],
+		})
+	}
+	return s
+}
+
+// SchemaFromPrimaryIP converts a PrimaryIP to a schema.PrimaryIP.
+func SchemaFromPrimaryIP(p *PrimaryIP) schema.PrimaryIP {
+	s := schema.PrimaryIP{
+		ID:           p.ID,
+		Name:         p.Name,
+		IP:           ipWithPrefix(p.IP, p.Network),
+		Type:         string(p.Type),
+		AssigneeType: string(p.AssigneeType),
+		AutoDelete:   p.AutoDelete,
+		Blocked:      p.Blocked,
+		Created:      p.Created,
+		Labels:       copyLabels(p.Labels),
+		Protection: schema.PrimaryIPProtection{
+			Delete: p.Protection.Delete,
+		},
+	}
+	if p.AssigneeID != 0 {
+		assigneeID := p.AssigneeID
+		s.AssigneeID = &assigneeID
+	}
+	if p.Datacenter != nil {
+		s.Datacenter = SchemaFromDatacenter(p.Datacenter)
+	}
+	for _, ip := range sortedKeys(p.DNSPtr) {
+		s.DNSPtr = append(s.DNSPtr, schema.PrimaryIPDNSPtr{
+			IP:     ip,
+			DNSPtr: p.DNSPtr[ip],
+		})
+	}
+	return s
+}
+
+// SchemaFromISO converts an ISO to a schema.ISO.
+func SchemaFromISO(i *ISO) schema.ISO {
+	return schema.ISO{
+		ID:          i.ID,
+		Name:        i.Name,
+		Description: i.Description,
+		Type:        string(i.Type),
+		Deprecated:  i.Deprecated,
+	}
+}
+
+// SchemaFromLocation converts a Location to a schema.Location.
+func SchemaFromLocation(l *Location) schema.Location {
+	return schema.Location{
+		ID:          l.ID,
+		Name:        l.Name,
+		Description: l.Description,
+		Country:     l.Country,
+		City:        l.City,
+		Latitude:    l.Latitude,
+		Longitude:   l.Longitude,
+		NetworkZone: string(l.NetworkZone),
+	}
+}
+
+// SchemaFromDatacenter converts a Datacenter to a schema.Datacenter.
+func SchemaFromDatacenter(d *Datacenter) schema.Datacenter {
+	s := schema.Datacenter{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+	}
+	if d.Location != nil {
+		s.Location = SchemaFromLocation(d.Location)
+	}
+	for _, t := range d.ServerTypes.Available {
+		s.ServerTypes.Available = append(s.ServerTypes.Available, t.ID)
+	}
+	for _, t := range d.ServerTypes.Supported {
+		s.ServerTypes.Supported = append(s.ServerTypes.Supported, t.ID)
+	}
+	return s
+}
+
+// SchemaFromServer converts a Server to a schema.Server.
+func SchemaFromServer(s *Server) schema.Server {
+	server := schema.Server{
+		ID:              s.ID,
+		Name:            s.Name,
+		Status:          string(s.Status),
+		Created:         s.Created,
+		PublicNet:       SchemaFromServerPublicNet(s.PublicNet),
+		IncludedTraffic: s.IncludedTraffic,
+		RescueEnabled:   s.RescueEnabled,
+		Locked:          s.Locked,
+		Protection: schema.ServerProtection{
+			Delete:  s.Protection.Delete,
+			Rebuild: s.Protection.Rebuild,
+		},
+		Labels: copyLabels(s.Labels),
+	}
+	if s.ServerType != nil {
+		server.ServerType = SchemaFromServerType(s.ServerType)
+	}
+	if s.Datacenter != nil {
+		server.Datacenter = SchemaFromDatacenter(s.Datacenter)
+	}
+	if s.Image != nil {
+		image := SchemaFromImage(s.Image)
+		server.Image = &image
+	}
+	if s.BackupWindow != "" {
+		backupWindow := s.BackupWindow
+		server.BackupWindow = &backupWindow
+	}
+	if s.OutgoingTraffic != 0 {
+		outgoingTraffic := s.OutgoingTraffic
+		server.OutgoingTraffic = &outgoingTraffic
+	}
+	if s.IngoingTraffic != 0 {
+		ingoingTraffic := s.IngoingTraffic
+		server.IngoingTraffic = &ingoingTraffic
+	}
+	if s.ISO != nil {
+		iso := SchemaFromISO(s.ISO)
+		server.ISO = &iso
+	}
+	for _, volume := range s.Volumes {
+		server.Volumes = append(server.Volumes, volume.ID)
+	}
+	for _, privNet := range s.PrivateNet {
+		server.PrivateNet = append(server.PrivateNet, SchemaFromServerPrivateNet(privNet))
+	}
+	if s.PlacementGroup != nil {
+		placementGroup := SchemaFromPlacementGroup(s.PlacementGroup)
+		server.PlacementGroup = &placementGroup
+	}
+	return server
+}
+
+// SchemaFromServerPublicNet converts a ServerPublicNet to a schema.ServerPublicNet.
+func SchemaFromServerPublicNet(p ServerPublicNet) schema.ServerPublicNet {
+	publicNet := schema.ServerPublicNet{
+		IPv4: SchemaFromServerPublicNetIPv4(p.IPv4),
+		IPv6: SchemaFromServerPublicNetIPv6(p.IPv6),
+	}
+	for _, floatingIP := range p.FloatingIPs {
+		publicNet.FloatingIPs = append(publicNet.FloatingIPs, floatingIP.ID)
+	}
+	return publicNet
+}
+
+// SchemaFromServerPublicNetIPv4 converts a ServerPublicNetIPv4 to
+// a schema.ServerPublicNetIPv4.
+func SchemaFromServerPublicNetIPv4(p ServerPublicNetIPv4) schema.ServerPublicNetIPv4 {
+	return schema.ServerPublicNetIPv4{
+		ID:      p.ID,
+		IP:      ipString(p.IP),
+		Blocked: p.Blocked,
+		DNSPtr:  p.DNSPtr,
+	}
+}
+
+// SchemaFromServerPublicNetIPv6 converts a ServerPublicNetIPv6 to
+// a schema.ServerPublicNetIPv6.
+func SchemaFromServerPublicNetIPv6(p ServerPublicNetIPv6) schema.ServerPublicNetIPv6 {
+	ipv6 := schema.ServerPublicNetIPv6{
+		ID:      p.ID,
+		IP:      ipWithPrefix(p.IP, p.Network),
+		Blocked: p.Blocked,
+	}
+	for _, ip := range sortedKeys(p.DNSPtr) {
+		ipv6.DNSPtr = append(ipv6.DNSPtr, schema.ServerPublicNetIPv6DNSPtr{
+			IP:     ip,
+			DNSPtr: p.DNSPtr[ip],
+		})
+	}
+	return ipv6
+}
+
+// SchemaFromServerPrivateNet converts a ServerPrivateNet to a schema.ServerPrivateNet.
+func SchemaFromServerPrivateNet(n ServerPrivateNet) schema.ServerPrivateNet {
+	s := schema.ServerPrivateNet{
+		IP:         ipString(n.IP),
+		MACAddress: n.MACAddress,
+	}
+	if n.Network != nil {
+		s.Network = n.Network.ID
+	}
+	for _, ip := range n.Aliases {
+		s.AliasIPs = append(s.AliasIPs, ip.String())
+	}
+	return s
+}
+
+// SchemaFromServerType converts a ServerType to a schema.ServerType.
+func SchemaFromServerType(st *ServerType) schema.ServerType {
+	s := schema.ServerType{
+		ID:          st.ID,
+		Name:        st.Name,
+		Description: st.Description,
+		Cores:       st.Cores,
+		Memory:      st.Memory,
+		Disk:        st.Disk,
+		StorageType: string(st.StorageType),
+		CPUType:     string(st.CPUType),
+	}
+	for _, pricing := range st.Pricings {
+		s.Prices = append(s.Prices, schema.PricingServerTypePrice{
+			Location:     locationName(pricing.Location),
+			PriceHourly:  schemaFromPrice(pricing.Hourly),
+			PriceMonthly: schemaFromPrice(pricing.Monthly),
+		})
+	}
+	return s
+}
+
+// SchemaFromSSHKey converts a SSHKey to a schema.SSHKey.
+func SchemaFromSSHKey(k *SSHKey) schema.SSHKey {
+	return schema.SSHKey{
+		ID:          k.ID,
+		Name:        k.Name,
+		Fingerprint: k.Fingerprint,
+		PublicKey:   k.PublicKey,
+		Labels:      copyLabels(k.Labels),
+		Created:     k.Created,
+	}
+}
+
+// SchemaFromImage converts an Image to a schema.Image.
+func SchemaFromImage(i *Image) schema.Image {
+	s := schema.Image{
+		ID:          i.ID,
+		Type:        string(i.Type),
+		Status:      string(i.Status),
+		Description: i.Description,
+		DiskSize:    i.DiskSize,
+		Created:     i.Created,
+		RapidDeploy: i.RapidDeploy,
+		OSFlavor:    i.OSFlavor,
+		Protection: schema.ImageProtection{
+			Delete: i.Protection.Delete,
+		},
+		Deprecated: i.Deprecated,
+		Labels:     copyLabels(i.Labels),
+	}
+	if i.Name != "" {
+		name := i.Name
+		s.Name = &name
+	}
+	if i.ImageSize != 0 {
+		imageSize := i.ImageSize
+		s.ImageSize = &imageSize
+	}
+	if i.OSVersion != "" {
+		osVersion := i.OSVersion
+		s.OSVersion = &osVersion
+	}
+	if i.CreatedFrom != nil {
+		s.CreatedFrom = &schema.ImageCreatedFrom{
+			ID:   i.CreatedFrom.ID,
+			Name: i.CreatedFrom.Name,
+		}
+	}
+	if i.BoundTo != nil {
+		boundTo := i.BoundTo.ID
+		s.BoundTo = &boundTo
+	}
+	return s
+}
+
+// SchemaFromVolume converts a Volume to a schema.Volume.
+func SchemaFromVolume(v *Volume) schema.Volume {
+	s := schema.Volume{
+		ID:          v.ID,
+		Name:        v.Name,
+		Size:        v.Size,
+		Status:      string(v.Status),
+		LinuxDevice: v.LinuxDevice,
+		Protection: schema.VolumeProtection{
+			Delete: v.Protection.Delete,
+		},
+		Labels:  copyLabels(v.Labels),
+		Created: v.Created,
+	}
+	if v.Location != nil {
+		s.Location = SchemaFromLocation(v.Location)
+	}
+	if v.Server != nil {
+		serverID := v.Server.ID
+		s.Server = &serverID
+	}
+	return s
+}
+
+// SchemaFromNetwork converts a Network to a schema.Network.
+func SchemaFromNetwork(n *Network) schema.Network {
+	s := schema.Network{
+		ID:      n.ID,
+		Name:    n.Name,
+		Created: n.Created,
+		IPRange: ipNetString(n.IPRange),
+		Protection: schema.NetworkProtection{
+			Delete: n.Protection.Delete,
+		},
+		Labels:                copyLabels(n.Labels),
+		ExposeRoutesToVSwitch: n.ExposeRoutesToVSwitch,
+	}
+	for _, subnet := range n.Subnets {
+		s.Subnets = append(s.Subnets, SchemaFromNetworkSubnet(subnet))
+	}
+	for _, route := range n.Routes {
+		s.Routes = append(s.Routes, SchemaFromNetworkRoute(route))
+	}
+	for _, server := range n.Servers {
+		s.Servers = append(s.Servers, server.ID)
+	}
+	return s
+}
+
+// SchemaFromNetworkSubnet converts a NetworkSubnet to a schema.NetworkSubnet.
+func SchemaFromNetworkSubnet(sn NetworkSubnet) schema.NetworkSubnet {
+	return schema.NetworkSubnet{
+		Type:        string(sn.Type),
+		IPRange:     ipNetString(sn.IPRange),
+		NetworkZone: string(sn.NetworkZone),
+		Gateway:     ipString(sn.Gateway),
+		VSwitchID:   sn.VSwitchID,
+	}
+}
+
+// SchemaFromNetworkRoute converts a NetworkRoute to a schema.NetworkRoute.
+func SchemaFromNetworkRoute(r NetworkRoute) schema.NetworkRoute {
+	return schema.NetworkRoute{
+		Destination: ipNetString(r.Destination),
+		Gateway:     ipString(r.Gateway),
+	}
+}
+
+// SchemaFromFirewall converts a Firewall to a schema.Firewall.
+func SchemaFromFirewall(f *Firewall) schema.Firewall {
+	s := schema.Firewall{
+		ID:      f.ID,
+		Name:    f.Name,
+		Labels:  copyLabels(f.Labels),
+		Created: f.Created,
+	}
+	for _, rule := range f.Rules {
+		s.Rules = append(s.Rules, SchemaFromFirewallRule(rule))
+	}
+	for _, res := range f.AppliedTo {
+		s.AppliedTo = append(s.AppliedTo, SchemaFromFirewallResource(res))
+	}
+	return s
+}
+
+// SchemaFromFirewallRule converts a FirewallRule to a schema.FirewallRule.
+func SchemaFromFirewallRule(r FirewallRule) schema.FirewallRule {
+	s := schema.FirewallRule{
+		Direction:   string(r.Direction),
+		Protocol:    string(r.Protocol),
+		Port:        r.Port,
+		Description: r.Description,
+	}
+	for _, ip := range r.SourceIPs {
+		s.SourceIPs = append(s.SourceIPs, ip.String())
+	}
+	for _, ip := range r.DestinationIPs {
+		s.DestinationIPs = append(s.DestinationIPs, ip.String())
+	}
+	return s
+}
+
+// SchemaFromFirewallResource converts a FirewallResource to a schema.FirewallResource.
+func SchemaFromFirewallResource(r FirewallResource) schema.FirewallResource {
+	s := schema.FirewallResource{Type: string(r.Type)}
+	if r.Server != nil {
+		s.Server = &schema.FirewallResourceServer{ID: r.Server.ID}
+	}
+	if r.LabelSelector != nil {
+		s.LabelSelector = &schema.FirewallResourceLabelSelector{Selector: r.LabelSelector.Selector}
+	}
+	return s
+}
+
+// SchemaFromLoadBalancerType converts a LoadBalancerType to a schema.LoadBalancerType.
+func SchemaFromLoadBalancerType(lt *LoadBalancerType) schema.LoadBalancerType {
+	s := schema.LoadBalancerType{
+		ID:                      lt.ID,
+		Name:                    lt.Name,
+		Description:             lt.Description,
+		MaxConnections:          lt.MaxConnections,
+		MaxServices:             lt.MaxServices,
+		MaxTargets:              lt.MaxTargets,
+		MaxAssignedCertificates: lt.MaxAssignedCertificates,
+	}
+	for _, pricing := range lt.Pricings {
+		s.Prices = append(s.Prices, schema.PricingLoadBalancerTypePrice{
+			Location:     locationName(pricing.Location),
+			PriceHourly:  schemaFromPrice(pricing.Hourly),
+			PriceMonthly: schemaFromPrice(pricing.Monthly),
+		})
+	}
+	return s
+}
+
+// SchemaFromLoadBalancer converts a LoadBalancer to a schema.LoadBalancer.
+func SchemaFromLoadBalancer(l *LoadBalancer) schema.LoadBalancer {
+	s := schema.LoadBalancer{
+		ID:   l.ID,
+		Name: l.Name,
+		PublicNet: schema.LoadBalancerPublicNet{
+			Enabled: l.PublicNet.Enabled,
+			IPv4: schema.LoadBalancerPublicNetIPv4{
+				IP:     ipString(l.PublicNet.IPv4.IP),
+				DNSPtr: l.PublicNet.IPv4.DNSPtr,
+			},
+			IPv6: schema.LoadBalancerPublicNetIPv6{
+				IP:     ipString(l.PublicNet.IPv6.IP),
+				DNSPtr: l.PublicNet.IPv6.DNSPtr,
+			},
+		},
+		Algorithm: schema.LoadBalancerAlgorithm{Type: string(l.Algorithm.Type)},
+		Protection: schema.LoadBalancerProtection{
+			Delete: l.Protection.Delete,
+		},
+		Labels:          copyLabels(l.Labels),
+		Created:         l.Created,
+		IncludedTraffic: l.IncludedTraffic,
+	}
+	if l.Location != nil {
+		s.Location = SchemaFromLocation(l.Location)
+	}
+	if l.LoadBalancerType != nil {
+		s.LoadBalancerType = SchemaFromLoadBalancerType(l.LoadBalancerType)
+	}
+	for _, privateNet := range l.PrivateNet {
+		n := schema.LoadBalancerPrivateNet{IP: ipString(privateNet.IP)}
+		if privateNet.Network != nil {
+			n.Network = privateNet.Network.ID
+		}
+		s.PrivateNet = append(s.PrivateNet, n)
+	}
+	if l.OutgoingTraffic != 0 {
+		outgoingTraffic := l.OutgoingTraffic
+		s.OutgoingTraffic = &outgoingTraffic
+	}
+	if l.IngoingTraffic != 0 {
+		ingoingTraffic := l.IngoingTraffic
+		s.IngoingTraffic = &ingoingTraffic
+	}
+	for _, service := range l.Services {
+		s.Services = append(s.Services, SchemaFromLoadBalancerService(service))
+	}
+	for _, target := range l.Targets {
+		s.Targets = append(s.Targets, SchemaFromLoadBalancerTarget(target))
+	}
+	return s
+}
+
+// SchemaFromLoadBalancerService converts a LoadBalancerService to a
+// schema.LoadBalancerService. The HTTP configuration is only included for
+// services not using the TCP protocol.
+func SchemaFromLoadBalancerService(ls LoadBalancerService) schema.LoadBalancerService {
+	s := schema.LoadBalancerService{
+		Protocol:        string(ls.Protocol),
+		ListenPort:      ls.ListenPort,
+		DestinationPort: ls.DestinationPort,
+		Proxyprotocol:   ls.Proxyprotocol,
+		HealthCheck: &schema.LoadBalancerServiceHealthCheck{
+			Protocol: string(ls.HealthCheck.Protocol),
+			Port:     ls.HealthCheck.Port,
+			Interval: int(ls.HealthCheck.Interval / time.Second),
+			Timeout:  int(ls.HealthCheck.Timeout / time.Second),
+			Retries:  ls.HealthCheck.Retries,
+		},
+	}
+	if ls.Protocol != LoadBalancerServiceProtocolTCP {
+		s.HTTP = &schema.LoadBalancerServiceHTTP{
+			CookieName:     ls.HTTP.CookieName,
+			CookieLifetime: int(ls.HTTP.CookieLifetime / time.Second),
+			RedirectHTTP:   ls.HTTP.RedirectHTTP,
+			StickySessions: ls.HTTP.StickySessions,
+		}
+		for _, certificate := range ls.HTTP.Certificates {
+			s.HTTP.Certificates = append(s.HTTP.Certificates, certificate.ID)
+		}
+	}
+	if ls.HealthCheck.HTTP != nil {
+		s.HealthCheck.HTTP = &schema.LoadBalancerServiceHealthCheckHTTP{
+			Domain:      ls.HealthCheck.HTTP.Domain,
+			Path:        ls.HealthCheck.HTTP.Path,
+			Response:    ls.HealthCheck.HTTP.Response,
+			StatusCodes: ls.HealthCheck.HTTP.StatusCodes,
+			TLS:         ls.HealthCheck.HTTP.TLS,
+		}
+	}
+	return s
+}
+
+// SchemaFromLoadBalancerTarget converts a LoadBalancerTarget to a schema.LoadBalancerTarget.
+func SchemaFromLoadBalancerTarget(lt LoadBalancerTarget) schema.LoadBalancerTarget {
+	s := schema.LoadBalancerTarget{
+		Type:         string(lt.Type),
+		UsePrivateIP: lt.UsePrivateIP,
+	}
+	if lt.Server != nil && lt.Server.Server != nil {
+		s.Server = &schema.LoadBalancerTargetServer{ID: lt.Server.Server.ID}
+	}
+	if lt.LabelSelector != nil {
+		s.LabelSelector = &schema.LoadBalancerTargetLabelSelector{
+			Selector: lt.LabelSelector.Selector,
+		}
+	}
+	if lt.IP != nil {
+		s.IP = &schema.LoadBalancerTargetIP{IP: lt.IP.IP}
+	}
+	for _, healthStatus := range lt.HealthStatus {
+		s.HealthStatus = append(s.HealthStatus, schema.LoadBalancerTargetHealthStatus{
+			ListenPort: healthStatus.ListenPort,
+			Status:     string(healthStatus.Status),
+		})
+	}
+	for _, target := range lt.Targets {
+		s.Targets = append(s.Targets, SchemaFromLoadBalancerTarget(target))
+	}
+	return s
+}
+
+// SchemaFromCertificate converts a Certificate to a schema.Certificate.
+func SchemaFromCertificate(c *Certificate) schema.Certificate {
+	s := schema.Certificate{
+		ID:             c.ID,
+		Name:           c.Name,
+		Labels:         copyLabels(c.Labels),
+		Type:           string(c.Type),
+		Certificate:    c.Certificate,
+		Created:        c.Created,
+		NotValidBefore: c.NotValidBefore,
+		NotValidAfter:  c.NotValidAfter,
+		DomainNames:    c.DomainNames,
+		Fingerprint:    c.Fingerprint,
+	}
+	if c.Status != nil {
+		s.Status = &schema.CertificateStatus{
+			Issuance: string(c.Status.Issuance),
+			Renewal:  string(c.Status.Renewal),
+		}
+		if c.Status.Error != nil {
+			certErr := SchemaFromError(*c.Status.Error)
+			s.Status.Error = &certErr
+		}
+	}
+	for _, ref := range c.UsedBy {
+		s.UsedBy = append(s.UsedBy, schema.CertificateUsedByRef{
+			ID:   ref.ID,
+			Type: string(ref.Type),
+		})
+	}
+	return s
+}
+
+// SchemaFromPagination converts a Pagination to a schema.MetaPagination.
+func SchemaFromPagination(p Pagination) schema.MetaPagination {
+	return schema.MetaPagination{
+		Page:         p.Page,
+		PerPage:      p.PerPage,
+		PreviousPage: p.PreviousPage,
+		NextPage:     p.NextPage,
+		LastPage:     p.LastPage,
+		TotalEntries: p.TotalEntries,
+	}
+}
+
+// SchemaFromError converts an Error to a schema.Error. Known details are
+// converted to their schema type and encoded into DetailsRaw.
+func SchemaFromError(e Error) schema.Error {
+	s := schema.Error{
+		Code:    string(e.Code),
+		Message: e.Message,
+	}
+
+	switch d := e.Details.(type) {
+	case ErrorDetailsInvalidInput:
+		details := schema.ErrorDetailsInvalidInput{}
+		for _, field := range d.Fields {
+			details.Fields = append(details.Fields, struct {
+				Name     string   `json:"name"`
+				Messages []string `json:"messages"`
+			}{
+				Name:     field.Name,
+				Messages: field.Messages,
+			})
+		}
+		s.Details = details
+	case ErrorDetailsUniquenessError:
+		details := schema.ErrorDetailsUniquenessError{}
+		for _, field := range d.Fields {
+			details.Fields = append(details.Fields, schema.ErrorDetailsUniquenessErrorField{Name: field.Name})
+		}
+		s.Details = details
+	case ErrorDetailsResourceLimitExceeded:
+		details := schema.ErrorDetailsResourceLimitExceeded{}
+		for _, limit := range d.Limits {
+			details.Limits = append(details.Limits, schema.ErrorDetailsResourceLimitExceededLimit{Name: limit.Name})
+		}
+		s.Details = details
+	case ErrorDetailsServerAlreadyAttached:
+		details := schema.ErrorDetailsServerAlreadyAttached{}
+		if d.Server != nil {
+			details.Server = d.Server.ID
+		}
+		s.Details = details
+	case ErrorDetailsLocked:
+		details := schema.ErrorDetailsLocked{}
+		if d.Action != nil {
+			details.Action = d.Action.ID
+		}
+		s.Details = details
+	}
+	if s.Details != nil {
+		s.DetailsRaw, _ = json.Marshal(s.Details)
+	}
+	return s
+}
+
+// SchemaFromPricing converts a Pricing to a schema.Pricing. The currency and
+// VAT rate are taken from the image price.
+func SchemaFromPricing(p Pricing) schema.Pricing {
+	s := schema.Pricing{
+		Currency: p.Image.PerGBMonth.Currency,
+		VATRate:  p.Image.PerGBMonth.VATRate,
+		Image: schema.PricingImage{
+			PricePerGBMonth: schemaFromPrice(p.Image.PerGBMonth),
+		},
+		FloatingIP: schema.PricingFloatingIP{
+			PriceMonthly: schemaFromPrice(p.FloatingIP.Monthly),
+		},
+		Traffic: schema.PricingTraffic{
+			PricePerTB: schemaFromPrice(p.Traffic.PerTB),
+		},
+		ServerBackup: schema.PricingServerBackup{
+			Percentage: p.ServerBackup.Percentage,
+		},
+	}
+	for _, serverType := range p.ServerTypes {
+		st := schema.PricingServerType{}
+		if serverType.ServerType != nil {
+			st.ID = serverType.ServerType.ID
+			st.Name = serverType.ServerType.Name
+		}
+		for _, pricing := range serverType.Pricings {
+			st.Prices = append(st.Prices, schema.PricingServerTypePrice{
+				Location:     locationName(pricing.Location),
+				PriceHourly:  schemaFromPrice(pricing.Hourly),
+				PriceMonthly: schemaFromPrice(pricing.Monthly),
+			})
+		}
+		s.ServerTypes = append(s.ServerTypes, st)
+	}
+	return s
+}
+
+// SchemaFromRecord converts a Record to a schema.Record.
+func SchemaFromRecord(r *Record) schema.Record {
+	return schema.Record{
+		ID:       r.ID,
+		Name:     r.Name,
+		Created:  r.Created,
+		Modified: r.Modified,
+		TTL:      r.TTL,
+		Type:     string(r.Type),
+		Value:    r.Value,
+		ZoneID:   r.ZoneID,
+	}
+}
+
+// SchemaFromZone converts a Zone to a schema.Zone.
+func SchemaFromZone(z *Zone) schema.Zone {
+	return schema.Zone{
+		ID:              z.ID,
+		Name:            z.Name,
+		Ns:              z.Ns,
+		LegacyDNSHost:   z.LegacyDNSHost,
+		LegacyNs:        z.LegacyNs,
+		Created:         z.Created,
+		Modified:        z.Modified,
+		Verified:        z.Verified,
+		Owner:           z.Owner,
+		Paused:          z.Paused,
+		Permission:      z.Permission,
+		Project:         z.Project,
+		Registrar:       z.Registrar,
+		Status:          z.Status,
+		TTL:             z.TTL,
+		IsSecondaryDNS:  z.IsSecondaryDNS,
+		TxtVerification: SchemaFromTxtVerification(&z.TxtVerification),
+		ZoneType:        SchemaFromZoneType(&z.ZoneType),
+		RecordsCount:    z.RecordsCount,
+	}
+}
+
+// SchemaFromTxtVerification converts a TxtVerification to a schema.TxtVerification.
+func SchemaFromTxtVerification(t *TxtVerification) schema.TxtVerification {
+	return schema.TxtVerification{
+		Name:  t.Name,
+		Token: t.Token,
+	}
+}
+
+// SchemaFromZoneType converts a ZoneType to a schema.ZoneType.
+func SchemaFromZoneType(z *ZoneType) schema.ZoneType {
+	s := schema.ZoneType{
+		ID:          z.ID,
+		Name:        z.Name,
+		Description: z.Description,
+	}
+	for _, price := range z.Prices {
+		s.Prices = append(s.Prices, schemaFromPrice(price))
+	}
+	return s
+}
+
+// SchemaFromPlacementGroup converts a PlacementGroup to a schema.PlacementGroup.
+func SchemaFromPlacementGroup(p *PlacementGroup) schema.PlacementGroup {
+	return schema.PlacementGroup{
+		ID:      p.ID,
+		Name:    p.Name,
+		Labels:  copyLabels(p.Labels),
+		Created: p.Created,
+		Servers: p.Servers,
+		Type:    string(p.Type),
+	}
+}
+
+// SchemaFromServerMetrics converts ServerMetrics to a schema.ServerMetrics.
+func SchemaFromServerMetrics(m *ServerMetrics) schema.ServerMetrics {
+	s := schema.ServerMetrics{
+		Start:      m.Start,
+		End:        m.End,
+		Step:       m.Step.Seconds(),
+		TimeSeries: make(map[string]schema.ServerTimeSeriesVals, len(m.TimeSeries)),
+	}
+	for name, series := range m.TimeSeries {
+		vals := schema.ServerTimeSeriesVals{
+			Values: make([][2]interface{}, 0, len(series)),
+		}
+		for _, v := range series {
+			ts := float64(v.Timestamp.Unix()) + float64(v.Timestamp.Nanosecond())/float64(time.Second)
+			vals.Values = append(vals.Values, [2]interface{}{ts, strconv.FormatFloat(v.Value, 'f', -1, 64)})
+		}
+		s.TimeSeries[name] = vals
+	}
+	return s
+}
+
+func schemaFromPrice(p Price) schema.Price {
+	return schema.Price{
+		Net:   p.Net,
+		Gross: p.Gross,
+	}
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	c := make(map[string]string, len(labels))
+	for key, value := range labels {
+		c[key] = value
+	}
+	return c
+}
+
+// sortedKeys returns the keys of m in ascending order, so converted DNS
+// pointers have a stable order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func locationName(l *Location) string {
+	if l == nil {
+		return ""
+	}
+	return l.Name
+}
+
+// ipString returns the string form of ip, or "" if ip is nil.
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+// ipWithPrefix returns ip in CIDR notation with the prefix length of
+// network, or just ip if network is nil.
+func ipWithPrefix(ip net.IP, network *net.IPNet) string {
+	if network == nil {
+		return ipString(ip)
+	}
+	ones, _ := network.Mask.Size()
+	return ipString(ip) + "/" + strconv.Itoa(ones)
+}
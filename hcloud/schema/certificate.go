@@ -0,0 +1,77 @@
+package schema
+
+import "time"
+
+// Certificate defines the schema of a certificate.
+type Certificate struct {
+	ID             int                    `json:"id"`
+	Name           string                 `json:"name"`
+	Labels         map[string]string      `json:"labels"`
+	Type           string                 `json:"type"`
+	Certificate    string                 `json:"certificate"`
+	Created        time.Time              `json:"created"`
+	NotValidBefore time.Time              `json:"not_valid_before"`
+	NotValidAfter  time.Time              `json:"not_valid_after"`
+	DomainNames    []string               `json:"domain_names"`
+	Fingerprint    string                 `json:"fingerprint"`
+	Status         *CertificateStatus     `json:"status"`
+	UsedBy         []CertificateUsedByRef `json:"used_by"`
+}
+
+// CertificateStatus defines the schema of the status of a managed certificate.
+type CertificateStatus struct {
+	Issuance string `json:"issuance"`
+	Renewal  string `json:"renewal"`
+	Error    *Error `json:"error,omitempty"`
+}
+
+// CertificateUsedByRef defines the schema of a resource using a certificate.
+type CertificateUsedByRef struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+}
+
+// CertificateListResponse defines the schema of the response when
+// listing certificates.
+type CertificateListResponse struct {
+	Certificates []Certificate `json:"certificates"`
+}
+
+// CertificateGetResponse defines the schema of the response when
+// retrieving a single certificate.
+type CertificateGetResponse struct {
+	Certificate Certificate `json:"certificate"`
+}
+
+// CertificateCreateRequest defines the schema of the request to create a certificate.
+type CertificateCreateRequest struct {
+	Name        string             `json:"name"`
+	Type        string             `json:"type,omitempty"`
+	DomainNames []string           `json:"domain_names,omitempty"`
+	Certificate string             `json:"certificate,omitempty"`
+	PrivateKey  string             `json:"private_key,omitempty"`
+	Labels      *map[string]string `json:"labels,omitempty"`
+}
+
+// CertificateCreateResponse defines the schema of the response when creating a certificate.
+type CertificateCreateResponse struct {
+	Certificate Certificate `json:"certificate"`
+	Action      *Action     `json:"action"`
+}
+
+// CertificateUpdateRequest defines the schema of the request to update a certificate.
+type CertificateUpdateRequest struct {
+	Name   *string            `json:"name,omitempty"`
+	Labels *map[string]string `json:"labels,omitempty"`
+}
+
+// CertificateUpdateResponse defines the schema of the response when updating a certificate.
+type CertificateUpdateResponse struct {
+	Certificate Certificate `json:"certificate"`
+}
+
+// CertificateIssuanceRetryResponse defines the schema of the response when
+// retrying the issuance of a managed certificate.
+type CertificateIssuanceRetryResponse struct {
+	Action Action `json:"action"`
+}
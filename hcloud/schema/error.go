@@ -17,6 +17,9 @@ func (e *Error) UnmarshalJSON(data []byte) (err error) {
 	if err = json.Unmarshal(data, alias); err != nil {
 		return
 	}
+	if len(e.DetailsRaw) == 0 || string(e.DetailsRaw) == "null" {
+		return
+	}
 	switch e.Code {
 	case "invalid_input":
 		details := ErrorDetailsInvalidInput{}
@@ -24,6 +27,28 @@ func (e *Error) UnmarshalJSON(data []byte) (err error) {
 			return
 		}
 		alias.Details = details
+	// Details of the following codes are optional. If they do not have the
+	// expected shape, the error is kept without details.
+	case "uniqueness_error":
+		details := ErrorDetailsUniquenessError{}
+		if json.Unmarshal(e.DetailsRaw, &details) == nil {
+			alias.Details = details
+		}
+	case "resource_limit_exceeded":
+		details := ErrorDetailsResourceLimitExceeded{}
+		if json.Unmarshal(e.DetailsRaw, &details) == nil {
+			alias.Details = details
+		}
+	case "server_already_attached":
+		details := ErrorDetailsServerAlreadyAttached{}
+		if json.Unmarshal(e.DetailsRaw, &details) == nil {
+			alias.Details = details
+		}
+	case "locked":
+		details := ErrorDetailsLocked{}
+		if json.Unmarshal(e.DetailsRaw, &details) == nil {
+			alias.Details = details
+		}
 	}
 	return
 }
@@ -41,3 +66,39 @@ type ErrorDetailsInvalidInput struct {
 		Messages []string `json:"messages"`
 	} `json:"fields"`
 }
+
+// ErrorDetailsUniquenessError defines the schema of the Details field
+// of an error with code 'uniqueness_error'.
+type ErrorDetailsUniquenessError struct {
+	Fields []ErrorDetailsUniquenessErrorField `json:"fields"`
+}
+
+// ErrorDetailsUniquenessErrorField defines the schema of a field whose
+// value is already used.
+type ErrorDetailsUniquenessErrorField struct {
+	Name string `json:"name"`
+}
+
+// ErrorDetailsResourceLimitExceeded defines the schema of the Details field
+// of an error with code 'resource_limit_exceeded'.
+type ErrorDetailsResourceLimitExceeded struct {
+	Limits []ErrorDetailsResourceLimitExceededLimit `json:"limits"`
+}
+
+// ErrorDetailsResourceLimitExceededLimit defines the schema of an exceeded
+// limit.
+type ErrorDetailsResourceLimitExceededLimit struct {
+	Name string `json:"name"`
+}
+
+// ErrorDetailsServerAlreadyAttached defines the schema of the Details field
+// of an error with code 'server_already_attached'.
+type ErrorDetailsServerAlreadyAttached struct {
+	Server int `json:"server"`
+}
+
+// ErrorDetailsLocked defines the schema of the Details field of an error
+// with code 'locked'.
+type ErrorDetailsLocked struct {
+	Action int `json:"action"`
+}
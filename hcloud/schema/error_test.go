@@ -2,6 +2,7 @@ package schema
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -52,3 +53,67 @@ func TestError(t *testing.T) {
 		}
 	})
 }
+
+func TestErrorDetails(t *testing.T) {
+	testCases := map[string]struct {
+		data    string
+		details interface{}
+	}{
+		"uniqueness_error": {
+			data:    `{"code": "uniqueness_error", "message": "name is already used", "details": {"fields": [{"name": "name"}]}}`,
+			details: ErrorDetailsUniquenessError{Fields: []ErrorDetailsUniquenessErrorField{{Name: "name"}}},
+		},
+		"resource_limit_exceeded": {
+			data:    `{"code": "resource_limit_exceeded", "message": "limit exceeded", "details": {"limits": [{"name": "project_limit"}]}}`,
+			details: ErrorDetailsResourceLimitExceeded{Limits: []ErrorDetailsResourceLimitExceededLimit{{Name: "project_limit"}}},
+		},
+		"server_already_attached": {
+			data:    `{"code": "server_already_attached", "message": "already attached", "details": {"server": 42}}`,
+			details: ErrorDetailsServerAlreadyAttached{Server: 42},
+		},
+		"locked": {
+			data:    `{"code": "locked", "message": "locked", "details": {"action": 13}}`,
+			details: ErrorDetailsLocked{Action: 13},
+		},
+		"missing details": {
+			data: `{"code": "invalid_input", "message": "invalid input"}`,
+		},
+		"null details": {
+			data: `{"code": "uniqueness_error", "message": "name is already used", "details": null}`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var e Error
+			if err := json.Unmarshal([]byte(tc.data), &e); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(e.Details, tc.details) {
+				t.Errorf("expected details %+v, got %+v", tc.details, e.Details)
+			}
+		})
+	}
+}
+
+func TestErrorMalformedDetails(t *testing.T) {
+	testCases := map[string]string{
+		"uniqueness_error":        `{"error": {"code": "uniqueness_error", "message": "msg", "details": {"fields": "public_key"}}}`,
+		"resource_limit_exceeded": `{"error": {"code": "resource_limit_exceeded", "message": "msg", "details": ["project_limit"]}}`,
+		"server_already_attached": `{"error": {"code": "server_already_attached", "message": "msg", "details": {"server": "42"}}}`,
+		"locked":                  `{"error": {"code": "locked", "message": "msg", "details": {"action": {"id": 13}}}}`,
+	}
+	for code, data := range testCases {
+		t.Run(code, func(t *testing.T) {
+			var resp ErrorResponse
+			if err := json.Unmarshal([]byte(data), &resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Error.Code != code || resp.Error.Message != "msg" {
+				t.Errorf("unexpected error: %+v", resp.Error)
+			}
+			if resp.Error.Details != nil {
+				t.Errorf("unexpected details: %+v", resp.Error.Details)
+			}
+		})
+	}
+}
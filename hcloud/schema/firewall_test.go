@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestFirewallCreateRequest(t *testing.T) {
+	var (
+		oneLabel    = map[string]string{"foo": "bar"}
+		nilLabels   map[string]string
+		emptyLabels = map[string]string{}
+	)
+
+	testCases := []struct {
+		name string
+		in   FirewallCreateRequest
+		out  []byte
+	}{
+		{
+			name: "no labels",
+			in:   FirewallCreateRequest{Name: "test"},
+			out:  []byte(`{"name":"test"}`),
+		},
+		{
+			name: "one label",
+			in:   FirewallCreateRequest{Name: "test", Labels: &oneLabel},
+			out:  []byte(`{"name":"test","labels":{"foo":"bar"}}`),
+		},
+		{
+			name: "nil labels",
+			in:   FirewallCreateRequest{Name: "test", Labels: &nilLabels},
+			out:  []byte(`{"name":"test","labels":null}`),
+		},
+		{
+			name: "empty labels",
+			in:   FirewallCreateRequest{Name: "test", Labels: &emptyLabels},
+			out:  []byte(`{"name":"test","labels":{}}`),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data, err := json.Marshal(testCase.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, testCase.out) {
+				t.Fatalf("output %s does not match %s", data, testCase.out)
+			}
+		})
+	}
+}
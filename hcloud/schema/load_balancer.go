@@ -0,0 +1,432 @@
+package schema
+
+import "time"
+
+// LoadBalancer defines the schema of a Load Balancer.
+type LoadBalancer struct {
+	ID               int                      `json:"id"`
+	Name             string                   `json:"name"`
+	PublicNet        LoadBalancerPublicNet    `json:"public_net"`
+	PrivateNet       []LoadBalancerPrivateNet `json:"private_net"`
+	Location         Location                 `json:"location"`
+	LoadBalancerType LoadBalancerType         `json:"load_balancer_type"`
+	Protection       LoadBalancerProtection   `json:"protection"`
+	Labels           map[string]string        `json:"labels"`
+	Created          time.Time                `json:"created"`
+	Services         []LoadBalancerService    `json:"services"`
+	Targets          []LoadBalancerTarget     `json:"targets"`
+	Algorithm        LoadBalancerAlgorithm    `json:"algorithm"`
+	IncludedTraffic  uint64                   `json:"included_traffic"`
+	OutgoingTraffic  *uint64                  `json:"outgoing_traffic"`
+	IngoingTraffic   *uint64                  `json:"ingoing_traffic"`
+}
+
+// LoadBalancerPublicNet defines the schema of a Load Balancer's public network
+// information.
+type LoadBalancerPublicNet struct {
+	Enabled bool                      `json:"enabled"`
+	IPv4    LoadBalancerPublicNetIPv4 `json:"ipv4"`
+	IPv6    LoadBalancerPublicNetIPv6 `json:"ipv6"`
+}
+
+// LoadBalancerPublicNetIPv4 defines the schema of a Load Balancer's public
+// IPv4 address.
+type LoadBalancerPublicNetIPv4 struct {
+	IP     string `json:"ip"`
+	DNSPtr string `json:"dns_ptr"`
+}
+
+// LoadBalancerPublicNetIPv6 defines the schema of a Load Balancer's public
+// IPv6 address.
+type LoadBalancerPublicNetIPv6 struct {
+	IP     string `json:"ip"`
+	DNSPtr string `json:"dns_ptr"`
+}
+
+// LoadBalancerPrivateNet defines the schema of a Load Balancer's private
+// network information.
+type LoadBalancerPrivateNet struct {
+	Network int    `json:"network"`
+	IP      string `json:"ip"`
+}
+
+// LoadBalancerAlgorithm defines the schema of the algorithm of a Load Balancer.
+type LoadBalancerAlgorithm struct {
+	Type string `json:"type"`
+}
+
+// LoadBalancerProtection defines the schema of a Load Balancer's resource protection.
+type LoadBalancerProtection struct {
+	Delete bool `json:"delete"`
+}
+
+// LoadBalancerService defines the schema of a Load Balancer service.
+type LoadBalancerService struct {
+	Protocol        string                          `json:"protocol"`
+	ListenPort      int                             `json:"listen_port"`
+	DestinationPort int                             `json:"destination_port"`
+	Proxyprotocol   bool                            `json:"proxyprotocol"`
+	HTTP            *LoadBalancerServiceHTTP        `json:"http"`
+	HealthCheck     *LoadBalancerServiceHealthCheck `json:"health_check"`
+}
+
+// LoadBalancerServiceHTTP defines the schema of the HTTP configuration of a
+// Load Balancer service.
+type LoadBalancerServiceHTTP struct {
+	CookieName     string `json:"cookie_name"`
+	CookieLifetime int    `json:"cookie_lifetime"`
+	Certificates   []int  `json:"certificates"`
+	RedirectHTTP   bool   `json:"redirect_http"`
+	StickySessions bool   `json:"sticky_sessions"`
+}
+
+// LoadBalancerServiceHealthCheck defines the schema of the health check of a
+// Load Balancer service.
+type LoadBalancerServiceHealthCheck struct {
+	Protocol string                              `json:"protocol"`
+	Port     int                                 `json:"port"`
+	Interval int                                 `json:"interval"`
+	Timeout  int                                 `json:"timeout"`
+	Retries  int                                 `json:"retries"`
+	HTTP     *LoadBalancerServiceHealthCheckHTTP `json:"http"`
+}
+
+// LoadBalancerServiceHealthCheckHTTP defines the schema of the HTTP
+// configuration of a Load Balancer service health check.
+type LoadBalancerServiceHealthCheckHTTP struct {
+	Domain      string   `json:"domain"`
+	Path        string   `json:"path"`
+	Response    string   `json:"response"`
+	StatusCodes []string `json:"status_codes"`
+	TLS         bool     `json:"tls"`
+}
+
+// LoadBalancerTarget defines the schema of a Load Balancer target.
+type LoadBalancerTarget struct {
+	Type          string                           `json:"type"`
+	Server        *LoadBalancerTargetServer        `json:"server"`
+	LabelSelector *LoadBalancerTargetLabelSelector `json:"label_selector"`
+	IP            *LoadBalancerTargetIP            `json:"ip"`
+	HealthStatus  []LoadBalancerTargetHealthStatus `json:"health_status"`
+	UsePrivateIP  bool                             `json:"use_private_ip"`
+	Targets       []LoadBalancerTarget             `json:"targets,omitempty"`
+}
+
+// LoadBalancerTargetHealthStatus defines the schema of the health status of a
+// Load Balancer target.
+type LoadBalancerTargetHealthStatus struct {
+	ListenPort int    `json:"listen_port"`
+	Status     string `json:"status"`
+}
+
+// LoadBalancerTargetServer defines the schema of a server target.
+type LoadBalancerTargetServer struct {
+	ID int `json:"id"`
+}
+
+// LoadBalancerTargetLabelSelector defines the schema of a label selector target.
+type LoadBalancerTargetLabelSelector struct {
+	Selector string `json:"selector"`
+}
+
+// LoadBalancerTargetIP defines the schema of an IP target.
+type LoadBalancerTargetIP struct {
+	IP string `json:"ip"`
+}
+
+// LoadBalancerListResponse defines the schema of the response when listing
+// Load Balancers.
+type LoadBalancerListResponse struct {
+	LoadBalancers []LoadBalancer `json:"load_balancers"`
+}
+
+// LoadBalancerGetResponse defines the schema of the response when retrieving
+// a single Load Balancer.
+type LoadBalancerGetResponse struct {
+	LoadBalancer LoadBalancer `json:"load_balancer"`
+}
+
+// LoadBalancerActionAddTargetRequest defines the schema of the request to add
+// a target to a Load Balancer.
+type LoadBalancerActionAddTargetRequest struct {
+	Type          string                                 `json:"type"`
+	Server        *LoadBalancerActionTargetServer        `json:"server,omitempty"`
+	LabelSelector *LoadBalancerActionTargetLabelSelector `json:"label_selector,omitempty"`
+	IP            *LoadBalancerActionTargetIP            `json:"ip,omitempty"`
+	UsePrivateIP  *bool                                  `json:"use_private_ip,omitempty"`
+}
+
+// LoadBalancerActionTargetServer defines the schema of a server target in a
+// Load Balancer action request.
+type LoadBalancerActionTargetServer struct {
+	ID int `json:"id"`
+}
+
+// LoadBalancerActionTargetLabelSelector defines the schema of a label selector
+// target in a Load Balancer action request.
+type LoadBalancerActionTargetLabelSelector struct {
+	Selector string `json:"selector"`
+}
+
+// LoadBalancerActionTargetIP defines the schema of an IP target in a Load
+// Balancer action request.
+type LoadBalancerActionTargetIP struct {
+	IP string `json:"ip"`
+}
+
+// LoadBalancerActionAddTargetResponse defines the schema of the response when
+// adding a target to a Load Balancer.
+type LoadBalancerActionAddTargetResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionRemoveTargetRequest defines the schema of the request to
+// remove a target from a Load Balancer.
+type LoadBalancerActionRemoveTargetRequest struct {
+	Type          string                                 `json:"type"`
+	Server        *LoadBalancerActionTargetServer        `json:"server,omitempty"`
+	LabelSelector *LoadBalancerActionTargetLabelSelector `json:"label_selector,omitempty"`
+	IP            *LoadBalancerActionTargetIP            `json:"ip,omitempty"`
+}
+
+// LoadBalancerActionRemoveTargetResponse defines the schema of the response
+// when removing a target from a Load Balancer.
+type LoadBalancerActionRemoveTargetResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionAddServiceRequest defines the schema of the request to add
+// a service to a Load Balancer.
+type LoadBalancerActionAddServiceRequest struct {
+	Protocol        string                                   `json:"protocol"`
+	ListenPort      *int                                     `json:"listen_port,omitempty"`
+	DestinationPort *int                                     `json:"destination_port,omitempty"`
+	Proxyprotocol   *bool                                    `json:"proxyprotocol,omitempty"`
+	HTTP            *LoadBalancerActionAddServiceRequestHTTP `json:"http,omitempty"`
+	HealthCheck     *LoadBalancerActionServiceHealthCheck    `json:"health_check,omitempty"`
+}
+
+// LoadBalancerActionAddServiceRequestHTTP defines the schema of the HTTP
+// configuration when adding a service to a Load Balancer.
+type LoadBalancerActionAddServiceRequestHTTP struct {
+	CookieName     *string `json:"cookie_name,omitempty"`
+	CookieLifetime *int    `json:"cookie_lifetime,omitempty"`
+	Certificates   *[]int  `json:"certificates,omitempty"`
+	RedirectHTTP   *bool   `json:"redirect_http,omitempty"`
+	StickySessions *bool   `json:"sticky_sessions,omitempty"`
+}
+
+// LoadBalancerActionServiceHealthCheck defines the schema of the health check
+// when adding or updating a service of a Load Balancer.
+type LoadBalancerActionServiceHealthCheck struct {
+	Protocol *string                                   `json:"protocol,omitempty"`
+	Port     *int                                      `json:"port,omitempty"`
+	Interval *int                                      `json:"interval,omitempty"`
+	Timeout  *int                                      `json:"timeout,omitempty"`
+	Retries  *int                                      `json:"retries,omitempty"`
+	HTTP     *LoadBalancerActionServiceHealthCheckHTTP `json:"http,omitempty"`
+}
+
+// LoadBalancerActionServiceHealthCheckHTTP defines the schema of the HTTP
+// configuration of a health check when adding or updating a service of a Load
+// Balancer.
+type LoadBalancerActionServiceHealthCheckHTTP struct {
+	Domain      *string   `json:"domain,omitempty"`
+	Path        *string   `json:"path,omitempty"`
+	Response    *string   `json:"response,omitempty"`
+	StatusCodes *[]string `json:"status_codes,omitempty"`
+	TLS         *bool     `json:"tls,omitempty"`
+}
+
+// LoadBalancerActionAddServiceResponse defines the schema of the response when
+// adding a service to a Load Balancer.
+type LoadBalancerActionAddServiceResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionUpdateServiceRequest defines the schema of the request to
+// update a service of a Load Balancer.
+type LoadBalancerActionUpdateServiceRequest struct {
+	ListenPort      int                                         `json:"listen_port"`
+	Protocol        *string                                     `json:"protocol,omitempty"`
+	DestinationPort *int                                        `json:"destination_port,omitempty"`
+	Proxyprotocol   *bool                                       `json:"proxyprotocol,omitempty"`
+	HTTP            *LoadBalancerActionUpdateServiceRequestHTTP `json:"http,omitempty"`
+	HealthCheck     *LoadBalancerActionServiceHealthCheck       `json:"health_check,omitempty"`
+}
+
+// LoadBalancerActionUpdateServiceRequestHTTP defines the schema of the HTTP
+// configuration when updating a service of a Load Balancer.
+type LoadBalancerActionUpdateServiceRequestHTTP struct {
+	CookieName     *string `json:"cookie_name,omitempty"`
+	CookieLifetime *int    `json:"cookie_lifetime,omitempty"`
+	Certificates   *[]int  `json:"certificates,omitempty"`
+	RedirectHTTP   *bool   `json:"redirect_http,omitempty"`
+	StickySessions *bool   `json:"sticky_sessions,omitempty"`
+}
+
+// LoadBalancerActionUpdateServiceResponse defines the schema of the response
+// when updating a service of a Load Balancer.
+type LoadBalancerActionUpdateServiceResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerDeleteServiceRequest defines the schema of the request to delete
+// a service of a Load Balancer.
+type LoadBalancerDeleteServiceRequest struct {
+	ListenPort int `json:"listen_port"`
+}
+
+// LoadBalancerDeleteServiceResponse defines the schema of the response when
+// deleting a service of a Load Balancer.
+type LoadBalancerDeleteServiceResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerCreateRequest defines the schema of the request to create a
+// Load Balancer.
+type LoadBalancerCreateRequest struct {
+	Name             string                              `json:"name"`
+	LoadBalancerType interface{}                         `json:"load_balancer_type"` // int or string
+	Algorithm        *LoadBalancerCreateRequestAlgorithm `json:"algorithm,omitempty"`
+	Location         *string                             `json:"location,omitempty"`
+	NetworkZone      *string                             `json:"network_zone,omitempty"`
+	Labels           *map[string]string                  `json:"labels,omitempty"`
+	Targets          []LoadBalancerCreateRequestTarget   `json:"targets,omitempty"`
+	Services         []LoadBalancerCreateRequestService  `json:"services,omitempty"`
+	PublicInterface  *bool                               `json:"public_interface,omitempty"`
+	Network          *int                                `json:"network,omitempty"`
+}
+
+// LoadBalancerCreateRequestAlgorithm defines the schema of the algorithm when
+// creating a Load Balancer.
+type LoadBalancerCreateRequestAlgorithm struct {
+	Type string `json:"type"`
+}
+
+// LoadBalancerCreateRequestTarget defines the schema of a target when creating
+// a Load Balancer.
+type LoadBalancerCreateRequestTarget struct {
+	Type          string                                 `json:"type"`
+	Server        *LoadBalancerActionTargetServer        `json:"server,omitempty"`
+	LabelSelector *LoadBalancerActionTargetLabelSelector `json:"label_selector,omitempty"`
+	IP            *LoadBalancerActionTargetIP            `json:"ip,omitempty"`
+	UsePrivateIP  *bool                                  `json:"use_private_ip,omitempty"`
+}
+
+// LoadBalancerCreateRequestService defines the schema of a service when
+// creating a Load Balancer.
+type LoadBalancerCreateRequestService struct {
+	Protocol        string                                   `json:"protocol"`
+	ListenPort      *int                                     `json:"listen_port,omitempty"`
+	DestinationPort *int                                     `json:"destination_port,omitempty"`
+	Proxyprotocol   *bool                                    `json:"proxyprotocol,omitempty"`
+	HTTP            *LoadBalancerActionAddServiceRequestHTTP `json:"http,omitempty"`
+	HealthCheck     *LoadBalancerActionServiceHealthCheck    `json:"health_check,omitempty"`
+}
+
+// LoadBalancerCreateResponse defines the schema of the response when creating
+// a Load Balancer.
+type LoadBalancerCreateResponse struct {
+	LoadBalancer LoadBalancer `json:"load_balancer"`
+	Action       Action       `json:"action"`
+}
+
+// LoadBalancerUpdateRequest defines the schema of the request to update a
+// Load Balancer.
+type LoadBalancerUpdateRequest struct {
+	Name   *string            `json:"name,omitempty"`
+	Labels *map[string]string `json:"labels,omitempty"`
+}
+
+// LoadBalancerUpdateResponse defines the schema of the response when updating
+// a Load Balancer.
+type LoadBalancerUpdateResponse struct {
+	LoadBalancer LoadBalancer `json:"load_balancer"`
+}
+
+// LoadBalancerActionChangeProtectionRequest defines the schema of the request
+// to change the resource protection of a Load Balancer.
+type LoadBalancerActionChangeProtectionRequest struct {
+	Delete *bool `json:"delete,omitempty"`
+}
+
+// LoadBalancerActionChangeProtectionResponse defines the schema of the
+// response when changing the resource protection of a Load Balancer.
+type LoadBalancerActionChangeProtectionResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionChangeAlgorithmRequest defines the schema of the request
+// to change the algorithm of a Load Balancer.
+type LoadBalancerActionChangeAlgorithmRequest struct {
+	Type string `json:"type"`
+}
+
+// LoadBalancerActionChangeAlgorithmResponse defines the schema of the response
+// when changing the algorithm of a Load Balancer.
+type LoadBalancerActionChangeAlgorithmResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionAttachToNetworkRequest defines the schema of the request
+// to attach a Load Balancer to a network.
+type LoadBalancerActionAttachToNetworkRequest struct {
+	Network int     `json:"network"`
+	IP      *string `json:"ip,omitempty"`
+}
+
+// LoadBalancerActionAttachToNetworkResponse defines the schema of the response
+// when attaching a Load Balancer to a network.
+type LoadBalancerActionAttachToNetworkResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionDetachFromNetworkRequest defines the schema of the request
+// to detach a Load Balancer from a network.
+type LoadBalancerActionDetachFromNetworkRequest struct {
+	Network int `json:"network"`
+}
+
+// LoadBalancerActionDetachFromNetworkResponse defines the schema of the
+// response when detaching a Load Balancer from a network.
+type LoadBalancerActionDetachFromNetworkResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionEnablePublicInterfaceResponse defines the schema of the
+// response when enabling the public interface of a Load Balancer.
+type LoadBalancerActionEnablePublicInterfaceResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionDisablePublicInterfaceResponse defines the schema of the
+// response when disabling the public interface of a Load Balancer.
+type LoadBalancerActionDisablePublicInterfaceResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionChangeTypeRequest defines the schema of the request to
+// change the type of a Load Balancer.
+type LoadBalancerActionChangeTypeRequest struct {
+	LoadBalancerType interface{} `json:"load_balancer_type"` // int or string
+}
+
+// LoadBalancerActionChangeTypeResponse defines the schema of the response when
+// changing the type of a Load Balancer.
+type LoadBalancerActionChangeTypeResponse struct {
+	Action Action `json:"action"`
+}
+
+// LoadBalancerActionChangeDNSPtrRequest defines the schema of the request to
+// change the reverse DNS pointer of a Load Balancer.
+type LoadBalancerActionChangeDNSPtrRequest struct {
+	IP     string  `json:"ip"`
+	DNSPtr *string `json:"dns_ptr"`
+}
+
+// LoadBalancerActionChangeDNSPtrResponse defines the schema of the response
+// when changing the reverse DNS pointer of a Load Balancer.
+type LoadBalancerActionChangeDNSPtrResponse struct {
+	Action Action `json:"action"`
+}
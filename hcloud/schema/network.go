@@ -13,6 +13,8 @@ type Network struct {
 	Servers    []int             `json:"servers"`
 	Protection NetworkProtection `json:"protection"`
 	Labels     map[string]string `json:"labels"`
+
+	ExposeRoutesToVSwitch bool `json:"expose_routes_to_vswitch"`
 }
 
 // NetworkSubnet represents a subnet of a network.
@@ -21,6 +23,7 @@ type NetworkSubnet struct {
 	IPRange     string `json:"ip_range"`
 	NetworkZone string `json:"network_zone"`
 	Gateway     string `json:"gateway"`
+	VSwitchID   int    `json:"vswitch_id,omitempty"`
 }
 
 // NetworkRoute represents a route of a network.
@@ -36,8 +39,9 @@ type NetworkProtection struct {
 
 // NetworkUpdateRequest defines the schema of the request to update a network.
 type NetworkUpdateRequest struct {
-	Name   string             `json:"name,omitempty"`
-	Labels *map[string]string `json:"labels,omitempty"`
+	Name                  string             `json:"name,omitempty"`
+	Labels                *map[string]string `json:"labels,omitempty"`
+	ExposeRoutesToVSwitch *bool              `json:"expose_routes_to_vswitch,omitempty"`
 }
 
 // NetworkUpdateResponse defines the schema of the response when updating a network.
@@ -64,6 +68,8 @@ type NetworkCreateRequest struct {
 	Subnets []NetworkSubnet    `json:"subnets,omitempty"`
 	Routes  []NetworkRoute     `json:"routes,omitempty"`
 	Labels  *map[string]string `json:"labels,omitempty"`
+
+	ExposeRoutesToVSwitch bool `json:"expose_routes_to_vswitch,omitempty"`
 }
 
 // NetworkCreateResponse defines the schema of the response when
@@ -91,6 +97,7 @@ type NetworkActionAddSubnetRequest struct {
 	IPRange     string `json:"ip_range,omitempty"`
 	NetworkZone string `json:"network_zone"`
 	Gateway     string `json:"gateway"`
+	VSwitchID   int    `json:"vswitch_id,omitempty"`
 }
 
 // NetworkActionAddSubnetResponse defines the schema of the response when
@@ -0,0 +1,53 @@
+package schema
+
+import "time"
+
+// PlacementGroup defines the schema of a placement group.
+type PlacementGroup struct {
+	ID      int               `json:"id"`
+	Name    string            `json:"name"`
+	Labels  map[string]string `json:"labels"`
+	Created time.Time         `json:"created"`
+	Servers []int             `json:"servers"`
+	Type    string            `json:"type"`
+}
+
+// PlacementGroupListResponse defines the schema of the response when
+// listing placement groups.
+type PlacementGroupListResponse struct {
+	PlacementGroups []PlacementGroup `json:"placement_groups"`
+}
+
+// PlacementGroupGetResponse defines the schema of the response when
+// retrieving a single placement group.
+type PlacementGroupGetResponse struct {
+	PlacementGroup PlacementGroup `json:"placement_group"`
+}
+
+// PlacementGroupCreateRequest defines the schema of the request to create a
+// placement group.
+type PlacementGroupCreateRequest struct {
+	Name   string             `json:"name"`
+	Labels *map[string]string `json:"labels,omitempty"`
+	Type   string             `json:"type"`
+}
+
+// PlacementGroupCreateResponse defines the schema of the response when
+// creating a placement group.
+type PlacementGroupCreateResponse struct {
+	PlacementGroup PlacementGroup `json:"placement_group"`
+	Action         *Action        `json:"action"`
+}
+
+// PlacementGroupUpdateRequest defines the schema of the request to update a
+// placement group.
+type PlacementGroupUpdateRequest struct {
+	Name   *string            `json:"name,omitempty"`
+	Labels *map[string]string `json:"labels,omitempty"`
+}
+
+// PlacementGroupUpdateResponse defines the schema of the response when
+// updating a placement group.
+type PlacementGroupUpdateResponse struct {
+	PlacementGroup PlacementGroup `json:"placement_group"`
+}
@@ -52,6 +52,14 @@ type PricingServerTypePrice struct {
 	PriceMonthly Price  `json:"price_monthly"`
 }
 
+// PricingLoadBalancerTypePrice defines the schema of pricing information for a
+// Load Balancer type at a location.
+type PricingLoadBalancerTypePrice struct {
+	Location     string `json:"location"`
+	PriceHourly  Price  `json:"price_hourly"`
+	PriceMonthly Price  `json:"price_monthly"`
+}
+
 // PricingGetResponse defines the schema of the response when retrieving pricing information.
 type PricingGetResponse struct {
 	Pricing Pricing `json:"pricing"`
@@ -0,0 +1,117 @@
+package schema
+
+import "time"
+
+// PrimaryIP defines the schema of a Primary IP.
+type PrimaryIP struct {
+	ID           int                 `json:"id"`
+	Name         string              `json:"name"`
+	IP           string              `json:"ip"`
+	Type         string              `json:"type"`
+	AssigneeID   *int                `json:"assignee_id"`
+	AssigneeType string              `json:"assignee_type"`
+	AutoDelete   bool                `json:"auto_delete"`
+	Blocked      bool                `json:"blocked"`
+	Created      time.Time           `json:"created"`
+	Datacenter   Datacenter          `json:"datacenter"`
+	DNSPtr       []PrimaryIPDNSPtr   `json:"dns_ptr"`
+	Labels       map[string]string   `json:"labels"`
+	Protection   PrimaryIPProtection `json:"protection"`
+}
+
+// PrimaryIPProtection represents the protection level of a Primary IP.
+type PrimaryIPProtection struct {
+	Delete bool `json:"delete"`
+}
+
+// PrimaryIPDNSPtr contains reverse DNS information for a
+// IPv4 or IPv6 Primary IP.
+type PrimaryIPDNSPtr struct {
+	IP     string `json:"ip"`
+	DNSPtr string `json:"dns_ptr"`
+}
+
+// PrimaryIPGetResponse defines the schema of the response when
+// retrieving a single Primary IP.
+type PrimaryIPGetResponse struct {
+	PrimaryIP PrimaryIP `json:"primary_ip"`
+}
+
+// PrimaryIPListResponse defines the schema of the response when
+// listing Primary IPs.
+type PrimaryIPListResponse struct {
+	PrimaryIPs []PrimaryIP `json:"primary_ips"`
+}
+
+// PrimaryIPCreateRequest defines the schema of the request to
+// create a Primary IP.
+type PrimaryIPCreateRequest struct {
+	Name         string             `json:"name"`
+	Type         string             `json:"type"`
+	AssigneeType string             `json:"assignee_type"`
+	AssigneeID   *int               `json:"assignee_id,omitempty"`
+	Datacenter   string             `json:"datacenter,omitempty"`
+	AutoDelete   *bool              `json:"auto_delete,omitempty"`
+	Labels       *map[string]string `json:"labels,omitempty"`
+}
+
+// PrimaryIPCreateResponse defines the schema of the response
+// when creating a Primary IP.
+type PrimaryIPCreateResponse struct {
+	PrimaryIP PrimaryIP `json:"primary_ip"`
+	Action    *Action   `json:"action"`
+}
+
+// PrimaryIPUpdateRequest defines the schema of the request to update a Primary IP.
+type PrimaryIPUpdateRequest struct {
+	Name       string             `json:"name,omitempty"`
+	Labels     *map[string]string `json:"labels,omitempty"`
+	AutoDelete *bool              `json:"auto_delete,omitempty"`
+}
+
+// PrimaryIPUpdateResponse defines the schema of the response when updating a Primary IP.
+type PrimaryIPUpdateResponse struct {
+	PrimaryIP PrimaryIP `json:"primary_ip"`
+}
+
+// PrimaryIPActionAssignRequest defines the schema of the request to
+// create an assign Primary IP action.
+type PrimaryIPActionAssignRequest struct {
+	AssigneeID   int    `json:"assignee_id"`
+	AssigneeType string `json:"assignee_type"`
+}
+
+// PrimaryIPActionAssignResponse defines the schema of the response when
+// creating an assign action.
+type PrimaryIPActionAssignResponse struct {
+	Action Action `json:"action"`
+}
+
+// PrimaryIPActionUnassignResponse defines the schema of the response when
+// creating an unassign action.
+type PrimaryIPActionUnassignResponse struct {
+	Action Action `json:"action"`
+}
+
+// PrimaryIPActionChangeDNSPtrRequest defines the schema for the request to
+// change a Primary IP's reverse DNS pointer.
+type PrimaryIPActionChangeDNSPtrRequest struct {
+	IP     string  `json:"ip"`
+	DNSPtr *string `json:"dns_ptr"`
+}
+
+// PrimaryIPActionChangeDNSPtrResponse defines the schema of the response when
+// creating a change_dns_ptr Primary IP action.
+type PrimaryIPActionChangeDNSPtrResponse struct {
+	Action Action `json:"action"`
+}
+
+// PrimaryIPActionChangeProtectionRequest defines the schema of the request to change the resource protection of a Primary IP.
+type PrimaryIPActionChangeProtectionRequest struct {
+	Delete *bool `json:"delete,omitempty"`
+}
+
+// PrimaryIPActionChangeProtectionResponse defines the schema of the response when changing the resource protection of a Primary IP.
+type PrimaryIPActionChangeProtectionResponse struct {
+	Action Action `json:"action"`
+}
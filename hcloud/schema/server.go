@@ -23,6 +23,7 @@ type Server struct {
 	Protection      ServerProtection   `json:"protection"`
 	Labels          map[string]string  `json:"labels"`
 	Volumes         []int              `json:"volumes"`
+	PlacementGroup  *PlacementGroup    `json:"placement_group"`
 }
 
 // ServerProtection defines the schema of a server's resource protection.
@@ -42,6 +43,7 @@ type ServerPublicNet struct {
 // ServerPublicNetIPv4 defines the schema of a server's public
 // network information for an IPv4.
 type ServerPublicNetIPv4 struct {
+	ID      int    `json:"id"`
 	IP      string `json:"ip"`
 	Blocked bool   `json:"blocked"`
 	DNSPtr  string `json:"dns_ptr"`
@@ -50,6 +52,7 @@ type ServerPublicNetIPv4 struct {
 // ServerPublicNetIPv6 defines the schema of a server's public
 // network information for an IPv6.
 type ServerPublicNetIPv6 struct {
+	ID      int                         `json:"id"`
 	IP      string                      `json:"ip"`
 	Blocked bool                        `json:"blocked"`
 	DNSPtr  []ServerPublicNetIPv6DNSPtr `json:"dns_ptr"`
@@ -85,18 +88,29 @@ type ServerListResponse struct {
 // ServerCreateRequest defines the schema for the request to
 // create a server.
 type ServerCreateRequest struct {
-	Name             string             `json:"name"`
-	ServerType       interface{}        `json:"server_type"` // int or string
-	Image            interface{}        `json:"image"`       // int or string
-	SSHKeys          []int              `json:"ssh_keys,omitempty"`
-	Location         string             `json:"location,omitempty"`
-	Datacenter       string             `json:"datacenter,omitempty"`
-	UserData         string             `json:"user_data,omitempty"`
-	StartAfterCreate *bool              `json:"start_after_create,omitempty"`
-	Labels           *map[string]string `json:"labels,omitempty"`
-	Automount        *bool              `json:"automount,omitempty"`
-	Volumes          []int              `json:"volumes,omitempty"`
-	Networks         []int              `json:"networks,omitempty"`
+	Name             string                 `json:"name"`
+	ServerType       interface{}            `json:"server_type"` // int or string
+	Image            interface{}            `json:"image"`       // int or string
+	SSHKeys          []int                  `json:"ssh_keys,omitempty"`
+	Location         string                 `json:"location,omitempty"`
+	Datacenter       string                 `json:"datacenter,omitempty"`
+	UserData         string                 `json:"user_data,omitempty"`
+	StartAfterCreate *bool                  `json:"start_after_create,omitempty"`
+	Labels           *map[string]string     `json:"labels,omitempty"`
+	Automount        *bool                  `json:"automount,omitempty"`
+	Volumes          []int                  `json:"volumes,omitempty"`
+	Networks         []int                  `json:"networks,omitempty"`
+	PlacementGroup   int                    `json:"placement_group,omitempty"`
+	PublicNet        *ServerCreatePublicNet `json:"public_net,omitempty"`
+}
+
+// ServerCreatePublicNet defines the schema of the public network
+// configuration of a server to create.
+type ServerCreatePublicNet struct {
+	EnableIPv4 bool `json:"enable_ipv4"`
+	EnableIPv6 bool `json:"enable_ipv6"`
+	IPv4ID     int  `json:"ipv4,omitempty"`
+	IPv6ID     int  `json:"ipv6,omitempty"`
 }
 
 // ServerCreateResponse defines the schema of the response when
@@ -352,3 +366,50 @@ type ServerActionChangeAliasIPsRequest struct {
 type ServerActionChangeAliasIPsResponse struct {
 	Action Action `json:"action"`
 }
+
+// ServerActionAddToPlacementGroupRequest defines the schema of the request to
+// add a server to a placement group.
+type ServerActionAddToPlacementGroupRequest struct {
+	PlacementGroup int `json:"placement_group"`
+}
+
+// ServerActionAddToPlacementGroupResponse defines the schema of the response when
+// creating an add_to_placement_group server action.
+type ServerActionAddToPlacementGroupResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerActionRemoveFromPlacementGroupResponse defines the schema of the response when
+// creating a remove_from_placement_group server action.
+type ServerActionRemoveFromPlacementGroupResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerGetMetricsResponse defines the schema of the response when
+// retrieving metrics for a server.
+type ServerGetMetricsResponse struct {
+	Metrics ServerMetrics `json:"metrics"`
+}
+
+// ServerMetrics defines the schema of the metrics of a server.
+type ServerMetrics struct {
+	Start      time.Time                       `json:"start"`
+	End        time.Time                       `json:"end"`
+	Step       float64                         `json:"step"`
+	TimeSeries map[string]ServerTimeSeriesVals `json:"time_series"`
+}
+
+// ServerTimeSeriesVals defines the schema of a single time series of server
+// metrics. Each value is a pair of a Unix timestamp (number) and the measured
+// value (string).
+type ServerTimeSeriesVals struct {
+	Values [][2]interface{} `json:"values"`
+}
+
+// ServerActionRequestConsoleResponse defines the schema of the response when
+// creating a request_console server action.
+type ServerActionRequestConsoleResponse struct {
+	Action   Action `json:"action"`
+	WSSURL   string `json:"wss_url"`
+	Password string `json:"password"`
+}
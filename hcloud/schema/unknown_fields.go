@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// UnknownFields returns the paths of all fields in the JSON document data
+// which would be dropped when decoding it into each of vs, for example
+// "server.public_net.ipv4.foo". A field is known if any of vs models it,
+// which allows checking responses that are decoded into several values, like
+// a response type and MetaResponse. Elements of arrays are denoted by "[]",
+// so a field unknown in several elements is reported once, like
+// "servers[].foo". The paths are sorted.
+//
+// Field names are matched the way encoding/json matches them, preferring an
+// exact match over a case-insensitive one. Values decoded into
+// interface{} or json.RawMessage may hold arbitrary fields.
+func UnknownFields(data []byte, vs ...interface{}) ([]string, error) {
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var types []reflect.Type
+	for _, v := range vs {
+		types = append(types, reflect.TypeOf(v))
+	}
+	seen := map[string]bool{}
+	var fields []string
+	collectUnknownFields(doc, types, "", func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			fields = append(fields, path)
+		}
+	})
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// collectUnknownFields reports the fields of doc which none of types model.
+func collectUnknownFields(doc interface{}, types []reflect.Type, path string, report func(string)) {
+	var structs, elems, maps []reflect.Type
+	for _, t := range types {
+		if t == nil {
+			continue
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case t == rawMessageType || t.Kind() == reflect.Interface:
+			return
+		case t.Kind() == reflect.Struct:
+			structs = append(structs, t)
+		case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+			elems = append(elems, t.Elem())
+		case t.Kind() == reflect.Map:
+			maps = append(maps, t.Elem())
+		}
+	}
+
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		for key, value := range doc {
+			var known []reflect.Type
+			for _, t := range structs {
+				if field, ok := lookupField(jsonFields(t), key); ok {
+					known = append(known, field)
+				}
+			}
+			known = append(known, maps...)
+			if len(known) == 0 {
+				if len(structs) > 0 {
+					report(joinPath(path, key))
+				}
+				continue
+			}
+			collectUnknownFields(value, known, joinPath(path, key), report)
+		}
+	case []interface{}:
+		if len(elems) == 0 {
+			return
+		}
+		for _, item := range doc {
+			collectUnknownFields(item, elems, path+"[]", report)
+		}
+	}
+}
+
+// lookupField returns the type of the field named key, preferring an exact
+// match over a case-insensitive one like encoding/json does.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// jsonFields returns the types of the fields of struct type t by the names
+// encoding/json uses for them, including fields promoted from embedded
+// structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for n, typ := range jsonFields(ft) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = typ
+					}
+				}
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	testCases := map[string]struct {
+		data     string
+		vs       []interface{}
+		expected []string
+	}{
+		"all known": {
+			data: `{"server": {"id": 1, "name": "web", "labels": {"a": "b"}, "public_net": {"ipv4": {"ip": "1.2.3.4"}}}}`,
+			vs:   []interface{}{&ServerGetResponse{}},
+		},
+		"case-insensitive match": {
+			data: `{"Server": {"ID": 1}}`,
+			vs:   []interface{}{&ServerGetResponse{}},
+		},
+		"nested": {
+			data: `{"server": {"id": 1, "rescue": true, "public_net": {"ipv4": {"ip": "1.2.3.4", "ptr": "x"}}}, "extra": 1}`,
+			vs:   []interface{}{&ServerGetResponse{}},
+			expected: []string{
+				"extra",
+				"server.public_net.ipv4.ptr",
+				"server.rescue",
+			},
+		},
+		"arrays reported once": {
+			data:     `{"servers": [{"id": 1, "foo": 1}, {"id": 2, "foo": 2}], "meta": {"pagination": {"page": 1, "pages": 1}}}`,
+			vs:       []interface{}{&ServerListResponse{}},
+			expected: []string{"meta", "servers[].foo"},
+		},
+		"several values": {
+			data:     `{"servers": [{"id": 1, "foo": 1}], "meta": {"pagination": {"page": 1, "pages": 1}}}`,
+			vs:       []interface{}{&ServerListResponse{}, &MetaResponse{}},
+			expected: []string{"meta.pagination.pages", "servers[].foo"},
+		},
+		"maps": {
+			data:     `{"start": "2017-01-01T00:00:00Z", "time_series": {"cpu": {"values": [], "unit": "%"}}}`,
+			vs:       []interface{}{&ServerMetrics{}},
+			expected: []string{"time_series.cpu.unit"},
+		},
+		"raw details": {
+			data: `{"error": {"code": "conflict", "message": "conflict", "details": {"anything": true}}}`,
+			vs:   []interface{}{&ErrorResponse{}},
+		},
+		"anonymous structs": {
+			data:     `{"server_types": {"available": [1], "deprecated": [2]}}`,
+			vs:       []interface{}{&Datacenter{}},
+			expected: []string{"server_types.deprecated"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fields, err := UnknownFields([]byte(tc.data), tc.vs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(fields, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, fields)
+			}
+		})
+	}
+
+	if _, err := UnknownFields([]byte(`{`), &ServerGetResponse{}); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
@@ -2,6 +2,9 @@ package hcloud
 
 import (
 	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
 	"testing"
 	"time"
 
@@ -850,6 +853,41 @@ func TestErrorFromSchema(t *testing.T) {
 			}
 		}
 	})
+
+	testCases := map[string]struct {
+		data    string
+		details interface{}
+	}{
+		"uniqueness_error": {
+			data:    `{"code": "uniqueness_error", "message": "SSH key with the same fingerprint already exists", "details": {"fields": [{"name": "public_key"}]}}`,
+			details: ErrorDetailsUniquenessError{Fields: []ErrorDetailsUniquenessErrorField{{Name: "public_key"}}},
+		},
+		"resource_limit_exceeded": {
+			data:    `{"code": "resource_limit_exceeded", "message": "project limit exceeded", "details": {"limits": [{"name": "project_limit"}]}}`,
+			details: ErrorDetailsResourceLimitExceeded{Limits: []ErrorDetailsResourceLimitExceededLimit{{Name: "project_limit"}}},
+		},
+		"server_already_attached": {
+			data:    `{"code": "server_already_attached", "message": "server is already attached", "details": {"server": 42}}`,
+			details: ErrorDetailsServerAlreadyAttached{Server: &Server{ID: 42}},
+		},
+		"locked": {
+			data:    `{"code": "locked", "message": "server is locked", "details": {"action": 13}}`,
+			details: ErrorDetailsLocked{Action: &Action{ID: 13}},
+		},
+		"locked without details": {
+			data: `{"code": "locked", "message": "server is locked"}`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var s schema.Error
+			unmarshalFixture(t, []byte(tc.data), &s)
+			err := ErrorFromSchema(s)
+			if !reflect.DeepEqual(err.Details, tc.details) {
+				t.Errorf("expected details %+v, got %+v", tc.details, err.Details)
+			}
+		})
+	}
 }
 
 func TestPaginationFromSchema(t *testing.T) {
@@ -1127,6 +1165,27 @@ func TestNetworkSubnetFromSchema(t *testing.T) {
 			t.Errorf("unexpected Gateway: %v", networkSubnet.Gateway)
 		}
 	})
+
+	t.Run("type vswitch", func(t *testing.T) {
+		data := []byte(`{
+			"type": "vswitch",
+			"ip_range": "10.0.2.0/24",
+			"network_zone": "eu-central",
+			"gateway": "10.0.0.1",
+			"vswitch_id": 1000
+		}`)
+		var s schema.NetworkSubnet
+		if err := json.Unmarshal(data, &s); err != nil {
+			t.Fatal(err)
+		}
+		networkSubnet := NetworkSubnetFromSchema(s)
+		if networkSubnet.Type != NetworkSubnetTypeVSwitch {
+			t.Errorf("unexpected Type: %v", networkSubnet.Type)
+		}
+		if networkSubnet.VSwitchID != 1000 {
+			t.Errorf("unexpected VSwitchID: %v", networkSubnet.VSwitchID)
+		}
+	})
 }
 
 func TestNetworkRouteFromSchema(t *testing.T) {
@@ -1147,6 +1206,362 @@ func TestNetworkRouteFromSchema(t *testing.T) {
 	}
 }
 
+func TestFirewallFromSchema(t *testing.T) {
+	data := []byte(`{
+		"id": 897,
+		"name": "my firewall",
+		"labels": {
+			"key": "value"
+		},
+		"created": "2017-08-16T17:29:14+00:00",
+		"rules": [
+			{
+				"direction": "in",
+				"source_ips": ["10.0.0.0/8", "2001:db8::/32"],
+				"protocol": "tcp",
+				"port": "80-85",
+				"description": "allow http"
+			},
+			{
+				"direction": "out",
+				"destination_ips": ["0.0.0.0/0"],
+				"protocol": "icmp"
+			}
+		],
+		"applied_to": [
+			{
+				"type": "server",
+				"server": {
+					"id": 42
+				}
+			},
+			{
+				"type": "label_selector",
+				"label_selector": {
+					"selector": "env=prod"
+				}
+			}
+		]
+	}`)
+
+	var s schema.Firewall
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	firewall := FirewallFromSchema(s)
+	if firewall.ID != 897 {
+		t.Errorf("unexpected ID: %v", firewall.ID)
+	}
+	if firewall.Name != "my firewall" {
+		t.Errorf("unexpected Name: %v", firewall.Name)
+	}
+	if firewall.Labels["key"] != "value" {
+		t.Errorf("unexpected Labels: %v", firewall.Labels)
+	}
+	if !firewall.Created.Equal(time.Date(2017, 8, 16, 17, 29, 14, 0, time.UTC)) {
+		t.Errorf("unexpected Created date: %v", firewall.Created)
+	}
+	if len(firewall.Rules) != 2 {
+		t.Fatalf("unexpected length of Rules: %v", len(firewall.Rules))
+	}
+	in := firewall.Rules[0]
+	if in.Direction != FirewallRuleDirectionIn || in.Protocol != FirewallRuleProtocolTCP {
+		t.Errorf("unexpected rule: %+v", in)
+	}
+	if in.Port == nil || *in.Port != "80-85" {
+		t.Errorf("unexpected Port: %v", in.Port)
+	}
+	if in.Description == nil || *in.Description != "allow http" {
+		t.Errorf("unexpected Description: %v", in.Description)
+	}
+	if len(in.SourceIPs) != 2 || in.SourceIPs[0].String() != "10.0.0.0/8" || in.SourceIPs[1].String() != "2001:db8::/32" {
+		t.Errorf("unexpected SourceIPs: %v", in.SourceIPs)
+	}
+	out := firewall.Rules[1]
+	if out.Direction != FirewallRuleDirectionOut || out.Port != nil {
+		t.Errorf("unexpected rule: %+v", out)
+	}
+	if len(out.DestinationIPs) != 1 || out.DestinationIPs[0].String() != "0.0.0.0/0" {
+		t.Errorf("unexpected DestinationIPs: %v", out.DestinationIPs)
+	}
+	if len(firewall.AppliedTo) != 2 {
+		t.Fatalf("unexpected length of AppliedTo: %v", len(firewall.AppliedTo))
+	}
+	if firewall.AppliedTo[0].Type != FirewallResourceTypeServer || firewall.AppliedTo[0].Server.ID != 42 {
+		t.Errorf("unexpected AppliedTo: %+v", firewall.AppliedTo[0])
+	}
+	if firewall.AppliedTo[1].Type != FirewallResourceTypeLabelSelector || firewall.AppliedTo[1].LabelSelector.Selector != "env=prod" {
+		t.Errorf("unexpected AppliedTo: %+v", firewall.AppliedTo[1])
+	}
+}
+
+func TestLoadBalancerFromSchema(t *testing.T) {
+	data := []byte(`{
+		"id": 4711,
+		"name": "Web Frontend",
+		"public_net": {
+			"enabled": true,
+			"ipv4": {"ip": "131.232.99.1", "dns_ptr": "lb.example.com"},
+			"ipv6": {"ip": "2001:db8::1", "dns_ptr": "lb.example.com"}
+		},
+		"private_net": [{"network": 4711, "ip": "10.0.255.1"}],
+		"location": {"id": 1, "name": "fsn1"},
+		"load_balancer_type": {"id": 1, "name": "lb11", "max_targets": 25},
+		"protection": {"delete": true},
+		"labels": {"key": "value"},
+		"created": "2016-01-30T23:50:00+00:00",
+		"services": [
+			{
+				"protocol": "https",
+				"listen_port": 443,
+				"destination_port": 80,
+				"proxyprotocol": false,
+				"http": {
+					"cookie_name": "HCLBSTICKY",
+					"cookie_lifetime": 300,
+					"certificates": [897],
+					"redirect_http": true,
+					"sticky_sessions": true
+				},
+				"health_check": {
+					"protocol": "http",
+					"port": 4711,
+					"interval": 15,
+					"timeout": 10,
+					"retries": 3,
+					"http": {
+						"domain": "example.com",
+						"path": "/",
+						"response": "{\"status\": \"ok\"}",
+						"status_codes": ["2??", "3??"],
+						"tls": false
+					}
+				}
+			}
+		],
+		"targets": [
+			{
+				"type": "label_selector",
+				"label_selector": {"selector": "role=web"},
+				"use_private_ip": true,
+				"targets": [
+					{
+						"type": "server",
+						"server": {"id": 80},
+						"health_status": [{"listen_port": 443, "status": "healthy"}]
+					}
+				]
+			},
+			{
+				"type": "ip",
+				"ip": {"ip": "203.0.113.1"}
+			}
+		],
+		"algorithm": {"type": "round_robin"},
+		"included_traffic": 654321,
+		"outgoing_traffic": 123456,
+		"ingoing_traffic": 7890
+	}`)
+
+	var s schema.LoadBalancer
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	loadBalancer := LoadBalancerFromSchema(s)
+	if loadBalancer.ID != 4711 || loadBalancer.Name != "Web Frontend" {
+		t.Errorf("unexpected Load Balancer: %v", loadBalancer)
+	}
+	if !loadBalancer.PublicNet.Enabled || loadBalancer.PublicNet.IPv4.IP.String() != "131.232.99.1" || loadBalancer.PublicNet.IPv6.DNSPtr != "lb.example.com" {
+		t.Errorf("unexpected PublicNet: %v", loadBalancer.PublicNet)
+	}
+	if len(loadBalancer.PrivateNet) != 1 || loadBalancer.PrivateNet[0].Network.ID != 4711 || loadBalancer.PrivateNet[0].IP.String() != "10.0.255.1" {
+		t.Errorf("unexpected PrivateNet: %v", loadBalancer.PrivateNet)
+	}
+	if loadBalancer.Location.Name != "fsn1" {
+		t.Errorf("unexpected Location: %v", loadBalancer.Location)
+	}
+	if loadBalancer.LoadBalancerType.Name != "lb11" || loadBalancer.LoadBalancerType.MaxTargets != 25 {
+		t.Errorf("unexpected LoadBalancerType: %v", loadBalancer.LoadBalancerType)
+	}
+	if !loadBalancer.Protection.Delete {
+		t.Errorf("unexpected Protection: %v", loadBalancer.Protection)
+	}
+	if loadBalancer.Labels["key"] != "value" {
+		t.Errorf("unexpected Labels: %v", loadBalancer.Labels)
+	}
+	if !loadBalancer.Created.Equal(time.Date(2016, 1, 30, 23, 50, 0, 0, time.UTC)) {
+		t.Errorf("unexpected Created: %v", loadBalancer.Created)
+	}
+	if loadBalancer.Algorithm.Type != LoadBalancerAlgorithmTypeRoundRobin {
+		t.Errorf("unexpected Algorithm: %v", loadBalancer.Algorithm)
+	}
+	if loadBalancer.IncludedTraffic != 654321 || loadBalancer.OutgoingTraffic != 123456 || loadBalancer.IngoingTraffic != 7890 {
+		t.Errorf("unexpected traffic: %d %d %d", loadBalancer.IncludedTraffic, loadBalancer.OutgoingTraffic, loadBalancer.IngoingTraffic)
+	}
+
+	if len(loadBalancer.Services) != 1 {
+		t.Fatalf("unexpected length of Services: %d", len(loadBalancer.Services))
+	}
+	service := loadBalancer.Services[0]
+	if service.Protocol != LoadBalancerServiceProtocolHTTPS || service.ListenPort != 443 || service.DestinationPort != 80 {
+		t.Errorf("unexpected Service: %v", service)
+	}
+	if service.HTTP.CookieLifetime != 5*time.Minute || !service.HTTP.StickySessions || !service.HTTP.RedirectHTTP {
+		t.Errorf("unexpected Service HTTP: %v", service.HTTP)
+	}
+	if len(service.HTTP.Certificates) != 1 || service.HTTP.Certificates[0].ID != 897 {
+		t.Errorf("unexpected Certificates: %v", service.HTTP.Certificates)
+	}
+	if service.HealthCheck.Interval != 15*time.Second || service.HealthCheck.Timeout != 10*time.Second || service.HealthCheck.Retries != 3 {
+		t.Errorf("unexpected HealthCheck: %v", service.HealthCheck)
+	}
+	if service.HealthCheck.HTTP == nil || len(service.HealthCheck.HTTP.StatusCodes) != 2 || service.HealthCheck.HTTP.Response != `{"status": "ok"}` {
+		t.Errorf("unexpected HealthCheck HTTP: %v", service.HealthCheck.HTTP)
+	}
+
+	if len(loadBalancer.Targets) != 2 {
+		t.Fatalf("unexpected length of Targets: %d", len(loadBalancer.Targets))
+	}
+	target := loadBalancer.Targets[0]
+	if target.Type != LoadBalancerTargetTypeLabelSelector || target.LabelSelector.Selector != "role=web" || !target.UsePrivateIP {
+		t.Errorf("unexpected Target: %v", target)
+	}
+	if len(target.Targets) != 1 || target.Targets[0].Server.Server.ID != 80 {
+		t.Fatalf("unexpected resolved Targets: %v", target.Targets)
+	}
+	if hs := target.Targets[0].HealthStatus; len(hs) != 1 || hs[0].ListenPort != 443 || hs[0].Status != LoadBalancerTargetHealthStatusStatusHealthy {
+		t.Errorf("unexpected HealthStatus: %v", hs)
+	}
+	if ip := loadBalancer.Targets[1].IP; ip == nil || ip.IP != "203.0.113.1" {
+		t.Errorf("unexpected IP target: %v", ip)
+	}
+}
+
+func TestCertificateFromSchema(t *testing.T) {
+	data := []byte(`{
+		"id": 897,
+		"name": "my website cert",
+		"labels": {"key": "value"},
+		"type": "managed",
+		"certificate": null,
+		"created": "2019-01-08T12:10:00+00:00",
+		"not_valid_before": "2019-01-08T10:00:00+00:00",
+		"not_valid_after": "2019-07-08T09:59:59+00:00",
+		"domain_names": ["example.com", "webmail.example.com"],
+		"fingerprint": "03:c7:55:9b:2a:d1:04:17:09:f6:d0:7f:18:34:63:d4:3e:5f",
+		"status": {
+			"issuance": "completed",
+			"renewal": "failed",
+			"error": {"code": "dns_zone_not_found", "message": "DNS zone not found"}
+		},
+		"used_by": [{"id": 4711, "type": "load_balancer"}]
+	}`)
+
+	var s schema.Certificate
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	certificate := CertificateFromSchema(s)
+	if certificate.ID != 897 || certificate.Name != "my website cert" || certificate.Type != CertificateTypeManaged {
+		t.Errorf("unexpected certificate: %v", certificate)
+	}
+	if certificate.Labels["key"] != "value" {
+		t.Errorf("unexpected Labels: %v", certificate.Labels)
+	}
+	if !certificate.NotValidAfter.Equal(time.Date(2019, 7, 8, 9, 59, 59, 0, time.UTC)) {
+		t.Errorf("unexpected NotValidAfter: %v", certificate.NotValidAfter)
+	}
+	if len(certificate.DomainNames) != 2 || certificate.Fingerprint == "" {
+		t.Errorf("unexpected DomainNames or Fingerprint: %v %s", certificate.DomainNames, certificate.Fingerprint)
+	}
+	if certificate.Status == nil || certificate.Status.Issuance != CertificateStatusTypeCompleted || !certificate.Status.IsFailed() {
+		t.Fatalf("unexpected Status: %v", certificate.Status)
+	}
+	if certificate.Status.Error == nil || certificate.Status.Error.Code != "dns_zone_not_found" {
+		t.Errorf("unexpected Status Error: %v", certificate.Status.Error)
+	}
+	if len(certificate.UsedBy) != 1 || certificate.UsedBy[0].ID != 4711 || certificate.UsedBy[0].Type != CertificateUsedByRefTypeLoadBalancer {
+		t.Errorf("unexpected UsedBy: %v", certificate.UsedBy)
+	}
+}
+
+func TestPlacementGroupFromSchema(t *testing.T) {
+	data := []byte(`{
+		"id": 897,
+		"name": "my placement group",
+		"labels": {"key": "value"},
+		"created": "2019-01-08T12:10:00+00:00",
+		"servers": [4711, 4712],
+		"type": "spread"
+	}`)
+
+	var s schema.PlacementGroup
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	placementGroup := PlacementGroupFromSchema(s)
+	if placementGroup.ID != 897 || placementGroup.Name != "my placement group" {
+		t.Errorf("unexpected placement group: %v", placementGroup)
+	}
+	if placementGroup.Labels["key"] != "value" {
+		t.Errorf("unexpected Labels: %v", placementGroup.Labels)
+	}
+	if !placementGroup.Created.Equal(time.Date(2019, 1, 8, 12, 10, 0, 0, time.UTC)) {
+		t.Errorf("unexpected Created: %v", placementGroup.Created)
+	}
+	if len(placementGroup.Servers) != 2 || placementGroup.Servers[0] != 4711 {
+		t.Errorf("unexpected Servers: %v", placementGroup.Servers)
+	}
+	if placementGroup.Type != PlacementGroupTypeSpread {
+		t.Errorf("unexpected Type: %v", placementGroup.Type)
+	}
+}
+
+func TestPrimaryIPFromSchema(t *testing.T) {
+	data := []byte(`{
+		"id": 42,
+		"name": "my-ip",
+		"ip": "2001:db8::/64",
+		"type": "ipv6",
+		"assignee_id": 17,
+		"assignee_type": "server",
+		"auto_delete": true,
+		"blocked": false,
+		"created": "2016-01-30T23:50:00+00:00",
+		"datacenter": {"id": 4, "name": "fsn1-dc14"},
+		"dns_ptr": [{"ip": "2001:db8::1", "dns_ptr": "server.example.com"}],
+		"labels": {"key": "value"},
+		"protection": {"delete": true}
+	}`)
+
+	var s schema.PrimaryIP
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	primaryIP := PrimaryIPFromSchema(s)
+	if primaryIP.ID != 42 || primaryIP.Name != "my-ip" || primaryIP.Type != PrimaryIPTypeIPv6 {
+		t.Errorf("unexpected Primary IP: %v", primaryIP)
+	}
+	if primaryIP.Network == nil || primaryIP.Network.String() != "2001:db8::/64" {
+		t.Errorf("unexpected Network: %v", primaryIP.Network)
+	}
+	if primaryIP.AssigneeID != 17 || primaryIP.AssigneeType != PrimaryIPAssigneeTypeServer || !primaryIP.IsAssigned() {
+		t.Errorf("unexpected assignee: %d %s", primaryIP.AssigneeID, primaryIP.AssigneeType)
+	}
+	if !primaryIP.AutoDelete || !primaryIP.Protection.Delete {
+		t.Errorf("unexpected AutoDelete or Protection: %v %v", primaryIP.AutoDelete, primaryIP.Protection)
+	}
+	if primaryIP.Datacenter == nil || primaryIP.Datacenter.ID != 4 {
+		t.Errorf("unexpected Datacenter: %v", primaryIP.Datacenter)
+	}
+	if primaryIP.DNSPtrForIP(net.ParseIP("2001:db8::1")) != "server.example.com" {
+		t.Errorf("unexpected DNSPtr: %v", primaryIP.DNSPtr)
+	}
+	if primaryIP.Labels["key"] != "value" {
+		t.Errorf("unexpected Labels: %v", primaryIP.Labels)
+	}
+}
+
 func TestPricingFromSchema(t *testing.T) {
 	data := []byte(`{
 		"currency": "EUR",
@@ -1289,3 +1704,512 @@ func TestPricingFromSchema(t *testing.T) {
 		}
 	}
 }
+
+func TestSchemaRoundTrip(t *testing.T) {
+	created := time.Date(2016, 1, 30, 23, 55, 0, 0, time.UTC)
+	strPtr := func(s string) *string { return &s }
+
+	location := &Location{
+		ID:          1,
+		Name:        "fsn1",
+		Description: "Falkenstein DC Park 1",
+		Country:     "DE",
+		City:        "Falkenstein",
+		Latitude:    50.47612,
+		Longitude:   12.370071,
+		NetworkZone: NetworkZoneEUCentral,
+	}
+	datacenter := &Datacenter{
+		ID:          1,
+		Name:        "fsn1-dc8",
+		Description: "Falkenstein 1 DC 8",
+		Location:    location,
+		ServerTypes: DatacenterServerTypes{
+			Supported: []*ServerType{{ID: 1}, {ID: 2}},
+			Available: []*ServerType{{ID: 1}},
+		},
+	}
+	serverType := &ServerType{
+		ID:          1,
+		Name:        "cx10",
+		Description: "CX10",
+		Cores:       1,
+		Memory:      1,
+		Disk:        20,
+		StorageType: StorageTypeLocal,
+		CPUType:     CPUTypeShared,
+		Pricings: []ServerTypeLocationPricing{
+			{
+				Location: &Location{Name: "fsn1"},
+				Hourly:   Price{Net: "1", Gross: "1.19"},
+				Monthly:  Price{Net: "1", Gross: "1.19"},
+			},
+		},
+	}
+	image := &Image{
+		ID:          4711,
+		Name:        "ubuntu-20.04",
+		Type:        ImageTypeSnapshot,
+		Status:      ImageStatusAvailable,
+		Description: "Ubuntu 20.04",
+		ImageSize:   2.3,
+		DiskSize:    10,
+		Created:     created,
+		CreatedFrom: &Server{ID: 1, Name: "server"},
+		BoundTo:     &Server{ID: 1},
+		RapidDeploy: true,
+		OSFlavor:    "ubuntu",
+		OSVersion:   "20.04",
+		Protection:  ImageProtection{Delete: true},
+		Deprecated:  created,
+		Labels:      map[string]string{"key": "value"},
+	}
+	placementGroup := &PlacementGroup{
+		ID:      1,
+		Name:    "pg",
+		Labels:  map[string]string{"key": "value"},
+		Created: created,
+		Servers: []int{1, 2},
+		Type:    PlacementGroupTypeSpread,
+	}
+	ipv6IP, ipv6Network, _ := net.ParseCIDR("2001:db8::/64")
+	pricing := Price{Currency: "EUR", VATRate: "19.00", Net: "1", Gross: "1.19"}
+
+	type roundTripCase struct {
+		value     interface{}
+		roundTrip func(*testing.T) interface{}
+	}
+	testCases := map[string]roundTripCase{}
+	add := func(name string, value interface{}, roundTrip func(*testing.T) interface{}) {
+		testCases[name] = roundTripCase{value, roundTrip}
+	}
+
+	action := &Action{
+		ID:           1,
+		Status:       ActionStatusError,
+		Command:      "create_server",
+		Progress:     100,
+		Started:      created,
+		Finished:     created.Add(time.Minute),
+		ErrorCode:    "action_failed",
+		ErrorMessage: "Action failed",
+		Resources:    []*ActionResource{{ID: 42, Type: ActionResourceTypeServer}},
+	}
+	add("Action", action, func(*testing.T) interface{} { return ActionFromSchema(SchemaFromAction(action)) })
+	add("Actions", []*Action{action}, func(*testing.T) interface{} { return ActionsFromSchema(SchemaFromActions([]*Action{action})) })
+
+	floatingIPv4 := &FloatingIP{
+		ID:           1,
+		Description:  "Web Frontend",
+		Created:      created,
+		IP:           net.ParseIP("131.232.99.1"),
+		Type:         FloatingIPTypeIPv4,
+		Server:       &Server{ID: 42},
+		DNSPtr:       map[string]string{"131.232.99.1": "fip01.example.com"},
+		HomeLocation: location,
+		Blocked:      true,
+		Protection:   FloatingIPProtection{Delete: true},
+		Labels:       map[string]string{"key": "value"},
+		Name:         "Web Frontend",
+	}
+	add("FloatingIP IPv4", floatingIPv4, func(*testing.T) interface{} { return FloatingIPFromSchema(SchemaFromFloatingIP(floatingIPv4)) })
+	floatingIPv6 := &FloatingIP{
+		ID:           2,
+		Created:      created,
+		IP:           ipv6IP,
+		Network:      ipv6Network,
+		Type:         FloatingIPTypeIPv6,
+		DNSPtr:       map[string]string{"2001:db8::1": "a.example.com", "2001:db8::2": "b.example.com"},
+		HomeLocation: location,
+		Labels:       map[string]string{},
+	}
+	add("FloatingIP IPv6", floatingIPv6, func(*testing.T) interface{} { return FloatingIPFromSchema(SchemaFromFloatingIP(floatingIPv6)) })
+
+	primaryIP := &PrimaryIP{
+		ID:           1,
+		Name:         "primary-ip",
+		IP:           ipv6IP,
+		Network:      ipv6Network,
+		Type:         PrimaryIPTypeIPv6,
+		AssigneeID:   17,
+		AssigneeType: PrimaryIPAssigneeTypeServer,
+		AutoDelete:   true,
+		Blocked:      true,
+		Created:      created,
+		Datacenter:   datacenter,
+		DNSPtr:       map[string]string{"2001:db8::1": "server.example.com"},
+		Labels:       map[string]string{"key": "value"},
+		Protection:   PrimaryIPProtection{Delete: true},
+	}
+	add("PrimaryIP", primaryIP, func(*testing.T) interface{} { return PrimaryIPFromSchema(SchemaFromPrimaryIP(primaryIP)) })
+
+	iso := &ISO{ID: 1, Name: "FreeBSD-11.0-RELEASE-amd64-dvd1", Description: "FreeBSD 11.0 x64", Type: ISOTypePublic, Deprecated: created}
+	add("ISO", iso, func(*testing.T) interface{} { return ISOFromSchema(SchemaFromISO(iso)) })
+	add("Location", location, func(*testing.T) interface{} { return LocationFromSchema(SchemaFromLocation(location)) })
+	add("Datacenter", datacenter, func(*testing.T) interface{} { return DatacenterFromSchema(SchemaFromDatacenter(datacenter)) })
+
+	server := &Server{
+		ID:      1,
+		Name:    "server.example.com",
+		Status:  ServerStatusRunning,
+		Created: created,
+		PublicNet: ServerPublicNet{
+			IPv4: ServerPublicNetIPv4{
+				ID:      1,
+				IP:      net.ParseIP("1.2.3.4"),
+				Blocked: true,
+				DNSPtr:  "server01.example.com",
+			},
+			IPv6: ServerPublicNetIPv6{
+				ID:      2,
+				IP:      ipv6IP,
+				Network: ipv6Network,
+				DNSPtr:  map[string]string{"2001:db8::1": "server.example.com"},
+			},
+			FloatingIPs: []*FloatingIP{{ID: 4}},
+		},
+		PrivateNet: []ServerPrivateNet{
+			{
+				Network:    &Network{ID: 4711},
+				IP:         net.ParseIP("10.0.1.1"),
+				Aliases:    []net.IP{net.ParseIP("10.0.1.2")},
+				MACAddress: "86:00:ff:2a:7d:e1",
+			},
+		},
+		ServerType:      serverType,
+		Datacenter:      datacenter,
+		IncludedTraffic: 654321,
+		OutgoingTraffic: 123456,
+		IngoingTraffic:  7891011,
+		BackupWindow:    "22-02",
+		RescueEnabled:   true,
+		Locked:          true,
+		ISO:             iso,
+		Image:           image,
+		Protection:      ServerProtection{Delete: true, Rebuild: true},
+		Labels:          map[string]string{"key": "value"},
+		Volumes:         []*Volume{{ID: 123}},
+		PlacementGroup:  placementGroup,
+	}
+	add("Server", server, func(*testing.T) interface{} { return ServerFromSchema(SchemaFromServer(server)) })
+	add("ServerType", serverType, func(*testing.T) interface{} { return ServerTypeFromSchema(SchemaFromServerType(serverType)) })
+
+	sshKey := &SSHKey{
+		ID:          2323,
+		Name:        "My key",
+		Fingerprint: "b7:2f:30:a0:2f:6c:58:6c:21:04:58:61:ba:06:3b:2c",
+		PublicKey:   "ssh-rsa AAAjjk76kgf...Xt",
+		Labels:      map[string]string{"key": "value"},
+		Created:     created,
+	}
+	add("SSHKey", sshKey, func(*testing.T) interface{} { return SSHKeyFromSchema(SchemaFromSSHKey(sshKey)) })
+	add("Image", image, func(*testing.T) interface{} { return ImageFromSchema(SchemaFromImage(image)) })
+
+	volume := &Volume{
+		ID:          1,
+		Name:        "db-storage",
+		Status:      VolumeStatusAvailable,
+		Server:      &Server{ID: 2},
+		Location:    location,
+		Size:        42,
+		Protection:  VolumeProtection{Delete: true},
+		Labels:      map[string]string{"key": "value"},
+		LinuxDevice: "/dev/disk/by-id/scsi-0HC_Volume_1",
+		Created:     created,
+	}
+	add("Volume", volume, func(*testing.T) interface{} { return VolumeFromSchema(SchemaFromVolume(volume)) })
+
+	network := &Network{
+		ID:      1,
+		Name:    "network",
+		Created: created,
+		IPRange: mustParseCIDR(t, "10.0.0.0/16"),
+		Subnets: []NetworkSubnet{
+			{
+				Type:        NetworkSubnetTypeCloud,
+				IPRange:     mustParseCIDR(t, "10.0.1.0/24"),
+				NetworkZone: NetworkZoneEUCentral,
+				Gateway:     net.ParseIP("10.0.0.1"),
+				VSwitchID:   3,
+			},
+		},
+		Routes: []NetworkRoute{
+			{
+				Destination: mustParseCIDR(t, "10.100.1.0/24"),
+				Gateway:     net.ParseIP("10.0.1.1"),
+			},
+		},
+		Servers:               []*Server{{ID: 4711}},
+		Protection:            NetworkProtection{Delete: true},
+		Labels:                map[string]string{"key": "value"},
+		ExposeRoutesToVSwitch: true,
+	}
+	add("Network", network, func(*testing.T) interface{} { return NetworkFromSchema(SchemaFromNetwork(network)) })
+
+	firewall := &Firewall{
+		ID:      897,
+		Name:    "my firewall",
+		Labels:  map[string]string{"key": "value"},
+		Created: created,
+		Rules: []FirewallRule{
+			{
+				Direction:   FirewallRuleDirectionIn,
+				SourceIPs:   []net.IPNet{*mustParseCIDR(t, "10.0.0.5/32"), *mustParseCIDR(t, "2001:db8::/64")},
+				Protocol:    FirewallRuleProtocolTCP,
+				Port:        strPtr("80-85"),
+				Description: strPtr("allow http"),
+			},
+		},
+		AppliedTo: []FirewallResource{
+			{Type: FirewallResourceTypeServer, Server: &FirewallResourceServer{ID: 42}},
+			{Type: FirewallResourceTypeLabelSelector, LabelSelector: &FirewallResourceLabelSelector{Selector: "a=b"}},
+		},
+	}
+	add("Firewall", firewall, func(*testing.T) interface{} { return FirewallFromSchema(SchemaFromFirewall(firewall)) })
+
+	loadBalancerType := &LoadBalancerType{
+		ID:                      1,
+		Name:                    "lb11",
+		Description:             "LB11",
+		MaxConnections:          20000,
+		MaxServices:             5,
+		MaxTargets:              25,
+		MaxAssignedCertificates: 10,
+		Pricings: []LoadBalancerTypeLocationPricing{
+			{
+				Location: &Location{Name: "fsn1"},
+				Hourly:   Price{Net: "1", Gross: "1.19"},
+				Monthly:  Price{Net: "1", Gross: "1.19"},
+			},
+		},
+	}
+	add("LoadBalancerType", loadBalancerType, func(*testing.T) interface{} {
+		return LoadBalancerTypeFromSchema(SchemaFromLoadBalancerType(loadBalancerType))
+	})
+
+	loadBalancer := &LoadBalancer{
+		ID:   4711,
+		Name: "Web Frontend",
+		PublicNet: LoadBalancerPublicNet{
+			Enabled: true,
+			IPv4:    LoadBalancerPublicNetIPv4{IP: net.ParseIP("131.232.99.1"), DNSPtr: "lb1.example.com"},
+			IPv6:    LoadBalancerPublicNetIPv6{IP: net.ParseIP("2001:db8::1"), DNSPtr: "lb1.example.com"},
+		},
+		PrivateNet:       []LoadBalancerPrivateNet{{Network: &Network{ID: 4711}, IP: net.ParseIP("10.0.255.1")}},
+		Location:         location,
+		LoadBalancerType: loadBalancerType,
+		Algorithm:        LoadBalancerAlgorithm{Type: LoadBalancerAlgorithmTypeRoundRobin},
+		Services: []LoadBalancerService{
+			{
+				Protocol:        LoadBalancerServiceProtocolHTTPS,
+				ListenPort:      443,
+				DestinationPort: 80,
+				HTTP: LoadBalancerServiceHTTP{
+					CookieName:     "HCLBSTICKY",
+					CookieLifetime: 5 * time.Minute,
+					Certificates:   []*Certificate{{ID: 897}},
+					RedirectHTTP:   true,
+					StickySessions: true,
+				},
+				HealthCheck: LoadBalancerServiceHealthCheck{
+					Protocol: "http",
+					Port:     4711,
+					Interval: 15 * time.Second,
+					Timeout:  10 * time.Second,
+					Retries:  3,
+					HTTP: &LoadBalancerServiceHealthCheckHTTP{
+						Domain:      "example.com",
+						Path:        "/",
+						Response:    "OK",
+						StatusCodes: []string{"2??"},
+						TLS:         true,
+					},
+				},
+			},
+			{
+				Protocol:        LoadBalancerServiceProtocolTCP,
+				ListenPort:      22,
+				DestinationPort: 22,
+				Proxyprotocol:   true,
+				HealthCheck: LoadBalancerServiceHealthCheck{
+					Protocol: LoadBalancerServiceProtocolTCP,
+					Port:     22,
+					Interval: 15 * time.Second,
+					Timeout:  10 * time.Second,
+					Retries:  3,
+				},
+			},
+		},
+		Targets: []LoadBalancerTarget{
+			{
+				Type:         LoadBalancerTargetTypeServer,
+				Server:       &LoadBalancerTargetServer{Server: &Server{ID: 80}},
+				HealthStatus: []LoadBalancerTargetHealthStatus{{ListenPort: 443, Status: LoadBalancerTargetHealthStatusStatusHealthy}},
+				UsePrivateIP: true,
+			},
+			{
+				Type:          LoadBalancerTargetTypeLabelSelector,
+				LabelSelector: &LoadBalancerTargetLabelSelector{Selector: "role=web"},
+				Targets: []LoadBalancerTarget{
+					{Type: LoadBalancerTargetTypeServer, Server: &LoadBalancerTargetServer{Server: &Server{ID: 81}}},
+				},
+			},
+			{
+				Type: "ip",
+				IP:   &LoadBalancerTargetIP{IP: "1.2.3.4"},
+			},
+		},
+		Protection:      LoadBalancerProtection{Delete: true},
+		Labels:          map[string]string{"key": "value"},
+		Created:         created,
+		IncludedTraffic: 10000,
+		OutgoingTraffic: 100,
+		IngoingTraffic:  10,
+	}
+	add("LoadBalancer", loadBalancer, func(*testing.T) interface{} { return LoadBalancerFromSchema(SchemaFromLoadBalancer(loadBalancer)) })
+
+	certErr := Error{Code: "error", Message: "Certificate could not be issued"}
+	certificate := &Certificate{
+		ID:             897,
+		Name:           "my website cert",
+		Labels:         map[string]string{"key": "value"},
+		Type:           CertificateTypeManaged,
+		Certificate:    "-----BEGIN CERTIFICATE-----\n...",
+		Created:        created,
+		NotValidBefore: created,
+		NotValidAfter:  created.AddDate(1, 0, 0),
+		DomainNames:    []string{"example.com", "webmail.example.com"},
+		Fingerprint:    "03:c7:55:9b:2a:d1:04:17:09:f6:d0:7f:18:34:63:d4:3e:5f",
+		Status: &CertificateStatus{
+			Issuance: CertificateStatusTypeFailed,
+			Renewal:  CertificateStatusTypePending,
+			Error:    &certErr,
+		},
+		UsedBy: []CertificateUsedByRef{{ID: 4711, Type: CertificateUsedByRefTypeLoadBalancer}},
+	}
+	add("Certificate", certificate, func(*testing.T) interface{} { return CertificateFromSchema(SchemaFromCertificate(certificate)) })
+
+	pagination := Pagination{Page: 2, PerPage: 25, PreviousPage: 1, NextPage: 3, LastPage: 4, TotalEntries: 100}
+	add("Pagination", pagination, func(*testing.T) interface{} { return PaginationFromSchema(SchemaFromPagination(pagination)) })
+
+	invalidInput := Error{
+		Code:    ErrorCodeInvalidInput,
+		Message: "invalid input",
+		Details: ErrorDetailsInvalidInput{
+			Fields: []ErrorDetailsInvalidInputField{{Name: "broken_field", Messages: []string{"is required"}}},
+		},
+	}
+	add("Error", invalidInput, func(*testing.T) interface{} { return ErrorFromSchema(SchemaFromError(invalidInput)) })
+	for _, details := range []interface{}{
+		ErrorDetailsUniquenessError{Fields: []ErrorDetailsUniquenessErrorField{{Name: "name"}}},
+		ErrorDetailsResourceLimitExceeded{Limits: []ErrorDetailsResourceLimitExceededLimit{{Name: "project_limit"}}},
+		ErrorDetailsServerAlreadyAttached{Server: &Server{ID: 42}},
+		ErrorDetailsLocked{Action: &Action{ID: 13}},
+	} {
+		e := Error{Code: ErrorCode("code"), Message: "message", Details: details}
+		add(fmt.Sprintf("Error with %T", details), e, func(*testing.T) interface{} { return ErrorFromSchema(SchemaFromError(e)) })
+	}
+	add("Error without details", certErr, func(*testing.T) interface{} { return ErrorFromSchema(SchemaFromError(certErr)) })
+
+	prices := Pricing{
+		Image:        ImagePricing{PerGBMonth: pricing},
+		FloatingIP:   FloatingIPPricing{Monthly: pricing},
+		Traffic:      TrafficPricing{PerTB: pricing},
+		ServerBackup: ServerBackupPricing{Percentage: "20"},
+		ServerTypes: []ServerTypePricing{
+			{
+				ServerType: &ServerType{ID: 1, Name: "cx11"},
+				Pricings: []ServerTypeLocationPricing{
+					{Location: &Location{Name: "fsn1"}, Hourly: pricing, Monthly: pricing},
+				},
+			},
+		},
+	}
+	add("Pricing", prices, func(*testing.T) interface{} { return PricingFromSchema(SchemaFromPricing(prices)) })
+
+	record := &Record{
+		ID:       "1",
+		Name:     "www",
+		Created:  "2020-08-27 20:08:09.589 +0000 UTC",
+		Modified: "2020-08-27 20:08:09.589 +0000 UTC",
+		TTL:      3600,
+		Type:     A,
+		Value:    "1.2.3.4",
+		ZoneID:   "2",
+	}
+	add("Record", record, func(*testing.T) interface{} { return RecordFromSchema(SchemaFromRecord(record)) })
+
+	zone := &Zone{
+		ID:              "2",
+		Name:            "example.com",
+		Ns:              []string{"hydrogen.ns.hetzner.com"},
+		LegacyDNSHost:   "legacy",
+		LegacyNs:        []string{"ns1.example.com"},
+		Created:         "2020-08-27 20:08:09.589 +0000 UTC",
+		Modified:        "2020-08-27 20:08:09.589 +0000 UTC",
+		Verified:        "2020-08-27 20:08:09.589 +0000 UTC",
+		Owner:           "owner",
+		Paused:          true,
+		Permission:      "permission",
+		Project:         "project",
+		Registrar:       "registrar",
+		Status:          "verified",
+		TTL:             86400,
+		IsSecondaryDNS:  true,
+		TxtVerification: TxtVerification{Name: "name", Token: "token"},
+		ZoneType: ZoneType{
+			ID:          "3",
+			Name:        "standard",
+			Description: "Standard zone",
+			Prices:      []Price{{Net: "0", Gross: "0"}},
+		},
+		RecordsCount: 4,
+	}
+	add("Zone", zone, func(*testing.T) interface{} { return ZoneFromSchema(SchemaFromZone(zone)) })
+	add("PlacementGroup", placementGroup, func(*testing.T) interface{} {
+		return PlacementGroupFromSchema(SchemaFromPlacementGroup(placementGroup))
+	})
+
+	metrics := &ServerMetrics{
+		Start: created,
+		End:   created.Add(2 * time.Minute),
+		Step:  time.Minute,
+		TimeSeries: map[string]ServerMetricsSeries{
+			"cpu": {
+				{Timestamp: created, Value: 0.5},
+				{Timestamp: created.Add(time.Minute), Value: 12.25},
+			},
+		},
+	}
+	add("ServerMetrics", metrics, func(t *testing.T) interface{} {
+		m, err := ServerMetricsFromSchema(SchemaFromServerMetrics(metrics))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	})
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.roundTrip(t); !reflect.DeepEqual(got, tt.value) {
+				t.Errorf("round trip changed value:\ngot  %+v\nwant %+v", got, tt.value)
+			}
+		})
+	}
+}
+
+// unmarshalFixture decodes the JSON fixture data into v and fails the test
+// if the fixture contains fields v does not model.
+func unmarshalFixture(t *testing.T, data []byte, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := schema.UnknownFields(data, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range fields {
+		t.Errorf("fixture contains unknown field %s", field)
+	}
+}
@@ -35,6 +35,7 @@ type Server struct {
 	Protection      ServerProtection
 	Labels          map[string]string
 	Volumes         []*Volume
+	PlacementGroup  *PlacementGroup
 }
 
 // ServerProtection represents the protection level of a server.
@@ -83,6 +84,7 @@ type ServerPublicNet struct {
 
 // ServerPublicNetIPv4 represents a server's public IPv4 address.
 type ServerPublicNetIPv4 struct {
+	ID      int
 	IP      net.IP
 	Blocked bool
 	DNSPtr  string
@@ -90,6 +92,7 @@ type ServerPublicNetIPv4 struct {
 
 // ServerPublicNetIPv6 represents a server's public IPv6 network and address.
 type ServerPublicNetIPv6 struct {
+	ID      int
 	IP      net.IP
 	Network *net.IPNet
 	Blocked bool
@@ -237,6 +240,40 @@ type ServerCreateOpts struct {
 	Automount        *bool
 	Volumes          []*Volume
 	Networks         []*Network
+	PlacementGroup   *PlacementGroup
+	PublicNet        *ServerCreatePublicNet
+}
+
+// ServerCreatePublicNet specifies the public network configuration of a
+// server to create. IPv4 and IPv6 select existing Primary IPs to assign;
+// if they are nil and the protocol is enabled, a new Primary IP is created
+// for the server. A nil ServerCreatePublicNet enables both protocols.
+type ServerCreatePublicNet struct {
+	EnableIPv4 bool
+	EnableIPv6 bool
+	IPv4       *PrimaryIP
+	IPv6       *PrimaryIP
+}
+
+// Validate checks if options are valid.
+func (o ServerCreatePublicNet) Validate() error {
+	if o.IPv4 != nil {
+		if !o.EnableIPv4 {
+			return errors.New("IPv4 Primary IP given but IPv4 is disabled")
+		}
+		if o.IPv4.Type != "" && o.IPv4.Type != PrimaryIPTypeIPv4 {
+			return errors.New("IPv4 Primary IP has invalid type")
+		}
+	}
+	if o.IPv6 != nil {
+		if !o.EnableIPv6 {
+			return errors.New("IPv6 Primary IP given but IPv6 is disabled")
+		}
+		if o.IPv6.Type != "" && o.IPv6.Type != PrimaryIPTypeIPv6 {
+			return errors.New("IPv6 Primary IP has invalid type")
+		}
+	}
+	return nil
 }
 
 // Validate checks if options are valid.
@@ -253,6 +290,17 @@ func (o ServerCreateOpts) Validate() error {
 	if o.Location != nil && o.Datacenter != nil {
 		return errors.New("location and datacenter are mutually exclusive")
 	}
+	if o.PlacementGroup != nil && o.PlacementGroup.ID == 0 && o.PlacementGroup.Name == "" {
+		return errors.New("missing placement group ID or name")
+	}
+	if o.PublicNet != nil {
+		if err := o.PublicNet.Validate(); err != nil {
+			return err
+		}
+		if !o.PublicNet.EnableIPv4 && !o.PublicNet.EnableIPv6 && len(o.Networks) == 0 {
+			return errors.New("server without public network must be attached to a network")
+		}
+	}
 	return nil
 }
 
@@ -297,6 +345,25 @@ func (c *ServerClient) Create(ctx context.Context, opts ServerCreateOpts) (Serve
 	for _, network := range opts.Networks {
 		reqBody.Networks = append(reqBody.Networks, network.ID)
 	}
+	if opts.PlacementGroup != nil {
+		placementGroup, err := c.client.PlacementGroup.reserve(ctx, opts.PlacementGroup, nil)
+		if err != nil {
+			return ServerCreateResult{}, nil, err
+		}
+		reqBody.PlacementGroup = placementGroup.ID
+	}
+	if opts.PublicNet != nil {
+		reqBody.PublicNet = &schema.ServerCreatePublicNet{
+			EnableIPv4: opts.PublicNet.EnableIPv4,
+			EnableIPv6: opts.PublicNet.EnableIPv6,
+		}
+		if opts.PublicNet.IPv4 != nil {
+			reqBody.PublicNet.IPv4ID = opts.PublicNet.IPv4.ID
+		}
+		if opts.PublicNet.IPv6 != nil {
+			reqBody.PublicNet.IPv6ID = opts.PublicNet.IPv6.ID
+		}
+	}
 
 	if opts.Location != nil {
 		if opts.Location.ID != 0 {
@@ -827,8 +894,38 @@ type ServerAttachToNetworkOpts struct {
 	AliasIPs []net.IP
 }
 
+// Validate checks if options are valid.
+func (o ServerAttachToNetworkOpts) Validate() error {
+	if o.Network == nil {
+		return errors.New("missing network")
+	}
+	return nil
+}
+
+// validateNetworkZone checks that network has a subnet in the network zone of
+// server's location, if both are known.
+func validateNetworkZone(server *Server, network *Network) error {
+	if server == nil || server.Datacenter == nil || server.Datacenter.Location == nil {
+		return nil
+	}
+	zone := server.Datacenter.Location.NetworkZone
+	if zone == "" || len(network.Subnets) == 0 {
+		return nil
+	}
+	if !network.hasNetworkZone(zone) {
+		return fmt.Errorf("network has no subnet in network zone %s of location %s", zone, server.Datacenter.Location.Name)
+	}
+	return nil
+}
+
 // AttachToNetwork attaches a server to a network.
 func (c *ServerClient) AttachToNetwork(ctx context.Context, server *Server, opts ServerAttachToNetworkOpts) (*Action, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	if err := validateNetworkZone(server, opts.Network); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
 	reqBody := schema.ServerActionAttachToNetworkRequest{
 		Network: opts.Network.ID,
 	}
@@ -918,3 +1015,68 @@ func (c *ServerClient) ChangeAliasIPs(ctx context.Context, server *Server, opts
 	}
 	return ActionFromSchema(respBody.Action), resp, err
 }
+
+// AddToPlacementGroup adds a server to a placement group. The current state of
+// the placement group is checked first; ErrPlacementGroupFull is returned
+// without calling the action if the group has no room left.
+func (c *ServerClient) AddToPlacementGroup(ctx context.Context, server *Server, placementGroup *PlacementGroup) (*Action, *Response, error) {
+	placementGroup, err := c.client.PlacementGroup.reserve(ctx, placementGroup, server)
+	if err != nil {
+		return nil, nil, err
+	}
+	reqBody := schema.ServerActionAddToPlacementGroupRequest{
+		PlacementGroup: placementGroup.ID,
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := fmt.Sprintf("/servers/%d/actions/add_to_placement_group", server.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, bytes.NewReader(reqBodyData))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.ServerActionAddToPlacementGroupResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// RemoveFromPlacementGroup removes a server from its placement group.
+func (c *ServerClient) RemoveFromPlacementGroup(ctx context.Context, server *Server) (*Action, *Response, error) {
+	path := fmt.Sprintf("/servers/%d/actions/remove_from_placement_group", server.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	respBody := schema.ServerActionRemoveFromPlacementGroupResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ActionFromSchema(respBody.Action), resp, nil
+}
+
+// waitForStatus polls the server until it has the given status.
+func (c *ServerClient) waitForStatus(ctx context.Context, server *Server, status ServerStatus) error {
+	for {
+		current, _, err := c.GetByID(ctx, server.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return fmt.Errorf("hcloud: server %d not found", server.ID)
+		}
+		if current.Status == status {
+			return nil
+		}
+		if err := sleep(ctx, c.client.pollInterval); err != nil {
+			return err
+		}
+	}
+}
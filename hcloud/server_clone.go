@@ -0,0 +1,253 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// cloneCleanupTimeout bounds the time spent deleting the temporary snapshot and
+// powering the source server on again. Cleanup does not use the caller's
+// context, as cloning most often fails because it has expired or been cancelled.
+const cloneCleanupTimeout = 5 * time.Minute
+
+// ServerCloneSnapshotPolicy specifies what happens to the temporary snapshot
+// created while cloning a server.
+type ServerCloneSnapshotPolicy string
+
+// List of snapshot policies.
+const (
+	// ServerCloneSnapshotDelete deletes the snapshot once all clones have been
+	// created, regardless of whether creating them succeeded.
+	ServerCloneSnapshotDelete ServerCloneSnapshotPolicy = "delete"
+
+	// ServerCloneSnapshotDeleteOnSuccess deletes the snapshot only if all clones
+	// have been created successfully, so a failed clone can be retried.
+	ServerCloneSnapshotDeleteOnSuccess ServerCloneSnapshotPolicy = "delete_on_success"
+
+	// ServerCloneSnapshotKeep keeps the snapshot.
+	ServerCloneSnapshotKeep ServerCloneSnapshotPolicy = "keep"
+)
+
+// ServerCloneOpts specifies options for cloning a server.
+type ServerCloneOpts struct {
+	Count int      // number of clones to create
+	Names []string // names of the clones, defaults to "<source>-clone-<n>"
+
+	// ShutdownSource shuts down the source server before creating the snapshot
+	// and starts it again afterwards, so the snapshot is consistent.
+	ShutdownSource bool
+
+	// CloneVolumes creates an empty volume of the same size for each volume
+	// attached to the source server and attaches it to the clone.
+	CloneVolumes bool
+
+	ServerType     *ServerType       // defaults to the source server's type
+	Location       *Location         // defaults to the source server's location
+	Labels         map[string]string // defaults to the source server's labels
+	SSHKeys        []*SSHKey         // SSH keys cannot be read from a server, so they must be passed
+	UserData       string
+	SnapshotPolicy ServerCloneSnapshotPolicy // defaults to ServerCloneSnapshotDelete
+}
+
+// Validate checks if options are valid.
+func (o ServerCloneOpts) Validate() error {
+	if o.Count <= 0 {
+		return errors.New("count must be greater than 0")
+	}
+	if o.Names != nil && len(o.Names) != o.Count {
+		return errors.New("number of names does not match count")
+	}
+	switch o.SnapshotPolicy {
+	case "", ServerCloneSnapshotDelete, ServerCloneSnapshotDeleteOnSuccess, ServerCloneSnapshotKeep:
+		break
+	default:
+		return errors.New("invalid snapshot policy")
+	}
+	return nil
+}
+
+// ServerCloneResult is the result of cloning a server.
+type ServerCloneResult struct {
+	Snapshot *Image               // nil if the snapshot has been deleted
+	Servers  []ServerCreateResult // the clones which have been created
+	Volumes  []*Volume            // the volumes created for the clones
+}
+
+// Clone creates copies of a server. It retrieves the current state of the source
+// server, creates a snapshot of it, waits until the snapshot is available and
+// creates the clones from it. The clones use the source server's type, location,
+// labels, and networks unless overridden in opts. Clone waits until all clones
+// have been created.
+//
+// If creating a clone fails, the clones created so far are returned along with
+// the error. The snapshot is handled according to opts.SnapshotPolicy, also if
+// it has been created but did not become available.
+func (c *ServerClient) Clone(ctx context.Context, source *Server, opts ServerCloneOpts) (ServerCloneResult, error) {
+	if err := opts.Validate(); err != nil {
+		return ServerCloneResult{}, fmt.Errorf("invalid options: %s", err)
+	}
+
+	source, _, err := c.GetByID(ctx, source.ID)
+	if err != nil {
+		return ServerCloneResult{}, err
+	}
+	if source == nil {
+		return ServerCloneResult{}, errors.New("hcloud: source server not found")
+	}
+
+	snapshot, err := c.cloneSnapshot(ctx, source, opts.ShutdownSource)
+	if snapshot == nil {
+		return ServerCloneResult{}, err
+	}
+	result := ServerCloneResult{Snapshot: snapshot}
+
+	if err == nil {
+		err = c.createClones(ctx, source, snapshot, opts, &result)
+	}
+
+	policy := opts.SnapshotPolicy
+	if policy == "" {
+		policy = ServerCloneSnapshotDelete
+	}
+	if policy == ServerCloneSnapshotDelete || (policy == ServerCloneSnapshotDeleteOnSuccess && err == nil) {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), cloneCleanupTimeout)
+		defer cancel()
+		if _, deleteErr := c.client.Image.Delete(cleanupCtx, snapshot); deleteErr != nil {
+			if err == nil {
+				err = fmt.Errorf("hcloud: deleting snapshot %d: %s", snapshot.ID, deleteErr)
+			}
+		} else {
+			result.Snapshot = nil
+		}
+	}
+	return result, err
+}
+
+// cloneSnapshot creates a snapshot of the source server and waits until it is
+// available, shutting the server down during the snapshot if requested. If the
+// snapshot has been created, it is returned even if an error occurs later on,
+// so the caller can clean it up.
+func (c *ServerClient) cloneSnapshot(ctx context.Context, source *Server, shutdown bool) (*Image, error) {
+	restart := false
+	if shutdown && source.Status != ServerStatusOff {
+		action, _, err := c.Shutdown(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.client.Action.waitFor(ctx, action); err != nil {
+			return nil, err
+		}
+		if err := c.waitForStatus(ctx, source, ServerStatusOff); err != nil {
+			return nil, err
+		}
+		restart = true
+	}
+
+	var snapshot *Image
+	result, _, err := c.CreateImage(ctx, source, &ServerCreateImageOpts{
+		Type:        ImageTypeSnapshot,
+		Description: String(fmt.Sprintf("clone of %s", source.Name)),
+	})
+	if err == nil {
+		snapshot = result.Image
+		err = c.client.Action.waitFor(ctx, result.Action)
+	}
+
+	if restart {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), cloneCleanupTimeout)
+		defer cancel()
+		action, _, poweronErr := c.Poweron(cleanupCtx, source)
+		if poweronErr == nil {
+			poweronErr = c.client.Action.waitFor(cleanupCtx, action)
+		}
+		if err == nil && poweronErr != nil {
+			err = poweronErr
+		}
+	}
+	if err != nil {
+		return snapshot, err
+	}
+
+	available, err := c.client.Image.waitForStatus(ctx, snapshot, ImageStatusAvailable)
+	if err != nil {
+		return snapshot, err
+	}
+	return available, nil
+}
+
+func (c *ServerClient) createClones(ctx context.Context, source *Server, snapshot *Image, opts ServerCloneOpts, result *ServerCloneResult) error {
+	createOpts := ServerCreateOpts{
+		ServerType: source.ServerType,
+		Image:      snapshot,
+		SSHKeys:    opts.SSHKeys,
+		UserData:   opts.UserData,
+		Labels:     source.Labels,
+	}
+	if opts.ServerType != nil {
+		createOpts.ServerType = opts.ServerType
+	}
+	if opts.Labels != nil {
+		createOpts.Labels = opts.Labels
+	}
+	if opts.Location != nil {
+		createOpts.Location = opts.Location
+	} else if source.Datacenter != nil && source.Datacenter.Location != nil {
+		createOpts.Location = source.Datacenter.Location
+	}
+	for _, privateNet := range source.PrivateNet {
+		createOpts.Networks = append(createOpts.Networks, privateNet.Network)
+	}
+
+	var sourceVolumes []*Volume
+	if opts.CloneVolumes {
+		for _, v := range source.Volumes {
+			volume, _, err := c.client.Volume.GetByID(ctx, v.ID)
+			if err != nil {
+				return err
+			}
+			if volume == nil {
+				return fmt.Errorf("hcloud: volume %d not found", v.ID)
+			}
+			sourceVolumes = append(sourceVolumes, volume)
+		}
+	}
+
+	for i := 0; i < opts.Count; i++ {
+		name := fmt.Sprintf("%s-clone-%d", source.Name, i+1)
+		if opts.Names != nil {
+			name = opts.Names[i]
+		}
+
+		cloneOpts := createOpts
+		cloneOpts.Name = name
+		cloneOpts.Volumes = nil
+		for _, sourceVolume := range sourceVolumes {
+			volumeResult, _, err := c.client.Volume.Create(ctx, VolumeCreateOpts{
+				Name:     fmt.Sprintf("%s-%s", name, sourceVolume.Name),
+				Size:     sourceVolume.Size,
+				Location: createOpts.Location,
+				Labels:   sourceVolume.Labels,
+			})
+			if err != nil {
+				return err
+			}
+			result.Volumes = append(result.Volumes, volumeResult.Volume)
+			if err := c.client.Action.waitForAll(ctx, append(volumeResult.NextActions, volumeResult.Action)...); err != nil {
+				return err
+			}
+			cloneOpts.Volumes = append(cloneOpts.Volumes, volumeResult.Volume)
+		}
+
+		createResult, _, err := c.Create(ctx, cloneOpts)
+		if err != nil {
+			return err
+		}
+		result.Servers = append(result.Servers, createResult)
+		if err := c.client.Action.waitForAll(ctx, append([]*Action{createResult.Action}, createResult.NextActions...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
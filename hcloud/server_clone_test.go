@@ -0,0 +1,271 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestServerClientClone(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+	env.Client.pollInterval = time.Millisecond
+
+	env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{
+				ID:         1,
+				Name:       "db",
+				Status:     "running",
+				ServerType: schema.ServerType{ID: 2},
+				Datacenter: schema.Datacenter{Location: schema.Location{ID: 3}},
+				Labels:     map[string]string{"role": "db"},
+				Volumes:    []int{4},
+				PrivateNet: []schema.ServerPrivateNet{{Network: 5}},
+			},
+		})
+	})
+	env.Mux.HandleFunc("/servers/1/actions/create_image", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerActionCreateImageResponse{
+			Action: schema.Action{ID: 1, Status: "running"},
+			Image:  schema.Image{ID: 6, Status: "creating"},
+		})
+	})
+	imageCalls := 0
+	imageDeleted := false
+	env.Mux.HandleFunc("/images/6", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			imageDeleted = true
+			return
+		}
+		imageCalls++
+		status := "creating"
+		if imageCalls > 1 {
+			status = "available"
+		}
+		json.NewEncoder(w).Encode(schema.ImageGetResponse{
+			Image: schema.Image{ID: 6, Status: status},
+		})
+	})
+	env.Mux.HandleFunc("/volumes/4", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.VolumeGetResponse{
+			Volume: schema.Volume{ID: 4, Name: "data", Size: 20},
+		})
+	})
+	volumeID := 100
+	env.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.VolumeCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Size != 20 {
+			t.Errorf("unexpected volume size: %d", reqBody.Size)
+		}
+		if reqBody.Location != float64(3) {
+			t.Errorf("unexpected volume location: %v", reqBody.Location)
+		}
+		volumeID++
+		json.NewEncoder(w).Encode(schema.VolumeCreateResponse{
+			Volume: schema.Volume{ID: volumeID, Name: reqBody.Name},
+		})
+	})
+	var names []string
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.ServerCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Image != float64(6) {
+			t.Errorf("unexpected image: %v", reqBody.Image)
+		}
+		if reqBody.ServerType != float64(2) {
+			t.Errorf("unexpected server type: %v", reqBody.ServerType)
+		}
+		if reqBody.Location != "3" {
+			t.Errorf("unexpected location: %v", reqBody.Location)
+		}
+		if reqBody.Labels == nil || (*reqBody.Labels)["role"] != "db" {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		if len(reqBody.Networks) != 1 || reqBody.Networks[0] != 5 {
+			t.Errorf("unexpected networks: %v", reqBody.Networks)
+		}
+		if len(reqBody.SSHKeys) != 1 || reqBody.SSHKeys[0] != 7 {
+			t.Errorf("unexpected SSH keys: %v", reqBody.SSHKeys)
+		}
+		if len(reqBody.Volumes) != 1 || reqBody.Volumes[0] != volumeID {
+			t.Errorf("unexpected volumes: %v", reqBody.Volumes)
+		}
+		names = append(names, reqBody.Name)
+		json.NewEncoder(w).Encode(schema.ServerCreateResponse{
+			Server: schema.Server{ID: 10 + len(names), Name: reqBody.Name},
+			Action: schema.Action{ID: 2, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/actions/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ActionGetResponse{
+			Action: schema.Action{Status: "success"},
+		})
+	})
+
+	ctx := context.Background()
+	result, err := env.Client.Server.Clone(ctx, &Server{ID: 1}, ServerCloneOpts{
+		Count:        2,
+		CloneVolumes: true,
+		SSHKeys:      []*SSHKey{{ID: 7}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Servers) != 2 || result.Servers[0].Server.ID != 11 || result.Servers[1].Server.ID != 12 {
+		t.Errorf("unexpected servers: %v", result.Servers)
+	}
+	if len(names) != 2 || names[0] != "db-clone-1" || names[1] != "db-clone-2" {
+		t.Errorf("unexpected names: %v", names)
+	}
+	if len(result.Volumes) != 2 || result.Volumes[0].Name != "db-clone-1-data" {
+		t.Errorf("unexpected volumes: %v", result.Volumes)
+	}
+	if !imageDeleted || result.Snapshot != nil {
+		t.Error("snapshot has not been deleted")
+	}
+}
+
+func TestServerClientCloneSnapshotFailed(t *testing.T) {
+	testCases := map[string]struct {
+		policy          ServerCloneSnapshotPolicy
+		expectedDeleted bool
+	}{
+		"delete":            {policy: ServerCloneSnapshotDelete, expectedDeleted: true},
+		"delete on success": {policy: ServerCloneSnapshotDeleteOnSuccess},
+		"keep":              {policy: ServerCloneSnapshotKeep},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			env := newTestEnv()
+			defer env.Teardown()
+			env.Client.pollInterval = time.Millisecond
+
+			env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(schema.ServerGetResponse{
+					Server: schema.Server{ID: 1, Name: "db", Status: "running"},
+				})
+			})
+			env.Mux.HandleFunc("/servers/1/actions/create_image", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(schema.ServerActionCreateImageResponse{
+					Action: schema.Action{ID: 1, Status: "running"},
+					Image:  schema.Image{ID: 6, Status: "creating"},
+				})
+			})
+			env.Mux.HandleFunc("/actions/1", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(schema.ActionGetResponse{
+					Action: schema.Action{ID: 1, Status: "error", Error: &schema.ActionError{Code: "action_failed", Message: "failed"}},
+				})
+			})
+			imageDeleted := false
+			env.Mux.HandleFunc("/images/6", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "DELETE" {
+					imageDeleted = true
+				}
+			})
+
+			result, err := env.Client.Server.Clone(context.Background(), &Server{ID: 1}, ServerCloneOpts{
+				Count:          1,
+				SnapshotPolicy: tc.policy,
+			})
+			if _, ok := err.(ActionError); !ok {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if imageDeleted != tc.expectedDeleted {
+				t.Errorf("expected snapshot deleted to be %v", tc.expectedDeleted)
+			}
+			if tc.expectedDeleted && result.Snapshot != nil {
+				t.Errorf("unexpected snapshot: %v", result.Snapshot)
+			}
+			if !tc.expectedDeleted && (result.Snapshot == nil || result.Snapshot.ID != 6) {
+				t.Errorf("expected snapshot to be returned, got %v", result.Snapshot)
+			}
+			if len(result.Servers) != 0 {
+				t.Errorf("unexpected servers: %v", result.Servers)
+			}
+		})
+	}
+}
+
+func TestServerClientCloneCancelled(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+	env.Client.pollInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{ID: 1, Name: "db", Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/servers/1/actions/create_image", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerActionCreateImageResponse{
+			Action: schema.Action{ID: 1, Status: "running"},
+			Image:  schema.Image{ID: 6, Status: "creating"},
+		})
+	})
+	env.Mux.HandleFunc("/actions/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ActionGetResponse{
+			Action: schema.Action{ID: 1, Status: "success"},
+		})
+	})
+	imageDeleted := false
+	env.Mux.HandleFunc("/images/6", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			imageDeleted = true
+			return
+		}
+		// The caller gives up while waiting for the snapshot.
+		cancel()
+		json.NewEncoder(w).Encode(schema.ImageGetResponse{
+			Image: schema.Image{ID: 6, Status: "creating"},
+		})
+	})
+
+	result, err := env.Client.Server.Clone(ctx, &Server{ID: 1}, ServerCloneOpts{Count: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !imageDeleted {
+		t.Error("expected snapshot to be deleted")
+	}
+	if result.Snapshot != nil {
+		t.Errorf("unexpected snapshot: %v", result.Snapshot)
+	}
+}
+
+func TestServerCloneOptsValidate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		opts  ServerCloneOpts
+		valid bool
+	}{
+		{"valid", ServerCloneOpts{Count: 1}, true},
+		{"missing count", ServerCloneOpts{}, false},
+		{"names mismatch", ServerCloneOpts{Count: 2, Names: []string{"a"}}, false},
+		{"invalid policy", ServerCloneOpts{Count: 1, SnapshotPolicy: "archive"}, false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.opts.Validate()
+			if testCase.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !testCase.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
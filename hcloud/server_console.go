@@ -0,0 +1,412 @@
+package hcloud
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// ServerRequestConsoleResult is the result of requesting a WebSocket VNC
+// console for a server.
+type ServerRequestConsoleResult struct {
+	Action   *Action
+	WSSURL   string
+	Password string
+}
+
+// RequestConsole requests a WebSocket VNC console for a server. The returned
+// URL is only valid for a short time.
+func (c *ServerClient) RequestConsole(ctx context.Context, server *Server) (ServerRequestConsoleResult, *Response, error) {
+	path := fmt.Sprintf("/servers/%d/actions/request_console", server.ID)
+	req, err := c.client.NewRequest(ctx, "POST", path, nil)
+	if err != nil {
+		return ServerRequestConsoleResult{}, nil, err
+	}
+
+	respBody := schema.ServerActionRequestConsoleResponse{}
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return ServerRequestConsoleResult{}, resp, err
+	}
+	return ServerRequestConsoleResult{
+		Action:   ActionFromSchema(respBody.Action),
+		WSSURL:   respBody.WSSURL,
+		Password: respBody.Password,
+	}, resp, nil
+}
+
+// ConsoleProxy bridges a server's WebSocket VNC console to plain TCP
+// connections, so that standard VNC viewers can connect to it. Each accepted
+// connection opens its own WebSocket connection to URL.
+type ConsoleProxy struct {
+	// URL is the WebSocket URL of the console, as returned by RequestConsole.
+	URL string
+
+	// TLSConfig is used for wss URLs. If nil, the default configuration is used.
+	TLSConfig *tls.Config
+
+	// ErrorFunc, if set, is called with errors of individual connections.
+	ErrorFunc func(err error)
+}
+
+// Serve accepts connections on l and forwards them to the console until ctx
+// is done or accepting fails. The listener is closed when Serve returns.
+func (p *ConsoleProxy) Serve(ctx context.Context, l net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := p.handle(ctx, conn); err != nil && p.ErrorFunc != nil {
+				p.ErrorFunc(err)
+			}
+		}()
+	}
+}
+
+func (p *ConsoleProxy) handle(ctx context.Context, conn net.Conn) error {
+	ws, err := dialWebSocket(ctx, p.URL, p.TLSConfig)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	errc := make(chan error, 2)
+	go func() {
+		_, err := io.Copy(ws, conn)
+		errc <- err
+	}()
+	go func() {
+		_, err := io.Copy(conn, ws)
+		errc <- err
+	}()
+
+	pending := 2
+	select {
+	case err = <-errc:
+		pending--
+	case <-ctx.Done():
+	}
+	conn.Close()
+	ws.Close()
+	for ; pending > 0; pending-- {
+		<-errc
+	}
+	return err
+}
+
+// The WebSocket client below implements the parts of RFC 6455 needed to
+// tunnel the binary VNC protocol.
+
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const (
+	wsOpContinuation = 0x0
+	wsOpText         = 0x1
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xa
+)
+
+// wsAcceptKey computes the Sec-WebSocket-Accept value for a key.
+func wsAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + wsGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func dialWebSocket(ctx context.Context, rawURL string, tlsConfig *tls.Config) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	var port string
+	switch u.Scheme {
+	case "ws":
+		port = "80"
+	case "wss":
+		port = "443"
+	default:
+		return nil, fmt.Errorf("hcloud: unsupported console URL scheme: %s", u.Scheme)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	ws, err := wsHandshake(conn, u, tlsConfig)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	ws.SetDeadline(time.Time{})
+	return ws, nil
+}
+
+func wsHandshake(conn net.Conn, u *url.URL, tlsConfig *tls.Config) (*wsConn, error) {
+	if u.Scheme == "wss" {
+		cfg := &tls.Config{}
+		if tlsConfig != nil {
+			cfg = tlsConfig.Clone()
+		}
+		if cfg.ServerName == "" {
+			cfg.ServerName = u.Hostname()
+		}
+		tlsConn := tls.Client(conn, cfg)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce[:])
+	fmt.Fprintf(conn, "GET %s HTTP/1.1\r\n"+
+		"Host: %s\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\n"+
+		"Sec-WebSocket-Version: 13\r\n"+
+		"Sec-WebSocket-Protocol: binary\r\n"+
+		"User-Agent: %s\r\n\r\n", u.RequestURI(), u.Host, key, UserAgent)
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("hcloud: console handshake failed: %s", resp.Status)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != wsAcceptKey(key) {
+		return nil, errors.New("hcloud: console handshake failed: invalid Sec-WebSocket-Accept")
+	}
+	return newWSConn(conn, r, true), nil
+}
+
+type wsFrameHeader struct {
+	fin    bool
+	opcode byte
+	masked bool
+	mask   [4]byte
+	length int64
+}
+
+func readWSFrameHeader(r io.Reader) (wsFrameHeader, error) {
+	var h wsFrameHeader
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:2]); err != nil {
+		return h, err
+	}
+	h.fin = b[0]&0x80 != 0
+	h.opcode = b[0] & 0x0f
+	h.masked = b[1]&0x80 != 0
+	switch n := b[1] & 0x7f; n {
+	case 126:
+		if _, err := io.ReadFull(r, b[:2]); err != nil {
+			return h, err
+		}
+		h.length = int64(binary.BigEndian.Uint16(b[:2]))
+	case 127:
+		if _, err := io.ReadFull(r, b[:8]); err != nil {
+			return h, err
+		}
+		h.length = int64(binary.BigEndian.Uint64(b[:8]))
+		if h.length < 0 {
+			return h, errors.New("hcloud: invalid websocket frame length")
+		}
+	default:
+		h.length = int64(n)
+	}
+	if h.masked {
+		if _, err := io.ReadFull(r, h.mask[:]); err != nil {
+			return h, err
+		}
+	}
+	return h, nil
+}
+
+func writeWSFrame(w io.Writer, opcode byte, payload []byte, masked bool) error {
+	buf := make([]byte, 0, 14+len(payload))
+	buf = append(buf, 0x80|opcode)
+	var maskBit byte
+	if masked {
+		maskBit = 0x80
+	}
+	switch n := len(payload); {
+	case n <= 125:
+		buf = append(buf, maskBit|byte(n))
+	case n <= 0xffff:
+		buf = append(buf, maskBit|126, byte(n>>8), byte(n))
+	default:
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(n))
+		buf = append(buf, maskBit|127)
+		buf = append(buf, l[:]...)
+	}
+	start := len(buf)
+	if masked {
+		var mask [4]byte
+		if _, err := rand.Read(mask[:]); err != nil {
+			return err
+		}
+		buf = append(buf, mask[:]...)
+		start += 4
+		buf = append(buf, payload...)
+		for i := range buf[start:] {
+			buf[start+i] ^= mask[i%4]
+		}
+	} else {
+		buf = append(buf, payload...)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// wsConn is a net.Conn exchanging binary WebSocket messages. Reads return the
+// payload of data frames and answer control frames transparently.
+type wsConn struct {
+	net.Conn
+	r      *bufio.Reader
+	masked bool // clients mask their frames, servers do not
+
+	readMu    sync.Mutex
+	frame     wsFrameHeader
+	remaining int64
+	pos       int64
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+func newWSConn(conn net.Conn, r *bufio.Reader, masked bool) *wsConn {
+	return &wsConn{Conn: conn, r: r, masked: masked}
+}
+
+func (c *wsConn) Read(p []byte) (int, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	for c.remaining == 0 {
+		h, err := readWSFrameHeader(c.r)
+		if err != nil {
+			return 0, err
+		}
+		switch h.opcode {
+		case wsOpContinuation, wsOpText, wsOpBinary:
+			c.frame, c.remaining, c.pos = h, h.length, 0
+		case wsOpClose:
+			payload, _ := c.readControlPayload(h)
+			c.writeFrame(wsOpClose, payload)
+			return 0, io.EOF
+		case wsOpPing:
+			payload, err := c.readControlPayload(h)
+			if err != nil {
+				return 0, err
+			}
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return 0, err
+			}
+		case wsOpPong:
+			if _, err := c.readControlPayload(h); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("hcloud: unexpected websocket opcode %d", h.opcode)
+		}
+	}
+
+	if int64(len(p)) > c.remaining {
+		p = p[:c.remaining]
+	}
+	n, err := c.r.Read(p)
+	if c.frame.masked {
+		for i := 0; i < n; i++ {
+			p[i] ^= c.frame.mask[(c.pos+int64(i))%4]
+		}
+	}
+	c.pos += int64(n)
+	c.remaining -= int64(n)
+	return n, err
+}
+
+func (c *wsConn) readControlPayload(h wsFrameHeader) ([]byte, error) {
+	if h.length > 125 {
+		return nil, errors.New("hcloud: websocket control frame too long")
+	}
+	payload := make([]byte, h.length)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return nil, err
+	}
+	if h.masked {
+		for i := range payload {
+			payload[i] ^= h.mask[i%4]
+		}
+	}
+	return payload, nil
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return writeWSFrame(c.Conn, opcode, payload, c.masked)
+}
+
+func (c *wsConn) Write(p []byte) (int, error) {
+	if err := c.writeFrame(wsOpBinary, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close sends a close frame and closes the underlying connection.
+func (c *wsConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.writeFrame(wsOpClose, []byte{0x03, 0xe8}) // 1000: normal closure
+		err = c.Conn.Close()
+	})
+	return err
+}
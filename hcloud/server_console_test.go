@@ -0,0 +1,177 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestServerClientRequestConsole(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers/1/actions/request_console", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("expected POST")
+		}
+		json.NewEncoder(w).Encode(schema.ServerActionRequestConsoleResponse{
+			Action:   schema.Action{ID: 1},
+			WSSURL:   "wss://console.hetzner.cloud/?server_id=1&token=3db32d15",
+			Password: "9MQaTg2VAGI0FIpc10k3UpRXcHj2wQ6x",
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.Server.RequestConsole(ctx, &Server{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Action == nil || result.Action.ID != 1 {
+		t.Errorf("unexpected action: %v", result.Action)
+	}
+	if result.WSSURL != "wss://console.hetzner.cloud/?server_id=1&token=3db32d15" {
+		t.Errorf("unexpected WSS URL: %s", result.WSSURL)
+	}
+	if result.Password != "9MQaTg2VAGI0FIpc10k3UpRXcHj2wQ6x" {
+		t.Errorf("unexpected password: %s", result.Password)
+	}
+}
+
+// newTestConsoleServer starts a WebSocket stand-in for the console endpoint.
+// It sends a ping and the given greeting, then echoes everything it receives.
+func newTestConsoleServer(t *testing.T, greeting string, pongs chan<- struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" || r.URL.Query().Get("token") != "secret" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+			"Upgrade: websocket\r\n" +
+			"Connection: Upgrade\r\n" +
+			"Sec-WebSocket-Accept: " + wsAcceptKey(r.Header.Get("Sec-WebSocket-Key")) + "\r\n\r\n")
+		rw.Flush()
+
+		if err := writeWSFrame(conn, wsOpPing, []byte("ping"), false); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := writeWSFrame(conn, wsOpBinary, []byte(greeting), false); err != nil {
+			t.Error(err)
+			return
+		}
+		for {
+			h, err := readWSFrameHeader(rw)
+			if err != nil {
+				return
+			}
+			if !h.masked {
+				t.Error("expected masked client frame")
+			}
+			payload := make([]byte, h.length)
+			if _, err := io.ReadFull(rw, payload); err != nil {
+				return
+			}
+			for i := range payload {
+				payload[i] ^= h.mask[i%4]
+			}
+			switch h.opcode {
+			case wsOpPong:
+				if string(payload) != "ping" {
+					t.Errorf("unexpected pong payload: %q", payload)
+				}
+				pongs <- struct{}{}
+			case wsOpBinary:
+				writeWSFrame(conn, wsOpBinary, payload, false)
+			case wsOpClose:
+				return
+			}
+		}
+	}))
+}
+
+func TestConsoleProxy(t *testing.T) {
+	pongs := make(chan struct{}, 1)
+	server := newTestConsoleServer(t, "RFB 003.008\n", pongs)
+	defer server.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	proxy := &ConsoleProxy{
+		URL:       strings.Replace(server.URL, "http://", "ws://", 1) + "/?token=secret",
+		ErrorFunc: func(err error) { t.Error(err) },
+	}
+	served := make(chan error, 1)
+	go func() { served <- proxy.Serve(ctx, l) }()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	greeting := make([]byte, 12)
+	if _, err := io.ReadFull(conn, greeting); err != nil {
+		t.Fatal(err)
+	}
+	if string(greeting) != "RFB 003.008\n" {
+		t.Errorf("unexpected greeting: %q", greeting)
+	}
+
+	// Large enough to require an extended payload length.
+	data := bytes.Repeat([]byte("0123456789"), 100)
+	if _, err := conn.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	echo := make([]byte, len(data))
+	if _, err := io.ReadFull(conn, echo); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(echo, data) {
+		t.Error("unexpected echo")
+	}
+
+	select {
+	case <-pongs:
+	case <-time.After(5 * time.Second):
+		t.Error("no pong received")
+	}
+
+	cancel()
+	if err := <-served; err != context.Canceled {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConsoleProxyHandshakeFailure(t *testing.T) {
+	server := newTestConsoleServer(t, "", nil)
+	defer server.Close()
+
+	ctx := context.Background()
+	_, err := dialWebSocket(ctx, strings.Replace(server.URL, "http://", "ws://", 1)+"/?token=wrong", nil)
+	if err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := dialWebSocket(ctx, server.URL, nil); err == nil {
+		t.Error("expected an error for http URL")
+	}
+}
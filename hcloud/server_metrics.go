@@ -0,0 +1,164 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+// ServerMetricType is the type of metrics to retrieve for a server.
+type ServerMetricType string
+
+// Available types of server metrics.
+const (
+	ServerMetricCPU     ServerMetricType = "cpu"
+	ServerMetricDisk    ServerMetricType = "disk"
+	ServerMetricNetwork ServerMetricType = "network"
+)
+
+// ServerMetrics contains the metrics of a server for a period of time. The
+// keys of TimeSeries are the names of the series as returned by the API, for
+// example "cpu" or "disk.0.iops.read".
+type ServerMetrics struct {
+	Start      time.Time
+	End        time.Time
+	Step       time.Duration
+	TimeSeries map[string]ServerMetricsSeries
+}
+
+// ServerMetricsValue is a single data point of a time series.
+type ServerMetricsValue struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// ServerMetricsSeries is a time series of server metrics ordered by
+// timestamp.
+type ServerMetricsSeries []ServerMetricsValue
+
+// Resample returns the series with one data point per interval of length
+// step, holding the average of all values within the interval. Intervals are
+// aligned to multiples of step since the Unix epoch, and intervals without
+// values are omitted.
+func (s ServerMetricsSeries) Resample(step time.Duration) ServerMetricsSeries {
+	if step <= 0 || len(s) == 0 {
+		return s
+	}
+	var (
+		resampled ServerMetricsSeries
+		bucket    time.Time
+		sum       float64
+		n         int
+	)
+	for _, v := range s {
+		ns := v.Timestamp.UnixNano()
+		b := time.Unix(0, ns-ns%int64(step)).UTC()
+		if n > 0 && !b.Equal(bucket) {
+			resampled = append(resampled, ServerMetricsValue{Timestamp: bucket, Value: sum / float64(n)})
+			sum, n = 0, 0
+		}
+		bucket = b
+		sum += v.Value
+		n++
+	}
+	return append(resampled, ServerMetricsValue{Timestamp: bucket, Value: sum / float64(n)})
+}
+
+// MergeServerMetricsSeries merges multiple series into one by summing up the
+// values with equal timestamps, for example to combine the read and write
+// bandwidth of a disk. Timestamps present in only some of the series are kept.
+func MergeServerMetricsSeries(series ...ServerMetricsSeries) ServerMetricsSeries {
+	sums := map[int64]float64{}
+	for _, s := range series {
+		for _, v := range s {
+			sums[v.Timestamp.UnixNano()] += v.Value
+		}
+	}
+	merged := make(ServerMetricsSeries, 0, len(sums))
+	for ts, value := range sums {
+		merged = append(merged, ServerMetricsValue{Timestamp: time.Unix(0, ts).UTC(), Value: value})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	return merged
+}
+
+// ServerGetMetricsOpts specifies options for retrieving server metrics. If
+// Step is zero, the API picks a step based on the length of the period.
+type ServerGetMetricsOpts struct {
+	Types []ServerMetricType
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+}
+
+// Validate checks if options are valid.
+func (o ServerGetMetricsOpts) Validate() error {
+	if len(o.Types) == 0 {
+		return errors.New("missing metric types")
+	}
+	for _, typ := range o.Types {
+		switch typ {
+		case ServerMetricCPU, ServerMetricDisk, ServerMetricNetwork:
+		default:
+			return fmt.Errorf("invalid metric type: %s", typ)
+		}
+	}
+	if o.Start.IsZero() || o.End.IsZero() {
+		return errors.New("missing start or end")
+	}
+	if !o.End.After(o.Start) {
+		return errors.New("end must be after start")
+	}
+	if o.Step < 0 {
+		return errors.New("step must not be negative")
+	}
+	return nil
+}
+
+func (o ServerGetMetricsOpts) values() url.Values {
+	vals := url.Values{}
+	types := make([]string, 0, len(o.Types))
+	for _, typ := range o.Types {
+		types = append(types, string(typ))
+	}
+	vals.Add("type", strings.Join(types, ","))
+	vals.Add("start", o.Start.Format(time.RFC3339))
+	vals.Add("end", o.End.Format(time.RFC3339))
+	if o.Step > 0 {
+		vals.Add("step", strconv.Itoa(int(math.Ceil(o.Step.Seconds()))))
+	}
+	return vals
+}
+
+// GetMetrics retrieves metrics of the given types for a server.
+func (c *ServerClient) GetMetrics(ctx context.Context, server *Server, opts ServerGetMetricsOpts) (*ServerMetrics, *Response, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid options: %s", err)
+	}
+	path := fmt.Sprintf("/servers/%d/metrics?%s", server.ID, opts.values().Encode())
+	req, err := c.client.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var respBody schema.ServerGetMetricsResponse
+	resp, err := c.client.Do(req, &respBody)
+	if err != nil {
+		return nil, resp, err
+	}
+	metrics, err := ServerMetricsFromSchema(respBody.Metrics)
+	if err != nil {
+		return nil, resp, fmt.Errorf("hcloud: invalid server metrics: %s", err)
+	}
+	return metrics, resp, nil
+}
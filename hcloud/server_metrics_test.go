@@ -0,0 +1,171 @@
+package hcloud
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerClientGetMetrics(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers/1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("type") != "cpu,disk" {
+			t.Errorf("unexpected type: %s", q.Get("type"))
+		}
+		if q.Get("start") != "2017-01-01T00:00:00Z" || q.Get("end") != "2017-01-01T23:00:00Z" {
+			t.Errorf("unexpected start or end: %s %s", q.Get("start"), q.Get("end"))
+		}
+		if q.Get("step") != "60" {
+			t.Errorf("unexpected step: %s", q.Get("step"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"metrics": {
+				"start": "2017-01-01T00:00:00+00:00",
+				"end": "2017-01-01T23:00:00+00:00",
+				"step": 60,
+				"time_series": {
+					"cpu": {"values": [[1435781470.622, "42"], [1435781471.622, "43.5"]]},
+					"disk.0.iops.read": {"values": [[1435781470.622, "1"]]}
+				}
+			}
+		}`))
+	})
+
+	ctx := context.Background()
+	metrics, _, err := env.Client.Server.GetMetrics(ctx, &Server{ID: 1}, ServerGetMetricsOpts{
+		Types: []ServerMetricType{ServerMetricCPU, ServerMetricDisk},
+		Start: time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2017, 1, 1, 23, 0, 0, 0, time.UTC),
+		Step:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if metrics.Step != time.Minute {
+		t.Errorf("unexpected step: %v", metrics.Step)
+	}
+	cpu := metrics.TimeSeries["cpu"]
+	if len(cpu) != 2 || cpu[1].Value != 43.5 {
+		t.Fatalf("unexpected cpu series: %v", cpu)
+	}
+	if d := cpu[0].Timestamp.Sub(time.Unix(1435781470, 622000000)); d > time.Millisecond || d < -time.Millisecond {
+		t.Errorf("unexpected timestamp: %v", cpu[0].Timestamp)
+	}
+	if len(metrics.TimeSeries["disk.0.iops.read"]) != 1 {
+		t.Errorf("unexpected disk series: %v", metrics.TimeSeries["disk.0.iops.read"])
+	}
+}
+
+func TestServerClientGetMetricsMalformedValue(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers/1/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"metrics": {"time_series": {"cpu": {"values": [[1435781470, "n/a"]]}}}}`))
+	})
+
+	ctx := context.Background()
+	_, _, err := env.Client.Server.GetMetrics(ctx, &Server{ID: 1}, ServerGetMetricsOpts{
+		Types: []ServerMetricType{ServerMetricCPU},
+		Start: time.Now().Add(-time.Hour),
+		End:   time.Now(),
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestServerGetMetricsOptsValidate(t *testing.T) {
+	now := time.Now()
+	testCases := map[string]struct {
+		Opts  ServerGetMetricsOpts
+		Valid bool
+	}{
+		"valid": {
+			Opts:  ServerGetMetricsOpts{Types: []ServerMetricType{ServerMetricNetwork}, Start: now.Add(-time.Hour), End: now},
+			Valid: true,
+		},
+		"missing types": {
+			Opts: ServerGetMetricsOpts{Start: now.Add(-time.Hour), End: now},
+		},
+		"invalid type": {
+			Opts: ServerGetMetricsOpts{Types: []ServerMetricType{"memory"}, Start: now.Add(-time.Hour), End: now},
+		},
+		"missing start": {
+			Opts: ServerGetMetricsOpts{Types: []ServerMetricType{ServerMetricCPU}, End: now},
+		},
+		"end before start": {
+			Opts: ServerGetMetricsOpts{Types: []ServerMetricType{ServerMetricCPU}, Start: now, End: now.Add(-time.Hour)},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := testCase.Opts.Validate()
+			if testCase.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.Valid && err == nil {
+				t.Error("expected an error")
+			}
+			if !testCase.Valid {
+				_, _, err := NewClient().Server.GetMetrics(context.Background(), &Server{ID: 1}, testCase.Opts)
+				if err == nil || !strings.HasPrefix(err.Error(), "invalid options: ") {
+					t.Errorf("expected invalid options error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestServerMetricsSeriesResample(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := ServerMetricsSeries{
+		{Timestamp: base, Value: 1},
+		{Timestamp: base.Add(30 * time.Second), Value: 3},
+		{Timestamp: base.Add(60 * time.Second), Value: 5},
+		{Timestamp: base.Add(200 * time.Second), Value: 7},
+	}
+	resampled := series.Resample(time.Minute)
+	expected := ServerMetricsSeries{
+		{Timestamp: base, Value: 2},
+		{Timestamp: base.Add(time.Minute), Value: 5},
+		{Timestamp: base.Add(3 * time.Minute), Value: 7},
+	}
+	if len(resampled) != len(expected) {
+		t.Fatalf("unexpected series: %v", resampled)
+	}
+	for i := range expected {
+		if !resampled[i].Timestamp.Equal(expected[i].Timestamp) || resampled[i].Value != expected[i].Value {
+			t.Errorf("unexpected value %d: %v", i, resampled[i])
+		}
+	}
+}
+
+func TestMergeServerMetricsSeries(t *testing.T) {
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	read := ServerMetricsSeries{
+		{Timestamp: base, Value: 1},
+		{Timestamp: base.Add(time.Minute), Value: 2},
+	}
+	write := ServerMetricsSeries{
+		{Timestamp: base.Add(time.Minute), Value: 10},
+		{Timestamp: base.Add(2 * time.Minute), Value: 20},
+	}
+	merged := MergeServerMetricsSeries(read, write)
+	expected := []float64{1, 12, 20}
+	if len(merged) != len(expected) {
+		t.Fatalf("unexpected series: %v", merged)
+	}
+	for i, value := range expected {
+		if merged[i].Value != value || !merged[i].Timestamp.Equal(base.Add(time.Duration(i)*time.Minute)) {
+			t.Errorf("unexpected value %d: %v", i, merged[i])
+		}
+	}
+}
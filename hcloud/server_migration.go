@@ -0,0 +1,739 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ServerMigrationStep is a step of a server migration.
+type ServerMigrationStep string
+
+// List of server migration steps in the order they are executed.
+const (
+	// ServerMigrationStepSnapshot creates a snapshot of the source server. If
+	// the source server is shut down for the snapshot, it is powered on again
+	// afterwards.
+	ServerMigrationStepSnapshot ServerMigrationStep = "snapshot"
+
+	// ServerMigrationStepCreateServer creates the target server from the snapshot.
+	ServerMigrationStepCreateServer ServerMigrationStep = "create_server"
+
+	// ServerMigrationStepVolumes creates volumes of the same size as the source
+	// server's volumes and attaches them to the target server. Volumes are bound
+	// to a location, so their data is not copied.
+	ServerMigrationStepVolumes ServerMigrationStep = "volumes"
+
+	// ServerMigrationStepReverseDNS copies custom reverse DNS pointers of the
+	// source server's primary IPs to the target server.
+	ServerMigrationStepReverseDNS ServerMigrationStep = "reverse_dns"
+
+	// ServerMigrationStepCutover assigns the source server's Floating IPs to the
+	// target server.
+	ServerMigrationStepCutover ServerMigrationStep = "cutover"
+
+	// ServerMigrationStepDeleteSource deletes the source server, unless it is
+	// kept. If no name has been specified for the target server, it is created
+	// as "<source>-<datacenter>" and renamed to the source server's name once
+	// the source server has been deleted.
+	ServerMigrationStepDeleteSource ServerMigrationStep = "delete_source"
+
+	// ServerMigrationStepCleanup deletes the snapshot and removes
+	// ServerMigrationLabel from the target server and its volumes.
+	ServerMigrationStepCleanup ServerMigrationStep = "cleanup"
+)
+
+// ServerMigrationSteps lists all steps of a migration in order.
+var ServerMigrationSteps = []ServerMigrationStep{
+	ServerMigrationStepSnapshot,
+	ServerMigrationStepCreateServer,
+	ServerMigrationStepVolumes,
+	ServerMigrationStepReverseDNS,
+	ServerMigrationStepCutover,
+	ServerMigrationStepDeleteSource,
+	ServerMigrationStepCleanup,
+}
+
+// ServerMigrationLabel is set on the snapshot, the target server and the volumes
+// created by a migration. Its value is the ID of the source server. A resumed migration only
+// adopts resources carrying this label.
+const ServerMigrationLabel = "hcloud-go/migration"
+
+// ServerMigrationOpts specifies options for migrating a server to another location.
+type ServerMigrationOpts struct {
+	Location       *Location   // target location; mutually exclusive with Datacenter
+	Datacenter     *Datacenter // target datacenter; mutually exclusive with Location
+	Name           string      // name of the target server, must differ from the source server's name, see ServerMigrationStepDeleteSource
+	ServerType     *ServerType // defaults to the source server's type
+	SSHKeys        []*SSHKey
+	ShutdownSource bool // shut down the source server while creating the snapshot
+	KeepSource     bool // do not delete the source server after the cutover
+}
+
+// Validate checks if options are valid.
+func (o ServerMigrationOpts) Validate() error {
+	if o.Location == nil && o.Datacenter == nil {
+		return errors.New("missing location or datacenter")
+	}
+	if o.Location != nil && o.Datacenter != nil {
+		return errors.New("location and datacenter are mutually exclusive")
+	}
+	return nil
+}
+
+// ServerMigrationState is the persistent state of a server migration. It can be
+// serialized to JSON and passed to ServerClient.ResumeMigration to continue a
+// migration after a crash.
+type ServerMigrationState struct {
+	SourceServerID   int                   `json:"source_server_id"`
+	SourceServerName string                `json:"source_server_name"`
+	TargetName       string                `json:"target_name"`
+	TargetDatacenter int                   `json:"target_datacenter"`
+	ServerType       int                   `json:"server_type"`
+	SSHKeys          []int                 `json:"ssh_keys,omitempty"`
+	ShutdownSource   bool                  `json:"shutdown_source"`
+	KeepSource       bool                  `json:"keep_source"`
+	RenameTarget     bool                  `json:"rename_target"`
+	FloatingIPs      []int                 `json:"floating_ips,omitempty"`   // Floating IPs assigned to the source server when planning
+	RestartSource    bool                  `json:"restart_source,omitempty"` // source server has been shut down for the snapshot and must be powered on again
+	Completed        []ServerMigrationStep `json:"completed"`
+	SnapshotID       int                   `json:"snapshot_id,omitempty"`
+	TargetServerID   int                   `json:"target_server_id,omitempty"`
+	Volumes          map[int]int           `json:"volumes,omitempty"` // source volume ID to target volume ID
+}
+
+// ServerMigration migrates a server to another location. A migration is created
+// with ServerClient.PlanMigration or ServerClient.ResumeMigration and executed
+// with Run or RunUntil.
+type ServerMigration struct {
+	State ServerMigrationState
+
+	// Save is called with the current state after each completed step and after
+	// each created resource. If Save returns an error, the migration stops.
+	Save func(ServerMigrationState) error
+
+	client *Client
+}
+
+// PlanMigration prepares the migration of a server to another location. It checks
+// that the server type is available in the target location and picks a
+// datacenter for the target server. Nothing is changed until the migration is run.
+func (c *ServerClient) PlanMigration(ctx context.Context, server *Server, opts ServerMigrationOpts) (*ServerMigration, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %s", err)
+	}
+
+	source, _, err := c.GetByID(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("hcloud: server %d not found", server.ID)
+	}
+	if opts.Name == source.Name {
+		return nil, errors.New("hcloud: target server name must differ from the source server's name, " +
+			"leave it empty to rename the target server once the source server has been deleted")
+	}
+
+	serverType := source.ServerType
+	if opts.ServerType != nil {
+		if opts.ServerType.ID != 0 {
+			serverType = opts.ServerType
+		} else if serverType, _, err = c.client.ServerType.GetByName(ctx, opts.ServerType.Name); err != nil {
+			return nil, err
+		} else if serverType == nil {
+			return nil, fmt.Errorf("hcloud: server type %s not found", opts.ServerType.Name)
+		}
+	}
+
+	var candidates []*Datacenter
+	if opts.Datacenter != nil {
+		var datacenter *Datacenter
+		if opts.Datacenter.ID != 0 {
+			datacenter, _, err = c.client.Datacenter.GetByID(ctx, opts.Datacenter.ID)
+		} else {
+			datacenter, _, err = c.client.Datacenter.GetByName(ctx, opts.Datacenter.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if datacenter != nil {
+			candidates = append(candidates, datacenter)
+		}
+	} else {
+		datacenters, err := c.client.Datacenter.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, datacenter := range datacenters {
+			if datacenter.Location == nil {
+				continue
+			}
+			if (opts.Location.ID != 0 && datacenter.Location.ID == opts.Location.ID) ||
+				(opts.Location.Name != "" && datacenter.Location.Name == opts.Location.Name) {
+				candidates = append(candidates, datacenter)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("hcloud: target datacenter not found")
+	}
+
+	var target *Datacenter
+	for _, datacenter := range candidates {
+		if source.Datacenter != nil && source.Datacenter.Location != nil && datacenter.Location != nil &&
+			datacenter.Location.ID == source.Datacenter.Location.ID {
+			return nil, errors.New("hcloud: server is already in the target location")
+		}
+		for _, available := range datacenter.ServerTypes.Available {
+			if available.ID == serverType.ID {
+				target = datacenter
+				break
+			}
+		}
+		if target != nil {
+			break
+		}
+	}
+	if target == nil {
+		return nil, fmt.Errorf("hcloud: server type %d is not available in the target location", serverType.ID)
+	}
+
+	state := ServerMigrationState{
+		SourceServerID:   source.ID,
+		SourceServerName: source.Name,
+		TargetName:       opts.Name,
+		TargetDatacenter: target.ID,
+		ServerType:       serverType.ID,
+		ShutdownSource:   opts.ShutdownSource,
+		KeepSource:       opts.KeepSource,
+		Volumes:          map[int]int{},
+	}
+	if state.TargetName == "" {
+		state.TargetName = source.Name + "-" + target.Name
+		state.RenameTarget = true
+	}
+	for _, sshKey := range opts.SSHKeys {
+		state.SSHKeys = append(state.SSHKeys, sshKey.ID)
+	}
+	for _, floatingIP := range source.PublicNet.FloatingIPs {
+		state.FloatingIPs = append(state.FloatingIPs, floatingIP.ID)
+	}
+	return c.ResumeMigration(state), nil
+}
+
+// ResumeMigration returns a migration continuing from the given state.
+func (c *ServerClient) ResumeMigration(state ServerMigrationState) *ServerMigration {
+	if state.Volumes == nil {
+		state.Volumes = map[int]int{}
+	}
+	return &ServerMigration{
+		State:  state,
+		client: c.client,
+	}
+}
+
+// Pending returns the steps which have not been completed yet.
+func (m *ServerMigration) Pending() []ServerMigrationStep {
+	var pending []ServerMigrationStep
+	for _, step := range ServerMigrationSteps {
+		if !m.completed(step) {
+			pending = append(pending, step)
+		}
+	}
+	return pending
+}
+
+// Run executes all pending steps of the migration.
+func (m *ServerMigration) Run(ctx context.Context) error {
+	return m.RunUntil(ctx, "")
+}
+
+// RunUntil executes the pending steps which come before the given step, for
+// example to verify the target server before ServerMigrationStepCutover.
+func (m *ServerMigration) RunUntil(ctx context.Context, until ServerMigrationStep) error {
+	steps := map[ServerMigrationStep]func(context.Context) error{
+		ServerMigrationStepSnapshot:     m.snapshot,
+		ServerMigrationStepCreateServer: m.createServer,
+		ServerMigrationStepVolumes:      m.volumes,
+		ServerMigrationStepReverseDNS:   m.reverseDNS,
+		ServerMigrationStepCutover:      m.cutover,
+		ServerMigrationStepDeleteSource: m.deleteSource,
+		ServerMigrationStepCleanup:      m.cleanup,
+	}
+	for _, step := range ServerMigrationSteps {
+		if step == until {
+			return nil
+		}
+		if m.completed(step) {
+			continue
+		}
+		if err := steps[step](ctx); err != nil {
+			return fmt.Errorf("hcloud: migration step %s: %s", step, err)
+		}
+		m.State.Completed = append(m.State.Completed, step)
+		if err := m.save(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ServerMigration) completed(step ServerMigrationStep) bool {
+	for _, s := range m.State.Completed {
+		if s == step {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *ServerMigration) save() error {
+	if m.Save == nil {
+		return nil
+	}
+	return m.Save(m.State)
+}
+
+func (m *ServerMigration) source(ctx context.Context) (*Server, error) {
+	source, _, err := m.client.Server.GetByID(ctx, m.State.SourceServerID)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, fmt.Errorf("source server %d not found", m.State.SourceServerID)
+	}
+	return source, nil
+}
+
+func (m *ServerMigration) target(ctx context.Context) (*Server, error) {
+	target, _, err := m.client.Server.GetByID(ctx, m.State.TargetServerID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, fmt.Errorf("target server %d not found", m.State.TargetServerID)
+	}
+	return target, nil
+}
+
+func (m *ServerMigration) snapshot(ctx context.Context) error {
+	err := m.createSnapshot(ctx)
+	if m.State.RestartSource {
+		// The source server is powered on again also if the snapshot failed,
+		// so it is not left down until the migration is resumed.
+		if restartErr := m.restartSource(ctx); err == nil {
+			err = restartErr
+		}
+	}
+	return err
+}
+
+func (m *ServerMigration) createSnapshot(ctx context.Context) error {
+	sourceID := strconv.Itoa(m.State.SourceServerID)
+	if m.State.SnapshotID == 0 {
+		// A previous run may have created the snapshot without saving its ID.
+		images, _, err := m.client.Image.List(ctx, ImageListOpts{
+			ListOpts: ListOpts{LabelSelector: ServerMigrationLabel + "=" + sourceID},
+			Type:     []ImageType{ImageTypeSnapshot},
+		})
+		if err != nil {
+			return err
+		}
+		if len(images) > 0 {
+			m.State.SnapshotID = images[0].ID
+		}
+	}
+
+	if m.State.SnapshotID == 0 {
+		source, err := m.source(ctx)
+		if err != nil {
+			return err
+		}
+		if m.State.ShutdownSource && source.Status != ServerStatusOff {
+			m.State.RestartSource = true
+			if err := m.save(); err != nil {
+				return err
+			}
+			action, _, err := m.client.Server.Shutdown(ctx, source)
+			if err != nil {
+				return err
+			}
+			if err := m.client.Action.waitFor(ctx, action); err != nil {
+				return err
+			}
+			if err := m.client.Server.waitForStatus(ctx, source, ServerStatusOff); err != nil {
+				return err
+			}
+		}
+		result, _, err := m.client.Server.CreateImage(ctx, source, &ServerCreateImageOpts{
+			Type:        ImageTypeSnapshot,
+			Description: String(fmt.Sprintf("migration of %s", source.Name)),
+			Labels:      map[string]string{ServerMigrationLabel: sourceID},
+		})
+		if err != nil {
+			return err
+		}
+		m.State.SnapshotID = result.Image.ID
+		if err := m.save(); err != nil {
+			return err
+		}
+		if err := m.client.Action.waitFor(ctx, result.Action); err != nil {
+			return err
+		}
+	}
+
+	_, err := m.client.Image.waitForStatus(ctx, &Image{ID: m.State.SnapshotID}, ImageStatusAvailable)
+	return err
+}
+
+func (m *ServerMigration) restartSource(ctx context.Context) error {
+	source, err := m.source(ctx)
+	if err != nil {
+		return err
+	}
+	if source.Status == ServerStatusOff {
+		action, _, err := m.client.Server.Poweron(ctx, source)
+		if err != nil {
+			return err
+		}
+		if err := m.client.Action.waitFor(ctx, action); err != nil {
+			return err
+		}
+	}
+	m.State.RestartSource = false
+	return m.save()
+}
+
+func (m *ServerMigration) createServer(ctx context.Context) error {
+	if m.State.TargetServerID != 0 {
+		return nil
+	}
+	sourceID := strconv.Itoa(m.State.SourceServerID)
+	// A previous run may have created the server without saving its ID. Only a
+	// server labeled by this migration is adopted, never the source server or
+	// an unrelated server which happens to have the target name.
+	existing, _, err := m.client.Server.GetByName(ctx, m.State.TargetName)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		if existing.ID == m.State.SourceServerID || existing.Labels[ServerMigrationLabel] != sourceID {
+			return fmt.Errorf("server %s already exists and has not been created by this migration", m.State.TargetName)
+		}
+		m.State.TargetServerID = existing.ID
+		return m.save()
+	}
+
+	source, err := m.source(ctx)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{ServerMigrationLabel: sourceID}
+	for key, value := range source.Labels {
+		labels[key] = value
+	}
+	opts := ServerCreateOpts{
+		Name:       m.State.TargetName,
+		ServerType: &ServerType{ID: m.State.ServerType},
+		Image:      &Image{ID: m.State.SnapshotID},
+		Datacenter: &Datacenter{ID: m.State.TargetDatacenter},
+		Labels:     labels,
+	}
+	for _, id := range m.State.SSHKeys {
+		opts.SSHKeys = append(opts.SSHKeys, &SSHKey{ID: id})
+	}
+	for _, privateNet := range source.PrivateNet {
+		opts.Networks = append(opts.Networks, privateNet.Network)
+	}
+	result, _, err := m.client.Server.Create(ctx, opts)
+	if err != nil {
+		return err
+	}
+	m.State.TargetServerID = result.Server.ID
+	if err := m.save(); err != nil {
+		return err
+	}
+	return m.client.Action.waitForAll(ctx, append([]*Action{result.Action}, result.NextActions...)...)
+}
+
+func (m *ServerMigration) volumes(ctx context.Context) error {
+	source, err := m.source(ctx)
+	if err != nil {
+		return err
+	}
+	target, err := m.target(ctx)
+	if err != nil {
+		return err
+	}
+
+	sourceID := strconv.Itoa(m.State.SourceServerID)
+	for _, v := range source.Volumes {
+		id, ok := m.State.Volumes[v.ID]
+		if !ok {
+			volume, _, err := m.client.Volume.GetByID(ctx, v.ID)
+			if err != nil {
+				return err
+			}
+			if volume == nil {
+				return fmt.Errorf("volume %d not found", v.ID)
+			}
+
+			// A previous run may have created the volume without saving its ID.
+			// Only a volume labeled by this migration is adopted.
+			name := target.Name + "-" + volume.Name
+			existing, _, err := m.client.Volume.GetByName(ctx, name)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				if existing.Labels[ServerMigrationLabel] != sourceID {
+					return fmt.Errorf("volume %s already exists and has not been created by this migration", name)
+				}
+				id = existing.ID
+			} else {
+				labels := map[string]string{}
+				for key, value := range volume.Labels {
+					labels[key] = value
+				}
+				labels[ServerMigrationLabel] = sourceID
+				result, _, err := m.client.Volume.Create(ctx, VolumeCreateOpts{
+					Name:   name,
+					Size:   volume.Size,
+					Server: target,
+					Labels: labels,
+				})
+				if err != nil {
+					return err
+				}
+				m.State.Volumes[v.ID] = result.Volume.ID
+				if err := m.save(); err != nil {
+					return err
+				}
+				if err := m.client.Action.waitForAll(ctx, append([]*Action{result.Action}, result.NextActions...)...); err != nil {
+					return err
+				}
+				continue
+			}
+			m.State.Volumes[v.ID] = id
+			if err := m.save(); err != nil {
+				return err
+			}
+		}
+
+		// Attaching a volume created or adopted by a previous run may have
+		// failed or been interrupted.
+		volume, _, err := m.client.Volume.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if volume == nil {
+			return fmt.Errorf("volume %d not found", id)
+		}
+		if volume.Server != nil && volume.Server.ID == target.ID {
+			continue
+		}
+		action, _, err := m.client.Volume.Attach(ctx, volume, target)
+		if err != nil {
+			return err
+		}
+		if err := m.client.Action.waitFor(ctx, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ServerMigration) reverseDNS(ctx context.Context) error {
+	source, err := m.source(ctx)
+	if err != nil {
+		return err
+	}
+	target, err := m.target(ctx)
+	if err != nil {
+		return err
+	}
+
+	ptrs := map[string]string{}
+	if ptr := source.PublicNet.IPv4.DNSPtr; ptr != "" && !isDefaultDNSPtr(ptr) && target.PublicNet.IPv4.IP != nil {
+		ptrs[target.PublicNet.IPv4.IP.String()] = ptr
+	}
+	if target.PublicNet.IPv6.Network != nil {
+		for ip, ptr := range source.PublicNet.IPv6.DNSPtr {
+			if targetIP := translateIPv6(net.ParseIP(ip), target.PublicNet.IPv6.Network); targetIP != nil {
+				ptrs[targetIP.String()] = ptr
+			}
+		}
+	}
+
+	for ip, ptr := range ptrs {
+		action, _, err := m.client.Server.ChangeDNSPtr(ctx, target, ip, String(ptr))
+		if err != nil {
+			return err
+		}
+		if err := m.client.Action.waitFor(ctx, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ServerMigration) cutover(ctx context.Context) error {
+	// The Floating IPs recorded when planning are assigned even if the source
+	// server has been deleted in the meantime.
+	floatingIPs := append([]int(nil), m.State.FloatingIPs...)
+	source, _, err := m.client.Server.GetByID(ctx, m.State.SourceServerID)
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		for _, floatingIP := range source.PublicNet.FloatingIPs {
+			if !containsInt(floatingIPs, floatingIP.ID) {
+				floatingIPs = append(floatingIPs, floatingIP.ID)
+			}
+		}
+	}
+	target, err := m.target(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range floatingIPs {
+		if serverHasFloatingIP(target, id) {
+			continue
+		}
+		action, _, err := m.client.FloatingIP.Assign(ctx, &FloatingIP{ID: id}, target)
+		if err != nil {
+			return err
+		}
+		if err := m.client.Action.waitFor(ctx, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ServerMigration) deleteSource(ctx context.Context) error {
+	if m.State.KeepSource {
+		return nil
+	}
+	source, _, err := m.client.Server.GetByID(ctx, m.State.SourceServerID)
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		if _, err := m.client.Server.Delete(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	target, err := m.target(ctx)
+	if err != nil {
+		return err
+	}
+	if !m.State.RenameTarget || target.Name == m.State.SourceServerName {
+		return nil
+	}
+	// The source server's name only becomes available once it has been deleted.
+	for {
+		_, _, err = m.client.Server.Update(ctx, target, ServerUpdateOpts{Name: m.State.SourceServerName})
+		if !IsError(err, ErrorCodeUniquenessError) {
+			return err
+		}
+		if err := sleep(ctx, m.client.pollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+func (m *ServerMigration) cleanup(ctx context.Context) error {
+	if m.State.SnapshotID != 0 {
+		_, err := m.client.Image.Delete(ctx, &Image{ID: m.State.SnapshotID})
+		if err != nil && !IsError(err, ErrorCodeNotFound) {
+			return err
+		}
+	}
+
+	for _, id := range m.State.Volumes {
+		volume, _, err := m.client.Volume.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if volume == nil {
+			continue
+		}
+		if labels, ok := withoutMigrationLabel(volume.Labels); ok {
+			if _, _, err := m.client.Volume.Update(ctx, volume, VolumeUpdateOpts{Labels: labels}); err != nil {
+				return err
+			}
+		}
+	}
+
+	target, _, err := m.client.Server.GetByID(ctx, m.State.TargetServerID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return nil
+	}
+	if labels, ok := withoutMigrationLabel(target.Labels); ok {
+		_, _, err = m.client.Server.Update(ctx, target, ServerUpdateOpts{Labels: labels})
+	}
+	return err
+}
+
+// withoutMigrationLabel returns a copy of labels without ServerMigrationLabel
+// and whether the label was present.
+func withoutMigrationLabel(labels map[string]string) (map[string]string, bool) {
+	if _, ok := labels[ServerMigrationLabel]; !ok {
+		return nil, false
+	}
+	result := map[string]string{}
+	for key, value := range labels {
+		if key != ServerMigrationLabel {
+			result[key] = value
+		}
+	}
+	return result, true
+}
+
+func serverHasFloatingIP(server *Server, id int) bool {
+	for _, floatingIP := range server.PublicNet.FloatingIPs {
+		if floatingIP.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isDefaultDNSPtr returns whether ptr is a reverse DNS pointer assigned by
+// Hetzner Cloud, which must not be copied to another IP address.
+func isDefaultDNSPtr(ptr string) bool {
+	return strings.HasPrefix(ptr, "static.") && strings.HasSuffix(ptr, ".your-server.de")
+}
+
+// translateIPv6 returns the address in network with the same interface
+// identifier as ip.
+func translateIPv6(ip net.IP, network *net.IPNet) net.IP {
+	ip = ip.To16()
+	if ip == nil || ip.To4() != nil {
+		return nil
+	}
+	translated := make(net.IP, net.IPv6len)
+	for i := range translated {
+		translated[i] = network.IP[i]&network.Mask[i] | ip[i]&^network.Mask[i]
+	}
+	return translated
+}
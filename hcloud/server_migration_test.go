@@ -0,0 +1,578 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+type migrationTestEnv struct {
+	testEnv
+	sourceDeleted  bool
+	sourceStatus   string
+	targetName     string
+	targetLabels   map[string]string
+	existing       *schema.Server // returned when looking up the target server by name
+	existingVolume *schema.Volume // returned when looking up the target volume by name
+	volumeServer   *int
+	volumeLabels   map[string]string
+	calls          []string
+}
+
+func newMigrationTestEnv(t *testing.T) *migrationTestEnv {
+	env := &migrationTestEnv{testEnv: newTestEnv(), sourceStatus: "running"}
+	env.Client.pollInterval = time.Millisecond
+
+	fsn1 := schema.Datacenter{ID: 1, Name: "fsn1-dc14", Location: schema.Location{ID: 1, Name: "fsn1"}}
+	fsn1.ServerTypes.Available = []int{1, 2}
+	hel1 := schema.Datacenter{ID: 2, Name: "hel1-dc2", Location: schema.Location{ID: 2, Name: "hel1"}}
+	hel1.ServerTypes.Available = []int{1}
+	env.Mux.HandleFunc("/datacenters", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.DatacenterListResponse{
+			Datacenters: []schema.Datacenter{fsn1, hel1},
+		})
+	})
+	env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			env.calls = append(env.calls, "delete_source")
+			env.sourceDeleted = true
+			return
+		}
+		if env.sourceDeleted {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(schema.ErrorResponse{
+				Error: schema.Error{Code: string(ErrorCodeNotFound)},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{
+				ID:         1,
+				Name:       "web",
+				Status:     env.sourceStatus,
+				ServerType: schema.ServerType{ID: 1},
+				Datacenter: schema.Datacenter{Location: schema.Location{ID: 1, Name: "fsn1"}},
+				PublicNet: schema.ServerPublicNet{
+					IPv4: schema.ServerPublicNetIPv4{IP: "1.2.3.4", DNSPtr: "www.example.com"},
+					IPv6: schema.ServerPublicNetIPv6{
+						IP:     "2001:db8:1::/64",
+						DNSPtr: []schema.ServerPublicNetIPv6DNSPtr{{IP: "2001:db8:1::1", DNSPtr: "www.example.com"}},
+					},
+					FloatingIPs: []int{7},
+				},
+				Volumes: []int{3},
+			},
+		})
+	})
+	env.Mux.HandleFunc("/servers/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			var reqBody schema.ServerUpdateRequest
+			json.NewDecoder(r.Body).Decode(&reqBody)
+			if reqBody.Name != "" {
+				env.targetName = reqBody.Name
+				env.calls = append(env.calls, "rename:"+reqBody.Name)
+			}
+			if reqBody.Labels != nil {
+				env.targetLabels = *reqBody.Labels
+				env.calls = append(env.calls, "update_labels")
+			}
+		}
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{
+				ID:     2,
+				Name:   env.targetName,
+				Labels: env.targetLabels,
+				PublicNet: schema.ServerPublicNet{
+					IPv4: schema.ServerPublicNetIPv4{IP: "5.6.7.8"},
+					IPv6: schema.ServerPublicNetIPv6{IP: "2001:db8:2::/64"},
+				},
+			},
+		})
+	})
+	env.Mux.HandleFunc("/servers/1/actions/shutdown", func(w http.ResponseWriter, r *http.Request) {
+		env.calls = append(env.calls, "shutdown_source")
+		env.sourceStatus = "off"
+		json.NewEncoder(w).Encode(schema.ServerActionShutdownResponse{
+			Action: schema.Action{ID: 5, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/servers/1/actions/poweron", func(w http.ResponseWriter, r *http.Request) {
+		env.calls = append(env.calls, "poweron_source")
+		env.sourceStatus = "running"
+		json.NewEncoder(w).Encode(schema.ServerActionPoweronResponse{
+			Action: schema.Action{ID: 6, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/servers/1/actions/create_image", func(w http.ResponseWriter, r *http.Request) {
+		env.calls = append(env.calls, "create_image")
+		json.NewEncoder(w).Encode(schema.ServerActionCreateImageResponse{
+			Action: schema.Action{ID: 1, Status: "running"},
+			Image:  schema.Image{ID: 4},
+		})
+	})
+	env.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		if s := r.URL.Query().Get("label_selector"); s != "hcloud-go/migration=1" {
+			t.Errorf("unexpected label selector: %s", s)
+		}
+		json.NewEncoder(w).Encode(schema.ImageListResponse{})
+	})
+	env.Mux.HandleFunc("/images/4", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			env.calls = append(env.calls, "delete_snapshot")
+			return
+		}
+		json.NewEncoder(w).Encode(schema.ImageGetResponse{
+			Image: schema.Image{ID: 4, Status: "available"},
+		})
+	})
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			var servers []schema.Server
+			if env.existing != nil && r.URL.Query().Get("name") == env.existing.Name {
+				servers = append(servers, *env.existing)
+			}
+			json.NewEncoder(w).Encode(schema.ServerListResponse{Servers: servers})
+			return
+		}
+		var reqBody schema.ServerCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Datacenter != "2" {
+			t.Errorf("unexpected datacenter: %s", reqBody.Datacenter)
+		}
+		if reqBody.Image != float64(4) {
+			t.Errorf("unexpected image: %v", reqBody.Image)
+		}
+		if reqBody.Labels == nil || (*reqBody.Labels)[ServerMigrationLabel] != "1" {
+			t.Errorf("unexpected labels: %v", reqBody.Labels)
+		}
+		env.targetName = reqBody.Name
+		env.targetLabels = *reqBody.Labels
+		env.calls = append(env.calls, "create_server:"+reqBody.Name)
+		json.NewEncoder(w).Encode(schema.ServerCreateResponse{
+			Server: schema.Server{ID: 2, Name: reqBody.Name},
+			Action: schema.Action{ID: 2, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/volumes/3", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.VolumeGetResponse{
+			Volume: schema.Volume{ID: 3, Name: "data", Size: 10},
+		})
+	})
+	env.Mux.HandleFunc("/volumes", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			var volumes []schema.Volume
+			if env.existingVolume != nil && r.URL.Query().Get("name") == env.existingVolume.Name {
+				volumes = append(volumes, *env.existingVolume)
+			}
+			json.NewEncoder(w).Encode(schema.VolumeListResponse{Volumes: volumes})
+			return
+		}
+		var reqBody schema.VolumeCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Size != 10 || reqBody.Server == nil || *reqBody.Server != 2 {
+			t.Errorf("unexpected volume request: %+v", reqBody)
+		}
+		if reqBody.Labels == nil || (*reqBody.Labels)[ServerMigrationLabel] != "1" {
+			t.Errorf("unexpected volume labels: %v", reqBody.Labels)
+		}
+		env.volumeServer = reqBody.Server
+		env.volumeLabels = *reqBody.Labels
+		env.calls = append(env.calls, "create_volume:"+reqBody.Name)
+		json.NewEncoder(w).Encode(schema.VolumeCreateResponse{
+			Volume: schema.Volume{ID: 5, Name: reqBody.Name},
+		})
+	})
+	env.Mux.HandleFunc("/volumes/5", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			var reqBody schema.VolumeUpdateRequest
+			json.NewDecoder(r.Body).Decode(&reqBody)
+			if reqBody.Labels != nil {
+				env.volumeLabels = *reqBody.Labels
+				env.calls = append(env.calls, "update_volume_labels")
+			}
+		}
+		json.NewEncoder(w).Encode(schema.VolumeGetResponse{
+			Volume: schema.Volume{ID: 5, Name: "web-hel1-dc2-data", Server: env.volumeServer, Labels: env.volumeLabels},
+		})
+	})
+	env.Mux.HandleFunc("/volumes/5/actions/attach", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.VolumeActionAttachVolumeRequest
+		json.NewDecoder(r.Body).Decode(&reqBody)
+		if reqBody.Server != 2 {
+			t.Errorf("unexpected server: %d", reqBody.Server)
+		}
+		env.calls = append(env.calls, "attach_volume")
+		env.volumeServer = &reqBody.Server
+		json.NewEncoder(w).Encode(schema.VolumeActionAttachVolumeResponse{
+			Action: schema.Action{ID: 7, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/servers/2/actions/change_dns_ptr", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.ServerActionChangeDNSPtrRequest
+		json.NewDecoder(r.Body).Decode(&reqBody)
+		if reqBody.IP != "5.6.7.8" && reqBody.IP != "2001:db8:2::1" {
+			t.Errorf("unexpected IP: %s", reqBody.IP)
+		}
+		env.calls = append(env.calls, "change_dns_ptr")
+		json.NewEncoder(w).Encode(schema.ServerActionChangeDNSPtrResponse{
+			Action: schema.Action{ID: 3, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/floating_ips/7/actions/assign", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.FloatingIPActionAssignRequest
+		json.NewDecoder(r.Body).Decode(&reqBody)
+		if reqBody.Server != 2 {
+			t.Errorf("unexpected server: %d", reqBody.Server)
+		}
+		env.calls = append(env.calls, "assign_floating_ip")
+		json.NewEncoder(w).Encode(schema.FloatingIPActionAssignResponse{
+			Action: schema.Action{ID: 4, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/actions/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ActionGetResponse{
+			Action: schema.Action{Status: "success"},
+		})
+	})
+	return env
+}
+
+func TestServerClientPlanMigration(t *testing.T) {
+	env := newMigrationTestEnv(t)
+	defer env.Teardown()
+
+	ctx := context.Background()
+
+	t.Run("server type not available", func(t *testing.T) {
+		_, err := env.Client.Server.PlanMigration(ctx, &Server{ID: 1}, ServerMigrationOpts{
+			Location:   &Location{Name: "hel1"},
+			ServerType: &ServerType{ID: 2},
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("same location", func(t *testing.T) {
+		_, err := env.Client.Server.PlanMigration(ctx, &Server{ID: 1}, ServerMigrationOpts{
+			Location: &Location{Name: "fsn1"},
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("source server name", func(t *testing.T) {
+		_, err := env.Client.Server.PlanMigration(ctx, &Server{ID: 1}, ServerMigrationOpts{
+			Location: &Location{Name: "hel1"},
+			Name:     "web",
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		migration, err := env.Client.Server.PlanMigration(ctx, &Server{ID: 1}, ServerMigrationOpts{
+			Location: &Location{Name: "hel1"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if migration.State.TargetDatacenter != 2 {
+			t.Errorf("unexpected target datacenter: %d", migration.State.TargetDatacenter)
+		}
+		if migration.State.TargetName != "web-hel1-dc2" {
+			t.Errorf("unexpected target name: %s", migration.State.TargetName)
+		}
+		if len(migration.State.FloatingIPs) != 1 || migration.State.FloatingIPs[0] != 7 {
+			t.Errorf("unexpected Floating IPs: %v", migration.State.FloatingIPs)
+		}
+		if len(migration.Pending()) != len(ServerMigrationSteps) {
+			t.Errorf("unexpected pending steps: %v", migration.Pending())
+		}
+	})
+}
+
+func TestServerMigrationRunAndResume(t *testing.T) {
+	env := newMigrationTestEnv(t)
+	defer env.Teardown()
+
+	ctx := context.Background()
+	migration, err := env.Client.Server.PlanMigration(ctx, &Server{ID: 1}, ServerMigrationOpts{
+		Location: &Location{Name: "hel1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []byte
+	migration.Save = func(state ServerMigrationState) error {
+		saved, err = json.Marshal(state)
+		return err
+	}
+
+	if err := migration.RunUntil(ctx, ServerMigrationStepCutover); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"create_image",
+		"create_server:web-hel1-dc2",
+		"create_volume:web-hel1-dc2-data",
+		"change_dns_ptr",
+		"change_dns_ptr",
+	}
+	if !equalStrings(env.calls, expected) {
+		t.Fatalf("unexpected calls: %v", env.calls)
+	}
+
+	var state ServerMigrationState
+	if err := json.Unmarshal(saved, &state); err != nil {
+		t.Fatal(err)
+	}
+	resumed := env.Client.Server.ResumeMigration(state)
+	pending := resumed.Pending()
+	if len(pending) != 3 || pending[0] != ServerMigrationStepCutover {
+		t.Fatalf("unexpected pending steps: %v", pending)
+	}
+
+	env.calls = nil
+	if err := resumed.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	expected = []string{
+		"assign_floating_ip",
+		"delete_source",
+		"rename:web",
+		"delete_snapshot",
+		"update_volume_labels",
+		"update_labels",
+	}
+	if !equalStrings(env.calls, expected) {
+		t.Fatalf("unexpected calls: %v", env.calls)
+	}
+	if len(resumed.Pending()) != 0 {
+		t.Errorf("unexpected pending steps: %v", resumed.Pending())
+	}
+	if _, ok := env.targetLabels[ServerMigrationLabel]; ok {
+		t.Errorf("migration label has not been removed: %v", env.targetLabels)
+	}
+	if _, ok := env.volumeLabels[ServerMigrationLabel]; ok {
+		t.Errorf("migration label has not been removed from volume: %v", env.volumeLabels)
+	}
+}
+
+func TestServerMigrationShutdownSource(t *testing.T) {
+	env := newMigrationTestEnv(t)
+	defer env.Teardown()
+
+	ctx := context.Background()
+	migration, err := env.Client.Server.PlanMigration(ctx, &Server{ID: 1}, ServerMigrationOpts{
+		Location:       &Location{Name: "hel1"},
+		ShutdownSource: true,
+		KeepSource:     true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migration.RunUntil(ctx, ServerMigrationStepCreateServer); err != nil {
+		t.Fatal(err)
+	}
+	if !equalStrings(env.calls, []string{"shutdown_source", "create_image", "poweron_source"}) {
+		t.Errorf("unexpected calls: %v", env.calls)
+	}
+	if env.sourceStatus != "running" || migration.State.RestartSource {
+		t.Errorf("source server has not been restarted: status %s, state %+v", env.sourceStatus, migration.State)
+	}
+}
+
+func TestServerMigrationResumeRestartSource(t *testing.T) {
+	env := newMigrationTestEnv(t)
+	defer env.Teardown()
+	env.sourceStatus = "off"
+
+	// The previous run created the snapshot but stopped before powering the
+	// source server on again.
+	migration := env.Client.Server.ResumeMigration(ServerMigrationState{
+		SourceServerID: 1,
+		ShutdownSource: true,
+		RestartSource:  true,
+		SnapshotID:     4,
+	})
+	if err := migration.RunUntil(context.Background(), ServerMigrationStepCreateServer); err != nil {
+		t.Fatal(err)
+	}
+	if !equalStrings(env.calls, []string{"poweron_source"}) {
+		t.Errorf("unexpected calls: %v", env.calls)
+	}
+	if env.sourceStatus != "running" || migration.State.RestartSource {
+		t.Errorf("source server has not been restarted: status %s, state %+v", env.sourceStatus, migration.State)
+	}
+}
+
+func TestServerMigrationResumeVolumes(t *testing.T) {
+	two := 2
+	testCases := map[string]struct {
+		existing *schema.Volume
+		state    map[int]int
+		server   *int
+		calls    []string
+		err      bool
+	}{
+		"unrelated volume": {
+			existing: &schema.Volume{ID: 5, Name: "web-hel1-dc2-data"},
+			err:      true,
+		},
+		"adopted and not attached": {
+			existing: &schema.Volume{ID: 5, Name: "web-hel1-dc2-data", Labels: map[string]string{ServerMigrationLabel: "1"}},
+			calls:    []string{"attach_volume"},
+		},
+		"adopted and attached": {
+			existing: &schema.Volume{ID: 5, Name: "web-hel1-dc2-data", Labels: map[string]string{ServerMigrationLabel: "1"}},
+			server:   &two,
+		},
+		"saved and not attached": {
+			state: map[int]int{3: 5},
+			calls: []string{"attach_volume"},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			env := newMigrationTestEnv(t)
+			defer env.Teardown()
+			env.targetName = "web-hel1-dc2"
+			env.existingVolume = tc.existing
+			env.volumeServer = tc.server
+
+			migration := env.Client.Server.ResumeMigration(ServerMigrationState{
+				SourceServerID: 1,
+				TargetServerID: 2,
+				SnapshotID:     4,
+				Volumes:        tc.state,
+				Completed: []ServerMigrationStep{
+					ServerMigrationStepSnapshot,
+					ServerMigrationStepCreateServer,
+				},
+			})
+			err := migration.RunUntil(context.Background(), ServerMigrationStepReverseDNS)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(migration.State.Volumes) != 0 || len(env.calls) != 0 {
+					t.Errorf("unexpected volumes %v, calls %v", migration.State.Volumes, env.calls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if migration.State.Volumes[3] != 5 {
+				t.Errorf("unexpected volumes: %v", migration.State.Volumes)
+			}
+			if !equalStrings(env.calls, tc.calls) {
+				t.Errorf("unexpected calls: %v", env.calls)
+			}
+			if env.volumeServer == nil || *env.volumeServer != 2 {
+				t.Errorf("volume is not attached to the target server")
+			}
+		})
+	}
+}
+
+func TestServerMigrationAdoptTarget(t *testing.T) {
+	testCases := map[string]struct {
+		existing schema.Server
+		adopted  bool
+	}{
+		"created by migration": {
+			existing: schema.Server{ID: 2, Name: "web-hel1-dc2", Labels: map[string]string{ServerMigrationLabel: "1"}},
+			adopted:  true,
+		},
+		"unrelated server": {
+			existing: schema.Server{ID: 2, Name: "web-hel1-dc2"},
+		},
+		"other migration": {
+			existing: schema.Server{ID: 2, Name: "web-hel1-dc2", Labels: map[string]string{ServerMigrationLabel: "9"}},
+		},
+		"source server": {
+			existing: schema.Server{ID: 1, Name: "web-hel1-dc2", Labels: map[string]string{ServerMigrationLabel: "1"}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			env := newMigrationTestEnv(t)
+			defer env.Teardown()
+			env.existing = &tc.existing
+
+			migration := env.Client.Server.ResumeMigration(ServerMigrationState{
+				SourceServerID:   1,
+				SourceServerName: "web",
+				TargetName:       "web-hel1-dc2",
+				TargetDatacenter: 2,
+				SnapshotID:       4,
+				Completed:        []ServerMigrationStep{ServerMigrationStepSnapshot},
+			})
+			err := migration.RunUntil(context.Background(), ServerMigrationStepVolumes)
+			if tc.adopted {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if migration.State.TargetServerID != 2 || len(env.calls) != 0 {
+					t.Errorf("unexpected target server %d, calls %v", migration.State.TargetServerID, env.calls)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if migration.State.TargetServerID != 0 || len(env.calls) != 0 {
+				t.Errorf("unexpected target server %d, calls %v", migration.State.TargetServerID, env.calls)
+			}
+		})
+	}
+}
+
+func TestServerMigrationCutoverSourceDeleted(t *testing.T) {
+	env := newMigrationTestEnv(t)
+	defer env.Teardown()
+	env.sourceDeleted = true
+
+	migration := env.Client.Server.ResumeMigration(ServerMigrationState{
+		SourceServerID: 1,
+		TargetServerID: 2,
+		FloatingIPs:    []int{7},
+		Completed: []ServerMigrationStep{
+			ServerMigrationStepSnapshot,
+			ServerMigrationStepCreateServer,
+			ServerMigrationStepVolumes,
+			ServerMigrationStepReverseDNS,
+		},
+	})
+	if err := migration.RunUntil(context.Background(), ServerMigrationStepDeleteSource); err != nil {
+		t.Fatal(err)
+	}
+	if !equalStrings(env.calls, []string{"assign_floating_ip"}) {
+		t.Errorf("unexpected calls: %v", env.calls)
+	}
+}
+
+func TestTranslateIPv6(t *testing.T) {
+	_, network, _ := net.ParseCIDR("2001:db8:2::/64")
+	ip := translateIPv6(net.ParseIP("2001:db8:1::abcd"), network)
+	if ip.String() != "2001:db8:2::abcd" {
+		t.Errorf("unexpected IP: %s", ip)
+	}
+	if translateIPv6(net.ParseIP("1.2.3.4"), network) != nil {
+		t.Error("expected nil for IPv4 address")
+	}
+}
@@ -0,0 +1,144 @@
+package hcloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// rescueCleanupTimeout bounds the time spent disabling rescue mode and
+// rebooting a server when cleaning up a rescue session. Cleanup does not use
+// the caller's context, as it has often expired or been cancelled by then.
+const rescueCleanupTimeout = 5 * time.Minute
+
+// RescueSession represents a server which has been booted into rescue mode.
+// A session must be closed by calling Close, which disables rescue mode and
+// reboots the server into its regular system.
+type RescueSession struct {
+	Server       *Server
+	Type         ServerRescueType
+	RootPassword string
+
+	client   *Client
+	mu       sync.Mutex
+	disabled bool
+	closed   bool
+}
+
+// StartRescue enables rescue mode for a server and boots it into the rescue
+// system. A running server is reset, a server which is off is powered on. The
+// call returns once both actions have completed.
+//
+// If the server cannot be booted into the rescue system, rescue mode is disabled
+// again before the error is returned.
+func (c *ServerClient) StartRescue(ctx context.Context, server *Server, opts ServerEnableRescueOpts) (*RescueSession, error) {
+	if opts.Type == "" {
+		opts.Type = ServerRescueTypeLinux64
+	}
+
+	current, _, err := c.GetByID(ctx, server.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("hcloud: server %d not found", server.ID)
+	}
+
+	result, _, err := c.EnableRescue(ctx, current, opts)
+	if err != nil {
+		return nil, err
+	}
+	session := &RescueSession{
+		Server:       current,
+		Type:         opts.Type,
+		RootPassword: result.RootPassword,
+		client:       c.client,
+	}
+	if err := c.client.Action.waitFor(ctx, result.Action); err != nil {
+		session.abort()
+		return nil, err
+	}
+
+	var action *Action
+	if current.Status == ServerStatusOff {
+		action, _, err = c.Poweron(ctx, current)
+	} else {
+		action, _, err = c.Reset(ctx, current)
+	}
+	if err == nil {
+		err = c.client.Action.waitFor(ctx, action)
+	}
+	if err != nil {
+		session.abort()
+		return nil, err
+	}
+	return session, nil
+}
+
+// WithRescue boots a server into the rescue system, calls fn with the session and
+// closes the session afterwards, regardless of whether fn succeeded or panicked.
+// The session is closed with its own context, so it is also closed when ctx has
+// been cancelled. The error returned by fn takes precedence over an error
+// returned when closing the session.
+func (c *ServerClient) WithRescue(ctx context.Context, server *Server, opts ServerEnableRescueOpts, fn func(*RescueSession) error) (err error) {
+	session, err := c.StartRescue(ctx, server, opts)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), rescueCleanupTimeout)
+		defer cancel()
+		if closeErr := session.Close(cleanupCtx); err == nil {
+			err = closeErr
+		}
+	}()
+	return fn(session)
+}
+
+// Close disables rescue mode and reboots the server into its regular system.
+// Close waits for both actions to complete. Calling Close on a session which has
+// already been closed successfully is a no-op, a failed Close may be retried.
+func (s *RescueSession) Close(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	if !s.disabled {
+		action, _, err := s.client.Server.DisableRescue(ctx, s.Server)
+		if err != nil {
+			return err
+		}
+		if err := s.client.Action.waitFor(ctx, action); err != nil {
+			return err
+		}
+		s.disabled = true
+	}
+
+	action, _, err := s.client.Server.Reboot(ctx, s.Server)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Action.waitFor(ctx, action); err != nil {
+		return err
+	}
+
+	s.closed = true
+	return nil
+}
+
+// abort disables rescue mode after a failed attempt to start the session.
+// Errors are ignored as the original error is reported to the caller.
+func (s *RescueSession) abort() {
+	ctx, cancel := context.WithTimeout(context.Background(), rescueCleanupTimeout)
+	defer cancel()
+
+	action, _, err := s.client.Server.DisableRescue(ctx, s.Server)
+	if err != nil {
+		return
+	}
+	s.client.Action.waitFor(ctx, action)
+}
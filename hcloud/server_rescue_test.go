@@ -0,0 +1,185 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func newRescueTestEnv(status string) (testEnv, *[]string) {
+	env := newTestEnv()
+	env.Client.pollInterval = time.Millisecond
+
+	var calls []string
+	env.Mux.HandleFunc("/servers/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ServerGetResponse{
+			Server: schema.Server{
+				ID:     1,
+				Status: status,
+			},
+		})
+	})
+	for i, command := range []string{"enable_rescue", "poweron", "reset", "disable_rescue", "reboot"} {
+		actionID := i + 1
+		command := command
+		env.Mux.HandleFunc("/servers/1/actions/"+command, func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, command)
+			json.NewEncoder(w).Encode(schema.ServerActionEnableRescueResponse{
+				Action: schema.Action{
+					ID:     actionID,
+					Status: "running",
+				},
+				RootPassword: "secret",
+			})
+		})
+	}
+	env.Mux.HandleFunc("/actions/", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.ActionGetResponse{
+			Action: schema.Action{
+				Status: "success",
+			},
+		})
+	})
+	return env, &calls
+}
+
+func TestServerClientStartRescue(t *testing.T) {
+	t.Run("running server", func(t *testing.T) {
+		env, calls := newRescueTestEnv("running")
+		defer env.Teardown()
+
+		ctx := context.Background()
+		session, err := env.Client.Server.StartRescue(ctx, &Server{ID: 1}, ServerEnableRescueOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if session.RootPassword != "secret" {
+			t.Errorf("unexpected root password: %s", session.RootPassword)
+		}
+		if session.Type != ServerRescueTypeLinux64 {
+			t.Errorf("unexpected rescue type: %s", session.Type)
+		}
+		if err := session.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if err := session.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []string{"enable_rescue", "reset", "disable_rescue", "reboot"}
+		if !equalStrings(*calls, expected) {
+			t.Errorf("unexpected calls: %v", *calls)
+		}
+	})
+
+	t.Run("stopped server", func(t *testing.T) {
+		env, calls := newRescueTestEnv("off")
+		defer env.Teardown()
+
+		ctx := context.Background()
+		if _, err := env.Client.Server.StartRescue(ctx, &Server{ID: 1}, ServerEnableRescueOpts{}); err != nil {
+			t.Fatal(err)
+		}
+		expected := []string{"enable_rescue", "poweron"}
+		if !equalStrings(*calls, expected) {
+			t.Errorf("unexpected calls: %v", *calls)
+		}
+	})
+}
+
+func TestServerClientWithRescue(t *testing.T) {
+	env, calls := newRescueTestEnv("running")
+	defer env.Teardown()
+
+	ctx := context.Background()
+	fnErr := errors.New("fn failed")
+	err := env.Client.Server.WithRescue(ctx, &Server{ID: 1}, ServerEnableRescueOpts{}, func(session *RescueSession) error {
+		return fnErr
+	})
+	if err != fnErr {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"enable_rescue", "reset", "disable_rescue", "reboot"}
+	if !equalStrings(*calls, expected) {
+		t.Errorf("unexpected calls: %v", *calls)
+	}
+}
+
+func TestServerClientWithRescueCleanup(t *testing.T) {
+	expected := []string{"enable_rescue", "reset", "disable_rescue", "reboot"}
+
+	t.Run("panic", func(t *testing.T) {
+		env, calls := newRescueTestEnv("running")
+		defer env.Teardown()
+
+		func() {
+			defer func() {
+				if r := recover(); r != "fn panicked" {
+					t.Errorf("unexpected panic: %v", r)
+				}
+			}()
+			env.Client.Server.WithRescue(context.Background(), &Server{ID: 1}, ServerEnableRescueOpts{}, func(session *RescueSession) error {
+				panic("fn panicked")
+			})
+		}()
+		if !equalStrings(*calls, expected) {
+			t.Errorf("unexpected calls: %v", *calls)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		env, calls := newRescueTestEnv("running")
+		defer env.Teardown()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		err := env.Client.Server.WithRescue(ctx, &Server{ID: 1}, ServerEnableRescueOpts{}, func(session *RescueSession) error {
+			cancel()
+			return ctx.Err()
+		})
+		if err != context.Canceled {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !equalStrings(*calls, expected) {
+			t.Errorf("unexpected calls: %v", *calls)
+		}
+	})
+}
+
+func TestServerClientStartRescueCancelled(t *testing.T) {
+	env, calls := newRescueTestEnv("running")
+	defer env.Teardown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env.Mux.HandleFunc("/actions/3", func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		json.NewEncoder(w).Encode(schema.ActionGetResponse{
+			Action: schema.Action{ID: 3, Status: "running"},
+		})
+	})
+	if _, err := env.Client.Server.StartRescue(ctx, &Server{ID: 1}, ServerEnableRescueOpts{}); err == nil {
+		t.Fatal("expected an error")
+	}
+	expected := []string{"enable_rescue", "reset", "disable_rescue"}
+	if !equalStrings(*calls, expected) {
+		t.Errorf("unexpected calls: %v", *calls)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ServerRebuildStatus describes the outcome of rebuilding a single server as
+// part of a rolling rebuild.
+type ServerRebuildStatus string
+
+// List of rebuild outcomes.
+const (
+	// ServerRebuildStatusRebuilt is set when the server has been rebuilt and
+	// passed the health check.
+	ServerRebuildStatusRebuilt ServerRebuildStatus = "rebuilt"
+
+	// ServerRebuildStatusFailed is set when rebuilding the server failed.
+	ServerRebuildStatusFailed ServerRebuildStatus = "failed"
+
+	// ServerRebuildStatusUnhealthy is set when the server has been rebuilt but
+	// failed the health check.
+	ServerRebuildStatusUnhealthy ServerRebuildStatus = "unhealthy"
+
+	// ServerRebuildStatusProtected is set when the server has been skipped
+	// because it is protected against rebuilds.
+	ServerRebuildStatusProtected ServerRebuildStatus = "protected"
+
+	// ServerRebuildStatusPending is set when the server has not been rebuilt
+	// because the rolling rebuild was aborted.
+	ServerRebuildStatusPending ServerRebuildStatus = "pending"
+)
+
+// ServerRebuildOutcome is the outcome of rebuilding a single server.
+type ServerRebuildOutcome struct {
+	Server *Server
+	Status ServerRebuildStatus
+	Err    error
+}
+
+// ServerRollingRebuildOpts specifies options for rebuilding servers in batches.
+type ServerRollingRebuildOpts struct {
+	LabelSelector string // selects the servers to rebuild
+	Image         *Image
+
+	// BatchSize is the number of servers rebuilt at the same time. Defaults to 1.
+	BatchSize int
+
+	// MaxUnavailable is the maximum number of servers which may be unavailable
+	// at the same time, counting servers being rebuilt as well as servers which
+	// failed, including failures OnFailure let pass. Batches are shrunk
+	// accordingly, and once the failed servers alone reach MaxUnavailable, the
+	// rolling rebuild is aborted with ErrMaxUnavailable. If MaxUnavailable is 0,
+	// failed servers are not counted and only BatchSize limits the number of
+	// servers rebuilt at the same time.
+	MaxUnavailable int
+
+	// HealthCheck is called with the servers rebuilt in a batch before the next
+	// batch is started. An error marks the batch as unhealthy.
+	HealthCheck func(ctx context.Context, servers []*Server) error
+
+	// OnFailure is called when a batch contains failed or unhealthy servers. If it
+	// returns nil, the rolling rebuild continues with the next batch, unless
+	// MaxUnavailable has been reached, otherwise it is aborted with the returned
+	// error. OnFailure may block, for example to
+	// pause the rollout until an operator decides how to proceed. If OnFailure is
+	// nil, the rolling rebuild is aborted on the first failure.
+	OnFailure func(ctx context.Context, failed []ServerRebuildOutcome) error
+}
+
+// Validate checks if options are valid.
+func (o ServerRollingRebuildOpts) Validate() error {
+	if o.Image == nil || (o.Image.ID == 0 && o.Image.Name == "") {
+		return errors.New("missing image")
+	}
+	if o.BatchSize < 0 {
+		return errors.New("batch size must not be negative")
+	}
+	if o.MaxUnavailable < 0 {
+		return errors.New("max unavailable must not be negative")
+	}
+	return nil
+}
+
+// ServerRollingRebuildResult is the result of a rolling rebuild. It contains an
+// outcome for every server matching the label selector.
+type ServerRollingRebuildResult struct {
+	Outcomes []ServerRebuildOutcome
+}
+
+// ErrMaxUnavailable is returned by RollingRebuild when the number of failed
+// servers reached the maximum number of unavailable servers.
+var ErrMaxUnavailable = errors.New("hcloud: maximum number of unavailable servers reached")
+
+// RollingRebuild rebuilds all servers matching a label selector in batches.
+// Servers which are protected against rebuilds are skipped.
+func (c *ServerClient) RollingRebuild(ctx context.Context, opts ServerRollingRebuildOpts) (ServerRollingRebuildResult, error) {
+	if err := opts.Validate(); err != nil {
+		return ServerRollingRebuildResult{}, fmt.Errorf("invalid options: %s", err)
+	}
+	batchSize := opts.BatchSize
+	if batchSize == 0 {
+		batchSize = 1
+	}
+	servers, err := c.AllWithOpts(ctx, ServerListOpts{ListOpts: ListOpts{LabelSelector: opts.LabelSelector, PerPage: 50}})
+	if err != nil {
+		return ServerRollingRebuildResult{}, err
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].ID < servers[j].ID })
+
+	result := ServerRollingRebuildResult{}
+	var pending []int // indices into result.Outcomes
+	for _, server := range servers {
+		outcome := ServerRebuildOutcome{Server: server, Status: ServerRebuildStatusPending}
+		if server.Protection.Rebuild {
+			outcome.Status = ServerRebuildStatusProtected
+		} else {
+			pending = append(pending, len(result.Outcomes))
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+
+	unavailable := 0
+	for len(pending) > 0 {
+		size := batchSize
+		if opts.MaxUnavailable > 0 && size > opts.MaxUnavailable-unavailable {
+			size = opts.MaxUnavailable - unavailable
+		}
+		if size <= 0 {
+			return result, ErrMaxUnavailable
+		}
+		if size > len(pending) {
+			size = len(pending)
+		}
+		batch := pending[:size]
+		pending = pending[size:]
+
+		c.rebuildBatch(ctx, opts.Image, result.Outcomes, batch)
+
+		var rebuilt []*Server
+		for _, i := range batch {
+			if result.Outcomes[i].Status == ServerRebuildStatusRebuilt {
+				rebuilt = append(rebuilt, result.Outcomes[i].Server)
+			}
+		}
+		if opts.HealthCheck != nil && len(rebuilt) > 0 {
+			if err := opts.HealthCheck(ctx, rebuilt); err != nil {
+				for _, i := range batch {
+					if result.Outcomes[i].Status == ServerRebuildStatusRebuilt {
+						result.Outcomes[i].Status = ServerRebuildStatusUnhealthy
+						result.Outcomes[i].Err = err
+					}
+				}
+			}
+		}
+
+		var failed []ServerRebuildOutcome
+		for _, i := range batch {
+			if result.Outcomes[i].Status != ServerRebuildStatusRebuilt {
+				failed = append(failed, result.Outcomes[i])
+			}
+		}
+		if len(failed) == 0 {
+			continue
+		}
+		unavailable += len(failed)
+		if opts.OnFailure == nil {
+			return result, fmt.Errorf("hcloud: rebuilding server %d: %s", failed[0].Server.ID, failed[0].Err)
+		}
+		if err := opts.OnFailure(ctx, failed); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// rebuildBatch rebuilds the servers referenced by batch concurrently and
+// records the outcomes.
+func (c *ServerClient) rebuildBatch(ctx context.Context, image *Image, outcomes []ServerRebuildOutcome, batch []int) {
+	var wg sync.WaitGroup
+	for _, i := range batch {
+		wg.Add(1)
+		go func(outcome *ServerRebuildOutcome) {
+			defer wg.Done()
+			action, _, err := c.Rebuild(ctx, outcome.Server, ServerRebuildOpts{Image: image})
+			if err == nil {
+				err = c.client.Action.waitFor(ctx, action)
+			}
+			if err != nil {
+				outcome.Status = ServerRebuildStatusFailed
+				outcome.Err = err
+				return
+			}
+			outcome.Status = ServerRebuildStatusRebuilt
+		}(&outcomes[i])
+	}
+	wg.Wait()
+}
@@ -0,0 +1,192 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func newRollingRebuildTestEnv(t *testing.T, failing map[int]bool) (testEnv, func() []int) {
+	env := newTestEnv()
+	env.Client.pollInterval = time.Millisecond
+
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		if s := r.URL.Query().Get("label_selector"); s != "role=web" {
+			t.Errorf("unexpected label selector: %s", s)
+		}
+		servers := []schema.Server{{ID: 4}, {ID: 1}, {ID: 2}, {ID: 3}}
+		servers[1].Protection.Rebuild = true
+		json.NewEncoder(w).Encode(schema.ServerListResponse{Servers: servers})
+	})
+
+	var (
+		mu      sync.Mutex
+		rebuilt []int
+	)
+	env.Mux.HandleFunc("/servers/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/servers/%d/actions/rebuild", &id)
+		mu.Lock()
+		rebuilt = append(rebuilt, id)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(schema.ServerActionRebuildResponse{
+			Action: schema.Action{ID: id, Status: "running"},
+		})
+	})
+	env.Mux.HandleFunc("/actions/", func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/actions/%d", &id)
+		action := schema.Action{ID: id, Status: "success"}
+		if failing[id] {
+			action.Status = "error"
+			action.Error = &schema.ActionError{Code: "action_failed", Message: "rebuild failed"}
+		}
+		json.NewEncoder(w).Encode(schema.ActionGetResponse{Action: action})
+	})
+	return env, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int{}, rebuilt...)
+	}
+}
+
+func TestServerClientRollingRebuild(t *testing.T) {
+	t.Run("continue on failure", func(t *testing.T) {
+		env, rebuilt := newRollingRebuildTestEnv(t, map[int]bool{2: true})
+		defer env.Teardown()
+
+		var (
+			checked []int
+			failed  []ServerRebuildOutcome
+		)
+		result, err := env.Client.Server.RollingRebuild(context.Background(), ServerRollingRebuildOpts{
+			LabelSelector:  "role=web",
+			Image:          &Image{Name: "ubuntu-20.04"},
+			BatchSize:      2,
+			MaxUnavailable: 3,
+			HealthCheck: func(ctx context.Context, servers []*Server) error {
+				for _, server := range servers {
+					checked = append(checked, server.ID)
+				}
+				return nil
+			},
+			OnFailure: func(ctx context.Context, outcomes []ServerRebuildOutcome) error {
+				failed = append(failed, outcomes...)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rebuilt()) != 3 {
+			t.Errorf("unexpected rebuilt servers: %v", rebuilt())
+		}
+		if fmt.Sprint(checked) != "[3 4]" {
+			t.Errorf("unexpected health checked servers: %v", checked)
+		}
+		if len(failed) != 1 || failed[0].Server.ID != 2 {
+			t.Errorf("unexpected failures: %v", failed)
+		}
+
+		expected := map[int]ServerRebuildStatus{
+			1: ServerRebuildStatusProtected,
+			2: ServerRebuildStatusFailed,
+			3: ServerRebuildStatusRebuilt,
+			4: ServerRebuildStatusRebuilt,
+		}
+		if len(result.Outcomes) != len(expected) {
+			t.Fatalf("unexpected outcomes: %v", result.Outcomes)
+		}
+		for _, outcome := range result.Outcomes {
+			if outcome.Status != expected[outcome.Server.ID] {
+				t.Errorf("unexpected status of server %d: %s", outcome.Server.ID, outcome.Status)
+			}
+		}
+	})
+
+	t.Run("abort on unhealthy batch", func(t *testing.T) {
+		env, rebuilt := newRollingRebuildTestEnv(t, nil)
+		defer env.Teardown()
+
+		checkErr := errors.New("service down")
+		result, err := env.Client.Server.RollingRebuild(context.Background(), ServerRollingRebuildOpts{
+			LabelSelector: "role=web",
+			Image:         &Image{ID: 1},
+			HealthCheck: func(ctx context.Context, servers []*Server) error {
+				return checkErr
+			},
+		})
+		if err == nil || !strings.Contains(err.Error(), checkErr.Error()) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(rebuilt()) != "[2]" {
+			t.Errorf("unexpected rebuilt servers: %v", rebuilt())
+		}
+		statuses := map[int]ServerRebuildStatus{}
+		for _, outcome := range result.Outcomes {
+			statuses[outcome.Server.ID] = outcome.Status
+		}
+		if statuses[2] != ServerRebuildStatusUnhealthy || statuses[3] != ServerRebuildStatusPending {
+			t.Errorf("unexpected outcomes: %v", statuses)
+		}
+	})
+
+	t.Run("continue on failure with default options", func(t *testing.T) {
+		env, rebuilt := newRollingRebuildTestEnv(t, map[int]bool{2: true, 3: true})
+		defer env.Teardown()
+
+		calls := 0
+		result, err := env.Client.Server.RollingRebuild(context.Background(), ServerRollingRebuildOpts{
+			LabelSelector: "role=web",
+			Image:         &Image{ID: 1},
+			OnFailure: func(ctx context.Context, outcomes []ServerRebuildOutcome) error {
+				calls++
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(rebuilt()) != "[2 3 4]" {
+			t.Errorf("unexpected rebuilt servers: %v", rebuilt())
+		}
+		if calls != 2 {
+			t.Errorf("expected OnFailure to be called twice, got %d", calls)
+		}
+		statuses := map[int]ServerRebuildStatus{}
+		for _, outcome := range result.Outcomes {
+			statuses[outcome.Server.ID] = outcome.Status
+		}
+		if statuses[2] != ServerRebuildStatusFailed || statuses[3] != ServerRebuildStatusFailed || statuses[4] != ServerRebuildStatusRebuilt {
+			t.Errorf("unexpected outcomes: %v", statuses)
+		}
+	})
+
+	t.Run("max unavailable", func(t *testing.T) {
+		env, rebuilt := newRollingRebuildTestEnv(t, map[int]bool{2: true})
+		defer env.Teardown()
+
+		_, err := env.Client.Server.RollingRebuild(context.Background(), ServerRollingRebuildOpts{
+			LabelSelector:  "role=web",
+			Image:          &Image{ID: 1},
+			MaxUnavailable: 1,
+			OnFailure: func(ctx context.Context, outcomes []ServerRebuildOutcome) error {
+				return nil
+			},
+		})
+		if err != ErrMaxUnavailable {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if fmt.Sprint(rebuilt()) != "[2]" {
+			t.Errorf("unexpected rebuilt servers: %v", rebuilt())
+		}
+	})
+}
@@ -442,6 +442,165 @@ func TestServersCreateWithNetworks(t *testing.T) {
 	}
 }
 
+func TestServersCreateWithPlacementGroup(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.PlacementGroupListResponse{
+			PlacementGroups: []schema.PlacementGroup{{ID: 3, Name: "mygroup", Type: "spread", Servers: []int{4, 5}}},
+		})
+	})
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.ServerCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.PlacementGroup != 3 {
+			t.Errorf("unexpected PlacementGroup: %v", reqBody.PlacementGroup)
+		}
+		json.NewEncoder(w).Encode(schema.ServerCreateResponse{
+			Server: schema.Server{
+				ID:             1,
+				PlacementGroup: &schema.PlacementGroup{ID: 3},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.Server.Create(ctx, ServerCreateOpts{
+		Name:           "test",
+		ServerType:     &ServerType{ID: 1},
+		Image:          &Image{ID: 2},
+		PlacementGroup: &PlacementGroup{Name: "mygroup"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Server.PlacementGroup == nil || result.Server.PlacementGroup.ID != 3 {
+		t.Errorf("unexpected placement group: %v", result.Server.PlacementGroup)
+	}
+}
+
+func TestServersCreateWithFullPlacementGroup(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/placement_groups/3", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.PlacementGroupGetResponse{
+			PlacementGroup: schema.PlacementGroup{ID: 3, Type: "spread", Servers: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		})
+	})
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected server creation")
+	})
+
+	ctx := context.Background()
+	_, _, err := env.Client.Server.Create(ctx, ServerCreateOpts{
+		Name:           "test",
+		ServerType:     &ServerType{ID: 1},
+		Image:          &Image{ID: 2},
+		PlacementGroup: &PlacementGroup{ID: 3},
+	})
+	if err != ErrPlacementGroupFull {
+		t.Fatalf("expected ErrPlacementGroupFull, got %v", err)
+	}
+}
+
+func TestServersCreateWithPublicNet(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.ServerCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.PublicNet == nil {
+			t.Fatal("missing PublicNet")
+		}
+		if !reqBody.PublicNet.EnableIPv4 || reqBody.PublicNet.EnableIPv6 || reqBody.PublicNet.IPv4ID != 3 || reqBody.PublicNet.IPv6ID != 0 {
+			t.Errorf("unexpected PublicNet: %v", reqBody.PublicNet)
+		}
+		json.NewEncoder(w).Encode(schema.ServerCreateResponse{
+			Server: schema.Server{
+				ID: 1,
+				PublicNet: schema.ServerPublicNet{
+					IPv4: schema.ServerPublicNetIPv4{ID: 3, IP: "131.232.99.1"},
+				},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	result, _, err := env.Client.Server.Create(ctx, ServerCreateOpts{
+		Name:       "test",
+		ServerType: &ServerType{ID: 1},
+		Image:      &Image{ID: 2},
+		PublicNet: &ServerCreatePublicNet{
+			EnableIPv4: true,
+			IPv4:       &PrimaryIP{ID: 3},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Server.PublicNet.IPv4.ID != 3 || result.Server.PublicNet.IPv6.ID != 0 {
+		t.Errorf("unexpected public net: %v", result.Server.PublicNet)
+	}
+}
+
+func TestServerCreatePublicNetValidate(t *testing.T) {
+	testCases := map[string]struct {
+		Opts  ServerCreateOpts
+		Valid bool
+	}{
+		"only IPv6": {
+			Opts: ServerCreateOpts{
+				PublicNet: &ServerCreatePublicNet{EnableIPv6: true},
+			},
+			Valid: true,
+		},
+		"no public net with network": {
+			Opts: ServerCreateOpts{
+				PublicNet: &ServerCreatePublicNet{},
+				Networks:  []*Network{{ID: 1}},
+			},
+			Valid: true,
+		},
+		"no public net without network": {
+			Opts: ServerCreateOpts{
+				PublicNet: &ServerCreatePublicNet{},
+			},
+		},
+		"primary IP for disabled protocol": {
+			Opts: ServerCreateOpts{
+				PublicNet: &ServerCreatePublicNet{EnableIPv6: true, IPv4: &PrimaryIP{ID: 1}},
+			},
+		},
+		"primary IP of wrong type": {
+			Opts: ServerCreateOpts{
+				PublicNet: &ServerCreatePublicNet{EnableIPv4: true, IPv4: &PrimaryIP{ID: 1, Type: PrimaryIPTypeIPv6}},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			opts := testCase.Opts
+			opts.Name = "test"
+			opts.ServerType = &ServerType{ID: 1}
+			opts.Image = &Image{ID: 1}
+			err := opts.Validate()
+			if testCase.Valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.Valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
 func TestServersCreateWithDatacenterID(t *testing.T) {
 	env := newTestEnv()
 	defer env.Teardown()
@@ -1616,6 +1775,45 @@ func TestServerClientAttachToNetwork(t *testing.T) {
 	})
 }
 
+func TestServerClientAttachToNetworkZoneMismatch(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers/1/actions/attach_to_network", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	var (
+		ctx    = context.Background()
+		server = &Server{
+			ID: 1,
+			Datacenter: &Datacenter{
+				Location: &Location{Name: "ash", NetworkZone: NetworkZoneUSEast},
+			},
+		}
+		opts = ServerAttachToNetworkOpts{
+			Network: &Network{
+				ID: 1,
+				Subnets: []NetworkSubnet{
+					{Type: NetworkSubnetTypeCloud, NetworkZone: NetworkZoneEUCentral},
+				},
+			},
+		}
+	)
+	if _, _, err := env.Client.Server.AttachToNetwork(ctx, server, opts); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	opts.Network.Subnets = append(opts.Network.Subnets, NetworkSubnet{Type: NetworkSubnetTypeCloud, NetworkZone: NetworkZoneUSEast})
+	if err := validateNetworkZone(server, opts.Network); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if err := (ServerAttachToNetworkOpts{}).Validate(); err == nil {
+		t.Error("expected an error for missing network")
+	}
+}
+
 func TestServerClientDetachFromNetwork(t *testing.T) {
 	var (
 		ctx    = context.Background()
@@ -1702,3 +1900,116 @@ func TestServerClientChangeAliasIP(t *testing.T) {
 		t.Errorf("unexpected action ID: %v", action.ID)
 	}
 }
+
+func TestServerClientAddToPlacementGroup(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = &Server{ID: 1}
+	)
+
+	t.Run("success", func(t *testing.T) {
+		env := newTestEnv()
+		defer env.Teardown()
+
+		env.Mux.HandleFunc("/placement_groups/3", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(schema.PlacementGroupGetResponse{
+				PlacementGroup: schema.PlacementGroup{ID: 3, Type: "spread", Servers: []int{2}},
+			})
+		})
+		env.Mux.HandleFunc("/servers/1/actions/add_to_placement_group", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" {
+				t.Error("expected POST")
+			}
+			var reqBody schema.ServerActionAddToPlacementGroupRequest
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Fatal(err)
+			}
+			if reqBody.PlacementGroup != 3 {
+				t.Errorf("unexpected PlacementGroup: %v", reqBody.PlacementGroup)
+			}
+			json.NewEncoder(w).Encode(schema.ServerActionAddToPlacementGroupResponse{
+				Action: schema.Action{
+					ID: 1,
+				},
+			})
+		})
+
+		action, _, err := env.Client.Server.AddToPlacementGroup(ctx, server, &PlacementGroup{ID: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if action.ID != 1 {
+			t.Errorf("unexpected action ID: %v", action.ID)
+		}
+	})
+
+	t.Run("full", func(t *testing.T) {
+		env := newTestEnv()
+		defer env.Teardown()
+
+		env.Mux.HandleFunc("/placement_groups/3", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(schema.PlacementGroupGetResponse{
+				PlacementGroup: schema.PlacementGroup{ID: 3, Type: "spread", Servers: []int{2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+			})
+		})
+		env.Mux.HandleFunc("/servers/1/actions/add_to_placement_group", func(w http.ResponseWriter, r *http.Request) {
+			t.Error("unexpected action")
+		})
+
+		_, _, err := env.Client.Server.AddToPlacementGroup(ctx, server, &PlacementGroup{ID: 3})
+		if err != ErrPlacementGroupFull {
+			t.Fatalf("expected ErrPlacementGroupFull, got %v", err)
+		}
+	})
+
+	t.Run("full but already member", func(t *testing.T) {
+		env := newTestEnv()
+		defer env.Teardown()
+
+		env.Mux.HandleFunc("/placement_groups/3", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(schema.PlacementGroupGetResponse{
+				PlacementGroup: schema.PlacementGroup{ID: 3, Type: "spread", Servers: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+			})
+		})
+		env.Mux.HandleFunc("/servers/1/actions/add_to_placement_group", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(schema.ServerActionAddToPlacementGroupResponse{
+				Action: schema.Action{
+					ID: 1,
+				},
+			})
+		})
+
+		if _, _, err := env.Client.Server.AddToPlacementGroup(ctx, server, &PlacementGroup{ID: 3}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestServerClientRemoveFromPlacementGroup(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = &Server{ID: 1}
+	)
+
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Mux.HandleFunc("/servers/1/actions/remove_from_placement_group", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Error("expected POST")
+		}
+		json.NewEncoder(w).Encode(schema.ServerActionRemoveFromPlacementGroupResponse{
+			Action: schema.Action{
+				ID: 1,
+			},
+		})
+	})
+
+	action, _, err := env.Client.Server.RemoveFromPlacementGroup(ctx, server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.ID != 1 {
+		t.Errorf("unexpected action ID: %v", action.ID)
+	}
+}
@@ -0,0 +1,226 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Labels set on snapshots created by a SnapshotRetention.
+const (
+	SnapshotRetentionLabelManaged = "hcloud-go/retention" // always "true"
+	SnapshotRetentionLabelServer  = "hcloud-go/server"    // ID of the server the snapshot was created from
+	SnapshotRetentionLabelCreated = "hcloud-go/created"   // Unix timestamp of the snapshot's creation
+)
+
+// SnapshotRetentionPolicy specifies how many snapshots are kept using a
+// grandfather-father-son scheme. For each period, the newest snapshot of
+// the given number of most recent hours, days, weeks, or months is kept.
+// A snapshot is kept if at least one period selects it.
+type SnapshotRetentionPolicy struct {
+	Hourly  int
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// Validate checks if the policy is valid.
+func (p SnapshotRetentionPolicy) Validate() error {
+	if p.Hourly < 0 || p.Daily < 0 || p.Weekly < 0 || p.Monthly < 0 {
+		return errors.New("negative retention count")
+	}
+	if p.Hourly+p.Daily+p.Weekly+p.Monthly == 0 {
+		return errors.New("policy does not keep any snapshot")
+	}
+	return nil
+}
+
+// SnapshotRetention creates labeled snapshots of servers and deletes snapshots
+// which are no longer covered by its policy. Only snapshots created by a
+// SnapshotRetention are ever considered for deletion.
+type SnapshotRetention struct {
+	Policy SnapshotRetentionPolicy
+
+	client *Client
+	now    func() time.Time
+}
+
+// NewSnapshotRetention creates a new snapshot retention engine.
+func NewSnapshotRetention(client *Client, policy SnapshotRetentionPolicy) *SnapshotRetention {
+	return &SnapshotRetention{
+		Policy: policy,
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// CreateSnapshot creates a snapshot of a server and labels it, so it is managed
+// by the retention engine. Labels and description passed in opts are preserved.
+func (r *SnapshotRetention) CreateSnapshot(ctx context.Context, server *Server, opts *ServerCreateImageOpts) (ServerCreateImageResult, *Response, error) {
+	createOpts := ServerCreateImageOpts{}
+	if opts != nil {
+		createOpts = *opts
+	}
+	createOpts.Type = ImageTypeSnapshot
+	labels := map[string]string{}
+	for key, value := range createOpts.Labels {
+		labels[key] = value
+	}
+	labels[SnapshotRetentionLabelManaged] = "true"
+	labels[SnapshotRetentionLabelServer] = strconv.Itoa(server.ID)
+	labels[SnapshotRetentionLabelCreated] = strconv.FormatInt(r.now().Unix(), 10)
+	createOpts.Labels = labels
+
+	return r.client.Server.CreateImage(ctx, server, &createOpts)
+}
+
+// SnapshotRetentionPlanOpts specifies which snapshots a plan covers. If neither
+// Server nor LabelSelector is set, all managed snapshots are covered. The policy
+// is always applied per server.
+type SnapshotRetentionPlanOpts struct {
+	Server        *Server
+	LabelSelector string
+}
+
+// SnapshotRetentionDecision describes what happens to a single snapshot.
+type SnapshotRetentionDecision struct {
+	Image  *Image
+	Reason string
+}
+
+// SnapshotRetentionPlan is the result of evaluating the policy. It lists the
+// snapshots which are kept and those which would be deleted by Apply.
+type SnapshotRetentionPlan struct {
+	Keep   []SnapshotRetentionDecision
+	Delete []SnapshotRetentionDecision
+}
+
+// Plan evaluates the retention policy without deleting anything.
+func (r *SnapshotRetention) Plan(ctx context.Context, opts SnapshotRetentionPlanOpts) (*SnapshotRetentionPlan, error) {
+	if err := r.Policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid options: %s", err)
+	}
+
+	selector := SnapshotRetentionLabelManaged + "=true"
+	if opts.Server != nil {
+		selector += "," + SnapshotRetentionLabelServer + "=" + strconv.Itoa(opts.Server.ID)
+	}
+	if opts.LabelSelector != "" {
+		selector += "," + opts.LabelSelector
+	}
+	images, err := r.client.Image.AllWithOpts(ctx, ImageListOpts{
+		ListOpts: ListOpts{LabelSelector: selector, PerPage: 50},
+		Type:     []ImageType{ImageTypeSnapshot},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byServer := map[string][]*Image{}
+	var serverIDs []string
+	for _, image := range images {
+		id := image.Labels[SnapshotRetentionLabelServer]
+		if _, ok := byServer[id]; !ok {
+			serverIDs = append(serverIDs, id)
+		}
+		byServer[id] = append(byServer[id], image)
+	}
+	sort.Strings(serverIDs)
+
+	plan := &SnapshotRetentionPlan{}
+	for _, id := range serverIDs {
+		r.planServer(plan, byServer[id])
+	}
+	return plan, nil
+}
+
+func (r *SnapshotRetention) planServer(plan *SnapshotRetentionPlan, images []*Image) {
+	sort.SliceStable(images, func(i, j int) bool {
+		return snapshotCreated(images[i]).After(snapshotCreated(images[j]))
+	})
+
+	reasons := map[*Image]string{}
+	periods := []struct {
+		name   string
+		count  int
+		bucket func(time.Time) string
+	}{
+		{"hourly", r.Policy.Hourly, func(t time.Time) string { return t.Format("2006-01-02T15") }},
+		{"daily", r.Policy.Daily, func(t time.Time) string { return t.Format("2006-01-02") }},
+		{"weekly", r.Policy.Weekly, func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-W%02d", year, week)
+		}},
+		{"monthly", r.Policy.Monthly, func(t time.Time) string { return t.Format("2006-01") }},
+	}
+	for _, period := range periods {
+		seen := map[string]bool{}
+		for _, image := range images {
+			if len(seen) == period.count {
+				break
+			}
+			bucket := period.bucket(snapshotCreated(image).UTC())
+			if seen[bucket] {
+				continue
+			}
+			seen[bucket] = true
+			if _, ok := reasons[image]; !ok {
+				reasons[image] = fmt.Sprintf("%s %s", period.name, bucket)
+			}
+		}
+	}
+
+	for _, image := range images {
+		if reason, ok := reasons[image]; ok {
+			plan.Keep = append(plan.Keep, SnapshotRetentionDecision{Image: image, Reason: reason})
+			continue
+		}
+		if reason := snapshotDeletionBlocker(image); reason != "" {
+			plan.Keep = append(plan.Keep, SnapshotRetentionDecision{Image: image, Reason: reason})
+			continue
+		}
+		plan.Delete = append(plan.Delete, SnapshotRetentionDecision{Image: image, Reason: "not covered by policy"})
+	}
+}
+
+// Apply deletes the snapshots listed in the plan's Delete decisions. Snapshots
+// which are protected or bound to a server are never deleted, even if the plan
+// lists them. Apply stops at the first error.
+func (r *SnapshotRetention) Apply(ctx context.Context, plan *SnapshotRetentionPlan) error {
+	for _, decision := range plan.Delete {
+		if reason := snapshotDeletionBlocker(decision.Image); reason != "" {
+			continue
+		}
+		if _, err := r.client.Image.Delete(ctx, decision.Image); err != nil {
+			return fmt.Errorf("hcloud: deleting image %d: %s", decision.Image.ID, err)
+		}
+	}
+	return nil
+}
+
+// snapshotDeletionBlocker returns why an image must not be deleted or an empty
+// string if it may be deleted.
+func snapshotDeletionBlocker(image *Image) string {
+	switch {
+	case image.Protection.Delete:
+		return "protected"
+	case image.BoundTo != nil:
+		return "bound to server"
+	case image.Type != ImageTypeSnapshot:
+		return "not a snapshot"
+	default:
+		return ""
+	}
+}
+
+// snapshotCreated returns the creation time recorded in the image's labels,
+// falling back to the image's creation time.
+func snapshotCreated(image *Image) time.Time {
+	if ts, err := strconv.ParseInt(image.Labels[SnapshotRetentionLabelCreated], 10, 64); err == nil {
+		return time.Unix(ts, 0)
+	}
+	return image.Created
+}
@@ -0,0 +1,163 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ptr1120/hcloud-go/hcloud/schema"
+)
+
+func TestSnapshotRetentionCreateSnapshot(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	env.Mux.HandleFunc("/servers/1/actions/create_image", func(w http.ResponseWriter, r *http.Request) {
+		var reqBody schema.ServerActionCreateImageRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatal(err)
+		}
+		if reqBody.Type == nil || *reqBody.Type != "snapshot" {
+			t.Errorf("unexpected type: %v", reqBody.Type)
+		}
+		expectedLabels := map[string]string{
+			"env":                         "prod",
+			SnapshotRetentionLabelManaged: "true",
+			SnapshotRetentionLabelServer:  "1",
+			SnapshotRetentionLabelCreated: strconv.FormatInt(now.Unix(), 10),
+		}
+		if reqBody.Labels == nil || len(*reqBody.Labels) != len(expectedLabels) {
+			t.Fatalf("unexpected labels: %v", reqBody.Labels)
+		}
+		for key, value := range expectedLabels {
+			if (*reqBody.Labels)[key] != value {
+				t.Errorf("unexpected label %s: %v", key, (*reqBody.Labels)[key])
+			}
+		}
+		json.NewEncoder(w).Encode(schema.ServerActionCreateImageResponse{
+			Action: schema.Action{ID: 1},
+			Image:  schema.Image{ID: 1},
+		})
+	})
+
+	retention := NewSnapshotRetention(env.Client, SnapshotRetentionPolicy{Daily: 7})
+	retention.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	opts := &ServerCreateImageOpts{Labels: map[string]string{"env": "prod"}}
+	result, _, err := retention.CreateSnapshot(ctx, &Server{ID: 1}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Image.ID != 1 {
+		t.Errorf("unexpected image ID: %d", result.Image.ID)
+	}
+	if len(opts.Labels) != 1 {
+		t.Errorf("options were modified: %v", opts.Labels)
+	}
+}
+
+func TestSnapshotRetentionPlanAndApply(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	base := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	var images []schema.Image
+	// One snapshot every 12 hours over ten days, newest has ID 1.
+	for i := 1; i <= 20; i++ {
+		created := base.Add(-time.Duration(i-1) * 12 * time.Hour)
+		images = append(images, schema.Image{
+			ID:   i,
+			Type: "snapshot",
+			Labels: map[string]string{
+				SnapshotRetentionLabelManaged: "true",
+				SnapshotRetentionLabelServer:  "1",
+				SnapshotRetentionLabelCreated: strconv.FormatInt(created.Unix(), 10),
+			},
+		})
+	}
+	images[15].Protection.Delete = true
+	images[16].BoundTo = Int(1)
+
+	env.Mux.HandleFunc("/images", func(w http.ResponseWriter, r *http.Request) {
+		if s := r.URL.Query().Get("label_selector"); s != "hcloud-go/retention=true,hcloud-go/server=1" {
+			t.Errorf("unexpected label selector: %s", s)
+		}
+		if typ := r.URL.Query().Get("type"); typ != "snapshot" {
+			t.Errorf("unexpected type: %s", typ)
+		}
+		json.NewEncoder(w).Encode(schema.ImageListResponse{Images: images})
+	})
+	var deleted []int
+	env.Mux.HandleFunc("/images/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("unexpected method: %s", r.Method)
+		}
+		var id int
+		fmt.Sscanf(r.URL.Path, "/images/%d", &id)
+		deleted = append(deleted, id)
+	})
+
+	retention := NewSnapshotRetention(env.Client, SnapshotRetentionPolicy{Hourly: 1, Daily: 3, Weekly: 2})
+
+	ctx := context.Background()
+	plan, err := retention.Plan(ctx, SnapshotRetentionPlanOpts{Server: &Server{ID: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keep []int
+	for _, decision := range plan.Keep {
+		keep = append(keep, decision.Image.ID)
+	}
+	sort.Ints(keep)
+	// ID 1 is the newest (hourly, daily), 2 and 4 are the newest of their days,
+	// 10 (2020-04-26) is the newest of the previous ISO week, 16 and 17 cannot
+	// be deleted.
+	expectedKeep := []int{1, 2, 4, 10, 16, 17}
+	if fmt.Sprint(keep) != fmt.Sprint(expectedKeep) {
+		t.Errorf("unexpected kept images: %v", keep)
+	}
+	if len(plan.Keep)+len(plan.Delete) != len(images) {
+		t.Errorf("unexpected number of decisions: %d", len(plan.Keep)+len(plan.Delete))
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("plan deleted images: %v", deleted)
+	}
+
+	if err := retention.Apply(ctx, plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != len(plan.Delete) {
+		t.Errorf("unexpected deleted images: %v", deleted)
+	}
+	for _, id := range deleted {
+		if id == 16 || id == 17 {
+			t.Errorf("deleted image %d", id)
+		}
+	}
+}
+
+func TestSnapshotRetentionPolicyValidate(t *testing.T) {
+	if err := (SnapshotRetentionPolicy{}).Validate(); err == nil {
+		t.Error("expected error for empty policy")
+	}
+	if err := (SnapshotRetentionPolicy{Daily: -1, Weekly: 2}).Validate(); err == nil {
+		t.Error("expected error for negative count")
+	}
+	if err := (SnapshotRetentionPolicy{Monthly: 12}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	retention := NewSnapshotRetention(NewClient(), SnapshotRetentionPolicy{})
+	_, err := retention.Plan(context.Background(), SnapshotRetentionPlanOpts{})
+	if err == nil || err.Error() != "invalid options: policy does not keep any snapshot" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
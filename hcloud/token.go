@@ -0,0 +1,211 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenEnv is the environment variable EnvToken reads the token from
+// when no other variable is given.
+const DefaultTokenEnv = "HCLOUD_TOKEN"
+
+// ErrNoToken is returned by a TokenProvider which has no token to offer.
+// ChainToken falls through to the next provider on this error.
+var ErrNoToken = errors.New("hcloud: no token available")
+
+// A TokenProvider provides the token a Client authenticates requests with.
+// It is consulted for every request, so it can rotate tokens at runtime.
+// Implementations must be safe for concurrent use.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenProviderFunc is an adapter to use an ordinary function as a
+// TokenProvider.
+type TokenProviderFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenProviderFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticTokenProvider is a TokenProvider which provides a fixed token until
+// it is replaced with SetToken.
+type StaticTokenProvider struct {
+	mu    sync.RWMutex
+	token string
+}
+
+// StaticToken returns a StaticTokenProvider providing token.
+func StaticToken(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{token: token}
+}
+
+// Token returns the current token or ErrNoToken if it is empty.
+func (p *StaticTokenProvider) Token(_ context.Context) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.token == "" {
+		return "", ErrNoToken
+	}
+	return p.token, nil
+}
+
+// SetToken replaces the token. Requests created afterwards use the new token.
+func (p *StaticTokenProvider) SetToken(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = token
+}
+
+// EnvToken returns a TokenProvider which reads the token from the environment
+// variable name on every call, or from DefaultTokenEnv if name is empty. It
+// returns ErrNoToken if the variable is unset or empty.
+func EnvToken(name string) TokenProvider {
+	if name == "" {
+		name = DefaultTokenEnv
+	}
+	return TokenProviderFunc(func(_ context.Context) (string, error) {
+		token := strings.TrimSpace(os.Getenv(name))
+		if token == "" {
+			return "", ErrNoToken
+		}
+		return token, nil
+	})
+}
+
+// fileTokenRacyWindow is the time after its modification during which a token
+// file is read again on every call. Within it, a rewrite of the file cannot be
+// detected reliably from the modification time, as file systems store it with
+// a granularity of up to two seconds, and tokens all have the same length.
+const fileTokenRacyWindow = 2 * time.Second
+
+// FileTokenProvider is a TokenProvider which reads the token from a file.
+// The file is read again whenever its modification time or size changes, and
+// on every call shortly after it has been modified, so the token can be
+// rotated by replacing or rewriting the file. Leading and trailing whitespace
+// is ignored.
+type FileTokenProvider struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+	readAt  time.Time
+}
+
+// FileToken returns a FileTokenProvider reading the token from path.
+func FileToken(path string) *FileTokenProvider {
+	return &FileTokenProvider{path: path}
+}
+
+// Token returns the token stored in the file. It returns ErrNoToken if the
+// file does not exist or is empty.
+func (p *FileTokenProvider) Token(_ context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if os.IsNotExist(err) {
+		return "", ErrNoToken
+	}
+	if err != nil {
+		return "", fmt.Errorf("hcloud: reading token file: %w", err)
+	}
+	if !info.ModTime().Equal(p.modTime) || info.Size() != p.size || p.readAt.Sub(p.modTime) < fileTokenRacyWindow {
+		readAt := time.Now()
+		data, err := ioutil.ReadFile(p.path)
+		if err != nil {
+			return "", fmt.Errorf("hcloud: reading token file: %w", err)
+		}
+		p.token = strings.TrimSpace(string(data))
+		p.modTime = info.ModTime()
+		p.size = info.Size()
+		p.readAt = readAt
+	}
+	if p.token == "" {
+		return "", ErrNoToken
+	}
+	return p.token, nil
+}
+
+// ChainToken returns a TokenProvider which consults providers in order and
+// returns the first token found. Providers returning ErrNoToken are skipped;
+// any other error is returned immediately. It returns ErrNoToken if no
+// provider has a token.
+func ChainToken(providers ...TokenProvider) TokenProvider {
+	return TokenProviderFunc(func(ctx context.Context) (string, error) {
+		for _, p := range providers {
+			token, err := p.Token(ctx)
+			if errors.Is(err, ErrNoToken) {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			return token, nil
+		}
+		return "", ErrNoToken
+	})
+}
+
+// authorize sets the Authorization header of a request against the Cloud API
+// to the token currently provided.
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	token, err := requestToken(ctx, c.tokenProvider)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return nil
+}
+
+// authorizeDNS sets the Auth-API-Token header of a request against the DNS
+// API to the token currently provided, falling back to the token of the Cloud
+// API.
+func (c *Client) authorizeDNS(ctx context.Context, req *http.Request) error {
+	tokenProvider := c.dnsTokenProvider
+	if tokenProvider == nil {
+		tokenProvider = c.tokenProvider
+	}
+	token, err := requestToken(ctx, tokenProvider)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Auth-API-Token", token)
+	return nil
+}
+
+// reauthorize updates the token of a request created by Client.NewRequest or
+// DNSServerClient.NewRequest before it is sent again.
+func (c *Client) reauthorize(req *http.Request) error {
+	if _, ok := req.Header[http.CanonicalHeaderKey("Auth-API-Token")]; ok {
+		return c.authorizeDNS(req.Context(), req)
+	}
+	if _, ok := req.Header["Authorization"]; ok {
+		return c.authorize(req.Context(), req)
+	}
+	return nil
+}
+
+// requestToken returns the token provided by p. Like a Client configured
+// without a token, it returns an empty token if p is nil or has no token, in
+// which case the API rejects the request as unauthenticated.
+func requestToken(ctx context.Context, p TokenProvider) (string, error) {
+	if p == nil {
+		return "", nil
+	}
+	token, err := p.Token(ctx)
+	if errors.Is(err, ErrNoToken) {
+		return "", nil
+	}
+	return token, err
+}
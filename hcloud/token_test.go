@@ -0,0 +1,275 @@
+package hcloud
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStaticToken(t *testing.T) {
+	p := StaticToken("old")
+	if token, err := p.Token(context.Background()); err != nil || token != "old" {
+		t.Fatalf("unexpected token %q, error %v", token, err)
+	}
+	p.SetToken("new")
+	if token, err := p.Token(context.Background()); err != nil || token != "new" {
+		t.Fatalf("unexpected token %q, error %v", token, err)
+	}
+	p.SetToken("")
+	if _, err := p.Token(context.Background()); err != ErrNoToken {
+		t.Fatalf("expected ErrNoToken, got %v", err)
+	}
+}
+
+func TestEnvToken(t *testing.T) {
+	const name = "HCLOUD_GO_TEST_TOKEN"
+	defer os.Unsetenv(name)
+
+	p := EnvToken(name)
+	if _, err := p.Token(context.Background()); err != ErrNoToken {
+		t.Fatalf("expected ErrNoToken, got %v", err)
+	}
+	os.Setenv(name, " token\n")
+	if token, err := p.Token(context.Background()); err != nil || token != "token" {
+		t.Fatalf("unexpected token %q, error %v", token, err)
+	}
+}
+
+func TestFileToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hcloud-go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+
+	p := FileToken(path)
+	if _, err := p.Token(context.Background()); err != ErrNoToken {
+		t.Fatalf("expected ErrNoToken for missing file, got %v", err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := p.Token(context.Background()); err != nil || token != "first" {
+		t.Fatalf("unexpected token %q, error %v", token, err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("second\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := p.Token(context.Background()); err != nil || token != "second" {
+		t.Fatalf("expected reloaded token, got %q, error %v", token, err)
+	}
+
+	// A rewrite with the same size is detected by the modification time.
+	if err := ioutil.WriteFile(path, []byte("third1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := p.Token(context.Background()); err != nil || token != "third1" {
+		t.Fatalf("expected reloaded token, got %q, error %v", token, err)
+	}
+
+	// A recently modified file is read again even if neither its size nor its
+	// modification time changed, as the latter may be too coarse to notice.
+	if err := ioutil.WriteFile(path, []byte("third2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := p.Token(context.Background()); err != nil || token != "third2" {
+		t.Fatalf("expected reloaded token, got %q, error %v", token, err)
+	}
+}
+
+func TestChainToken(t *testing.T) {
+	failure := errors.New("failure")
+	testCases := map[string]struct {
+		providers []TokenProvider
+		token     string
+		err       error
+	}{
+		"first": {
+			providers: []TokenProvider{StaticToken("a"), StaticToken("b")},
+			token:     "a",
+		},
+		"falls through": {
+			providers: []TokenProvider{StaticToken(""), StaticToken("b")},
+			token:     "b",
+		},
+		"error": {
+			providers: []TokenProvider{
+				StaticToken(""),
+				TokenProviderFunc(func(_ context.Context) (string, error) { return "", failure }),
+				StaticToken("c"),
+			},
+			err: failure,
+		},
+		"none": {
+			providers: []TokenProvider{StaticToken("")},
+			err:       ErrNoToken,
+		},
+		"empty": {
+			err: ErrNoToken,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			token, err := ChainToken(tc.providers...).Token(context.Background())
+			if err != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+			if token != tc.token {
+				t.Fatalf("expected token %q, got %q", tc.token, token)
+			}
+		})
+	}
+}
+
+func TestClientTokenProvider(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	p := StaticToken("first")
+	env.Client = NewClient(WithEndpoint(env.Server.URL), WithTokenProvider(p))
+
+	var (
+		mu      sync.Mutex
+		headers []string
+	)
+	env.Mux.HandleFunc("/locations", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Get("Authorization"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"locations": []}`))
+	})
+
+	ctx := context.Background()
+	if _, _, err := env.Client.Location.List(ctx, LocationListOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	p.SetToken("second")
+	if _, _, err := env.Client.Location.List(ctx, LocationListOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 2 || headers[0] != "Bearer first" || headers[1] != "Bearer second" {
+		t.Errorf("unexpected authorization headers: %v", headers)
+	}
+
+	// Rotating the token while requests are in flight is safe.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.SetToken("third")
+			if _, _, err := env.Client.Location.List(ctx, LocationListOpts{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestClientTokenProviderRetry(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	p := StaticToken("first")
+	env.Client = NewClient(
+		WithEndpoint(env.Server.URL),
+		WithTokenProvider(p),
+		WithBackoffFunc(func(_ int) time.Duration {
+			p.SetToken("second")
+			return 0
+		}),
+	)
+
+	var headers []string
+	env.Mux.HandleFunc("/locations", func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if len(headers) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"code": "rate_limit_exceeded", "message": "limit of 3600 requests per hour reached"}}`))
+			return
+		}
+		w.Write([]byte(`{"locations": []}`))
+	})
+
+	if _, _, err := env.Client.Location.List(context.Background(), LocationListOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 2 || headers[0] != "Bearer first" || headers[1] != "Bearer second" {
+		t.Errorf("unexpected authorization headers: %v", headers)
+	}
+}
+
+func TestClientTokenProviderError(t *testing.T) {
+	failure := errors.New("failure")
+	client := NewClient(WithTokenProvider(TokenProviderFunc(func(_ context.Context) (string, error) {
+		return "", failure
+	})))
+	if _, err := client.NewRequest(context.Background(), "GET", "/servers", nil); err != failure {
+		t.Errorf("expected provider error, got %v", err)
+	}
+	if _, err := client.DNSServer.NewRequest(context.Background(), "GET", "/zones", nil); err != failure {
+		t.Errorf("expected provider error for DNS request, got %v", err)
+	}
+
+	client = NewClient(WithTokenProvider(StaticToken("")))
+	req, err := client.NewRequest(context.Background(), "GET", "/servers", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h := req.Header.Get("Authorization"); h != "Bearer " {
+		t.Errorf("unexpected authorization header without token: %q", h)
+	}
+}
+
+func TestClientDNSToken(t *testing.T) {
+	testCases := map[string]struct {
+		options  []ClientOption
+		expected string
+	}{
+		"cloud token": {
+			options:  []ClientOption{WithToken("cloud")},
+			expected: "cloud",
+		},
+		"dns token": {
+			options:  []ClientOption{WithToken("cloud"), WithDNSToken("dns")},
+			expected: "dns",
+		},
+		"dns token provider": {
+			options:  []ClientOption{WithToken("cloud"), WithDNSTokenProvider(ChainToken(StaticToken(""), StaticToken("dns")))},
+			expected: "dns",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client := NewClient(tc.options...)
+			req, err := client.DNSServer.NewRequest(context.Background(), "GET", "/zones", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h := req.Header.Get("Auth-API-Token"); h != tc.expected {
+				t.Errorf("expected token %q, got %q", tc.expected, h)
+			}
+			if h := req.Header.Get("Authorization"); h != "" {
+				t.Errorf("unexpected authorization header: %q", h)
+			}
+		})
+	}
+}